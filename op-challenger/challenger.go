@@ -11,13 +11,40 @@ import (
 
 // Main is the programmatic entry-point for running op-challenger
 func Main(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	return MainWithShutdownRequests(ctx, logger, cfg, nil)
+}
+
+// ShutdownRequests is a source of requests to shut down op-challenger.
+type ShutdownRequests interface {
+	// Start returns the channel requests are received from. It is called once the service has been created.
+	Start() <-chan struct{}
+	// Stop is called once a shutdown is confirmed or op-challenger exits, after which no more requests are received.
+	Stop()
+}
+
+// MainWithShutdownRequests runs op-challenger until ctx is done or a shutdown request received from
+// shutdownRequests is confirmed.
+func MainWithShutdownRequests(ctx context.Context, logger log.Logger, cfg *config.Config, shutdownRequests ShutdownRequests) error {
 	if err := cfg.Check(); err != nil {
 		return err
 	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	service, err := game.NewService(ctx, logger, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create the fault service: %w", err)
 	}
+	if shutdownRequests != nil {
+		requests := shutdownRequests.Start()
+		defer shutdownRequests.Stop()
+		go func() {
+			confirmed := service.AwaitShutdown(ctx, requests)
+			shutdownRequests.Stop()
+			if confirmed {
+				cancel()
+			}
+		}()
+	}
 
 	return service.MonitorGame(ctx)
 }
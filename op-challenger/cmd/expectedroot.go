@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// expectedRootCommand prints the root claim the challenger expects for a game, using the same trace as when playing
+// it. The global options configure the challenger as when running it.
+var expectedRootCommand = &cli.Command{
+	Name:      "expected-root",
+	Usage:     "Print the root claim the challenger expects for a fault dispute game",
+	UsageText: "op-challenger [challenger options] expected-root --game <address>",
+	Description: "Prints the root claim value the challenger agrees with for the game, from the trace of the game's type.\n" +
+		"The challenger defends a root claim with this value and attacks any other.",
+	Flags:  []cli.Flag{gameFlag},
+	Action: expectedRoot,
+}
+
+func expectedRoot(ctx *cli.Context) error {
+	game, rpc, err := parseGameArgs(ctx)
+	if err != nil {
+		return err
+	}
+	cfg, err := flags.NewConfigFromCLI(ctx)
+	if err != nil {
+		return err
+	}
+	logger, err := setupStderrLogging(ctx)
+	if err != nil {
+		return err
+	}
+
+	l1Client, err := client.DialEthClientWithTimeout(client.DefaultDialTimeout, logger, rpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	dir, err := os.MkdirTemp("", "op-challenger-expected-root")
+	if err != nil {
+		return fmt.Errorf("failed to create trace directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	root, err := fault.LoadExpectedRootClaim(ctx.Context, logger, cfg, dir, game, l1Client)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(ctx.App.Writer, root.Hex())
+	return err
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+)
+
+func TestExpectedRoot(t *testing.T) {
+	t.Run("RequireGame", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"game\" not set", append(addRequiredArgs(config.TraceTypeAlphabet), "expected-root"))
+	})
+
+	t.Run("InvalidGame", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game address", append(addRequiredArgs(config.TraceTypeAlphabet), "expected-root", "--game", "foo"))
+	})
+
+	t.Run("RequireTraceType", func(t *testing.T) {
+		args := append(addRequiredArgsExcept(config.TraceTypeAlphabet, "--trace-type"), "expected-root", "--game", gameAddressValue)
+		verifyArgsInvalid(t, "flag trace-type is required", args)
+	})
+
+	t.Run("RequireTraceTypeOptions", func(t *testing.T) {
+		args := append(addRequiredArgsExcept(config.TraceTypeAlphabet, "--alphabet"), "expected-root", "--game", gameAddressValue)
+		verifyArgsInvalid(t, "flag alphabet is required", args)
+	})
+}
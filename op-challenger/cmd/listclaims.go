@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	listClaimsFormatText = "text"
+	listClaimsFormatJSON = "json"
+)
+
+var (
+	gameFlag = &cli.StringFlag{
+		Name:     "game",
+		Usage:    "Address of the fault dispute game.",
+		Required: true,
+	}
+	listClaimsFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format of the claim tree. Valid options: text, json",
+		Value: listClaimsFormatText,
+	}
+)
+
+// listClaimsCommand prints the claim tree of a single game. The global options select the L1 node to read the game
+// from and, when --trace-type is set, the trace each claim is compared with.
+var listClaimsCommand = &cli.Command{
+	Name:      "list-claims",
+	Usage:     "Print the claim tree of a fault dispute game",
+	UsageText: "op-challenger --l1-eth-rpc <url> [challenger options] list-claims --game <address> [--format text|json]",
+	Description: "Prints each claim in the game with its position, trace index, value, claimant and whether it has been countered.\n" +
+		"If the challenger options are set, including --trace-type, each claim is also compared with the challenger's trace.",
+	Flags:  []cli.Flag{gameFlag, listClaimsFormatFlag},
+	Action: listClaims,
+}
+
+func listClaims(ctx *cli.Context) error {
+	format := ctx.String(listClaimsFormatFlag.Name)
+	if format != listClaimsFormatText && format != listClaimsFormatJSON {
+		return fmt.Errorf("unknown format: %v", format)
+	}
+	game, rpc, err := parseGameArgs(ctx)
+	if err != nil {
+		return err
+	}
+	var cfg *config.Config
+	if ctx.IsSet(flags.TraceTypeFlag.Name) {
+		cfg, err = flags.NewConfigFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	logger, err := setupStderrLogging(ctx)
+	if err != nil {
+		return err
+	}
+
+	l1Client, err := client.DialEthClientWithTimeout(client.DefaultDialTimeout, logger, rpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	dir, err := os.MkdirTemp("", "op-challenger-list-claims")
+	if err != nil {
+		return fmt.Errorf("failed to create trace directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tree, err := fault.LoadClaimTree(ctx.Context, logger, cfg, dir, game, l1Client)
+	if err != nil {
+		return err
+	}
+	if format == listClaimsFormatText {
+		return tree.WriteText(ctx.App.Writer)
+	}
+	encoder := json.NewEncoder(ctx.App.Writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tree); err != nil {
+		return fmt.Errorf("failed to write claim tree: %w", err)
+	}
+	return nil
+}
+
+// parseGameArgs returns the game the command inspects and the L1 node to read it from.
+func parseGameArgs(ctx *cli.Context) (common.Address, string, error) {
+	game, err := opservice.ParseAddress(ctx.String(gameFlag.Name))
+	if err != nil {
+		return common.Address{}, "", fmt.Errorf("invalid game address: %w", err)
+	}
+	rpc := ctx.String(flags.L1EthRpcFlag.Name)
+	if rpc == "" {
+		return common.Address{}, "", fmt.Errorf("flag %s is required", flags.L1EthRpcFlag.Name)
+	}
+	return game, rpc, nil
+}
+
+// setupStderrLogging creates a logger writing to stderr, so the claim tree written to stdout can be piped to other
+// tools.
+func setupStderrLogging(ctx *cli.Context) (log.Logger, error) {
+	logCfg := oplog.ReadCLIConfig(ctx)
+	if err := logCfg.Check(); err != nil {
+		return nil, fmt.Errorf("log config error: %w", err)
+	}
+	handler := log.StreamHandler(os.Stderr, oplog.Format(logCfg.Format, logCfg.Color))
+	logger := log.New()
+	logger.SetHandler(log.LvlFilterHandler(oplog.Level(logCfg.Level), log.SyncHandler(handler)))
+	return logger, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+)
+
+var gameAddressValue = "0xaa00000000000000000000000000000000000000"
+
+func TestListClaims(t *testing.T) {
+	t.Run("RequireGame", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"game\" not set", []string{"--l1-eth-rpc", l1EthRpc, "list-claims"})
+	})
+
+	t.Run("InvalidGame", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game address", []string{"--l1-eth-rpc", l1EthRpc, "list-claims", "--game", "foo"})
+	})
+
+	t.Run("RequireL1EthRpc", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l1-eth-rpc is required", []string{"list-claims", "--game", gameAddressValue})
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown format: yaml", []string{"--l1-eth-rpc", l1EthRpc, "list-claims", "--game", gameAddressValue, "--format", "yaml"})
+	})
+
+	t.Run("RequireChallengerOptionsWithTraceType", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag game-factory-address is required", []string{"--l1-eth-rpc", l1EthRpc, "--trace-type", config.TraceTypeAlphabet.String(), "list-claims", "--game", gameAddressValue})
+	})
+
+	t.Run("RequireTraceTypeOptions", func(t *testing.T) {
+		args := append(addRequiredArgsExcept(config.TraceTypeAlphabet, "--alphabet"), "list-claims", "--game", gameAddressValue)
+		verifyArgsInvalid(t, "flag alphabet is required", args)
+	})
+}
@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"sync"
 
 	op_challenger "github.com/ethereum-optimism/optimism/op-challenger"
 	"github.com/ethereum/go-ethereum/log"
@@ -13,21 +16,17 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/flags"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
-)
-
-var (
-	GitCommit = ""
-	GitDate   = ""
+	"github.com/ethereum-optimism/optimism/op-service/opio"
 )
 
 // VersionWithMeta holds the textual version string including the metadata.
 var VersionWithMeta = func() string {
 	v := version.Version
-	if GitCommit != "" {
-		v += "-" + GitCommit[:8]
+	if version.GitCommit != "" {
+		v += "-" + version.GitCommit[:8]
 	}
-	if GitDate != "" {
-		v += "-" + GitDate
+	if version.GitDate != "" {
+		v += "-" + version.GitDate
 	}
 	if version.Meta != "" {
 		v += "-" + version.Meta
@@ -37,14 +36,62 @@ var VersionWithMeta = func() string {
 
 func main() {
 	args := os.Args
-	if err := run(args, op_challenger.Main); err != nil {
+	action := func(ctx context.Context, log log.Logger, cfg *config.Config) error {
+		return op_challenger.MainWithShutdownRequests(ctx, log, cfg, newInterruptRequests())
+	}
+	if err := run(context.Background(), args, action); err != nil && !errors.Is(err, context.Canceled) {
 		log.Crit("Application failed", "err", err)
 	}
 }
 
+// interruptRequests requests a shutdown on each interrupt once started, which may need to be confirmed by a second
+// interrupt if game clocks are about to expire. Once confirmed, in-flight moves can complete before exiting.
+// Before it is started, such as while starting up or running a subcommand, and after it is stopped, interrupts
+// have their default behaviour of exiting immediately, so a further interrupt cuts the shutdown grace period short.
+type interruptRequests struct {
+	interrupts chan os.Signal
+	requests   chan struct{}
+	stopped    chan struct{}
+	stopOnce   sync.Once
+}
+
+func newInterruptRequests() *interruptRequests {
+	return &interruptRequests{
+		interrupts: make(chan os.Signal, 1),
+		requests:   make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+}
+
+func (r *interruptRequests) Start() <-chan struct{} {
+	signal.Notify(r.interrupts, opio.DefaultInterruptSignals...)
+	go func() {
+		for {
+			select {
+			case <-r.interrupts:
+			case <-r.stopped:
+				return
+			}
+			select {
+			case r.requests <- struct{}{}:
+			case <-r.stopped:
+				return
+			}
+		}
+	}()
+	return r.requests
+}
+
+func (r *interruptRequests) Stop() {
+	r.stopOnce.Do(func() {
+		signal.Stop(r.interrupts)
+		close(r.stopped)
+	})
+}
+
 type ConfigAction func(ctx context.Context, log log.Logger, config *config.Config) error
 
-func run(args []string, action ConfigAction) error {
+func run(ctx context.Context, args []string, action ConfigAction) error {
 	oplog.SetupDefaults()
 
 	app := cli.NewApp()
@@ -53,6 +100,7 @@ func run(args []string, action ConfigAction) error {
 	app.Name = "op-challenger"
 	app.Usage = "Challenge outputs"
 	app.Description = "Ensures that on chain outputs are correct."
+	app.Commands = []*cli.Command{listClaimsCommand, expectedRootCommand, simulateCommand}
 	app.Action = func(ctx *cli.Context) error {
 		logger, err := setupLogging(ctx)
 		if err != nil {
@@ -66,7 +114,7 @@ func run(args []string, action ConfigAction) error {
 		}
 		return action(ctx.Context, logger, cfg)
 	}
-	return app.Run(args)
+	return app.RunContext(ctx, args)
 }
 
 func setupLogging(ctx *cli.Context) (log.Logger, error) {
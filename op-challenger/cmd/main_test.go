@@ -3,6 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
 	"testing"
 	"time"
 
@@ -25,6 +31,7 @@ var (
 	datadir                 = "./test_data"
 	cannonL2                = "http://example.com:9545"
 	alphabetTrace           = "abcdefghijz"
+	traceFile               = "./trace.json"
 	agreeWithProposedOutput = "true"
 )
 
@@ -45,14 +52,14 @@ func TestLogLevel(t *testing.T) {
 
 func TestDefaultCLIOptionsMatchDefaultConfig(t *testing.T) {
 	cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
-	defaultCfg := config.NewConfig(common.HexToAddress(gameFactoryAddressValue), l1EthRpc, config.TraceTypeAlphabet, true, datadir)
+	defaultCfg := config.NewConfig(common.HexToAddress(gameFactoryAddressValue), l1EthRpc, []config.TraceType{config.TraceTypeAlphabet}, true, datadir)
 	// Add in the extra CLI options required when using alphabet trace type
 	defaultCfg.AlphabetTrace = alphabetTrace
 	require.Equal(t, defaultCfg, cfg)
 }
 
 func TestDefaultConfigIsValid(t *testing.T) {
-	cfg := config.NewConfig(common.HexToAddress(gameFactoryAddressValue), l1EthRpc, config.TraceTypeAlphabet, true, datadir)
+	cfg := config.NewConfig(common.HexToAddress(gameFactoryAddressValue), l1EthRpc, []config.TraceType{config.TraceTypeAlphabet}, true, datadir)
 	// Add in options that are required based on the specific trace type
 	// To avoid needing to specify unused options, these aren't included in the params for NewConfig
 	cfg.AlphabetTrace = alphabetTrace
@@ -81,10 +88,24 @@ func TestTraceType(t *testing.T) {
 		traceType := traceType
 		t.Run("Valid_"+traceType.String(), func(t *testing.T) {
 			cfg := configForArgs(t, addRequiredArgs(traceType))
-			require.Equal(t, traceType, cfg.TraceType)
+			require.Equal(t, []config.TraceType{traceType}, cfg.TraceTypes)
 		})
 	}
 
+	t.Run("Multiple", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--trace-type=alphabet", "--alphabet="+alphabetTrace))
+		require.ElementsMatch(t, []config.TraceType{config.TraceTypeCannon, config.TraceTypeAlphabet}, cfg.TraceTypes)
+	})
+
+	t.Run("IgnoreDuplicates", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--trace-type=alphabet"))
+		require.Equal(t, []config.TraceType{config.TraceTypeAlphabet}, cfg.TraceTypes)
+	})
+
+	t.Run("RequireOptionsForEachType", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag alphabet is required", addRequiredArgs(config.TraceTypeCannon, "--trace-type=alphabet"))
+	})
+
 	t.Run("Invalid", func(t *testing.T) {
 		verifyArgsInvalid(t, "unknown trace type: \"foo\"", addRequiredArgsExcept(config.TraceTypeAlphabet, "--trace-type", "--trace-type=foo"))
 	})
@@ -104,6 +125,74 @@ func TestGameFactoryAddress(t *testing.T) {
 	t.Run("Invalid", func(t *testing.T) {
 		verifyArgsInvalid(t, "invalid address: foo", addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-factory-address", "--game-factory-address=foo"))
 	})
+
+	t.Run("Multiple", func(t *testing.T) {
+		addr1 := common.Address{0xbb}
+		addr2 := common.Address{0xcc}
+		addr3 := common.Address{0xdd}
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-factory-address",
+			"--game-factory-address="+addr1.Hex()+", "+addr2.Hex()+","+addr3.Hex()))
+		require.Equal(t, addr1, cfg.GameFactoryAddress)
+		require.Equal(t, []common.Address{addr2, addr3}, cfg.ExtraGameFactories)
+		require.Equal(t, []common.Address{addr1, addr2, addr3}, cfg.GameFactories())
+	})
+
+	t.Run("InvalidInList", func(t *testing.T) {
+		addr := common.Address{0xbb}
+		verifyArgsInvalid(t, "invalid address: foo", addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-factory-address", "--game-factory-address="+addr.Hex()+",foo"))
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		addr := common.Address{0xbb}
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-factory-address", "--game-factory-address="+addr.Hex()+","+addr.Hex()))
+		require.ErrorIs(t, cfg.Check(), config.ErrDuplicateGameFactory)
+	})
+}
+
+func TestFactoryOption(t *testing.T) {
+	t.Run("NoneByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.FactoryOptions)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		factory := common.HexToAddress(gameFactoryAddressValue)
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--factory-option="+factory.Hex()+".alphabet=xyz"))
+		require.Equal(t, []config.FactoryOption{{Factory: factory, Key: config.FactoryOptionAlphabet, Value: "xyz"}}, cfg.FactoryOptions)
+		factoryCfg, err := cfg.ForFactory(factory)
+		require.NoError(t, err)
+		require.Equal(t, "xyz", factoryCfg.AlphabetTrace)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid factory option", addRequiredArgs(config.TraceTypeAlphabet, "--factory-option=alphabet"))
+	})
+
+	t.Run("UnknownFactory", func(t *testing.T) {
+		factory := common.Address{0xee}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--factory-option="+factory.Hex()+".alphabet=xyz"))
+		require.ErrorIs(t, cfg.Check(), config.ErrUnknownGameFactory)
+	})
+}
+
+func TestAcceptedPrestates(t *testing.T) {
+	t.Run("Optional", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.AcceptedPrestates)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		prestate1 := common.Hash{0xaa}
+		prestate2 := common.Hash{0xbb}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--accepted-absolute-prestate="+prestate1.Hex(), "--accepted-absolute-prestate="+prestate2.Hex()))
+		require.Equal(t, []common.Hash{prestate1, prestate2}, cfg.AcceptedPrestates)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid accepted absolute prestate", addRequiredArgs(config.TraceTypeAlphabet, "--accepted-absolute-prestate=0x1234"))
+		verifyArgsInvalid(t, "invalid accepted absolute prestate", addRequiredArgs(config.TraceTypeAlphabet, "--accepted-absolute-prestate=foo"))
+	})
 }
 
 func TestGameAllowlist(t *testing.T) {
@@ -123,6 +212,24 @@ func TestGameAllowlist(t *testing.T) {
 	})
 }
 
+func TestGameDenylist(t *testing.T) {
+	t.Run("Optional", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.GameDenylist)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr1 := common.Address{0xbb, 0xcc, 0xdd}
+		addr2 := common.Address{0xee}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-denylist="+addr1.Hex()+","+addr2.Hex()))
+		require.Equal(t, []common.Address{addr1, addr2}, cfg.GameDenylist)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid address: foo", addRequiredArgs(config.TraceTypeAlphabet, "--game-denylist=foo"))
+	})
+}
+
 func TestTxManagerFlagsSupported(t *testing.T) {
 	// Not a comprehensive list of flags, just enough to sanity check the txmgr.CLIFlags were defined
 	cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--"+txmgr.NumConfirmationsFlagName, "7"))
@@ -169,6 +276,21 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestTraceFile(t *testing.T) {
+	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+		configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--trace-file"))
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag trace-file is required", addRequiredArgsExcept(config.TraceTypeFile, "--trace-file"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeFile, "--trace-file", "--trace-file=./other.json"))
+		require.Equal(t, "./other.json", cfg.TraceFile)
+	})
+}
+
 func TestCannonBin(t *testing.T) {
 	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
 		configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--cannon-bin"))
@@ -273,6 +395,657 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestMaxMoveGas(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxMoveGas)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-move-gas=500000"))
+		require.Equal(t, uint64(500000), cfg.MaxMoveGas)
+	})
+}
+
+func TestMoveLimits(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxMovesPerCycle)
+		require.Equal(t, config.DefaultMaxParallelMoves, cfg.MaxParallelMoves)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-moves-per-cycle=20", "--max-parallel-moves=4"))
+		require.Equal(t, uint(20), cfg.MaxMovesPerCycle)
+		require.Equal(t, uint(4), cfg.MaxParallelMoves)
+	})
+}
+
+func TestMaxMoveGasPrice(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxMoveGasPrice)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-move-gas-price=40.5"))
+		require.Equal(t, 40.5, cfg.MaxMoveGasPrice)
+	})
+}
+
+func TestMoveTips(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MinMoveTip)
+		require.Zero(t, cfg.MaxMoveTip)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--min-move-tip=0.5", "--max-move-tip=3.5"))
+		require.Equal(t, 0.5, cfg.MinMoveTip)
+		require.Equal(t, 3.5, cfg.MaxMoveTip)
+	})
+}
+
+func TestUrgentTipMultiplier(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultUrgentTipMultiplier, cfg.UrgentTipMultiplier)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--urgent-tip-multiplier=2.5"))
+		require.Equal(t, 2.5, cfg.UrgentTipMultiplier)
+	})
+}
+
+func TestStrictDataAvailability(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.StrictDataAvailability)
+		require.False(t, cfg.AcceptUnfinalizedRisk)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--strict-data-availability", "--accept-unfinalized-risk"))
+		require.True(t, cfg.StrictDataAvailability)
+		require.True(t, cfg.AcceptUnfinalizedRisk)
+	})
+}
+
+func TestHaltOnSelfConflict(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.HaltOnSelfConflict)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--halt-on-self-conflict"))
+		require.True(t, cfg.HaltOnSelfConflict)
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("UsesDefaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.BreakerFailures)
+		require.Equal(t, config.DefaultBreakerWindow, cfg.BreakerWindow)
+		require.Equal(t, config.DefaultBreakerProbeInterval, cfg.BreakerProbeInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--circuit-breaker-failures", "3",
+			"--circuit-breaker-window", "10m",
+			"--circuit-breaker-probe-interval", "30s"))
+		require.Equal(t, uint(3), cfg.BreakerFailures)
+		require.Equal(t, 10*time.Minute, cfg.BreakerWindow)
+		require.Equal(t, 30*time.Second, cfg.BreakerProbeInterval)
+	})
+
+	t.Run("DeprecatedRevertsAlias", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--circuit-breaker-reverts", "4"))
+		require.Equal(t, uint(4), cfg.BreakerFailures)
+	})
+}
+
+func TestClaimLoadConcurrency(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultClaimLoadConcurrency, cfg.ClaimLoadConcurrency)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--claim-load-concurrency=25"))
+		require.Equal(t, uint(25), cfg.ClaimLoadConcurrency)
+	})
+}
+
+func TestStatusConfirmations(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.StatusConfirmations)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--status-confirmations=12"))
+		require.Equal(t, uint64(12), cfg.StatusConfirmations)
+	})
+}
+
+func TestTraceCacheSize(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultTraceCacheSize, cfg.TraceCacheSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--trace-cache-size=1024"))
+		require.Equal(t, uint64(1024), cfg.TraceCacheSize)
+	})
+}
+
+func TestTraceDiskCache(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.TraceDiskCacheDir)
+		require.Equal(t, config.DefaultTraceDiskCacheSize, cfg.TraceDiskCacheSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--trace-disk-cache-dir=/tmp/cache", "--trace-disk-cache-size=1024"))
+		require.Equal(t, "/tmp/cache", cfg.TraceDiskCacheDir)
+		require.Equal(t, uint64(1024), cfg.TraceDiskCacheSize)
+	})
+}
+
+func TestTraceRateLimit(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.TraceRateLimit)
+		require.Equal(t, config.DefaultTraceRateBurst, cfg.TraceRateBurst)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--trace-rate-limit=0.5", "--trace-rate-burst=3"))
+		require.Equal(t, 0.5, cfg.TraceRateLimit)
+		require.Equal(t, uint(3), cfg.TraceRateBurst)
+	})
+}
+
+func TestGameLogs(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.GameLogs)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-logs"))
+		require.True(t, cfg.GameLogs)
+		require.Equal(t, log.LvlInfo, cfg.GameLogLevel)
+	})
+
+	t.Run("UsesLogLevel", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-logs", "--log.level=WARN"))
+		require.Equal(t, log.LvlWarn, cfg.GameLogLevel)
+	})
+}
+
+func TestAutoClaimBonds(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.AutoClaimBonds)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--auto-claim-bonds"))
+		require.True(t, cfg.AutoClaimBonds)
+	})
+}
+
+func TestBondClaimDelay(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.BondClaimDelay)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--bond-claim-delay=168h"))
+		require.Equal(t, 168*time.Hour, cfg.BondClaimDelay)
+	})
+}
+
+func TestMoveBond(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MoveBond)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--move-bond=0.08"))
+		require.Equal(t, 0.08, cfg.MoveBond)
+	})
+}
+
+func TestMaxSpendPerGame(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxSpendPerGame)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-spend-per-game=0.5"))
+		require.Equal(t, 0.5, cfg.MaxSpendPerGame)
+	})
+}
+
+func TestStopNewGamesWhenUnderfunded(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.StopNewGamesUnderfunded)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--stop-new-games-when-underfunded"))
+		require.True(t, cfg.StopNewGamesUnderfunded)
+	})
+}
+
+func TestMonitorOnly(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.MonitorOnly)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--monitor-only"))
+		require.True(t, cfg.MonitorOnly)
+	})
+}
+
+func TestClockSkewThreshold(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultClockSkewThreshold, cfg.ClockSkewThreshold)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--clock-skew-threshold=1m"))
+		require.Equal(t, time.Minute, cfg.ClockSkewThreshold)
+	})
+}
+
+func TestResolvedGameRetention(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.ResolvedGameRetention)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--resolved-game-retention=24h"))
+		require.Equal(t, 24*time.Hour, cfg.ResolvedGameRetention)
+	})
+
+	t.Run("Forever", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--resolved-game-retention=-1s"))
+		require.Negative(t, cfg.ResolvedGameRetention)
+	})
+}
+
+func TestPreserveLostGameData(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.PreserveLostGameData)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--preserve-lost-game-data"))
+		require.True(t, cfg.PreserveLostGameData)
+	})
+}
+
+func TestDashboard(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.Dashboard)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--dashboard", "--rpc.enabled"))
+		require.True(t, cfg.Dashboard)
+	})
+}
+
+func TestStepPregenDepth(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.StepPregenDepth)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--step-pregen-depth=20"))
+		require.Equal(t, uint(20), cfg.StepPregenDepth)
+	})
+}
+
+func TestAnalyzeGames(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.AnalyzeGames)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--analyze-games"))
+		require.True(t, cfg.AnalyzeGames)
+	})
+}
+
+func TestUrgentClockThreshold(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultUrgentClockThreshold, cfg.UrgentClockThreshold)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--urgent-clock-threshold=10m"))
+		require.Equal(t, 10*time.Minute, cfg.UrgentClockThreshold)
+	})
+}
+
+func TestMinMoveClock(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MinMoveClock)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--min-move-clock=10m"))
+		require.Equal(t, 10*time.Minute, cfg.MinMoveClock)
+	})
+}
+
+func TestActTimeBudget(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultActTimeBudget, cfg.ActTimeBudget)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--act-time-budget=2s"))
+		require.Equal(t, 2*time.Second, cfg.ActTimeBudget)
+	})
+}
+
+func TestGameInfoInterval(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultGameInfoInterval, cfg.GameInfoInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-info-interval=10m"))
+		require.Equal(t, 10*time.Minute, cfg.GameInfoInterval)
+	})
+}
+
+func TestShutdownGracePeriod(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultShutdownGracePeriod, cfg.ShutdownGracePeriod)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--shutdown-grace-period=10s"))
+		require.Equal(t, 10*time.Second, cfg.ShutdownGracePeriod)
+	})
+}
+
+func TestShutdownProtection(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.ShutdownProtection)
+		require.Equal(t, config.DefaultShutdownConfirmTimeout, cfg.ShutdownConfirmTimeout)
+		require.False(t, cfg.ForceShutdown)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--shutdown-protection=1h", "--shutdown-confirm-timeout=30s", "--force"))
+		require.Equal(t, time.Hour, cfg.ShutdownProtection)
+		require.Equal(t, 30*time.Second, cfg.ShutdownConfirmTimeout)
+		require.True(t, cfg.ForceShutdown)
+	})
+}
+
+func TestMetricsInstance(t *testing.T) {
+	t.Run("NotLabelledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.MetricsInstance)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--metrics-instance=goerli"))
+		require.Equal(t, "goerli", cfg.MetricsInstance)
+	})
+}
+
+func TestHealthStalenessWindow(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultHealthStalenessWindow, cfg.HealthStalenessWindow)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--health-staleness-window=10m"))
+		require.Equal(t, 10*time.Minute, cfg.HealthStalenessWindow)
+	})
+}
+
+func TestStaleGameThreshold(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultStaleGameThreshold, cfg.StaleGameThreshold)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--stale-game-threshold=3h"))
+		require.Equal(t, 3*time.Hour, cfg.StaleGameThreshold)
+	})
+}
+
+func TestEventLog(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.EventLog)
+		require.Equal(t, config.DefaultEventLogMaxSize, cfg.EventLogMaxSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--event-log=/tmp/events.jsonl", "--event-log-max-size=1024"))
+		require.Equal(t, "/tmp/events.jsonl", cfg.EventLog)
+		require.Equal(t, uint64(1024), cfg.EventLogMaxSize)
+	})
+}
+
+func TestResultStoreSQLite(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.ResultStoreSQLite)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--store.sqlite=/tmp/results.db"))
+		require.Equal(t, "/tmp/results.db", cfg.ResultStoreSQLite)
+	})
+}
+
+func TestDeprioritizeAfterLosses(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.DeprioritizeAfterLosses)
+		require.Equal(t, config.DefaultDeprioritizeWindow, cfg.DeprioritizeWindow)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--deprioritize-after-losses=3", "--deprioritize-window=24h"))
+		require.Equal(t, uint64(3), cfg.DeprioritizeAfterLosses)
+		require.Equal(t, 24*time.Hour, cfg.DeprioritizeWindow)
+	})
+}
+
+func TestInterruptRequests(t *testing.T) {
+	r := newInterruptRequests()
+	requests := r.Start()
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGINT))
+	select {
+	case <-requests:
+	case <-time.After(10 * time.Second):
+		t.Fatal("interrupt did not request a shutdown")
+	}
+	r.Stop()
+	r.Stop()
+}
+
+// TestSubcommandExitsOnInterrupt runs a subcommand in a separate process that blocks loading from L1 and checks
+// it exits when interrupted.
+func TestSubcommandExitsOnInterrupt(t *testing.T) {
+	if args := os.Getenv("OP_CHALLENGER_TEST_ARGS"); args != "" {
+		os.Args = append([]string{"op-challenger"}, "--l1-eth-rpc", args, "list-claims", "--game", gameAddressValue)
+		main()
+		return
+	}
+	if signal.Ignored(syscall.SIGINT) {
+		// Signals ignored when a process starts stay ignored, so the subcommand can't be interrupted.
+		t.Skip("SIGINT is ignored")
+	}
+	requested := make(chan struct{}, 1)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case requested <- struct{}{}:
+		default:
+		}
+		<-done
+	}))
+	defer server.Close()
+	// Unblock the request before closing the server, which waits for it to complete.
+	defer close(done)
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSubcommandExitsOnInterrupt$")
+	cmd.Env = append(os.Environ(), "OP_CHALLENGER_TEST_ARGS="+server.URL)
+	require.NoError(t, cmd.Start())
+	defer func() {
+		_ = cmd.Process.Kill()
+	}()
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	select {
+	case <-requested:
+	case err := <-exited:
+		t.Fatalf("subcommand exited before loading from L1: %v", err)
+	case <-time.After(30 * time.Second):
+		t.Fatal("subcommand did not load from L1")
+	}
+
+	require.NoError(t, cmd.Process.Signal(syscall.SIGINT))
+	select {
+	case err := <-exited:
+		require.Error(t, err, "should exit because of the interrupt")
+	case <-time.After(10 * time.Second):
+		t.Fatal("subcommand did not exit on interrupt")
+	}
+}
+
+func TestRPCConfig(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.RPCConfig.Enabled)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--rpc.enabled", "--rpc.addr=127.0.0.1", "--rpc.port=9545"))
+		require.True(t, cfg.RPCConfig.Enabled)
+		require.Equal(t, "127.0.0.1", cfg.RPCConfig.ListenAddr)
+		require.Equal(t, 9545, cfg.RPCConfig.ListenPort)
+		require.False(t, cfg.RPCConfig.EnableAdmin)
+	})
+
+	t.Run("EnableAdmin", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--rpc.enabled", "--rpc.enable-admin"))
+		require.True(t, cfg.RPCConfig.EnableAdmin)
+	})
+}
+
+func TestRelaxedPollInterval(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.RelaxedPollInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--relaxed-poll-interval=1m"))
+		require.Equal(t, time.Minute, cfg.RelaxedPollInterval)
+	})
+}
+
+func TestContestedPollInterval(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.ContestedPollInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--contested-poll-interval=12s"))
+		require.Equal(t, 12*time.Second, cfg.ContestedPollInterval)
+	})
+}
+
+func TestTerminalPollInterval(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultTerminalPollInterval, cfg.TerminalPollInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--terminal-poll-interval=1h"))
+		require.Equal(t, time.Hour, cfg.TerminalPollInterval)
+	})
+}
+
+func TestScheduleJitter(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultScheduleJitter, cfg.ScheduleJitter)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--schedule-jitter=0.5"))
+		require.Equal(t, 0.5, cfg.ScheduleJitter)
+	})
+}
+
+func TestGameTypeOption(t *testing.T) {
+	t.Run("NoneByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.GameTypeOptions)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--game-type-option=cannon.max-move-gas=500000", "--game-type-option=255.max-move-gas=0"))
+		require.Equal(t, []config.GameTypeOption{
+			{GameType: config.CannonFaultGameID, Key: config.GameTypeOptionMaxMoveGas, Value: "500000"},
+			{GameType: config.AlphabetFaultGameID, Key: config.GameTypeOptionMaxMoveGas, Value: "0"},
+		}, cfg.GameTypeOptions)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game type option", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-option=cannon"))
+	})
+}
+
 func TestRequireEitherCannonNetworkOrRollupAndGenesis(t *testing.T) {
 	verifyArgsInvalid(
 		t,
@@ -349,7 +1122,7 @@ func runWithArgs(cliArgs []string) (log.Logger, config.Config, error) {
 	cfg := new(config.Config)
 	var logger log.Logger
 	fullArgs := append([]string{"op-challenger"}, cliArgs...)
-	err := run(fullArgs, func(ctx context.Context, log log.Logger, config *config.Config) error {
+	err := run(context.Background(), fullArgs, func(ctx context.Context, log log.Logger, config *config.Config) error {
 		logger = log
 		cfg = config
 		return nil
@@ -380,6 +1153,8 @@ func requiredArgs(traceType config.TraceType) map[string]string {
 	switch traceType {
 	case config.TraceTypeAlphabet:
 		args["--alphabet"] = alphabetTrace
+	case config.TraceTypeFile:
+		args["--trace-file"] = traceFile
 	case config.TraceTypeCannon:
 		args["--cannon-network"] = cannonNetwork
 		args["--cannon-bin"] = cannonBin
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test/inmemory"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+)
+
+// maxSimulateDepth limits the depth of simulated games, as the number of claims grows with the depth and the
+// alphabet traces are held in memory.
+const maxSimulateDepth = 32
+
+const alphabetTraceUsage = "as a word, 0x-prefixed hex data with a state per byte, or @ and a file of state values"
+
+var (
+	simulateHonestFlag = &cli.StringFlag{
+		Name:     "honest",
+		Usage:    "Correct alphabet trace of the honest player, " + alphabetTraceUsage + ".",
+		Required: true,
+	}
+	simulateDishonestFlag = &cli.StringFlag{
+		Name:     "dishonest",
+		Usage:    "Alphabet trace of the dishonest player, " + alphabetTraceUsage + ".",
+		Required: true,
+	}
+	simulateDepthFlag = &cli.UintFlag{
+		Name:  "depth",
+		Usage: "Maximum depth of the game.",
+		Value: 4,
+	}
+	simulateHonestRootFlag = &cli.BoolFlag{
+		Name:  "honest-root",
+		Usage: "Make the root claim from the honest trace, so the honest player defends it. By default the dishonest player makes the root claim.",
+	}
+	simulateMaxRoundsFlag = &cli.UintFlag{
+		Name:  "max-rounds",
+		Usage: "Maximum number of rounds of each player progressing the game before the simulation fails.",
+		Value: 100,
+	}
+)
+
+// simulateCommand plays a game in memory between the challenger's agent using an honest trace and one using a
+// dishonest trace, without an L1 chain. The global options only configure logging.
+var simulateCommand = &cli.Command{
+	Name:      "simulate",
+	Usage:     "Simulate a fault dispute game between an honest and a dishonest player",
+	UsageText: "op-challenger simulate --honest <trace> --dishonest <trace> [--depth <depth>] [--honest-root] [--max-rounds <rounds>]",
+	Description: "Plays a game in memory between two players, each progressing the game in turn as the challenger does, using alphabet traces.\n" +
+		"Steps are checked against the honest trace, and the game's claims are checked to be valid after every turn.\n" +
+		"Prints every move, step and resolution with the player that made it, and the winner.",
+	Flags:  []cli.Flag{simulateHonestFlag, simulateDishonestFlag, simulateDepthFlag, simulateHonestRootFlag, simulateMaxRoundsFlag},
+	Action: simulate,
+}
+
+func simulate(ctx *cli.Context) error {
+	depth := ctx.Uint(simulateDepthFlag.Name)
+	if depth == 0 || depth > maxSimulateDepth {
+		return fmt.Errorf("depth must be between 1 and %v", maxSimulateDepth)
+	}
+	honest, err := alphabet.NewTraceProviderFromSpec(ctx.String(simulateHonestFlag.Name), uint64(depth))
+	if err != nil {
+		return fmt.Errorf("invalid honest trace: %w", err)
+	}
+	dishonest, err := alphabet.NewTraceProviderFromSpec(ctx.String(simulateDishonestFlag.Name), uint64(depth))
+	if err != nil {
+		return fmt.Errorf("invalid dishonest trace: %w", err)
+	}
+	logger, err := setupStderrLogging(ctx)
+	if err != nil {
+		return err
+	}
+
+	sim, err := inmemory.Simulate(ctx.Context, logger, honest, dishonest, int(depth), ctx.Bool(simulateHonestRootFlag.Name), int(ctx.Uint(simulateMaxRoundsFlag.Name)))
+	if sim != nil {
+		// The transcript of a failed simulation shows how the game got into the state it failed in.
+		if writeErr := sim.WriteTranscript(ctx.App.Writer); writeErr != nil {
+			return errors.Join(err, writeErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+	return nil
+}
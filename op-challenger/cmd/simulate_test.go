@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Run("RequireHonest", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"honest\" not set", []string{"simulate", "--dishonest", "abcd"})
+	})
+
+	t.Run("RequireDishonest", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"dishonest\" not set", []string{"simulate", "--honest", "abcd"})
+	})
+
+	t.Run("InvalidDepth", func(t *testing.T) {
+		verifyArgsInvalid(t, "depth must be between 1 and 32", []string{"simulate", "--honest", "abcd", "--dishonest", "abce", "--depth", "0"})
+		verifyArgsInvalid(t, "depth must be between 1 and 32", []string{"simulate", "--honest", "abcd", "--dishonest", "abce", "--depth", "33"})
+	})
+
+	t.Run("InvalidTrace", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid dishonest trace", []string{"simulate", "--honest", "abcd", "--dishonest", "0xzz"})
+	})
+
+	t.Run("Unfinished", func(t *testing.T) {
+		verifyArgsInvalid(t, "game did not finish", []string{"--log.level", "crit", "simulate", "--honest", "abcdefgh", "--dishonest", "ABCDEFGH", "--depth", "3", "--max-rounds", "1"})
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		_, _, err := runWithArgs([]string{"--log.level", "crit", "simulate", "--honest", "abcdefgh", "--dishonest", "ABCDEFGH", "--depth", "3"})
+		require.NoError(t, err)
+	})
+}
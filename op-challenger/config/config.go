@@ -3,11 +3,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"runtime"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -16,13 +20,30 @@ import (
 
 var (
 	ErrMissingTraceType              = errors.New("missing trace type")
+	ErrConflictingTraceTypes         = errors.New("trace types play the same game type")
 	ErrMissingDatadir                = errors.New("missing datadir")
 	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
+	ErrClaimLoadConcurrencyZero      = errors.New("claim load concurrency must not be 0")
+	ErrMaxParallelMovesZero          = errors.New("max parallel moves must not be 0")
+	ErrNegativeMaxMoveGasPrice       = errors.New("max move gas price must not be negative")
+	ErrNegativeMoveTip               = errors.New("min and max move tip must not be negative")
+	ErrMaxMoveTipBelowMin            = errors.New("max move tip must not be below min move tip")
+	ErrUrgentTipMultiplierBelowOne   = errors.New("urgent tip multiplier must be at least 1")
+	ErrNegativeMoveBond              = errors.New("move bond must not be negative")
+	ErrNegativeMaxSpendPerGame       = errors.New("max spend per game must not be negative")
+	ErrUnfinalizedRiskWithoutStrict  = errors.New("accepting unfinalized risk requires strict data availability")
+	ErrEventLogMaxSizeZero           = errors.New("event log max size must not be 0")
+	ErrTraceDiskCacheSizeZero        = errors.New("trace disk cache size must not be 0")
+	ErrNegativeTraceRateLimit        = errors.New("trace rate limit must not be negative")
+	ErrTraceRateBurstZero            = errors.New("trace rate burst must not be 0")
+	ErrInvalidScheduleJitter         = errors.New("schedule jitter must be between 0 and 1")
+	ErrDashboardRequiresRPC          = errors.New("dashboard requires the RPC server to be enabled")
 	ErrMissingCannonL2               = errors.New("missing cannon L2")
 	ErrMissingCannonBin              = errors.New("missing cannon bin")
 	ErrMissingCannonServer           = errors.New("missing cannon server")
 	ErrMissingCannonAbsolutePreState = errors.New("missing cannon absolute pre-state")
 	ErrMissingAlphabetTrace          = errors.New("missing alphabet trace")
+	ErrMissingTraceFile              = errors.New("missing trace file")
 	ErrMissingL1EthRPC               = errors.New("missing l1 eth rpc url")
 	ErrMissingGameFactoryAddress     = errors.New("missing game factory address")
 	ErrMissingCannonSnapshotFreq     = errors.New("missing cannon snapshot freq")
@@ -38,6 +59,7 @@ type TraceType string
 const (
 	TraceTypeAlphabet TraceType = "alphabet"
 	TraceTypeCannon   TraceType = "cannon"
+	TraceTypeFile     TraceType = "file"
 
 	// Mainnet games
 	CannonFaultGameID = 0
@@ -46,7 +68,7 @@ const (
 	AlphabetFaultGameID = 255
 )
 
-var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon}
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypeFile}
 
 // GameIdToString maps game IDs to their string representation.
 var GameIdToString = map[uint8]string{
@@ -67,6 +89,22 @@ func (t *TraceType) Set(value string) error {
 	return nil
 }
 
+// GameTypes returns the game types the trace type can play.
+// A trace file may be exported from either game type so it can play both. The absolute prestate
+// validation rejects games the trace doesn't belong to.
+func (t TraceType) GameTypes() []uint8 {
+	switch t {
+	case TraceTypeCannon:
+		return []uint8{CannonFaultGameID}
+	case TraceTypeAlphabet:
+		return []uint8{AlphabetFaultGameID}
+	case TraceTypeFile:
+		return []uint8{CannonFaultGameID, AlphabetFaultGameID}
+	default:
+		return nil
+	}
+}
+
 func ValidTraceType(value TraceType) bool {
 	for _, t := range TraceTypes {
 		if t == value {
@@ -83,6 +121,54 @@ const (
 	// The default value is 11 days, which is a 4 day resolution buffer
 	// plus the 7 day game finalization window.
 	DefaultGameWindow = time.Duration(11 * 24 * time.Hour)
+	// DefaultUrgentClockThreshold is the default remaining chess clock time
+	// below which a game is checked every block.
+	DefaultUrgentClockThreshold = time.Duration(time.Hour)
+	// DefaultTraceCacheSize is the default maximum size in bytes of each game's trace cache.
+	DefaultTraceCacheSize = uint64(32 * 1024 * 1024)
+	// DefaultTraceDiskCacheSize is the default maximum size in bytes of each game's disk trace cache.
+	DefaultTraceDiskCacheSize = uint64(256 * 1024 * 1024)
+	// DefaultTraceRateBurst is the default number of rate limited trace provider requests allowed at once.
+	DefaultTraceRateBurst = uint(10)
+	// DefaultClaimLoadConcurrency is the default number of claims fetched concurrently when loading a game.
+	DefaultClaimLoadConcurrency = uint(10)
+	// DefaultMaxParallelMoves is the default number of move transactions sent for a game at once.
+	DefaultMaxParallelMoves = uint(1)
+	// DefaultShutdownGracePeriod is the default time to wait for in-flight moves to confirm when shutting down.
+	DefaultShutdownGracePeriod = time.Duration(time.Minute)
+	// DefaultShutdownConfirmTimeout is the default time to wait for a shutdown to be confirmed
+	// when game clocks expire within the shutdown protection window.
+	DefaultShutdownConfirmTimeout = time.Duration(10 * time.Second)
+	// DefaultStaleGameThreshold is the default time a game's claim count can stay unchanged while a response is
+	// pending before the health check reports it as stale.
+	DefaultStaleGameThreshold = time.Duration(time.Hour)
+	// DefaultHealthStalenessWindow is the default time a subsystem can be failing before the health check reports
+	// it as unhealthy.
+	DefaultHealthStalenessWindow = time.Duration(5 * time.Minute)
+	// DefaultEventLogMaxSize is the default size in bytes the event log may reach before it is rotated.
+	DefaultEventLogMaxSize = uint64(100 * 1024 * 1024)
+	// DefaultDeprioritizeWindow is the default time the games lost by an address are counted over to decide whether
+	// to deprioritize its new games.
+	DefaultDeprioritizeWindow = time.Duration(7 * 24 * time.Hour)
+	// DefaultScheduleJitter is the default fraction each game's poll interval is randomly varied by.
+	DefaultScheduleJitter = 0.2
+	// DefaultActTimeBudget is the default time loading and acting on a game may take before a warning is logged,
+	// half the interval at which the monitor checks for new blocks to progress games at.
+	DefaultActTimeBudget = time.Duration(500 * time.Millisecond)
+	// DefaultBreakerWindow is the default time consecutive failed transactions are counted over by the circuit breaker.
+	DefaultBreakerWindow = time.Duration(time.Hour)
+	// DefaultBreakerProbeInterval is the default time between probe transactions while the circuit breaker is open.
+	DefaultBreakerProbeInterval = time.Duration(5 * time.Minute)
+	// DefaultClockSkewThreshold is the default skew of the local clock from L1 block timestamps that is alerted on.
+	DefaultClockSkewThreshold = time.Duration(30 * time.Second)
+	// DefaultTerminalPollInterval is the default time between checks of games that are no longer in progress but
+	// still scheduled.
+	DefaultTerminalPollInterval = time.Duration(5 * time.Minute)
+	// DefaultGameInfoInterval is the default time between logs of the state of a game that hasn't changed.
+	DefaultGameInfoInterval = time.Duration(time.Hour)
+	// DefaultUrgentTipMultiplier is the default multiplier of the priority fee of urgent moves, which pays the
+	// suggested fee.
+	DefaultUrgentTipMultiplier = 1.0
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -91,13 +177,73 @@ const (
 type Config struct {
 	L1EthRpc                string           // L1 RPC Url
 	GameFactoryAddress      common.Address   // Address of the dispute game factory
+	ExtraGameFactories      []common.Address // Addresses of further dispute game factories to play games from, sharing this config
+	FactoryOptions          []FactoryOption  // Overrides of trace provider config for specific factories
 	GameAllowlist           []common.Address // Allowlist of fault game addresses
+	GameDenylist            []common.Address // Denylist of fault game addresses, takes precedence over the allowlist
+	AcceptedPrestates       []common.Hash    // Onchain absolute prestates of games played by other trace providers during prestate upgrades
 	GameWindow              time.Duration    // Maximum time duration to look for games to progress
 	AgreeWithProposedOutput bool             // Temporary config if we agree or disagree with the posted output
 	Datadir                 string           // Data Directory
 	MaxConcurrency          uint             // Maximum number of threads to use when progressing games
+	ClaimLoadConcurrency    uint             // Maximum number of claims to fetch concurrently when loading a game
+	StatusConfirmations     uint64           // Number of L1 blocks a game's resolution must be buried by before it is treated as final. 0 acts on the status at the chain head
+	MaxMoveGas              uint64           // Maximum estimated gas for a move or step transaction. 0 disables the limit
+	MaxMovesPerCycle        uint             // Maximum number of moves made in a game each time it is progressed, most urgent first. 0 disables the limit
+	MaxParallelMoves        uint             // Maximum number of move transactions in flight at once for each game
+	MaxMoveGasPrice         float64          // Maximum L1 base fee in gwei to make moves at, unless the move is urgent. 0 disables the limit
+	MinMoveTip              float64          // Minimum priority fee in gwei paid by transactions. 0 disables the minimum
+	MaxMoveTip              float64          // Maximum priority fee in gwei paid by transactions. 0 disables the maximum
+	UrgentTipMultiplier     float64          // Multiplier of the priority fee of moves and steps countering claims within the urgent clock threshold
+	StrictDataAvailability  bool             // Defer cannon game moves until the game's L2 block is finalized on the L2 node
+	AcceptUnfinalizedRisk   bool             // Make urgent moves from unfinalized L2 data in strict data availability mode
+	HaltOnSelfConflict      bool             // Stop moving in a game once an opponent counters an agreed claim with the value of our own trace
+	BreakerFailures         uint             // Consecutive failed transactions across games after which non-critical transactions are halted. 0 disables the circuit breaker
+	BreakerWindow           time.Duration    // Time consecutive failed transactions are counted over. 0 counts every consecutive failure
+	BreakerProbeInterval    time.Duration    // Time between probe transactions while non-critical transactions are halted
+	TraceCacheSize          uint64           // Maximum size in bytes of each game's trace cache. 0 disables the cache
+	TraceDiskCacheDir       string           // Directory to persist trace data to across restarts. Empty disables the disk cache
+	TraceDiskCacheSize      uint64           // Maximum size in bytes of each game's disk trace cache
+	TraceRateLimit          float64          // Maximum step data and absolute prestate requests per second to trace providers, across all games. 0 disables the limit
+	TraceRateBurst          uint             // Maximum number of rate limited trace provider requests allowed at once
+	MetricsLabelByFactory   bool             // Label per-game metrics by factory address instead of game address
+	MetricsInstance         string           // Value of the instance label added to every metric. Empty for no instance label
+	GameLogs                bool             // Write the logs of each game to a JSON file in the game's data directory
+	GameLogLevel            log.Lvl          // Most verbose level written to game log files, matching the log level
+	UrgentClockThreshold    time.Duration    // Remaining chess clock time below which a game is checked every block
+	RelaxedPollInterval     time.Duration    // Time between checks of games that are not urgent. 0 checks every game every block
+	ContestedPollInterval   time.Duration    // Time between checks of games with claims added since they were last checked, if shorter than the relaxed interval. 0 checks them every block
+	TerminalPollInterval    time.Duration    // Time between checks of games that are no longer in progress but still scheduled, such as to retry claiming bonds. 0 checks them every block
+	ScheduleJitter          float64          // Fraction each game's poll interval is randomly varied by, either way, so games aren't all checked at once
+	MinMoveClock            time.Duration    // Remaining chess clock time needed to make a move. 0 disables the check
+	ActTimeBudget           time.Duration    // Time loading and acting on a game may take before a warning is logged. 0 disables the warning
+	GameInfoInterval        time.Duration    // Time between logs of the state of a game whose claims and status haven't changed. 0 only logs changes
+	ShutdownGracePeriod     time.Duration    // Maximum time to wait for in-flight moves to confirm when shutting down
+	ShutdownProtection      time.Duration    // Shutdowns must be confirmed if a game clock expires within this window. 0 disables the check
+	ShutdownConfirmTimeout  time.Duration    // Time to wait for a protected shutdown to be confirmed by another request
+	ForceShutdown           bool             // Shut down without confirmation even if game clocks expire within the protection window
+	StaleGameThreshold      time.Duration    // Time a game's claim count can stay unchanged with a response pending before the health check reports it as stale
+	HealthStalenessWindow   time.Duration    // Time a subsystem can be failing before the health check reports it as unhealthy
+	EventLog                string           // Path to write a JSONL stream of game events to. Empty disables the event log
+	EventLogMaxSize         uint64           // Size in bytes the event log may reach before it is rotated
+	ResultStoreSQLite       string           // Path of a SQLite database to record game results in for later analysis. Empty disables the result store
+	DeprioritizeAfterLosses uint64           // Games created by an address that lost more than this many games within the window are played at the lowest priority. 0 disables
+	DeprioritizeWindow      time.Duration    // Time the games lost by an address are counted over to decide whether to deprioritize its new games. 0 counts all losses
+	AutoClaimBonds          bool             // Claim the bonds credited to the challenger once a game is won
+	BondClaimDelay          time.Duration    // Time after a game resolves before its bonds are claimed, such as the DelayedWETH withdrawal delay
+	MoveBond                float64          // Bond in ether expected to be posted with each move, used to estimate the balance needed to play the games in progress
+	MaxSpendPerGame         float64          // Maximum ether to spend on each game's transactions before no more moves are made in it. 0 disables the limit
+	StopNewGamesUnderfunded bool             // Stop playing new games while the balance is below the estimate needed to play the games in progress
+	ResolvedGameRetention   time.Duration    // Time to keep the data of resolved games. 0 deletes it immediately and a negative value keeps it forever
+	PreserveLostGameData    bool             // Keep the data of lost games regardless of ResolvedGameRetention
+	ClockSkewThreshold      time.Duration    // Skew of the local clock from L1 block timestamps above which an error is logged. 0 disables the alert
+	MonitorOnly             bool             // Monitor games and log the transactions that would be sent without sending any, so no funded key is needed
+	Dashboard               bool             // Serve a dashboard page from the RPC server
+	AnalyzeGames            bool             // Estimate whether each game is winning if both sides play optimally
+	StepPregenDepth         uint             // Game depth beyond which step data is generated in the background. 0 disables pre-generation
+	GameTypeOptions         []GameTypeOption // Overrides of optional behaviours for specific game types
 
-	TraceType TraceType // Type of trace
+	TraceTypes []TraceType // Types of trace to play games with. Each plays different game types
 
 	// Specific to the alphabet trace provider
 	AlphabetTrace string // String for the AlphabetTraceProvider
@@ -112,15 +258,19 @@ type Config struct {
 	CannonL2               string // L2 RPC Url
 	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
 
+	// Specific to the file trace provider
+	TraceFile string // Path to the JSON file to replay the trace from
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	RPCConfig     rpc.CLIConfig
 }
 
 func NewConfig(
 	gameFactoryAddress common.Address,
 	l1EthRpc string,
-	traceType TraceType,
+	traceTypes []TraceType,
 	agreeWithProposedOutput bool,
 	datadir string,
 ) Config {
@@ -131,16 +281,40 @@ func NewConfig(
 
 		AgreeWithProposedOutput: agreeWithProposedOutput,
 
-		TraceType: traceType,
+		TraceTypes: traceTypes,
 
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
+		RPCConfig:     rpc.DefaultCLIConfig(),
 
 		Datadir: datadir,
 
 		CannonSnapshotFreq: DefaultCannonSnapshotFreq,
 		GameWindow:         DefaultGameWindow,
+
+		UrgentClockThreshold: DefaultUrgentClockThreshold,
+		TraceCacheSize:       DefaultTraceCacheSize,
+		TraceDiskCacheSize:   DefaultTraceDiskCacheSize,
+		TraceRateBurst:       DefaultTraceRateBurst,
+		ClaimLoadConcurrency: DefaultClaimLoadConcurrency,
+		MaxParallelMoves:     DefaultMaxParallelMoves,
+		ShutdownGracePeriod:  DefaultShutdownGracePeriod,
+		GameLogLevel:         log.LvlInfo,
+
+		ShutdownConfirmTimeout: DefaultShutdownConfirmTimeout,
+		StaleGameThreshold:     DefaultStaleGameThreshold,
+		HealthStalenessWindow:  DefaultHealthStalenessWindow,
+		EventLogMaxSize:        DefaultEventLogMaxSize,
+		DeprioritizeWindow:     DefaultDeprioritizeWindow,
+		ScheduleJitter:         DefaultScheduleJitter,
+		ActTimeBudget:          DefaultActTimeBudget,
+		GameInfoInterval:       DefaultGameInfoInterval,
+		TerminalPollInterval:   DefaultTerminalPollInterval,
+		BreakerWindow:          DefaultBreakerWindow,
+		BreakerProbeInterval:   DefaultBreakerProbeInterval,
+		ClockSkewThreshold:     DefaultClockSkewThreshold,
+		UrgentTipMultiplier:    DefaultUrgentTipMultiplier,
 	}
 }
 
@@ -148,19 +322,101 @@ func (c Config) Check() error {
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
 	}
-	if c.GameFactoryAddress == (common.Address{}) {
-		return ErrMissingGameFactoryAddress
+	if err := c.checkFactories(); err != nil {
+		return err
 	}
-	if c.TraceType == "" {
+	if len(c.TraceTypes) == 0 {
 		return ErrMissingTraceType
 	}
+	if _, err := c.GameTraceTypes(); err != nil {
+		return err
+	}
 	if c.Datadir == "" {
 		return ErrMissingDatadir
 	}
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
-	if c.TraceType == TraceTypeCannon {
+	if c.ClaimLoadConcurrency == 0 {
+		return ErrClaimLoadConcurrencyZero
+	}
+	if c.MaxParallelMoves == 0 {
+		return ErrMaxParallelMovesZero
+	}
+	if c.MaxMoveGasPrice < 0 {
+		return ErrNegativeMaxMoveGasPrice
+	}
+	if c.MinMoveTip < 0 || c.MaxMoveTip < 0 {
+		return ErrNegativeMoveTip
+	}
+	if c.MaxMoveTip > 0 && c.MaxMoveTip < c.MinMoveTip {
+		return ErrMaxMoveTipBelowMin
+	}
+	if c.UrgentTipMultiplier < 1 {
+		return ErrUrgentTipMultiplierBelowOne
+	}
+	if c.MoveBond < 0 {
+		return ErrNegativeMoveBond
+	}
+	if c.MaxSpendPerGame < 0 {
+		return ErrNegativeMaxSpendPerGame
+	}
+	if c.AcceptUnfinalizedRisk && !c.StrictDataAvailability {
+		return ErrUnfinalizedRiskWithoutStrict
+	}
+	if c.EventLog != "" && c.EventLogMaxSize == 0 {
+		return ErrEventLogMaxSizeZero
+	}
+	if c.TraceDiskCacheDir != "" && c.TraceDiskCacheSize == 0 {
+		return ErrTraceDiskCacheSizeZero
+	}
+	if c.TraceRateLimit < 0 {
+		return ErrNegativeTraceRateLimit
+	}
+	if c.TraceRateLimit > 0 && c.TraceRateBurst == 0 {
+		return ErrTraceRateBurstZero
+	}
+	if c.ScheduleJitter < 0 || c.ScheduleJitter > 1 {
+		return ErrInvalidScheduleJitter
+	}
+	if c.Dashboard && !c.RPCConfig.Enabled {
+		return ErrDashboardRequiresRPC
+	}
+	factories := c.GameFactories()
+	for _, factory := range factories {
+		cfg, err := c.ForFactory(factory)
+		if err != nil {
+			return err
+		}
+		if err := cfg.checkTraceProviders(); err != nil && len(factories) > 1 {
+			return fmt.Errorf("game factory %v: %w", factory, err)
+		} else if err != nil {
+			return err
+		}
+	}
+	for _, option := range c.GameTypeOptions {
+		if err := new(GameTypeOptions).apply(option); err != nil {
+			return err
+		}
+	}
+	if err := c.TxMgrConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.PprofConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.RPCConfig.Check(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkTraceProviders checks the config of each enabled trace type, which may differ between factories.
+func (c Config) checkTraceProviders() error {
+	if c.TraceTypeEnabled(TraceTypeCannon) {
 		if c.CannonBin == "" {
 			return ErrMissingCannonBin
 		}
@@ -195,17 +451,74 @@ func (c Config) Check() error {
 			return ErrMissingCannonSnapshotFreq
 		}
 	}
-	if c.TraceType == TraceTypeAlphabet && c.AlphabetTrace == "" {
+	if c.TraceTypeEnabled(TraceTypeAlphabet) && c.AlphabetTrace == "" {
 		return ErrMissingAlphabetTrace
 	}
-	if err := c.TxMgrConfig.Check(); err != nil {
-		return err
+	if c.TraceTypeEnabled(TraceTypeFile) && c.TraceFile == "" {
+		return ErrMissingTraceFile
 	}
-	if err := c.MetricsConfig.Check(); err != nil {
-		return err
+	return nil
+}
+
+// MaxMoveGasPriceWei returns the gas price ceiling for moves in wei, or nil if moves aren't limited by gas price.
+func (c Config) MaxMoveGasPriceWei() *big.Int {
+	return gweiOrNil(c.MaxMoveGasPrice)
+}
+
+// MinMoveTipWei returns the minimum priority fee of transactions in wei, or nil if there is no minimum.
+func (c Config) MinMoveTipWei() *big.Int {
+	return gweiOrNil(c.MinMoveTip)
+}
+
+// MaxMoveTipWei returns the maximum priority fee of transactions in wei, or nil if there is no maximum.
+func (c Config) MaxMoveTipWei() *big.Int {
+	return gweiOrNil(c.MaxMoveTip)
+}
+
+func gweiOrNil(gwei float64) *big.Int {
+	if gwei <= 0 {
+		return nil
 	}
-	if err := c.PprofConfig.Check(); err != nil {
-		return err
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
+
+// MoveBondWei returns the bond expected to be posted with each move in wei.
+func (c Config) MoveBondWei() *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(c.MoveBond), big.NewFloat(params.Ether)).Int(nil)
+	return wei
+}
+
+// MaxSpendPerGameWei returns the maximum to spend on each game in wei, or nil if the spend isn't limited.
+func (c Config) MaxSpendPerGameWei() *big.Int {
+	if c.MaxSpendPerGame <= 0 {
+		return nil
 	}
-	return nil
+	wei, _ := new(big.Float).Mul(big.NewFloat(c.MaxSpendPerGame), big.NewFloat(params.Ether)).Int(nil)
+	return wei
+}
+
+// TraceTypeEnabled returns true if games are played with traceType.
+func (c Config) TraceTypeEnabled(traceType TraceType) bool {
+	for _, t := range c.TraceTypes {
+		if t == traceType {
+			return true
+		}
+	}
+	return false
+}
+
+// GameTraceTypes maps each playable game type to the trace type it is played with.
+// Returns ErrConflictingTraceTypes if more than one trace type plays the same game type.
+func (c Config) GameTraceTypes() (map[uint8]TraceType, error) {
+	gameTypes := make(map[uint8]TraceType)
+	for _, traceType := range c.TraceTypes {
+		for _, gameType := range traceType.GameTypes() {
+			if existing, ok := gameTypes[gameType]; ok && existing != traceType {
+				return nil, fmt.Errorf("%w: %v and %v both play game type %v", ErrConflictingTraceTypes, existing, traceType, gameType)
+			}
+			gameTypes[gameType] = traceType
+		}
+	}
+	return gameTypes, nil
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"math/big"
 	"runtime"
 	"testing"
 
@@ -20,20 +21,25 @@ var (
 	validCannonAbsolutPreState = "pre.json"
 	validDatadir               = "/tmp/data"
 	validCannonL2              = "http://localhost:9545"
+	validTraceFile             = "trace.json"
 	agreeWithProposedOutput    = true
 )
 
-func validConfig(traceType TraceType) Config {
-	cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, traceType, agreeWithProposedOutput, validDatadir)
-	switch traceType {
-	case TraceTypeAlphabet:
-		cfg.AlphabetTrace = validAlphabetTrace
-	case TraceTypeCannon:
-		cfg.CannonBin = validCannonBin
-		cfg.CannonServer = validCannonOpProgramBin
-		cfg.CannonAbsolutePreState = validCannonAbsolutPreState
-		cfg.CannonL2 = validCannonL2
-		cfg.CannonNetwork = validCannonNetwork
+func validConfig(traceTypes ...TraceType) Config {
+	cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, traceTypes, agreeWithProposedOutput, validDatadir)
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case TraceTypeAlphabet:
+			cfg.AlphabetTrace = validAlphabetTrace
+		case TraceTypeCannon:
+			cfg.CannonBin = validCannonBin
+			cfg.CannonServer = validCannonOpProgramBin
+			cfg.CannonAbsolutePreState = validCannonAbsolutPreState
+			cfg.CannonL2 = validCannonL2
+			cfg.CannonNetwork = validCannonNetwork
+		case TraceTypeFile:
+			cfg.TraceFile = validTraceFile
+		}
 	}
 	return cfg
 }
@@ -49,6 +55,35 @@ func TestValidConfigIsValid(t *testing.T) {
 	}
 }
 
+func TestTraceTypes(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		config := validConfig()
+		require.ErrorIs(t, config.Check(), ErrMissingTraceType)
+	})
+
+	t.Run("Multiple", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon, TraceTypeAlphabet)
+		require.NoError(t, config.Check())
+		gameTypes, err := config.GameTraceTypes()
+		require.NoError(t, err)
+		require.Equal(t, map[uint8]TraceType{
+			CannonFaultGameID:   TraceTypeCannon,
+			AlphabetFaultGameID: TraceTypeAlphabet,
+		}, gameTypes)
+	})
+
+	t.Run("RequireOptionsForEachType", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon, TraceTypeAlphabet)
+		config.AlphabetTrace = ""
+		require.ErrorIs(t, config.Check(), ErrMissingAlphabetTrace)
+	})
+
+	t.Run("Conflicting", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon, TraceTypeFile)
+		require.ErrorIs(t, config.Check(), ErrConflictingTraceTypes)
+	})
+}
+
 func TestTxMgrConfig(t *testing.T) {
 	t.Run("Invalid", func(t *testing.T) {
 		config := validConfig(TraceTypeCannon)
@@ -81,6 +116,12 @@ func TestAlphabetTraceRequired(t *testing.T) {
 	require.ErrorIs(t, config.Check(), ErrMissingAlphabetTrace)
 }
 
+func TestTraceFileRequired(t *testing.T) {
+	config := validConfig(TraceTypeFile)
+	config.TraceFile = ""
+	require.ErrorIs(t, config.Check(), ErrMissingTraceFile)
+}
+
 func TestCannonBinRequired(t *testing.T) {
 	config := validConfig(TraceTypeCannon)
 	config.CannonBin = ""
@@ -118,6 +159,177 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestEventLogMaxSize(t *testing.T) {
+	t.Run("RequiredWhenEventLogEnabled", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.EventLog = "/tmp/events.jsonl"
+		config.EventLogMaxSize = 0
+		require.ErrorIs(t, config.Check(), ErrEventLogMaxSizeZero)
+	})
+
+	t.Run("NotRequiredWhenEventLogDisabled", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.EventLogMaxSize = 0
+		require.NoError(t, config.Check())
+	})
+}
+
+func TestDashboardRequiresRPC(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	config.Dashboard = true
+	require.ErrorIs(t, config.Check(), ErrDashboardRequiresRPC)
+
+	config.RPCConfig.Enabled = true
+	require.NoError(t, config.Check())
+}
+
+func TestTraceDiskCacheSize(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Equal(t, DefaultTraceDiskCacheSize, config.TraceDiskCacheSize)
+	config.TraceDiskCacheSize = 0
+	require.NoError(t, config.Check(), "should not require a size when the disk cache is disabled")
+
+	config.TraceDiskCacheDir = "/tmp/cache"
+	require.ErrorIs(t, config.Check(), ErrTraceDiskCacheSizeZero)
+}
+
+func TestTraceRateLimit(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Zero(t, config.TraceRateLimit)
+	require.Equal(t, DefaultTraceRateBurst, config.TraceRateBurst)
+	config.TraceRateBurst = 0
+	require.NoError(t, config.Check(), "should not require a burst when the rate is unlimited")
+
+	config.TraceRateLimit = 2.5
+	require.ErrorIs(t, config.Check(), ErrTraceRateBurstZero)
+	config.TraceRateBurst = 1
+	require.NoError(t, config.Check())
+
+	config.TraceRateLimit = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeTraceRateLimit)
+}
+
+func TestClaimLoadConcurrency(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.ClaimLoadConcurrency = 0
+		require.ErrorIs(t, config.Check(), ErrClaimLoadConcurrencyZero)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		require.Equal(t, DefaultClaimLoadConcurrency, config.ClaimLoadConcurrency)
+	})
+}
+
+func TestMaxParallelMoves(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.MaxParallelMoves = 0
+		require.ErrorIs(t, config.Check(), ErrMaxParallelMovesZero)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		require.Equal(t, DefaultMaxParallelMoves, config.MaxParallelMoves)
+	})
+}
+
+func TestMaxMoveGasPrice(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Nil(t, config.MaxMoveGasPriceWei(), "should be disabled by default")
+
+	config.MaxMoveGasPrice = 1.5
+	require.NoError(t, config.Check())
+	require.Equal(t, big.NewInt(1_500_000_000), config.MaxMoveGasPriceWei())
+
+	config.MaxMoveGasPrice = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeMaxMoveGasPrice)
+}
+
+func TestMoveTips(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Nil(t, config.MinMoveTipWei(), "should have no minimum by default")
+	require.Nil(t, config.MaxMoveTipWei(), "should have no maximum by default")
+	require.Equal(t, DefaultUrgentTipMultiplier, config.UrgentTipMultiplier)
+
+	config.MinMoveTip = 0.5
+	config.MaxMoveTip = 2
+	require.NoError(t, config.Check())
+	require.Equal(t, big.NewInt(500_000_000), config.MinMoveTipWei())
+	require.Equal(t, big.NewInt(2_000_000_000), config.MaxMoveTipWei())
+
+	config.MaxMoveTip = 0.25
+	require.ErrorIs(t, config.Check(), ErrMaxMoveTipBelowMin)
+
+	config.MaxMoveTip = 0
+	require.NoError(t, config.Check(), "should allow a minimum without a maximum")
+
+	config.MinMoveTip = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeMoveTip)
+
+	config = validConfig(TraceTypeAlphabet)
+	config.MaxMoveTip = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeMoveTip)
+}
+
+func TestUrgentTipMultiplier(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	config.UrgentTipMultiplier = 2.5
+	require.NoError(t, config.Check())
+
+	config.UrgentTipMultiplier = 0.5
+	require.ErrorIs(t, config.Check(), ErrUrgentTipMultiplierBelowOne)
+}
+
+func TestMoveBond(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Zero(t, config.MoveBondWei().Sign(), "should be zero by default")
+
+	config.MoveBond = 0.08
+	require.NoError(t, config.Check())
+	require.Equal(t, big.NewInt(80_000_000_000_000_000), config.MoveBondWei())
+
+	config.MoveBond = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeMoveBond)
+}
+
+func TestMaxSpendPerGame(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Nil(t, config.MaxSpendPerGameWei(), "should be disabled by default")
+
+	config.MaxSpendPerGame = 0.5
+	require.NoError(t, config.Check())
+	require.Equal(t, big.NewInt(500_000_000_000_000_000), config.MaxSpendPerGameWei())
+
+	config.MaxSpendPerGame = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeMaxSpendPerGame)
+}
+
+func TestScheduleJitter(t *testing.T) {
+	config := validConfig(TraceTypeCannon)
+	require.Equal(t, DefaultScheduleJitter, config.ScheduleJitter)
+
+	config.ScheduleJitter = 0
+	require.NoError(t, config.Check())
+	config.ScheduleJitter = 1
+	require.NoError(t, config.Check())
+
+	config.ScheduleJitter = -0.1
+	require.ErrorIs(t, config.Check(), ErrInvalidScheduleJitter)
+	config.ScheduleJitter = 1.1
+	require.ErrorIs(t, config.Check(), ErrInvalidScheduleJitter)
+}
+
+func TestAcceptUnfinalizedRisk(t *testing.T) {
+	config := validConfig(TraceTypeCannon)
+	config.AcceptUnfinalizedRisk = true
+	require.ErrorIs(t, config.Check(), ErrUnfinalizedRiskWithoutStrict)
+
+	config.StrictDataAvailability = true
+	require.NoError(t, config.Check())
+}
+
 func TestCannonL2Required(t *testing.T) {
 	config := validConfig(TraceTypeCannon)
 	config.CannonL2 = ""
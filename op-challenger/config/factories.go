@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	ErrInvalidFactoryOption = errors.New("invalid factory option")
+	ErrUnknownFactoryOption = errors.New("unknown factory option")
+	ErrUnknownGameFactory   = errors.New("factory option for unknown game factory")
+	ErrDuplicateGameFactory = errors.New("duplicate game factory address")
+)
+
+const (
+	// FactoryOptionAlphabet overrides AlphabetTrace for games of the factory.
+	FactoryOptionAlphabet = "alphabet"
+	// FactoryOptionTraceFile overrides TraceFile for games of the factory.
+	FactoryOptionTraceFile = "trace-file"
+	// FactoryOptionCannonNetwork overrides CannonNetwork for games of the factory.
+	FactoryOptionCannonNetwork = "cannon-network"
+	// FactoryOptionCannonRollupConfig overrides CannonRollupConfigPath for games of the factory.
+	FactoryOptionCannonRollupConfig = "cannon-rollup-config"
+	// FactoryOptionCannonL2Genesis overrides CannonL2GenesisPath for games of the factory.
+	FactoryOptionCannonL2Genesis = "cannon-l2-genesis"
+	// FactoryOptionCannonPrestate overrides CannonAbsolutePreState for games of the factory.
+	FactoryOptionCannonPrestate = "cannon-prestate"
+	// FactoryOptionCannonL2 overrides CannonL2 for games of the factory.
+	FactoryOptionCannonL2 = "cannon-l2"
+)
+
+// FactoryOptionKeys are the supported factory option keys.
+var FactoryOptionKeys = []string{
+	FactoryOptionAlphabet,
+	FactoryOptionTraceFile,
+	FactoryOptionCannonNetwork,
+	FactoryOptionCannonRollupConfig,
+	FactoryOptionCannonL2Genesis,
+	FactoryOptionCannonPrestate,
+	FactoryOptionCannonL2,
+}
+
+// FactoryOption overrides a single trace provider setting for the games of one dispute game factory.
+// It is parsed from strings of the form <factory-address>.<key>=<value>.
+type FactoryOption struct {
+	Factory common.Address
+	Key     string
+	Value   string
+}
+
+func (o FactoryOption) String() string {
+	return fmt.Sprintf("%v.%v=%v", o.Factory, o.Key, o.Value)
+}
+
+// ParseFactoryOption parses a <factory-address>.<key>=<value> option.
+// The key is not validated so that unknown options are reported by [Config.Check].
+func ParseFactoryOption(value string) (FactoryOption, error) {
+	addr, rest, ok := strings.Cut(value, ".")
+	if !ok {
+		return FactoryOption{}, fmt.Errorf("%w: %q must be <factory-address>.<key>=<value>", ErrInvalidFactoryOption, value)
+	}
+	key, val, ok := strings.Cut(rest, "=")
+	if !ok || key == "" {
+		return FactoryOption{}, fmt.Errorf("%w: %q must be <factory-address>.<key>=<value>", ErrInvalidFactoryOption, value)
+	}
+	if !common.IsHexAddress(addr) {
+		return FactoryOption{}, fmt.Errorf("%w: invalid factory address %q", ErrInvalidFactoryOption, addr)
+	}
+	return FactoryOption{Factory: common.HexToAddress(addr), Key: key, Value: val}, nil
+}
+
+// GameFactories returns the addresses of every dispute game factory to play games from, GameFactoryAddress first.
+func (c Config) GameFactories() []common.Address {
+	return append([]common.Address{c.GameFactoryAddress}, c.ExtraGameFactories...)
+}
+
+// ForFactory returns the config to play the games of factory with. The config is shared by every factory as the
+// defaults, with any FactoryOptions for factory applied on top.
+// The returned config plays games from factory only.
+func (c Config) ForFactory(factory common.Address) (Config, error) {
+	cfg := c
+	cfg.GameFactoryAddress = factory
+	cfg.ExtraGameFactories = nil
+	cfg.FactoryOptions = nil
+	for _, option := range c.FactoryOptions {
+		if option.Factory != factory {
+			continue
+		}
+		if err := cfg.applyFactoryOption(option); err != nil {
+			return Config{}, err
+		}
+	}
+	return cfg, nil
+}
+
+func (c *Config) applyFactoryOption(option FactoryOption) error {
+	switch option.Key {
+	case FactoryOptionAlphabet:
+		c.AlphabetTrace = option.Value
+	case FactoryOptionTraceFile:
+		c.TraceFile = option.Value
+	case FactoryOptionCannonNetwork:
+		c.CannonNetwork = option.Value
+	case FactoryOptionCannonRollupConfig:
+		c.CannonRollupConfigPath = option.Value
+	case FactoryOptionCannonL2Genesis:
+		c.CannonL2GenesisPath = option.Value
+	case FactoryOptionCannonPrestate:
+		c.CannonAbsolutePreState = option.Value
+	case FactoryOptionCannonL2:
+		c.CannonL2 = option.Value
+	default:
+		return fmt.Errorf("%w: %v", ErrUnknownFactoryOption, option.Key)
+	}
+	return nil
+}
+
+// checkFactories checks the factories are distinct and that every factory option applies to one of them.
+func (c Config) checkFactories() error {
+	factories := c.GameFactories()
+	for i, factory := range factories {
+		if factory == (common.Address{}) {
+			return ErrMissingGameFactoryAddress
+		}
+		if slices.Contains(factories[:i], factory) {
+			return fmt.Errorf("%w: %v", ErrDuplicateGameFactory, factory)
+		}
+	}
+	for _, option := range c.FactoryOptions {
+		if !slices.Contains(factories, option.Factory) {
+			return fmt.Errorf("%w: %v", ErrUnknownGameFactory, option)
+		}
+	}
+	return nil
+}
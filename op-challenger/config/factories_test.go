@@ -0,0 +1,107 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFactoryOption(t *testing.T) {
+	factory := common.Address{0xaa}
+	tests := []struct {
+		value    string
+		expected FactoryOption
+		err      error
+	}{
+		{factory.Hex() + ".cannon-l2=http://l2", FactoryOption{Factory: factory, Key: "cannon-l2", Value: "http://l2"}, nil},
+		{factory.Hex() + ".alphabet=", FactoryOption{Factory: factory, Key: "alphabet", Value: ""}, nil},
+		{factory.Hex(), FactoryOption{}, ErrInvalidFactoryOption},
+		{factory.Hex() + ".cannon-l2", FactoryOption{}, ErrInvalidFactoryOption},
+		{factory.Hex() + ".=1", FactoryOption{}, ErrInvalidFactoryOption},
+		{"0x1234.cannon-l2=http://l2", FactoryOption{}, ErrInvalidFactoryOption},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			option, err := ParseFactoryOption(test.value)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expected, option)
+		})
+	}
+}
+
+func TestForFactory(t *testing.T) {
+	other := common.Address{0xbb}
+	cfg := validConfig(TraceTypeCannon)
+	cfg.ExtraGameFactories = []common.Address{other}
+	cfg.FactoryOptions = []FactoryOption{
+		{Factory: other, Key: FactoryOptionCannonL2, Value: "http://other-l2"},
+		{Factory: other, Key: FactoryOptionCannonNetwork, Value: "goerli"},
+	}
+	require.NoError(t, cfg.Check())
+	require.Equal(t, []common.Address{validGameFactoryAddress, other}, cfg.GameFactories())
+
+	primary, err := cfg.ForFactory(validGameFactoryAddress)
+	require.NoError(t, err)
+	require.Equal(t, validGameFactoryAddress, primary.GameFactoryAddress)
+	require.Equal(t, validCannonL2, primary.CannonL2)
+	require.Equal(t, validCannonNetwork, primary.CannonNetwork)
+	require.Equal(t, []common.Address{validGameFactoryAddress}, primary.GameFactories())
+
+	otherCfg, err := cfg.ForFactory(other)
+	require.NoError(t, err)
+	require.Equal(t, other, otherCfg.GameFactoryAddress)
+	require.Equal(t, "http://other-l2", otherCfg.CannonL2)
+	require.Equal(t, "goerli", otherCfg.CannonNetwork)
+	require.Equal(t, validCannonBin, otherCfg.CannonBin, "should share the defaults")
+	require.Empty(t, otherCfg.FactoryOptions)
+}
+
+func TestGameFactoriesMustBeValid(t *testing.T) {
+	other := common.Address{0xbb}
+
+	t.Run("Duplicate", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ExtraGameFactories = []common.Address{other, validGameFactoryAddress}
+		require.ErrorIs(t, cfg.Check(), ErrDuplicateGameFactory)
+	})
+
+	t.Run("MissingExtra", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ExtraGameFactories = []common.Address{{}}
+		require.ErrorIs(t, cfg.Check(), ErrMissingGameFactoryAddress)
+	})
+
+	t.Run("OptionForUnknownFactory", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.FactoryOptions = []FactoryOption{{Factory: other, Key: FactoryOptionAlphabet, Value: "abc"}}
+		require.ErrorIs(t, cfg.Check(), ErrUnknownGameFactory)
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.FactoryOptions = []FactoryOption{{Factory: validGameFactoryAddress, Key: "foo", Value: "1"}}
+		require.ErrorIs(t, cfg.Check(), ErrUnknownFactoryOption)
+	})
+
+	t.Run("InvalidFactoryConfig", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ExtraGameFactories = []common.Address{other}
+		cfg.FactoryOptions = []FactoryOption{{Factory: other, Key: FactoryOptionCannonL2, Value: ""}}
+		err := cfg.Check()
+		require.ErrorIs(t, err, ErrMissingCannonL2)
+		require.ErrorContains(t, err, other.Hex())
+	})
+
+	t.Run("OverrideMissingDefault", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.CannonL2 = ""
+		cfg.ExtraGameFactories = []common.Address{other}
+		cfg.FactoryOptions = []FactoryOption{
+			{Factory: validGameFactoryAddress, Key: FactoryOptionCannonL2, Value: "http://l2"},
+			{Factory: other, Key: FactoryOptionCannonL2, Value: "http://other-l2"},
+		}
+		require.NoError(t, cfg.Check(), "should only require the config of each factory to be complete")
+	})
+}
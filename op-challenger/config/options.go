@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidGameTypeOption = errors.New("invalid game type option")
+	ErrUnknownGameType       = errors.New("unknown game type")
+	ErrUnknownGameTypeOption = errors.New("unknown game type option")
+)
+
+const (
+	// GameTypeOptionMaxMoveGas overrides MaxMoveGas, skipping moves that aren't economic for the game type.
+	GameTypeOptionMaxMoveGas = "max-move-gas"
+	// GameTypeOptionTraceCacheSize overrides TraceCacheSize for games of the game type.
+	GameTypeOptionTraceCacheSize = "trace-cache-size"
+)
+
+// GameTypeOptions are the optional behaviours of the challenger that can differ between game types.
+type GameTypeOptions struct {
+	MaxMoveGas     uint64 // Maximum estimated gas for a move or step transaction. 0 disables the limit
+	TraceCacheSize uint64 // Maximum size in bytes of each game's trace cache. 0 disables the cache
+}
+
+// GameTypeOption overrides a single option for one game type.
+// It is parsed from strings of the form <game-type>.<key>=<value> where the game type is its name or ID.
+type GameTypeOption struct {
+	GameType uint8
+	Key      string
+	Value    string
+}
+
+func (o GameTypeOption) String() string {
+	return fmt.Sprintf("%v.%v=%v", o.GameType, o.Key, o.Value)
+}
+
+// ParseGameTypeOption parses a <game-type>.<key>=<value> option.
+// The key is not validated so that unknown options are reported by [Config.Check].
+func ParseGameTypeOption(value string) (GameTypeOption, error) {
+	name, rest, ok := strings.Cut(value, ".")
+	if !ok {
+		return GameTypeOption{}, fmt.Errorf("%w: %q must be <game-type>.<key>=<value>", ErrInvalidGameTypeOption, value)
+	}
+	key, val, ok := strings.Cut(rest, "=")
+	if !ok || key == "" {
+		return GameTypeOption{}, fmt.Errorf("%w: %q must be <game-type>.<key>=<value>", ErrInvalidGameTypeOption, value)
+	}
+	gameType, err := parseGameType(name)
+	if err != nil {
+		return GameTypeOption{}, err
+	}
+	return GameTypeOption{GameType: gameType, Key: key, Value: val}, nil
+}
+
+func parseGameType(name string) (uint8, error) {
+	for id, idName := range GameIdToString {
+		if strings.EqualFold(name, idName) {
+			return id, nil
+		}
+	}
+	id, err := strconv.ParseUint(name, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownGameType, name)
+	}
+	return uint8(id), nil
+}
+
+// OptionsForGameType resolves the options for a game type, applying any overrides for that game type
+// on top of the global config.
+func (c Config) OptionsForGameType(gameType uint8) (GameTypeOptions, error) {
+	opts := GameTypeOptions{
+		MaxMoveGas:     c.MaxMoveGas,
+		TraceCacheSize: c.TraceCacheSize,
+	}
+	for _, option := range c.GameTypeOptions {
+		if option.GameType != gameType {
+			continue
+		}
+		if err := opts.apply(option); err != nil {
+			return GameTypeOptions{}, err
+		}
+	}
+	return opts, nil
+}
+
+func (o *GameTypeOptions) apply(option GameTypeOption) error {
+	switch option.Key {
+	case GameTypeOptionMaxMoveGas:
+		gas, err := strconv.ParseUint(option.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w %v: %w", ErrInvalidGameTypeOption, option, err)
+		}
+		o.MaxMoveGas = gas
+	case GameTypeOptionTraceCacheSize:
+		size, err := strconv.ParseUint(option.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w %v: %w", ErrInvalidGameTypeOption, option, err)
+		}
+		o.TraceCacheSize = size
+	default:
+		return fmt.Errorf("%w: %v", ErrUnknownGameTypeOption, option.Key)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGameTypeOption(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected GameTypeOption
+		err      error
+	}{
+		{"cannon.max-move-gas=100", GameTypeOption{GameType: CannonFaultGameID, Key: "max-move-gas", Value: "100"}, nil},
+		{"Alphabet.max-move-gas=", GameTypeOption{GameType: AlphabetFaultGameID, Key: "max-move-gas", Value: ""}, nil},
+		{"7.foo=bar", GameTypeOption{GameType: 7, Key: "foo", Value: "bar"}, nil},
+		{"cannon", GameTypeOption{}, ErrInvalidGameTypeOption},
+		{"cannon.max-move-gas", GameTypeOption{}, ErrInvalidGameTypeOption},
+		{"cannon.=1", GameTypeOption{}, ErrInvalidGameTypeOption},
+		{"unknown.max-move-gas=1", GameTypeOption{}, ErrUnknownGameType},
+		{"256.max-move-gas=1", GameTypeOption{}, ErrUnknownGameType},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			option, err := ParseGameTypeOption(test.value)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expected, option)
+		})
+	}
+}
+
+func TestOptionsForGameType(t *testing.T) {
+	cfg := validConfig(TraceTypeAlphabet)
+	cfg.MaxMoveGas = 1000
+	cfg.TraceCacheSize = 2000
+	cfg.GameTypeOptions = []GameTypeOption{
+		{GameType: CannonFaultGameID, Key: GameTypeOptionMaxMoveGas, Value: "500000"},
+		{GameType: AlphabetFaultGameID, Key: GameTypeOptionTraceCacheSize, Value: "0"},
+	}
+	require.NoError(t, cfg.Check())
+
+	cannon, err := cfg.OptionsForGameType(CannonFaultGameID)
+	require.NoError(t, err)
+	require.Equal(t, GameTypeOptions{MaxMoveGas: 500000, TraceCacheSize: 2000}, cannon)
+
+	alphabet, err := cfg.OptionsForGameType(AlphabetFaultGameID)
+	require.NoError(t, err)
+	require.Equal(t, GameTypeOptions{MaxMoveGas: 1000, TraceCacheSize: 0}, alphabet)
+}
+
+func TestGameTypeOptionsMustBeValid(t *testing.T) {
+	t.Run("UnknownKey", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.GameTypeOptions = []GameTypeOption{{GameType: CannonFaultGameID, Key: "foo", Value: "1"}}
+		require.ErrorIs(t, cfg.Check(), ErrUnknownGameTypeOption)
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.GameTypeOptions = []GameTypeOption{{GameType: CannonFaultGameID, Key: GameTypeOptionMaxMoveGas, Value: "abc"}}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidGameTypeOption)
+	})
+}
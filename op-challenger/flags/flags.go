@@ -6,9 +6,13 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
@@ -35,7 +39,7 @@ var (
 	}
 	FactoryAddressFlag = &cli.StringFlag{
 		Name:    "game-factory-address",
-		Usage:   "Address of the fault game factory contract.",
+		Usage:   "Address of the fault game factory contract, or a comma-separated list of addresses to play the games of several factories.",
 		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
 	}
 	GameAllowlistFlag = &cli.StringSliceFlag{
@@ -44,14 +48,23 @@ var (
 			"If empty, the challenger will play all games.",
 		EnvVars: prefixEnvVars("GAME_ALLOWLIST"),
 	}
-	TraceTypeFlag = &cli.GenericFlag{
-		Name:    "trace-type",
-		Usage:   "The trace type. Valid options: " + openum.EnumString(config.TraceTypes),
+	GameDenylistFlag = &cli.StringSliceFlag{
+		Name: "game-denylist",
+		Usage: "List of Fault Game contract addresses the challenger must not play. " +
+			"Takes precedence over the allowlist if a game is in both.",
+		EnvVars: prefixEnvVars("GAME_DENYLIST"),
+	}
+	AcceptedAbsolutePrestateFlag = &cli.StringSliceFlag{
+		Name: "accepted-absolute-prestate",
+		Usage: "Onchain absolute prestate hash of games that are valid but played with a different trace provider, so are skipped rather than reported as a prestate mismatch. " +
+			"May be specified multiple times to skip games with each prestate during an absolute prestate upgrade.",
+		EnvVars: prefixEnvVars("ACCEPTED_ABSOLUTE_PRESTATE"),
+	}
+	TraceTypeFlag = &cli.StringSliceFlag{
+		Name: "trace-type",
+		Usage: "The trace type. May be specified multiple times to play games of each trace type's game types. " +
+			"Valid options: " + openum.EnumString(config.TraceTypes),
 		EnvVars: prefixEnvVars("TRACE_TYPE"),
-		Value: func() *config.TraceType {
-			out := config.TraceType("") // No default value
-			return &out
-		}(),
 	}
 	AgreeWithProposedOutputFlag = &cli.BoolFlag{
 		Name:    "agree-with-proposed-output",
@@ -70,11 +83,27 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	ClaimLoadConcurrencyFlag = &cli.UintFlag{
+		Name:    "claim-load-concurrency",
+		Usage:   "Maximum number of claims to fetch concurrently when loading a game",
+		EnvVars: prefixEnvVars("CLAIM_LOAD_CONCURRENCY"),
+		Value:   config.DefaultClaimLoadConcurrency,
+	}
+	StatusConfirmationsFlag = &cli.Uint64Flag{
+		Name:    "status-confirmations",
+		Usage:   "Number of L1 blocks a game's resolution must be buried by before the game is treated as resolved, so resolutions reorged out are not acted on. 0 uses the status at the chain head.",
+		EnvVars: prefixEnvVars("STATUS_CONFIRMATIONS"),
+	}
 	AlphabetFlag = &cli.StringFlag{
 		Name:    "alphabet",
-		Usage:   "Correct Alphabet Trace (alphabet trace type only)",
+		Usage:   "Correct Alphabet Trace as a word, 0x-prefixed hex data with a state per byte, or @ and a file of state values (alphabet trace type only)",
 		EnvVars: prefixEnvVars("ALPHABET"),
 	}
+	TraceFileFlag = &cli.StringFlag{
+		Name:    "trace-file",
+		Usage:   "Path to the JSON file to replay the trace from (file trace type only)",
+		EnvVars: prefixEnvVars("TRACE_FILE"),
+	}
 	CannonNetworkFlag = &cli.StringFlag{
 		Name:    "cannon-network",
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s (cannon trace type only)", strings.Join(chaincfg.AvailableNetworks(), ", ")),
@@ -112,7 +141,7 @@ var (
 	}
 	CannonSnapshotFreqFlag = &cli.UintFlag{
 		Name:    "cannon-snapshot-freq",
-		Usage:   "Frequency of cannon snapshots to generate in VM steps (cannon trace type only)",
+		Usage:   "Frequency of cannon snapshots to generate in VM steps (cannon trace type only). Proof generation resumes from the closest snapshot, including after a restart.",
 		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_FREQ"),
 		Value:   config.DefaultCannonSnapshotFreq,
 	}
@@ -122,6 +151,299 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	MaxMoveGasFlag = &cli.Uint64Flag{
+		Name:    "max-move-gas",
+		Usage:   "Maximum estimated gas for a move or step transaction. Moves estimated to use more gas are skipped. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_MOVE_GAS"),
+	}
+	MaxMovesPerCycleFlag = &cli.UintFlag{
+		Name:    "max-moves-per-cycle",
+		Usage:   "Maximum number of moves made in a game each time it is progressed. The moves countering claims with the least time remaining are made first and the rest deferred. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_MOVES_PER_CYCLE"),
+	}
+	MaxMoveGasPriceFlag = &cli.Float64Flag{
+		Name:    "max-move-gas-price",
+		Usage:   "Maximum L1 base fee in gwei to make moves at. Moves are deferred while the base fee is higher unless the remaining clock is within the urgent-clock-threshold. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_MOVE_GAS_PRICE"),
+	}
+	MinMoveTipFlag = &cli.Float64Flag{
+		Name:    "min-move-tip",
+		Usage:   "Minimum priority fee in gwei paid by transactions, raising the fee suggested by the L1 node. 0 for no minimum.",
+		EnvVars: prefixEnvVars("MIN_MOVE_TIP"),
+	}
+	MaxMoveTipFlag = &cli.Float64Flag{
+		Name:    "max-move-tip",
+		Usage:   "Maximum priority fee in gwei paid by transactions, including urgent moves. 0 for no maximum.",
+		EnvVars: prefixEnvVars("MAX_MOVE_TIP"),
+	}
+	UrgentTipMultiplierFlag = &cli.Float64Flag{
+		Name:    "urgent-tip-multiplier",
+		Usage:   "Multiplier of the priority fee of moves and steps countering claims whose remaining clock is within the urgent-clock-threshold, so they are mined before the clock expires.",
+		EnvVars: prefixEnvVars("URGENT_TIP_MULTIPLIER"),
+		Value:   config.DefaultUrgentTipMultiplier,
+	}
+	StrictDataAvailabilityFlag = &cli.BoolFlag{
+		Name:    "strict-data-availability",
+		Usage:   "Defer moves in cannon games until the L2 block the game disputes is finalized on the L2 node. Urgent moves are also deferred unless accept-unfinalized-risk is set.",
+		EnvVars: prefixEnvVars("STRICT_DATA_AVAILABILITY"),
+	}
+	AcceptUnfinalizedRiskFlag = &cli.BoolFlag{
+		Name:    "accept-unfinalized-risk",
+		Usage:   "With strict-data-availability, make moves from unfinalized L2 data once the remaining clock is within the urgent-clock-threshold rather than letting the clock expire.",
+		EnvVars: prefixEnvVars("ACCEPT_UNFINALIZED_RISK"),
+	}
+	HaltOnSelfConflictFlag = &cli.BoolFlag{
+		Name:    "halt-on-self-conflict",
+		Usage:   "Stop making moves in a game once an opponent counters a claim we agree with using the value our own trace has at that position, which may indicate a configuration error. Steps and resolution continue.",
+		EnvVars: prefixEnvVars("HALT_ON_SELF_CONFLICT"),
+	}
+	BreakerFailuresFlag = &cli.UintFlag{
+		Name: "circuit-breaker-failures",
+		// The breaker only counted reverts when it was introduced, so the old name is kept as a deprecated alias.
+		Aliases: []string{"circuit-breaker-reverts"},
+		Usage:   "Number of consecutive failed transactions, reverted or not sent, across all games, after which transactions are halted until a periodic probe transaction succeeds. Steps against claims with urgent clocks are still sent. 0 disables the circuit breaker. --circuit-breaker-reverts is a deprecated alias.",
+		EnvVars: append(prefixEnvVars("CIRCUIT_BREAKER_FAILURES"), prefixEnvVars("CIRCUIT_BREAKER_REVERTS")...),
+	}
+	BreakerWindowFlag = &cli.DurationFlag{
+		Name:    "circuit-breaker-window",
+		Usage:   "Time consecutive failed transactions are counted over by the circuit breaker. 0 counts every consecutive failure.",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_WINDOW"),
+		Value:   config.DefaultBreakerWindow,
+	}
+	BreakerProbeIntervalFlag = &cli.DurationFlag{
+		Name:    "circuit-breaker-probe-interval",
+		Usage:   "Time between probe transactions sent to detect recovery while the circuit breaker is open.",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_PROBE_INTERVAL"),
+		Value:   config.DefaultBreakerProbeInterval,
+	}
+	ClockSkewThresholdFlag = &cli.DurationFlag{
+		Name:    "clock-skew-threshold",
+		Usage:   "Skew of the local clock from L1 block timestamps above which an error is logged. A local clock behind L1 is corrected for regardless. 0 disables the alert.",
+		EnvVars: prefixEnvVars("CLOCK_SKEW_THRESHOLD"),
+		Value:   config.DefaultClockSkewThreshold,
+	}
+	MaxParallelMovesFlag = &cli.UintFlag{
+		Name:    "max-parallel-moves",
+		Usage:   "Maximum number of move transactions in flight at once for each game.",
+		EnvVars: prefixEnvVars("MAX_PARALLEL_MOVES"),
+		Value:   config.DefaultMaxParallelMoves,
+	}
+	TraceCacheSizeFlag = &cli.Uint64Flag{
+		Name:    "trace-cache-size",
+		Usage:   "Maximum size in bytes of the cache of trace data kept for each game. 0 disables the cache.",
+		EnvVars: prefixEnvVars("TRACE_CACHE_SIZE"),
+		Value:   config.DefaultTraceCacheSize,
+	}
+	TraceDiskCacheDirFlag = &cli.StringFlag{
+		Name:    "trace-disk-cache-dir",
+		Usage:   "Directory to persist the trace data computed for each game to so it isn't recomputed after a restart. Disabled if not set.",
+		EnvVars: prefixEnvVars("TRACE_DISK_CACHE_DIR"),
+	}
+	TraceDiskCacheSizeFlag = &cli.Uint64Flag{
+		Name:    "trace-disk-cache-size",
+		Usage:   "Maximum size in bytes of the trace data persisted for each game. The least recently used data is evicted.",
+		EnvVars: prefixEnvVars("TRACE_DISK_CACHE_SIZE"),
+		Value:   config.DefaultTraceDiskCacheSize,
+	}
+	TraceRateLimitFlag = &cli.Float64Flag{
+		Name:    "trace-rate-limit",
+		Usage:   "Maximum step data and absolute prestate requests per second to the trace providers of all games. Games over the limit retry the next time they are progressed. 0 for no limit.",
+		EnvVars: prefixEnvVars("TRACE_RATE_LIMIT"),
+	}
+	TraceRateBurstFlag = &cli.UintFlag{
+		Name:    "trace-rate-burst",
+		Usage:   "Maximum number of rate limited trace provider requests allowed at once.",
+		EnvVars: prefixEnvVars("TRACE_RATE_BURST"),
+		Value:   config.DefaultTraceRateBurst,
+	}
+	GameLogsFlag = &cli.BoolFlag{
+		Name:    "game-logs",
+		Usage:   "Also write the logs of each game to a JSON file in the game's data directory.",
+		EnvVars: prefixEnvVars("GAME_LOGS"),
+	}
+	MonitorOnlyFlag = &cli.BoolFlag{
+		Name:    "monitor-only",
+		Usage:   "Monitor games without submitting any transactions, logging the moves, steps and resolutions that would be made instead. No private key is needed. Every metric is labelled mode=monitor.",
+		EnvVars: prefixEnvVars("MONITOR_ONLY"),
+	}
+	AutoClaimBondsFlag = &cli.BoolFlag{
+		Name:    "auto-claim-bonds",
+		Usage:   "Claim the bonds credited to the challenger once a game is won. Requires a game contract that supports claiming credit.",
+		EnvVars: prefixEnvVars("AUTO_CLAIM_BONDS"),
+	}
+	BondClaimDelayFlag = &cli.DurationFlag{
+		Name:    "bond-claim-delay",
+		Usage:   "Time after a game resolves before its bonds are claimed, such as the withdrawal delay of the DelayedWETH holding them. Games are not checked again until it elapses. 0 claims once claiming doesn't revert.",
+		EnvVars: prefixEnvVars("BOND_CLAIM_DELAY"),
+	}
+	MoveBondFlag = &cli.Float64Flag{
+		Name:    "move-bond",
+		Usage:   "Bond in ether expected to be posted with each move. The balance is alerted on when it is below one move bond plus the gas for a move at the current gas price for each game in progress.",
+		EnvVars: prefixEnvVars("MOVE_BOND"),
+	}
+	MaxSpendPerGameFlag = &cli.Float64Flag{
+		Name:    "max-spend-per-game",
+		Usage:   "Maximum ether to spend on the transactions of each game, counting the fees of mined transactions. No move is made that would exceed it, with its cost estimated as the move bond plus its gas at the current base fee. Steps, resolution and bond claims continue. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MAX_SPEND_PER_GAME"),
+	}
+	StopNewGamesWhenUnderfundedFlag = &cli.BoolFlag{
+		Name:    "stop-new-games-when-underfunded",
+		Usage:   "Stop playing new games while the balance is below the estimate needed to play the games in progress. Games already being played keep being played.",
+		EnvVars: prefixEnvVars("STOP_NEW_GAMES_WHEN_UNDERFUNDED"),
+	}
+	ResolvedGameRetentionFlag = &cli.DurationFlag{
+		Name:    "resolved-game-retention",
+		Usage:   "Time to keep the data of resolved games, including cannon proofs and snapshots, before deleting it. 0 deletes it once the game resolves and a negative duration such as -1s keeps it forever.",
+		EnvVars: prefixEnvVars("RESOLVED_GAME_RETENTION"),
+	}
+	PreserveLostGameDataFlag = &cli.BoolFlag{
+		Name:    "preserve-lost-game-data",
+		Usage:   "Keep the data of lost games regardless of the resolved game retention, as evidence for investigating the loss.",
+		EnvVars: prefixEnvVars("PRESERVE_LOST_GAME_DATA"),
+	}
+	UrgentClockThresholdFlag = &cli.DurationFlag{
+		Name:    "urgent-clock-threshold",
+		Usage:   "Remaining chess clock time below which a game is checked every block.",
+		EnvVars: prefixEnvVars("URGENT_CLOCK_THRESHOLD"),
+		Value:   config.DefaultUrgentClockThreshold,
+	}
+	RelaxedPollIntervalFlag = &cli.DurationFlag{
+		Name:    "relaxed-poll-interval",
+		Usage:   "Time between checks of games with more remaining chess clock time than the urgent threshold. 0 checks every game every block.",
+		EnvVars: prefixEnvVars("RELAXED_POLL_INTERVAL"),
+	}
+	ContestedPollIntervalFlag = &cli.DurationFlag{
+		Name:    "contested-poll-interval",
+		Usage:   "Time between checks of games that had claims added since they were last checked, when shorter than the relaxed poll interval. 0 checks them every block.",
+		EnvVars: prefixEnvVars("CONTESTED_POLL_INTERVAL"),
+	}
+	TerminalPollIntervalFlag = &cli.DurationFlag{
+		Name:    "terminal-poll-interval",
+		Usage:   "Time between checks of games that are no longer in progress but still need action, such as claiming bonds or loading their final state. Completed games are not checked again. 0 checks them every block.",
+		EnvVars: prefixEnvVars("TERMINAL_POLL_INTERVAL"),
+		Value:   config.DefaultTerminalPollInterval,
+	}
+	ScheduleJitterFlag = &cli.Float64Flag{
+		Name:    "schedule-jitter",
+		Usage:   "Fraction, between 0 and 1, to randomly vary each game's poll interval by either way so games on the same interval aren't all checked at once. Games checked every block are not delayed. 0 disables the jitter.",
+		EnvVars: prefixEnvVars("SCHEDULE_JITTER"),
+		Value:   config.DefaultScheduleJitter,
+	}
+	MinMoveClockFlag = &cli.DurationFlag{
+		Name:    "min-move-clock",
+		Usage:   "Remaining chess clock time needed to make a move. Games with less time left to counter any claim are conceded. 0 disables the check.",
+		EnvVars: prefixEnvVars("MIN_MOVE_CLOCK"),
+	}
+	ActTimeBudgetFlag = &cli.DurationFlag{
+		Name:    "act-time-budget",
+		Usage:   "Time loading a game's claims and acting on them may take each time the game is progressed before a warning is logged. 0 disables the warning.",
+		EnvVars: prefixEnvVars("ACT_TIME_BUDGET"),
+		Value:   config.DefaultActTimeBudget,
+	}
+	GameInfoIntervalFlag = &cli.DurationFlag{
+		Name:    "game-info-interval",
+		Usage:   "Time between logs of the state of a game whose claim count and status haven't changed. Changes are always logged. 0 only logs changes.",
+		EnvVars: prefixEnvVars("GAME_INFO_INTERVAL"),
+		Value:   config.DefaultGameInfoInterval,
+	}
+	ShutdownGracePeriodFlag = &cli.DurationFlag{
+		Name:    "shutdown-grace-period",
+		Usage:   "Maximum time to wait for in-flight moves to confirm when shutting down. Unconfirmed transactions are logged when it expires.",
+		EnvVars: prefixEnvVars("SHUTDOWN_GRACE_PERIOD"),
+		Value:   config.DefaultShutdownGracePeriod,
+	}
+	ShutdownProtectionFlag = &cli.DurationFlag{
+		Name:    "shutdown-protection",
+		Usage:   "Require shutdowns to be confirmed by a second interrupt if a game clock expires within this window. 0 disables the check.",
+		EnvVars: prefixEnvVars("SHUTDOWN_PROTECTION"),
+	}
+	ShutdownConfirmTimeoutFlag = &cli.DurationFlag{
+		Name:    "shutdown-confirm-timeout",
+		Usage:   "Time to wait for a protected shutdown to be confirmed by a second interrupt before continuing to run.",
+		EnvVars: prefixEnvVars("SHUTDOWN_CONFIRM_TIMEOUT"),
+		Value:   config.DefaultShutdownConfirmTimeout,
+	}
+	ForceShutdownFlag = &cli.BoolFlag{
+		Name:    "force",
+		Usage:   "Shut down on the first interrupt even if game clocks expire within the shutdown protection window.",
+		EnvVars: prefixEnvVars("FORCE"),
+	}
+	StaleGameThresholdFlag = &cli.DurationFlag{
+		Name:    "stale-game-threshold",
+		Usage:   "Time an unresolved game's claim count can stay unchanged while the challenger has a response pending before the RPC server's health check reports it as stale.",
+		EnvVars: prefixEnvVars("STALE_GAME_THRESHOLD"),
+		Value:   config.DefaultStaleGameThreshold,
+	}
+	HealthStalenessWindowFlag = &cli.DurationFlag{
+		Name:    "health-staleness-window",
+		Usage:   "Time a subsystem, such as polling the game factory or loading a game's claims, can be failing before the RPC server's health check reports it as unhealthy.",
+		EnvVars: prefixEnvVars("HEALTH_STALENESS_WINDOW"),
+		Value:   config.DefaultHealthStalenessWindow,
+	}
+	EventLogFlag = &cli.StringFlag{
+		Name:    "event-log",
+		Usage:   "Path to append a JSONL stream of game events to, such as claims countered and games resolved. Disabled if not set.",
+		EnvVars: prefixEnvVars("EVENT_LOG"),
+	}
+	EventLogMaxSizeFlag = &cli.Uint64Flag{
+		Name:    "event-log-max-size",
+		Usage:   "Size in bytes the event log may reach before it is rotated.",
+		EnvVars: prefixEnvVars("EVENT_LOG_MAX_SIZE"),
+		Value:   config.DefaultEventLogMaxSize,
+	}
+	ResultStoreSQLiteFlag = &cli.StringFlag{
+		Name:    "store.sqlite",
+		Usage:   "Path of a SQLite database to record the games played, moves, steps and resolutions in for long-term analysis. Created if it doesn't exist. Disabled if not set.",
+		EnvVars: prefixEnvVars("STORE_SQLITE"),
+	}
+	DeprioritizeAfterLossesFlag = &cli.Uint64Flag{
+		Name:    "deprioritize-after-losses",
+		Usage:   "Play the new games created by an address that has lost more than this many games within the deprioritize window at the lowest priority, after all other games. 0 disables.",
+		EnvVars: prefixEnvVars("DEPRIORITIZE_AFTER_LOSSES"),
+	}
+	DeprioritizeWindowFlag = &cli.DurationFlag{
+		Name:    "deprioritize-window",
+		Usage:   "Time the games lost by an address are counted over to decide whether to deprioritize its new games. 0 counts all losses since the challenger started, or recorded in the result store.",
+		EnvVars: prefixEnvVars("DEPRIORITIZE_WINDOW"),
+		Value:   config.DefaultDeprioritizeWindow,
+	}
+	DashboardFlag = &cli.BoolFlag{
+		Name:    "dashboard",
+		Usage:   "Serve a dashboard page showing the status of each game at /dashboard on the RPC server. Requires the RPC server to be enabled.",
+		EnvVars: prefixEnvVars("DASHBOARD"),
+	}
+	StepPregenDepthFlag = &cli.UintFlag{
+		Name:    "step-pregen-depth",
+		Usage:   "Once a game is deeper than this depth, generate the step data it is likely to need in the background so it is cached before a step is required. Requires a trace cache. 0 disables pre-generation.",
+		EnvVars: prefixEnvVars("STEP_PREGEN_DEPTH"),
+	}
+	AnalyzeGamesFlag = &cli.BoolFlag{
+		Name:    "analyze-games",
+		Usage:   "Estimate whether each game is winning if both sides play optimally from now on, reported by challenger_gameStatuses. Recomputed when claims are added.",
+		EnvVars: prefixEnvVars("ANALYZE_GAMES"),
+	}
+	GameTypeOptionFlag = &cli.StringSliceFlag{
+		Name: "game-type-option",
+		Usage: "Override an optional behaviour for a single game type, as <game-type>.<key>=<value>. " +
+			"The game type may be its name or ID. Supported keys: " + config.GameTypeOptionMaxMoveGas + ", " + config.GameTypeOptionTraceCacheSize,
+		EnvVars: prefixEnvVars("GAME_TYPE_OPTION"),
+	}
+	FactoryOptionFlag = &cli.StringSliceFlag{
+		Name: "factory-option",
+		Usage: "Override a trace provider setting for the games of a single game factory, as <factory-address>.<key>=<value>. " +
+			"Other settings are shared by all factories. Supported keys: " + strings.Join(config.FactoryOptionKeys, ", "),
+		EnvVars: prefixEnvVars("FACTORY_OPTION"),
+	}
+	MetricsLabelByFactoryFlag = &cli.BoolFlag{
+		Name:    "metrics-label-by-factory",
+		Usage:   "Label per-game metrics by the game factory address instead of the game address to limit metric cardinality.",
+		EnvVars: prefixEnvVars("METRICS_LABEL_BY_FACTORY"),
+	}
+	MetricsInstanceFlag = &cli.StringFlag{
+		Name:    "metrics-instance",
+		Usage:   "Value of the instance label added to every metric, to distinguish challengers sharing a metrics registry. No instance label is added if not set.",
+		EnvVars: prefixEnvVars("METRICS_INSTANCE"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -137,7 +459,10 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	MaxConcurrencyFlag,
 	AlphabetFlag,
+	TraceFileFlag,
 	GameAllowlistFlag,
+	GameDenylistFlag,
+	AcceptedAbsolutePrestateFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
 	CannonL2GenesisFlag,
@@ -147,6 +472,62 @@ var optionalFlags = []cli.Flag{
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
 	GameWindowFlag,
+	MaxMoveGasFlag,
+	MaxMovesPerCycleFlag,
+	MaxParallelMovesFlag,
+	MaxMoveGasPriceFlag,
+	MinMoveTipFlag,
+	MaxMoveTipFlag,
+	UrgentTipMultiplierFlag,
+	StrictDataAvailabilityFlag,
+	AcceptUnfinalizedRiskFlag,
+	HaltOnSelfConflictFlag,
+	BreakerFailuresFlag,
+	BreakerWindowFlag,
+	BreakerProbeIntervalFlag,
+	ClockSkewThresholdFlag,
+	ClaimLoadConcurrencyFlag,
+	StatusConfirmationsFlag,
+	TraceCacheSizeFlag,
+	TraceDiskCacheDirFlag,
+	TraceDiskCacheSizeFlag,
+	TraceRateLimitFlag,
+	TraceRateBurstFlag,
+	GameLogsFlag,
+	MonitorOnlyFlag,
+	AutoClaimBondsFlag,
+	BondClaimDelayFlag,
+	MoveBondFlag,
+	MaxSpendPerGameFlag,
+	StopNewGamesWhenUnderfundedFlag,
+	ResolvedGameRetentionFlag,
+	PreserveLostGameDataFlag,
+	UrgentClockThresholdFlag,
+	RelaxedPollIntervalFlag,
+	ContestedPollIntervalFlag,
+	TerminalPollIntervalFlag,
+	ScheduleJitterFlag,
+	MinMoveClockFlag,
+	ActTimeBudgetFlag,
+	GameInfoIntervalFlag,
+	ShutdownGracePeriodFlag,
+	ShutdownProtectionFlag,
+	ShutdownConfirmTimeoutFlag,
+	ForceShutdownFlag,
+	StaleGameThresholdFlag,
+	HealthStalenessWindowFlag,
+	EventLogFlag,
+	EventLogMaxSizeFlag,
+	ResultStoreSQLiteFlag,
+	DeprioritizeAfterLossesFlag,
+	DeprioritizeWindowFlag,
+	DashboardFlag,
+	AnalyzeGamesFlag,
+	StepPregenDepthFlag,
+	GameTypeOptionFlag,
+	FactoryOptionFlag,
+	MetricsLabelByFactoryFlag,
+	MetricsInstanceFlag,
 }
 
 func init() {
@@ -154,6 +535,7 @@ func init() {
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, rpc.CLIFlags(envVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -167,8 +549,21 @@ func CheckRequired(ctx *cli.Context) error {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
 	}
-	gameType := config.TraceType(strings.ToLower(ctx.String(TraceTypeFlag.Name)))
-	switch gameType {
+	traceTypes, err := parseTraceTypes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, traceType := range traceTypes {
+		if err := checkTraceTypeRequired(ctx, traceType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTraceTypeRequired checks the flags required by traceType are set.
+func checkTraceTypeRequired(ctx *cli.Context, traceType config.TraceType) error {
+	switch traceType {
 	case config.TraceTypeCannon:
 		if !ctx.IsSet(CannonNetworkFlag.Name) &&
 			!(ctx.IsSet(CannonRollupConfigFlag.Name) && ctx.IsSet(CannonL2GenesisFlag.Name)) {
@@ -196,6 +591,10 @@ func CheckRequired(ctx *cli.Context) error {
 		if !ctx.IsSet(AlphabetFlag.Name) {
 			return fmt.Errorf("flag %s is required", "alphabet")
 		}
+	case config.TraceTypeFile:
+		if !ctx.IsSet(TraceFileFlag.Name) {
+			return fmt.Errorf("flag %s is required", TraceFileFlag.Name)
+		}
 	default:
 		return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
 	}
@@ -207,10 +606,19 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err := CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	gameFactoryAddress, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
+	factoryAddrs := strings.Split(ctx.String(FactoryAddressFlag.Name), ",")
+	gameFactoryAddress, err := opservice.ParseAddress(strings.TrimSpace(factoryAddrs[0]))
 	if err != nil {
 		return nil, err
 	}
+	var extraGameFactories []common.Address
+	for _, addr := range factoryAddrs[1:] {
+		factory, err := opservice.ParseAddress(strings.TrimSpace(addr))
+		if err != nil {
+			return nil, err
+		}
+		extraGameFactories = append(extraGameFactories, factory)
+	}
 	var allowedGames []common.Address
 	if ctx.StringSlice(GameAllowlistFlag.Name) != nil {
 		for _, addr := range ctx.StringSlice(GameAllowlistFlag.Name) {
@@ -221,12 +629,56 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 			allowedGames = append(allowedGames, gameAddress)
 		}
 	}
+	var deniedGames []common.Address
+	for _, addr := range ctx.StringSlice(GameDenylistFlag.Name) {
+		gameAddress, err := opservice.ParseAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		deniedGames = append(deniedGames, gameAddress)
+	}
+
+	var acceptedPrestates []common.Hash
+	for _, value := range ctx.StringSlice(AcceptedAbsolutePrestateFlag.Name) {
+		prestate, err := parseHash(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid accepted absolute prestate: %w", err)
+		}
+		acceptedPrestates = append(acceptedPrestates, prestate)
+	}
+
+	// Game log files are written after the log level filter so they are filtered separately.
+	gameLogLevel, err := log.LvlFromString(strings.ToLower(ctx.String(oplog.LevelFlagName)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+
+	var factoryOptions []config.FactoryOption
+	for _, value := range ctx.StringSlice(FactoryOptionFlag.Name) {
+		option, err := config.ParseFactoryOption(value)
+		if err != nil {
+			return nil, err
+		}
+		factoryOptions = append(factoryOptions, option)
+	}
+
+	var gameTypeOptions []config.GameTypeOption
+	for _, value := range ctx.StringSlice(GameTypeOptionFlag.Name) {
+		option, err := config.ParseGameTypeOption(value)
+		if err != nil {
+			return nil, err
+		}
+		gameTypeOptions = append(gameTypeOptions, option)
+	}
 
 	txMgrConfig := txmgr.ReadCLIConfig(ctx)
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
 
-	traceTypeFlag := config.TraceType(strings.ToLower(ctx.String(TraceTypeFlag.Name)))
+	traceTypes, err := parseTraceTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	maxConcurrency := ctx.Uint(MaxConcurrencyFlag.Name)
 	if maxConcurrency == 0 {
@@ -235,12 +687,38 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	return &config.Config{
 		// Required Flags
 		L1EthRpc:                ctx.String(L1EthRpcFlag.Name),
-		TraceType:               traceTypeFlag,
+		TraceTypes:              traceTypes,
 		GameFactoryAddress:      gameFactoryAddress,
+		ExtraGameFactories:      extraGameFactories,
+		FactoryOptions:          factoryOptions,
 		GameAllowlist:           allowedGames,
+		GameDenylist:            deniedGames,
+		AcceptedPrestates:       acceptedPrestates,
 		GameWindow:              ctx.Duration(GameWindowFlag.Name),
 		MaxConcurrency:          maxConcurrency,
+		MaxMoveGas:              ctx.Uint64(MaxMoveGasFlag.Name),
+		MaxMovesPerCycle:        ctx.Uint(MaxMovesPerCycleFlag.Name),
+		MaxParallelMoves:        ctx.Uint(MaxParallelMovesFlag.Name),
+		MaxMoveGasPrice:         ctx.Float64(MaxMoveGasPriceFlag.Name),
+		MinMoveTip:              ctx.Float64(MinMoveTipFlag.Name),
+		MaxMoveTip:              ctx.Float64(MaxMoveTipFlag.Name),
+		UrgentTipMultiplier:     ctx.Float64(UrgentTipMultiplierFlag.Name),
+		StrictDataAvailability:  ctx.Bool(StrictDataAvailabilityFlag.Name),
+		AcceptUnfinalizedRisk:   ctx.Bool(AcceptUnfinalizedRiskFlag.Name),
+		HaltOnSelfConflict:      ctx.Bool(HaltOnSelfConflictFlag.Name),
+		BreakerFailures:         ctx.Uint(BreakerFailuresFlag.Name),
+		BreakerWindow:           ctx.Duration(BreakerWindowFlag.Name),
+		BreakerProbeInterval:    ctx.Duration(BreakerProbeIntervalFlag.Name),
+		ClockSkewThreshold:      ctx.Duration(ClockSkewThresholdFlag.Name),
+		ClaimLoadConcurrency:    ctx.Uint(ClaimLoadConcurrencyFlag.Name),
+		StatusConfirmations:     ctx.Uint64(StatusConfirmationsFlag.Name),
+		TraceCacheSize:          ctx.Uint64(TraceCacheSizeFlag.Name),
+		TraceDiskCacheDir:       ctx.String(TraceDiskCacheDirFlag.Name),
+		TraceDiskCacheSize:      ctx.Uint64(TraceDiskCacheSizeFlag.Name),
+		TraceRateLimit:          ctx.Float64(TraceRateLimitFlag.Name),
+		TraceRateBurst:          ctx.Uint(TraceRateBurstFlag.Name),
 		AlphabetTrace:           ctx.String(AlphabetFlag.Name),
+		TraceFile:               ctx.String(TraceFileFlag.Name),
 		CannonNetwork:           ctx.String(CannonNetworkFlag.Name),
 		CannonRollupConfigPath:  ctx.String(CannonRollupConfigFlag.Name),
 		CannonL2GenesisPath:     ctx.String(CannonL2GenesisFlag.Name),
@@ -253,6 +731,69 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		AgreeWithProposedOutput: ctx.Bool(AgreeWithProposedOutputFlag.Name),
 		TxMgrConfig:             txMgrConfig,
 		MetricsConfig:           metricsConfig,
+		MetricsLabelByFactory:   ctx.Bool(MetricsLabelByFactoryFlag.Name),
+		MetricsInstance:         ctx.String(MetricsInstanceFlag.Name),
+		GameLogs:                ctx.Bool(GameLogsFlag.Name),
+		GameLogLevel:            gameLogLevel,
+		UrgentClockThreshold:    ctx.Duration(UrgentClockThresholdFlag.Name),
+		RelaxedPollInterval:     ctx.Duration(RelaxedPollIntervalFlag.Name),
+		ContestedPollInterval:   ctx.Duration(ContestedPollIntervalFlag.Name),
+		TerminalPollInterval:    ctx.Duration(TerminalPollIntervalFlag.Name),
+		ScheduleJitter:          ctx.Float64(ScheduleJitterFlag.Name),
+		MinMoveClock:            ctx.Duration(MinMoveClockFlag.Name),
+		ActTimeBudget:           ctx.Duration(ActTimeBudgetFlag.Name),
+		GameInfoInterval:        ctx.Duration(GameInfoIntervalFlag.Name),
+		ShutdownGracePeriod:     ctx.Duration(ShutdownGracePeriodFlag.Name),
+		ShutdownProtection:      ctx.Duration(ShutdownProtectionFlag.Name),
+		ShutdownConfirmTimeout:  ctx.Duration(ShutdownConfirmTimeoutFlag.Name),
+		ForceShutdown:           ctx.Bool(ForceShutdownFlag.Name),
+		StaleGameThreshold:      ctx.Duration(StaleGameThresholdFlag.Name),
+		HealthStalenessWindow:   ctx.Duration(HealthStalenessWindowFlag.Name),
+		EventLog:                ctx.String(EventLogFlag.Name),
+		EventLogMaxSize:         ctx.Uint64(EventLogMaxSizeFlag.Name),
+		ResultStoreSQLite:       ctx.String(ResultStoreSQLiteFlag.Name),
+		DeprioritizeAfterLosses: ctx.Uint64(DeprioritizeAfterLossesFlag.Name),
+		DeprioritizeWindow:      ctx.Duration(DeprioritizeWindowFlag.Name),
+		MonitorOnly:             ctx.Bool(MonitorOnlyFlag.Name),
+		AutoClaimBonds:          ctx.Bool(AutoClaimBondsFlag.Name),
+		BondClaimDelay:          ctx.Duration(BondClaimDelayFlag.Name),
+		MoveBond:                ctx.Float64(MoveBondFlag.Name),
+		MaxSpendPerGame:         ctx.Float64(MaxSpendPerGameFlag.Name),
+		StopNewGamesUnderfunded: ctx.Bool(StopNewGamesWhenUnderfundedFlag.Name),
+		ResolvedGameRetention:   ctx.Duration(ResolvedGameRetentionFlag.Name),
+		PreserveLostGameData:    ctx.Bool(PreserveLostGameDataFlag.Name),
+		Dashboard:               ctx.Bool(DashboardFlag.Name),
+		AnalyzeGames:            ctx.Bool(AnalyzeGamesFlag.Name),
+		StepPregenDepth:         ctx.Uint(StepPregenDepthFlag.Name),
+		GameTypeOptions:         gameTypeOptions,
 		PprofConfig:             pprofConfig,
+		RPCConfig:               rpc.ReadCLIConfig(ctx),
 	}, nil
 }
+
+// parseTraceTypes parses the trace types to play games with, ignoring any trace type specified more than once.
+func parseTraceTypes(ctx *cli.Context) ([]config.TraceType, error) {
+	var traceTypes []config.TraceType
+	for _, value := range ctx.StringSlice(TraceTypeFlag.Name) {
+		var traceType config.TraceType
+		if err := traceType.Set(strings.ToLower(value)); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(traceTypes, traceType) {
+			traceTypes = append(traceTypes, traceType)
+		}
+	}
+	return traceTypes, nil
+}
+
+// parseHash parses a 32 byte hash from a 0x prefixed hex string.
+func parseHash(value string) (common.Hash, error) {
+	data, err := hexutil.Decode(value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid hash %v: %w", value, err)
+	}
+	if len(data) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid hash %v: must be %v bytes", value, common.HashLength)
+	}
+	return common.BytesToHash(data), nil
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+type gameTracker interface {
+	TrackedGames() []scheduler.TrackedGame
+}
+
+// trackedAbandoner only abandons the games being played. The data directories of other games are deleted, along
+// with the abandonment record written to them, so abandoning them would be forgotten.
+type trackedAbandoner struct {
+	abandoner rpc.Abandoner
+	games     gameTracker
+}
+
+func newTrackedAbandoner(abandoner rpc.Abandoner, games gameTracker) *trackedAbandoner {
+	return &trackedAbandoner{
+		abandoner: abandoner,
+		games:     games,
+	}
+}
+
+// Abandon abandons game for reason, returning [scheduler.ErrGameNotTracked] if it isn't being played.
+func (a *trackedAbandoner) Abandon(game common.Address, reason string) error {
+	tracked := slices.ContainsFunc(a.games.TrackedGames(), func(g scheduler.TrackedGame) bool {
+		return g.Game == game
+	})
+	if !tracked {
+		return fmt.Errorf("%w: %v", scheduler.ErrGameNotTracked, game)
+	}
+	return a.abandoner.Abandon(game, reason)
+}
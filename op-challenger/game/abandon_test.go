@@ -0,0 +1,45 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackedAbandoner(t *testing.T) {
+	tracked := common.Address{0xaa}
+	untracked := common.Address{0xbb}
+	abandoner := &stubRecordingAbandoner{abandoned: make(map[common.Address]string)}
+	games := &stubGameTracker{games: []scheduler.TrackedGame{{Game: tracked}}}
+	a := newTrackedAbandoner(abandoner, games)
+
+	t.Run("Tracked", func(t *testing.T) {
+		require.NoError(t, a.Abandon(tracked, "stuck"))
+		require.Equal(t, "stuck", abandoner.abandoned[tracked])
+	})
+
+	t.Run("Untracked", func(t *testing.T) {
+		err := a.Abandon(untracked, "stuck")
+		require.ErrorIs(t, err, scheduler.ErrGameNotTracked)
+		require.NotContains(t, abandoner.abandoned, untracked)
+	})
+}
+
+type stubRecordingAbandoner struct {
+	abandoned map[common.Address]string
+}
+
+func (s *stubRecordingAbandoner) Abandon(game common.Address, reason string) error {
+	s.abandoned[game] = reason
+	return nil
+}
+
+type stubGameTracker struct {
+	games []scheduler.TrackedGame
+}
+
+func (s *stubGameTracker) TrackedGames() []scheduler.TrackedGame {
+	return s.games
+}
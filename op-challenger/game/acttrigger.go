@@ -0,0 +1,89 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// actRequestsDir is the directory in the datadir that operators without access to the RPC API create files in,
+	// each named by the address of a game to progress immediately.
+	actRequestsDir = "act-requests"
+	// actRequestPollInterval is how often the directory is checked for new requests.
+	actRequestPollInterval = 5 * time.Second
+)
+
+type gameActor interface {
+	ActNow(ctx context.Context, game common.Address) (types.ActResult, error)
+}
+
+// actTrigger progresses the games requested by the files in the act requests directory, as the admin_actOnGame
+// RPC method does. Each file is removed before its game is progressed, so a request is only handled once, and the
+// outcome is logged.
+type actTrigger struct {
+	logger log.Logger
+	clock  clock.Clock
+	dir    string
+	actor  gameActor
+}
+
+func newActTrigger(logger log.Logger, cl clock.Clock, datadir string, actor gameActor) *actTrigger {
+	return &actTrigger{
+		logger: logger,
+		clock:  cl,
+		dir:    filepath.Join(datadir, actRequestsDir),
+		actor:  actor,
+	}
+}
+
+// run handles the requests in the directory until ctx is done.
+func (t *actTrigger) run(ctx context.Context) {
+	for {
+		t.check(ctx)
+		if err := t.clock.SleepCtx(ctx, actRequestPollInterval); err != nil {
+			return
+		}
+	}
+}
+
+// check progresses the game named by each file in the directory. The directory needn't exist.
+func (t *actTrigger) check(ctx context.Context) {
+	entries, err := os.ReadDir(t.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		t.logger.Error("Failed to list act requests", "dir", t.dir, "err", err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(t.dir, entry.Name())
+		if err := os.Remove(path); err != nil {
+			t.logger.Error("Failed to remove act request, not acting on game", "path", path, "err", err)
+			continue
+		}
+		if !common.IsHexAddress(entry.Name()) {
+			t.logger.Warn("Ignoring act request not named by a game address", "path", path)
+			continue
+		}
+		game := common.HexToAddress(entry.Name())
+		t.logger.Info("Acting on game by request", "game", game)
+		result, err := t.actor.ActNow(ctx, game)
+		if err != nil {
+			t.logger.Error("Failed to act on requested game", "game", game, "err", err)
+			continue
+		}
+		t.logger.Info("Acted on requested game", "game", game, "succeeded", result.Succeeded, "status", result.Status,
+			"claims", result.Claims, "resolved", result.Resolved, "err", result.Error)
+	}
+}
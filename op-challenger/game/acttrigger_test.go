@@ -0,0 +1,90 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActTrigger(t *testing.T) {
+	known := common.Address{0xaa}
+	unknown := common.Address{0xbb}
+	setup := func(t *testing.T) (*actTrigger, *stubGameActor, string, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		actor := &stubGameActor{results: map[common.Address]types.ActResult{
+			known: {Game: known, Succeeded: true, Status: "In Progress", Claims: 3},
+		}}
+		datadir := t.TempDir()
+		return newActTrigger(logger, clock.SystemClock, datadir, actor), actor, filepath.Join(datadir, actRequestsDir), handler
+	}
+	request := func(t *testing.T, dir string, name string) {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	t.Run("NoDirectory", func(t *testing.T) {
+		trigger, actor, _, handler := setup(t)
+		trigger.check(context.Background())
+		require.Empty(t, actor.requested)
+		require.Nil(t, handler.FindLog(log.LvlError, "Failed to list act requests"))
+	})
+
+	t.Run("ActOnceOnRequestedGame", func(t *testing.T) {
+		trigger, actor, dir, handler := setup(t)
+		request(t, dir, known.Hex())
+		trigger.check(context.Background())
+		require.Equal(t, []common.Address{known}, actor.requested)
+		record := handler.FindLog(log.LvlInfo, "Acted on requested game")
+		require.NotNil(t, record)
+		require.Equal(t, uint64(3), record.GetContextValue("claims"))
+		require.NoFileExists(t, filepath.Join(dir, known.Hex()))
+
+		trigger.check(context.Background())
+		require.Len(t, actor.requested, 1, "should only handle each request once")
+	})
+
+	t.Run("UnknownGame", func(t *testing.T) {
+		trigger, actor, dir, handler := setup(t)
+		request(t, dir, unknown.Hex())
+		trigger.check(context.Background())
+		require.Equal(t, []common.Address{unknown}, actor.requested)
+		require.NotNil(t, handler.FindLog(log.LvlError, "Failed to act on requested game"))
+	})
+
+	t.Run("IgnoreInvalidNames", func(t *testing.T) {
+		trigger, actor, dir, handler := setup(t)
+		request(t, dir, "not-a-game")
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, known.Hex()), 0755))
+		trigger.check(context.Background())
+		require.Empty(t, actor.requested)
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Ignoring act request not named by a game address"))
+		require.NoFileExists(t, filepath.Join(dir, "not-a-game"))
+	})
+}
+
+var errStubUnknownGame = errors.New("unknown game")
+
+type stubGameActor struct {
+	results   map[common.Address]types.ActResult
+	requested []common.Address
+}
+
+func (s *stubGameActor) ActNow(_ context.Context, game common.Address) (types.ActResult, error) {
+	s.requested = append(s.requested, game)
+	result, ok := s.results[game]
+	if !ok {
+		return types.ActResult{}, errStubUnknownGame
+	}
+	return result, nil
+}
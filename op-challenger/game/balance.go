@@ -0,0 +1,97 @@
+package game
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// moveGasEstimate is the rough gas used by a move, used to estimate the balance needed to play active games.
+const moveGasEstimate = 300_000
+
+type fundsClient interface {
+	balanceFetcher
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+type gameSummaries interface {
+	Summaries() []types.GameSummary
+}
+
+type balanceMetricer interface {
+	RecordLowBalance(low bool)
+}
+
+// balanceWatcher checks the balance of the account transactions are sent from covers a rough estimate of the funds
+// needed to play the games in progress, so that running out of funds is alerted on before moves start failing.
+// The estimate is one move per game in progress, each posting the move bond and paying for moveGasEstimate gas at
+// the current gas price.
+type balanceWatcher struct {
+	logger       log.Logger
+	metrics      balanceMetricer
+	client       fundsClient
+	account      common.Address
+	games        gameSummaries
+	moveBond     *big.Int
+	stopNewGames bool
+
+	low bool
+}
+
+// newBalanceWatcher creates a [balanceWatcher] for account. If stopNewGames is set, new games aren't played while the
+// balance is too low.
+func newBalanceWatcher(logger log.Logger, m balanceMetricer, client fundsClient, account common.Address, games gameSummaries, moveBond *big.Int, stopNewGames bool) *balanceWatcher {
+	return &balanceWatcher{
+		logger:       logger,
+		metrics:      m,
+		client:       client,
+		account:      account,
+		games:        games,
+		moveBond:     moveBond,
+		stopNewGames: stopNewGames,
+	}
+}
+
+// PauseNewGames checks the balance and returns true if new games shouldn't be played because it is too low.
+// If the balance can't be checked, the previous result is kept.
+func (w *balanceWatcher) PauseNewGames(ctx context.Context) bool {
+	w.check(ctx)
+	return w.low && w.stopNewGames
+}
+
+func (w *balanceWatcher) check(ctx context.Context) {
+	balance, err := w.client.BalanceAt(ctx, w.account, nil)
+	if err != nil {
+		w.logger.Warn("Failed to check balance of account", "account", w.account, "err", err)
+		return
+	}
+	gasPrice, err := w.client.SuggestGasPrice(ctx)
+	if err != nil {
+		w.logger.Warn("Failed to fetch gas price to estimate required balance", "err", err)
+		return
+	}
+	active := 0
+	for _, summary := range w.games.Summaries() {
+		if summary.Status == types.GameStatusInProgress.String() {
+			active++
+		}
+	}
+	perMove := new(big.Int).Mul(gasPrice, big.NewInt(moveGasEstimate))
+	perMove.Add(perMove, w.moveBond)
+	required := new(big.Int).Mul(perMove, big.NewInt(int64(active)))
+
+	low := balance.Cmp(required) < 0
+	if low && !w.low {
+		w.logger.Error("Balance too low to play the games in progress",
+			"account", w.account, "balance", balance, "required", required, "active_games", active,
+			"estimate", "one move per game in progress, each posting the move bond and paying for the move gas at the gas price",
+			"move_bond", w.moveBond, "move_gas", moveGasEstimate, "gas_price", gasPrice, "stop_new_games", w.stopNewGames)
+	} else if !low && w.low {
+		w.logger.Info("Balance sufficient to play the games in progress", "account", w.account, "balance", balance, "required", required)
+	}
+	w.low = low
+	w.metrics.RecordLowBalance(low)
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceWatcher(t *testing.T) {
+	account := common.Address{0xaa}
+	inProgress := types.GameStatusInProgress.String()
+	games := &stubGameSummaries{summaries: []types.GameSummary{
+		{Status: inProgress},
+		{Status: inProgress},
+		{Status: types.GameStatusChallengerWon.String()},
+	}}
+	// Each of the two games in progress needs a bond of 1000 plus 300,000 gas at 1 wei.
+	required := big.NewInt(2 * (1000 + moveGasEstimate))
+
+	setup := func(t *testing.T, stopNewGames bool) (*balanceWatcher, *stubFundsClient, *stubBalanceMetrics, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		client := &stubFundsClient{balance: required, gasPrice: big.NewInt(1)}
+		m := &stubBalanceMetrics{}
+		return newBalanceWatcher(logger, m, client, account, games, big.NewInt(1000), stopNewGames), client, m, handler
+	}
+
+	t.Run("Sufficient", func(t *testing.T) {
+		watcher, client, m, handler := setup(t, true)
+		require.False(t, watcher.PauseNewGames(context.Background()))
+		require.Equal(t, account, client.account)
+		require.False(t, m.low)
+		require.Nil(t, handler.FindLog(log.LvlError, "Balance too low to play the games in progress"))
+	})
+
+	t.Run("Low", func(t *testing.T) {
+		watcher, client, m, handler := setup(t, true)
+		client.balance = new(big.Int).Sub(required, big.NewInt(1))
+		require.True(t, watcher.PauseNewGames(context.Background()))
+		require.True(t, m.low)
+		record := handler.FindLog(log.LvlError, "Balance too low to play the games in progress")
+		require.NotNil(t, record)
+		require.Equal(t, required, record.GetContextValue("required"))
+		require.Equal(t, 2, record.GetContextValue("active_games"))
+
+		handler.Clear()
+		require.True(t, watcher.PauseNewGames(context.Background()))
+		require.Nil(t, handler.FindLog(log.LvlError, "Balance too low to play the games in progress"), "should only log once")
+
+		client.balance = required
+		require.False(t, watcher.PauseNewGames(context.Background()))
+		require.False(t, m.low)
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Balance sufficient to play the games in progress"))
+	})
+
+	t.Run("LowWithoutStoppingNewGames", func(t *testing.T) {
+		watcher, client, m, _ := setup(t, false)
+		client.balance = big.NewInt(0)
+		require.False(t, watcher.PauseNewGames(context.Background()))
+		require.True(t, m.low)
+	})
+
+	t.Run("KeepResultWhenCheckFails", func(t *testing.T) {
+		watcher, client, m, _ := setup(t, true)
+		client.balance = big.NewInt(0)
+		require.True(t, watcher.PauseNewGames(context.Background()))
+
+		client.err = errors.New("boom")
+		require.True(t, watcher.PauseNewGames(context.Background()))
+		require.True(t, m.low)
+	})
+}
+
+type stubFundsClient struct {
+	account  common.Address
+	balance  *big.Int
+	gasPrice *big.Int
+	err      error
+}
+
+func (s *stubFundsClient) BalanceAt(_ context.Context, account common.Address, _ *big.Int) (*big.Int, error) {
+	s.account = account
+	return s.balance, s.err
+}
+
+func (s *stubFundsClient) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	return s.gasPrice, s.err
+}
+
+type stubGameSummaries struct {
+	summaries []types.GameSummary
+}
+
+func (s *stubGameSummaries) Summaries() []types.GameSummary {
+	return s.summaries
+}
+
+type stubBalanceMetrics struct {
+	low bool
+}
+
+func (s *stubBalanceMetrics) RecordLowBalance(low bool) {
+	s.low = low
+}
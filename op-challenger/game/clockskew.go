@@ -0,0 +1,179 @@
+package game
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// skewSamples is the number of recent L1 blocks the clock skew is estimated from.
+	skewSamples = 32
+	// minSkewSamples is the number of blocks that must be seen before the clock skew is estimated.
+	minSkewSamples = 5
+	// skewOutlierDeviations is the number of median absolute deviations from the median beyond which a sample is
+	// rejected as an outlier.
+	skewOutlierDeviations = 3
+	// minSkewDeviation is the least median absolute deviation used to reject outliers, so that samples agreeing to
+	// within the one second resolution of block timestamps aren't rejected for differing slightly.
+	minSkewDeviation = time.Second
+)
+
+type headerFetcher func(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+
+type skewMetricer interface {
+	RecordClockSkew(skew time.Duration)
+}
+
+// clockSkewEstimator estimates the skew of the local clock from L1 time by comparing the timestamp of each new L1
+// block with the local time it is first seen.
+// Samples are delayed by block propagation and polling, and greatly delayed while the L1 node is lagging, so samples
+// far from the median are rejected as outliers before the rest are averaged. An error is logged while the skew
+// exceeds the threshold.
+// It is safe for concurrent use.
+type clockSkewEstimator struct {
+	logger    log.Logger
+	clock     clock.Clock
+	metrics   skewMetricer
+	threshold time.Duration
+
+	mu        sync.Mutex
+	lastBlock uint64
+	// samples are the local receipt time less the timestamp of the most recent blocks, oldest first.
+	samples  []time.Duration
+	skew     time.Duration
+	alerting bool
+}
+
+// newClockSkewEstimator creates a [clockSkewEstimator] comparing L1 timestamps with cl. A threshold of 0 disables
+// the alert.
+func newClockSkewEstimator(logger log.Logger, cl clock.Clock, m skewMetricer, threshold time.Duration) *clockSkewEstimator {
+	return &clockSkewEstimator{
+		logger:    logger,
+		clock:     cl,
+		metrics:   m,
+		threshold: threshold,
+	}
+}
+
+// Skew returns how far the local clock is estimated to be ahead of L1 time, negative if it is behind.
+// It is 0 until enough blocks have been seen.
+func (e *clockSkewEstimator) Skew() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.skew
+}
+
+// Observe samples the skew from the L1 block with number and timestamp if it is newer than any seen before.
+func (e *clockSkewEstimator) Observe(number uint64, timestamp uint64) {
+	received := e.clock.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if number <= e.lastBlock {
+		return
+	}
+	e.lastBlock = number
+	e.samples = append(e.samples, received.Sub(time.Unix(int64(timestamp), 0)))
+	if len(e.samples) > skewSamples {
+		e.samples = e.samples[len(e.samples)-skewSamples:]
+	}
+	if len(e.samples) < minSkewSamples {
+		return
+	}
+	e.skew = estimateSkew(e.samples)
+	e.metrics.RecordClockSkew(e.skew)
+	e.checkThreshold()
+}
+
+// checkThreshold logs when the skew first exceeds the threshold and once it is back within it.
+// Must be called with mu held.
+func (e *clockSkewEstimator) checkThreshold() {
+	if e.threshold == 0 {
+		return
+	}
+	exceeded := e.skew > e.threshold || e.skew < -e.threshold
+	if exceeded && !e.alerting {
+		e.logger.Error("Local clock skewed from L1 block timestamps, check the host's time synchronisation",
+			"skew", e.skew, "threshold", e.threshold)
+	} else if !exceeded && e.alerting {
+		e.logger.Info("Local clock skew back within threshold", "skew", e.skew, "threshold", e.threshold)
+	}
+	e.alerting = exceeded
+}
+
+// BlockNumberFetcher returns a [blockNumberFetcher] that loads the latest L1 header with fetch, sampling the skew
+// from it.
+func (e *clockSkewEstimator) BlockNumberFetcher(fetch headerFetcher) blockNumberFetcher {
+	return func(ctx context.Context) (uint64, error) {
+		header, err := fetch(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		number := header.Number.Uint64()
+		e.Observe(number, header.Time)
+		return number, nil
+	}
+}
+
+// estimateSkew returns the mean of samples after rejecting those too far from the median.
+func estimateSkew(samples []time.Duration) time.Duration {
+	median := medianDuration(samples)
+	deviations := make([]time.Duration, len(samples))
+	for i, sample := range samples {
+		deviations[i] = absDuration(sample - median)
+	}
+	limit := medianDuration(deviations)
+	if limit < minSkewDeviation {
+		limit = minSkewDeviation
+	}
+	limit *= skewOutlierDeviations
+	var total time.Duration
+	count := 0
+	for i, sample := range samples {
+		if deviations[i] <= limit {
+			total += sample
+			count++
+		}
+	}
+	// The median is always within the limit so at least one sample is counted.
+	return total / time.Duration(count)
+}
+
+func medianDuration(values []time.Duration) time.Duration {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// l1Clock is a [clock.Clock] for comparing with L1 timestamps, which is corrected for the estimated skew when the
+// local clock is behind L1.
+// A local clock that appears to be ahead may instead be a lagging L1 node, so it is only alerted on. Correcting it
+// would make chess clock deadlines appear later than they are.
+type l1Clock struct {
+	clock.Clock
+	skew *clockSkewEstimator
+}
+
+func (c *l1Clock) Now() time.Time {
+	if skew := c.skew.Skew(); skew < 0 {
+		return c.Clock.Now().Add(-skew)
+	}
+	return c.Clock.Now()
+}
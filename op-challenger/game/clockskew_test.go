@@ -0,0 +1,159 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewEstimator(t *testing.T) {
+	start := time.Unix(100_000, 0)
+	setup := func(t *testing.T) (*clockSkewEstimator, *clock.DeterministicClock, *testlog.CapturingHandler, *stubSkewMetrics) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		cl := clock.NewDeterministicClock(start)
+		m := &stubSkewMetrics{}
+		return newClockSkewEstimator(logger, cl, m, 30*time.Second), cl, handler, m
+	}
+	// observeBlocks feeds 12 second blocks, each seen delay after the local clock reaches its timestamp plus skew.
+	observeBlocks := func(e *clockSkewEstimator, cl *clock.DeterministicClock, first uint64, count int, skew time.Duration, delay func(i int) time.Duration) {
+		for i := 0; i < count; i++ {
+			number := first + uint64(i)
+			timestamp := uint64(start.Unix()) + number*12
+			cl.AdvanceTime(time.Unix(int64(timestamp), 0).Add(skew).Add(delay(i)).Sub(cl.Now()))
+			e.Observe(number, timestamp)
+		}
+	}
+	noDelay := func(int) time.Duration { return 0 }
+
+	t.Run("NoEstimateUntilMinSamples", func(t *testing.T) {
+		e, cl, _, m := setup(t)
+		observeBlocks(e, cl, 1, minSkewSamples-1, -time.Minute, noDelay)
+		require.Zero(t, e.Skew())
+		require.False(t, m.recorded)
+		observeBlocks(e, cl, minSkewSamples, 1, -time.Minute, noDelay)
+		require.Equal(t, -time.Minute, e.Skew())
+		require.Equal(t, -time.Minute, m.skew)
+	})
+
+	t.Run("IgnoresRepeatedBlocks", func(t *testing.T) {
+		e, cl, _, _ := setup(t)
+		observeBlocks(e, cl, 1, minSkewSamples, 0, noDelay)
+		cl.AdvanceTime(time.Hour)
+		e.Observe(minSkewSamples, uint64(start.Unix()))
+		e.Observe(1, uint64(start.Unix()))
+		require.Zero(t, e.Skew())
+	})
+
+	t.Run("Ahead", func(t *testing.T) {
+		e, cl, _, _ := setup(t)
+		observeBlocks(e, cl, 1, skewSamples, 5*time.Second, noDelay)
+		require.Equal(t, 5*time.Second, e.Skew())
+	})
+
+	t.Run("Behind", func(t *testing.T) {
+		e, cl, _, _ := setup(t)
+		observeBlocks(e, cl, 1, skewSamples, -5*time.Second, noDelay)
+		require.Equal(t, -5*time.Second, e.Skew())
+	})
+
+	t.Run("RejectsOutliers", func(t *testing.T) {
+		e, cl, _, _ := setup(t)
+		// Blocks are normally seen within half a second but the L1 node occasionally lags by minutes.
+		observeBlocks(e, cl, 1, skewSamples, -10*time.Second, func(i int) time.Duration {
+			if i%8 == 0 {
+				return 3 * time.Minute
+			}
+			return time.Duration(i%2) * 500 * time.Millisecond
+		})
+		skew := e.Skew()
+		require.InDelta(t, float64(-10*time.Second), float64(skew), float64(time.Second))
+	})
+
+	t.Run("UsesRecentBlocks", func(t *testing.T) {
+		e, cl, _, _ := setup(t)
+		observeBlocks(e, cl, 1, skewSamples, -time.Minute, noDelay)
+		observeBlocks(e, cl, skewSamples+1, skewSamples, 0, noDelay)
+		require.Zero(t, e.Skew(), "should only use the most recent samples once the clock is corrected")
+	})
+
+	t.Run("Alerts", func(t *testing.T) {
+		e, cl, handler, _ := setup(t)
+		observeBlocks(e, cl, 1, skewSamples, 20*time.Second, noDelay)
+		require.Nil(t, handler.FindLog(log.LvlError, "Local clock skewed from L1 block timestamps, check the host's time synchronisation"))
+
+		observeBlocks(e, cl, skewSamples+1, skewSamples, -time.Minute, noDelay)
+		require.NotNil(t, handler.FindLog(log.LvlError, "Local clock skewed from L1 block timestamps, check the host's time synchronisation"))
+		require.Nil(t, handler.FindLog(log.LvlInfo, "Local clock skew back within threshold"))
+
+		observeBlocks(e, cl, 2*skewSamples+1, skewSamples, 0, noDelay)
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Local clock skew back within threshold"))
+	})
+
+	t.Run("BlockNumberFetcher", func(t *testing.T) {
+		e, cl, _, _ := setup(t)
+		number := uint64(0)
+		fetch := e.BlockNumberFetcher(func(ctx context.Context, n *big.Int) (*ethtypes.Header, error) {
+			require.Nil(t, n, "should fetch the latest header")
+			number++
+			return &ethtypes.Header{Number: new(big.Int).SetUint64(number), Time: uint64(cl.Now().Add(time.Minute).Unix())}, nil
+		})
+		for i := 0; i < minSkewSamples; i++ {
+			actual, err := fetch(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, number, actual)
+		}
+		require.Equal(t, -time.Minute, e.Skew())
+	})
+
+	t.Run("BlockNumberFetcherError", func(t *testing.T) {
+		e, _, _, _ := setup(t)
+		expected := errors.New("boom")
+		fetch := e.BlockNumberFetcher(func(ctx context.Context, n *big.Int) (*ethtypes.Header, error) {
+			return nil, expected
+		})
+		_, err := fetch(context.Background())
+		require.ErrorIs(t, err, expected)
+	})
+}
+
+func TestL1Clock(t *testing.T) {
+	setup := func(t *testing.T, skew time.Duration) (*l1Clock, *clock.DeterministicClock) {
+		cl := clock.NewDeterministicClock(time.Unix(100_000, 0))
+		e := newClockSkewEstimator(testlog.Logger(t, log.LvlError), cl, &stubSkewMetrics{}, 0)
+		for i := uint64(1); i <= minSkewSamples; i++ {
+			e.Observe(i, uint64(cl.Now().Add(-skew).Unix()))
+		}
+		require.Equal(t, skew, e.Skew())
+		return &l1Clock{Clock: cl, skew: e}, cl
+	}
+
+	t.Run("CorrectsWhenBehind", func(t *testing.T) {
+		c, cl := setup(t, -time.Minute)
+		require.Equal(t, cl.Now().Add(time.Minute), c.Now())
+	})
+
+	t.Run("DoesNotCorrectWhenAhead", func(t *testing.T) {
+		c, cl := setup(t, time.Minute)
+		require.Equal(t, cl.Now(), c.Now())
+	})
+}
+
+type stubSkewMetrics struct {
+	recorded bool
+	skew     time.Duration
+}
+
+func (s *stubSkewMetrics) RecordClockSkew(skew time.Duration) {
+	s.recorded = true
+	s.skew = skew
+}
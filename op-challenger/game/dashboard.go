@@ -0,0 +1,91 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type balanceFetcher interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// dashboardSource loads the data rendered by the dashboard from the status registry, the data directory and
+// the balance of the account transactions are sent from.
+type dashboardSource struct {
+	statuses *fault.StatusRegistry
+	datadir  string
+	client   balanceFetcher
+	from     common.Address
+}
+
+func newDashboardSource(statuses *fault.StatusRegistry, datadir string, client balanceFetcher, from common.Address) *dashboardSource {
+	return &dashboardSource{
+		statuses: statuses,
+		datadir:  datadir,
+		client:   client,
+		from:     from,
+	}
+}
+
+func (d *dashboardSource) DashboardData(ctx context.Context) (rpc.DashboardData, error) {
+	balance, err := d.client.BalanceAt(ctx, d.from, nil)
+	if err != nil {
+		return rpc.DashboardData{}, fmt.Errorf("failed to fetch balance of %v: %w", d.from, err)
+	}
+	usage, err := diskUsage(d.datadir)
+	if err != nil {
+		return rpc.DashboardData{}, fmt.Errorf("failed to calculate disk usage: %w", err)
+	}
+	return rpc.DashboardData{
+		Games:     d.statuses.Summaries(),
+		DiskUsage: usage,
+		Balances:  map[common.Address]*hexutil.Big{d.from: (*hexutil.Big)(balance)},
+	}, nil
+}
+
+// diskUsage returns the total size in bytes of the files in dir.
+// Files removed while the directory is being walked are skipped.
+func diskUsage(dir string) (uint64, error) {
+	var total uint64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		total += uint64(info.Size())
+		return nil
+	})
+	return total, err
+}
+
+// statusDiskManager stops recording the status of games before their data is removed.
+type statusDiskManager struct {
+	scheduler.DiskManager
+	statuses *fault.StatusRegistry
+}
+
+func (d *statusDiskManager) RemoveAllExcept(keep []common.Address) error {
+	d.statuses.RemoveAllExcept(keep)
+	return d.DiskManager.RemoveAllExcept(keep)
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardSource(t *testing.T) {
+	from := common.Address{0xcc}
+	game := common.Address{0xaa}
+	setup := func(t *testing.T) (*dashboardSource, *stubBalanceFetcher, string) {
+		dir := t.TempDir()
+		statuses := fault.NewStatusRegistry(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		statuses.RecordState(game, types.GameStatusInProgress, 2, time.Time{})
+		client := &stubBalanceFetcher{balance: big.NewInt(1234)}
+		return newDashboardSource(statuses, dir, client, from), client, dir
+	}
+
+	t.Run("Data", func(t *testing.T) {
+		source, _, dir := setup(t)
+		gameDir := filepath.Join(dir, "game-"+game.Hex())
+		require.NoError(t, os.MkdirAll(gameDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "top.json"), make([]byte, 10), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(gameDir, "proof.json"), make([]byte, 32), 0644))
+
+		data, err := source.DashboardData(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(42), data.DiskUsage)
+		require.Equal(t, map[common.Address]*hexutil.Big{from: (*hexutil.Big)(big.NewInt(1234))}, data.Balances)
+		require.Len(t, data.Games, 1)
+		require.Equal(t, game, data.Games[0].Game)
+		require.Equal(t, uint64(2), data.Games[0].Claims)
+	})
+
+	t.Run("BalanceError", func(t *testing.T) {
+		source, client, _ := setup(t)
+		client.err = errors.New("boom")
+		_, err := source.DashboardData(context.Background())
+		require.ErrorIs(t, err, client.err)
+	})
+}
+
+func TestStatusDiskManager(t *testing.T) {
+	statuses := fault.NewStatusRegistry(clock.NewDeterministicClock(time.Unix(1000, 0)))
+	keep := common.Address{0xaa}
+	removed := common.Address{0xbb}
+	statuses.RecordState(keep, types.GameStatusInProgress, 1, time.Time{})
+	statuses.RecordState(removed, types.GameStatusInProgress, 1, time.Time{})
+	disk := &statusDiskManager{DiskManager: newDiskManager(t.TempDir()), statuses: statuses}
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
+	summaries := statuses.Summaries()
+	require.Len(t, summaries, 1)
+	require.Equal(t, keep, summaries[0].Game)
+}
+
+type stubBalanceFetcher struct {
+	balance *big.Int
+	err     error
+}
+
+func (s *stubBalanceFetcher) BalanceAt(_ context.Context, _ common.Address, _ *big.Int) (*big.Int, error) {
+	return s.balance, s.err
+}
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cache"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/slices"
 )
@@ -27,11 +30,34 @@ func (d *diskManager) DirForGame(addr common.Address) string {
 }
 
 func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
-	entries, err := os.ReadDir(d.datadir)
+	games, err := gameDirs(d.datadir)
 	if err != nil {
-		return fmt.Errorf("failed to list directory: %w", err)
+		return err
 	}
 	var errs []error
+	for _, game := range games {
+		if slices.Contains(keep, game.addr) {
+			// Preserve data for games we should keep.
+			continue
+		}
+		errs = append(errs, os.RemoveAll(game.dir))
+	}
+	return errors.Join(errs...)
+}
+
+// gameDir is the data directory of a game.
+type gameDir struct {
+	addr common.Address
+	dir  string
+}
+
+// gameDirs returns the data directory of each game in datadir.
+func gameDirs(datadir string) ([]gameDir, error) {
+	entries, err := os.ReadDir(datadir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	var games []gameDir
 	for _, entry := range entries {
 		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), gameDirPrefix) {
 			// Skip files and directories that don't have the game directory prefix.
@@ -45,11 +71,29 @@ func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
 			// Ignore directories with non-address names.
 			continue
 		}
-		if slices.Contains(keep, addr) {
-			// Preserve data for games we should keep.
-			continue
-		}
-		errs = append(errs, os.RemoveAll(filepath.Join(d.datadir, entry.Name())))
+		games = append(games, gameDir{addr: addr, dir: filepath.Join(datadir, entry.Name())})
 	}
-	return errors.Join(errs...)
+	return games, nil
+}
+
+// traceCacheDiskManager removes the disk trace caches of games along with their data.
+type traceCacheDiskManager struct {
+	scheduler.DiskManager
+	dir string
+}
+
+func (d *traceCacheDiskManager) RemoveAllExcept(keep []common.Address) error {
+	return errors.Join(cache.PruneDiskCache(d.dir, keep), d.DiskManager.RemoveAllExcept(keep))
+}
+
+// progressDiskManager stops tracking the progress of games before their data is removed, so games dropped by the
+// scheduler aren't reported as stale.
+type progressDiskManager struct {
+	scheduler.DiskManager
+	progress *fault.ProgressTracker
+}
+
+func (d *progressDiskManager) RemoveAllExcept(keep []common.Address) error {
+	d.progress.RemoveAllExcept(keep)
+	return d.DiskManager.RemoveAllExcept(keep)
 }
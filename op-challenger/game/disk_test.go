@@ -4,7 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cache"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -55,3 +59,34 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	require.DirExists(t, unexpectedDir, "should not delete unexpected dir")
 	require.DirExists(t, invalidHexDir, "should not delete dir with invalid address")
 }
+
+func TestTraceCacheDiskManager_RemoveAllExcept(t *testing.T) {
+	cacheDir := t.TempDir()
+	keep := common.Address{0x53}
+	remove := common.Address{0xaa}
+	disk := &traceCacheDiskManager{DiskManager: newDiskManager(t.TempDir()), dir: cacheDir}
+	for _, game := range []common.Address{keep, remove} {
+		require.NoError(t, os.MkdirAll(disk.DirForGame(game), 0777))
+		require.NoError(t, os.WriteFile(cache.DiskCachePath(cacheDir, game), []byte("{}"), 0644))
+	}
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
+	require.FileExists(t, cache.DiskCachePath(cacheDir, keep))
+	require.DirExists(t, disk.DirForGame(keep))
+	require.NoFileExists(t, cache.DiskCachePath(cacheDir, remove), "should remove trace cache of removed game")
+	require.NoDirExists(t, disk.DirForGame(remove))
+}
+
+func TestProgressDiskManager_RemoveAllExcept(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	progress := fault.NewProgressTracker(cl)
+	keep := common.Address{0x53}
+	dropped := common.Address{0xaa}
+	progress.Record(keep, 1, true)
+	progress.Record(dropped, 1, true)
+	cl.AdvanceTime(2 * time.Minute)
+	disk := &progressDiskManager{DiskManager: newDiskManager(t.TempDir()), progress: progress}
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
+	require.Equal(t, []common.Address{keep}, progress.GetStaleGames(time.Minute), "should stop tracking dropped game")
+}
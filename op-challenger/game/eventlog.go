@@ -0,0 +1,65 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// eventSinks is a [types.EventSink] emitting each event to all of its sinks.
+type eventSinks []types.EventSink
+
+func (s eventSinks) Emit(event types.Event) {
+	for _, sink := range s {
+		sink.Emit(event)
+	}
+}
+
+// jsonlEventSink is a [types.EventSink] that appends each event as a line of JSON to a file,
+// rotating the file once it exceeds a maximum size.
+type jsonlEventSink struct {
+	logger log.Logger
+	clock  clock.Clock
+
+	mu   sync.Mutex
+	file *rotatingFile
+}
+
+func newJSONLEventSink(logger log.Logger, cl clock.Clock, path string, maxSize int64) *jsonlEventSink {
+	return &jsonlEventSink{
+		logger: logger,
+		clock:  cl,
+		file:   &rotatingFile{path: path, maxSize: maxSize},
+	}
+}
+
+// Emit writes event to the file. Failures are logged rather than returned so that
+// recording events never interferes with playing games.
+func (s *jsonlEventSink) Emit(event types.Event) {
+	if event.Time.IsZero() {
+		event.Time = s.clock.Now()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("Failed to encode event", "type", event.Type, "err", err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.file.Write(append(data, '\n')); err != nil {
+		s.logger.Error("Failed to write event", "type", event.Type, "err", err)
+	}
+}
+
+func (s *jsonlEventSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close event log: %w", err)
+	}
+	return nil
+}
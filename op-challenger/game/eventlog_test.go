@@ -0,0 +1,92 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSinks(t *testing.T) {
+	first := &recordingEventSink{}
+	second := &recordingEventSink{}
+	event := types.Event{Type: types.EventGameDiscovered, Game: common.Address{0xaa}}
+	eventSinks{first, second}.Emit(event)
+	require.Equal(t, []types.Event{event}, first.events)
+	require.Equal(t, []types.Event{event}, second.events)
+}
+
+func TestJSONLEventSink_WritesEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	sink := newJSONLEventSink(testlog.Logger(t, log.LvlError), cl, path, 1024*1024)
+
+	claimIndex := 2
+	txHash := common.Hash{0xcc}
+	countered := types.Event{Type: types.EventClaimCountered, Game: common.Address{0xaa}, ClaimIndex: &claimIndex, TxHash: &txHash}
+	resolved := types.Event{Type: types.EventGameResolved, Time: time.Unix(500, 0), Game: common.Address{0xbb}, Status: "Challenger Won"}
+	sink.Emit(countered)
+	sink.Emit(resolved)
+	require.NoError(t, sink.Close())
+
+	countered.Time = time.Unix(1000, 0)
+	events := readEvents(t, path)
+	require.Len(t, events, 2)
+	requireEventEqual(t, countered, events[0])
+	requireEventEqual(t, resolved, events[1])
+}
+
+func TestJSONLEventSink_Rotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := newJSONLEventSink(testlog.Logger(t, log.LvlError), clock.NewDeterministicClock(time.Unix(1000, 0)), path, 150)
+	sink.Emit(types.Event{Type: types.EventGameDiscovered, Game: common.Address{0x01}})
+	sink.Emit(types.Event{Type: types.EventGameDiscovered, Game: common.Address{0x02}})
+	sink.Emit(types.Event{Type: types.EventGameDiscovered, Game: common.Address{0x03}})
+	require.NoError(t, sink.Close())
+
+	rotated := readEvents(t, path+".1")
+	require.Len(t, rotated, 1)
+	require.Equal(t, common.Address{0x02}, rotated[0].Game)
+	current := readEvents(t, path)
+	require.Len(t, current, 1)
+	require.Equal(t, common.Address{0x03}, current[0].Game)
+}
+
+func requireEventEqual(t *testing.T, expected types.Event, actual types.Event) {
+	require.True(t, expected.Time.Equal(actual.Time), "expected time %v but got %v", expected.Time, actual.Time)
+	expected.Time = time.Time{}
+	actual.Time = time.Time{}
+	require.Equal(t, expected, actual)
+}
+
+func readEvents(t *testing.T, path string) []types.Event {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var events []types.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event types.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+type recordingEventSink struct {
+	events []types.Event
+}
+
+func (s *recordingEventSink) Emit(event types.Event) {
+	s.events = append(s.events, event)
+}
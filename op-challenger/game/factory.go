@@ -29,6 +29,8 @@ type FaultDisputeGame struct {
 	GameType  uint8
 	Timestamp uint64
 	Proxy     common.Address
+	// Factory is the dispute game factory that created the game, set by [multiFactorySource].
+	Factory common.Address
 }
 
 type gameLoader struct {
@@ -68,8 +70,43 @@ func (l *gameLoader) FetchAllGamesAtBlock(ctx context.Context, earliestTimestamp
 		if game.Timestamp < earliestTimestamp {
 			break
 		}
-		games = append(games, game)
+		games = append(games, FaultDisputeGame{GameType: game.GameType, Timestamp: game.Timestamp, Proxy: game.Proxy})
 	}
 
 	return games, nil
 }
+
+// FetchGameCount fetches the number of games created by the factory as of a given block number.
+func (l *gameLoader) FetchGameCount(ctx context.Context, blockNumber *big.Int) (uint64, error) {
+	if blockNumber == nil {
+		return 0, ErrMissingBlockNumber
+	}
+	gameCount, err := l.caller.GameCount(&bind.CallOpts{
+		Context:     ctx,
+		BlockNumber: blockNumber,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch game count: %w", err)
+	}
+	return gameCount.Uint64(), nil
+}
+
+// FetchGames fetches the games with indices in the range [from, to) at a given block number, newest first.
+func (l *gameLoader) FetchGames(ctx context.Context, blockNumber *big.Int, from uint64, to uint64) ([]FaultDisputeGame, error) {
+	if blockNumber == nil {
+		return nil, ErrMissingBlockNumber
+	}
+	callOpts := &bind.CallOpts{
+		Context:     ctx,
+		BlockNumber: blockNumber,
+	}
+	games := make([]FaultDisputeGame, 0, to-from)
+	for i := to; i > from; i-- {
+		game, err := l.caller.GameAtIndex(callOpts, new(big.Int).SetUint64(i-1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch game at index %d: %w", i-1, err)
+		}
+		games = append(games, FaultDisputeGame{GameType: game.GameType, Timestamp: game.Timestamp, Proxy: game.Proxy})
+	}
+	return games, nil
+}
@@ -85,6 +85,53 @@ func TestGameLoader_FetchAllGames(t *testing.T) {
 	}
 }
 
+func TestGameLoader_FetchGames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Count", func(t *testing.T) {
+		loader := NewGameLoader(newMockMinimalDisputeGameFactoryCaller(10, false, false))
+		count, err := loader.FetchGameCount(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), count)
+	})
+
+	t.Run("CountError", func(t *testing.T) {
+		loader := NewGameLoader(newMockMinimalDisputeGameFactoryCaller(10, true, false))
+		_, err := loader.FetchGameCount(context.Background(), big.NewInt(1))
+		require.ErrorIs(t, err, gameCountErr)
+	})
+
+	t.Run("RangeNewestFirst", func(t *testing.T) {
+		caller := newMockMinimalDisputeGameFactoryCaller(10, false, false)
+		loader := NewGameLoader(caller)
+		games, err := loader.FetchGames(context.Background(), big.NewInt(1), 3, 6)
+		require.NoError(t, err)
+		expected := []FaultDisputeGame{caller.games[5], caller.games[4], caller.games[3]}
+		require.Equal(t, expected, translateGames(games))
+	})
+
+	t.Run("EmptyRange", func(t *testing.T) {
+		loader := NewGameLoader(newMockMinimalDisputeGameFactoryCaller(10, false, false))
+		games, err := loader.FetchGames(context.Background(), big.NewInt(1), 4, 4)
+		require.NoError(t, err)
+		require.Empty(t, games)
+	})
+
+	t.Run("IndexError", func(t *testing.T) {
+		loader := NewGameLoader(newMockMinimalDisputeGameFactoryCaller(10, false, true))
+		_, err := loader.FetchGames(context.Background(), big.NewInt(1), 0, 10)
+		require.ErrorIs(t, err, gameIndexErr)
+	})
+
+	t.Run("MissingBlockNumber", func(t *testing.T) {
+		loader := NewGameLoader(newMockMinimalDisputeGameFactoryCaller(10, false, false))
+		_, err := loader.FetchGameCount(context.Background(), nil)
+		require.ErrorIs(t, err, ErrMissingBlockNumber)
+		_, err = loader.FetchGames(context.Background(), nil, 0, 10)
+		require.ErrorIs(t, err, ErrMissingBlockNumber)
+	})
+}
+
 func generateMockGames(count uint64) []FaultDisputeGame {
 	games := make([]FaultDisputeGame, count)
 
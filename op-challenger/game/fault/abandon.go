@@ -0,0 +1,101 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const abandonedFile = "abandoned.json"
+
+// AbandonedGame records why and when the challenger stopped participating in a game.
+type AbandonedGame struct {
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// AbandonedGames records the games the challenger has stopped participating in.
+// Each abandonment is persisted in the game's data directory so it survives restarts.
+// It is safe for concurrent use by the players of different games.
+type AbandonedGames struct {
+	clock      clock.Clock
+	dirForGame func(addr common.Address) string
+
+	mu sync.Mutex
+	// games caches whether each game that has been checked is abandoned. Nil entries are not abandoned.
+	games map[common.Address]*AbandonedGame
+}
+
+func NewAbandonedGames(cl clock.Clock, dirForGame func(addr common.Address) string) *AbandonedGames {
+	return &AbandonedGames{
+		clock:      cl,
+		dirForGame: dirForGame,
+		games:      make(map[common.Address]*AbandonedGame),
+	}
+}
+
+// Abandon records that the challenger has stopped participating in game for reason.
+// Abandoning a game that is already abandoned keeps the original record.
+func (a *AbandonedGames) Abandon(game common.Address, reason string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	existing, err := a.load(game)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	record := &AbandonedGame{Reason: reason, Time: a.clock.Now()}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode abandoned game: %w", err)
+	}
+	dir := a.dirForGame(game)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create game dir: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, abandonedFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write abandoned game: %w", err)
+	}
+	a.games[game] = record
+	return nil
+}
+
+// Abandoned returns the abandonment record of game, or false if it hasn't been abandoned.
+func (a *AbandonedGames) Abandoned(game common.Address) (AbandonedGame, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, err := a.load(game)
+	if err != nil || record == nil {
+		return AbandonedGame{}, false, err
+	}
+	return *record, true, nil
+}
+
+// load returns the abandonment record of game, reading it from disk the first time the game is checked.
+func (a *AbandonedGames) load(game common.Address) (*AbandonedGame, error) {
+	if record, ok := a.games[game]; ok {
+		return record, nil
+	}
+	data, err := os.ReadFile(filepath.Join(a.dirForGame(game), abandonedFile))
+	if errors.Is(err, os.ErrNotExist) {
+		a.games[game] = nil
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read abandoned game: %w", err)
+	}
+	var record AbandonedGame
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode abandoned game: %w", err)
+	}
+	a.games[game] = &record
+	return &record, nil
+}
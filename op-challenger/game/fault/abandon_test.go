@@ -0,0 +1,69 @@
+package fault
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbandonedGames(t *testing.T) {
+	dirForGame := func(dir string) func(addr common.Address) string {
+		return func(addr common.Address) string {
+			return filepath.Join(dir, addr.Hex())
+		}
+	}
+	game := common.Address{0xaa}
+	other := common.Address{0xbb}
+
+	t.Run("NotAbandoned", func(t *testing.T) {
+		games := NewAbandonedGames(clock.NewDeterministicClock(time.Unix(1000, 0)), dirForGame(t.TempDir()))
+		_, ok, err := games.Abandoned(game)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("Abandon", func(t *testing.T) {
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		games := NewAbandonedGames(cl, dirForGame(t.TempDir()))
+		require.NoError(t, games.Abandon(game, "bad prestate"))
+		record, ok, err := games.Abandoned(game)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "bad prestate", record.Reason)
+		require.True(t, cl.Now().Equal(record.Time))
+
+		_, ok, err = games.Abandoned(other)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("KeepOriginalRecord", func(t *testing.T) {
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		games := NewAbandonedGames(cl, dirForGame(t.TempDir()))
+		require.NoError(t, games.Abandon(game, "first"))
+		cl.AdvanceTime(time.Minute)
+		require.NoError(t, games.Abandon(game, "second"))
+		record, ok, err := games.Abandoned(game)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "first", record.Reason)
+		require.True(t, time.Unix(1000, 0).Equal(record.Time))
+	})
+
+	t.Run("PersistAcrossRestart", func(t *testing.T) {
+		dir := t.TempDir()
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		require.NoError(t, NewAbandonedGames(cl, dirForGame(dir)).Abandon(game, "bad prestate"))
+
+		restarted := NewAbandonedGames(cl, dirForGame(dir))
+		record, ok, err := restarted.Abandoned(game)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "bad prestate", record.Reason)
+		require.True(t, cl.Now().Equal(record.Time))
+	})
+}
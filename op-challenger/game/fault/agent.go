@@ -1,13 +1,23 @@
 package fault
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/ratelimit"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
 )
 
 // Responder takes a response action & executes.
@@ -15,60 +25,362 @@ import (
 type Responder interface {
 	CallResolve(ctx context.Context) (types.GameStatus, error)
 	Resolve(ctx context.Context) error
+	CallResolveClaim(ctx context.Context, claimIdx uint64) error
+	ResolveClaim(ctx context.Context, claimIdx uint64) error
 	Respond(ctx context.Context, response types.Claim) error
 	Step(ctx context.Context, stepData types.StepCallData) error
+	EstimateRespondGas(ctx context.Context, response types.Claim) (uint64, error)
+	EstimateStepGas(ctx context.Context, stepData types.StepCallData) (uint64, error)
+	BaseFee(ctx context.Context) (*big.Int, error)
+	// Spent returns the total value in wei spent on the game's mined transactions.
+	Spent() *big.Int
 }
 
-type ClaimLoader interface {
-	FetchClaims(ctx context.Context) ([]types.Claim, error)
+var ErrInvalidStepProof = errors.New("invalid step proof from trace provider")
+
+// The phases the time taken to act on a game is recorded by.
+// The load and total phases are recorded by the [GamePlayer], which loads the claims the agent acts on.
+const (
+	phaseTotal   = "total"
+	phaseLoad    = "load"
+	phaseSolve   = "solve"
+	phaseRespond = "respond"
+)
+
+// L2Finality reports whether the L2 data a game's trace is derived from is finalized.
+type L2Finality interface {
+	L2BlockFinalized(ctx context.Context) (bool, error)
+}
+
+// MoveLimits bounds the moves the agent makes each time it acts on a game.
+type MoveLimits struct {
+	// MaxGas is the maximum estimated gas for a move or step transaction. 0 disables the limit.
+	MaxGas uint64
+	// MaxPerCycle is the maximum number of moves made each cycle, 0 for no limit. The moves countering the claims
+	// with the least time left on their clocks are made first, with the rest made in later cycles.
+	MaxPerCycle int
+	// MaxParallel is the maximum number of move transactions in flight at once. Values below 1 send one at a time.
+	MaxParallel int
+	// MaxGasPrice is the maximum L1 base fee in wei to make moves at, nil for no limit. While the base fee is higher,
+	// moves are deferred unless the countered claim's clock has no more than UrgentClock remaining.
+	MaxGasPrice *big.Int
+	// UrgentClock is the remaining clock below which moves are made regardless of MaxGasPrice.
+	UrgentClock time.Duration
+	// Finality reports whether the L2 data the moves are derived from is finalized, nil if moves don't require it.
+	// While the data isn't finalized moves are deferred, with urgent moves only made if AcceptUnfinalizedRisk is set.
+	Finality L2Finality
+	// AcceptUnfinalizedRisk allows urgent moves to be made from unfinalized L2 data rather than letting clocks expire.
+	AcceptUnfinalizedRisk bool
+	// HaltOnSelfConflict stops moves in the game once an opponent counters a claim we agree with using our own
+	// trace's value. Steps and resolution continue.
+	HaltOnSelfConflict bool
+	// Claimant is the address moves are sent from. A move is skipped if the claimant already has a claim at its
+	// position countering the same parent, as a second claim there would conflict with our own. Zero disables the check.
+	Claimant common.Address
+	// MaxSpend is the maximum value in wei spent on the game's transactions, nil for no limit. Once a move would
+	// exceed it, no more moves are made in the game. Steps and resolution continue.
+	MaxSpend *big.Int
+	// MoveBond is the bond expected to be posted with each move, counted towards MaxSpend along with the move's
+	// estimated gas. Nil if moves aren't expected to post a bond.
+	MoveBond *big.Int
 }
 
 type Agent struct {
+	metrics                 metrics.Metricer
+	clock                   clock.Clock
+	game                    common.Address
 	solver                  *solver.Solver
-	loader                  ClaimLoader
+	strategy                ResolutionStrategy
+	validator               types.StepDataValidator
 	responder               Responder
 	updater                 types.OracleUpdater
 	maxDepth                int
+	limits                  MoveLimits
+	gameDuration            time.Duration
 	agreeWithProposedOutput bool
 	log                     log.Logger
+
+	// resolvedClaims are the contract indices of the claims whose subgames are known to be resolved.
+	resolvedClaims map[int]bool
+	// checkedCounters are the contract indices of the claims already checked for self conflicts.
+	checkedCounters map[int]bool
+	// agreement caches whether our trace agrees with the claim at each contract index.
+	agreement map[int]bool
+	// selfConflict is true once an opponent has countered an agreed claim with our own trace's value.
+	selfConflict bool
+	// spendCapped is true once a move would have exceeded the spend limit.
+	spendCapped bool
+	// respondTime is the time spent sending transactions during the current Act.
+	respondTime time.Duration
+	// responsePending is true if the last Act found moves or steps to make.
+	responsePending bool
 }
 
-func NewAgent(loader ClaimLoader, maxDepth int, trace types.TraceProvider, responder Responder, updater types.OracleUpdater, agreeWithProposedOutput bool, log log.Logger) *Agent {
+// NewAgent creates an agent that acts on the game as decided by strategy.
+// If strategy is nil, the [AggressiveStrategy] is used.
+// The gameDuration is used to find the claims whose subgames can be resolved, 0 if unknown.
+func NewAgent(m metrics.Metricer, cl clock.Clock, game common.Address, maxDepth int, limits MoveLimits, gameDuration time.Duration, trace types.TraceProvider, responder Responder, updater types.OracleUpdater, strategy ResolutionStrategy, agreeWithProposedOutput bool, log log.Logger) *Agent {
+	validator, _ := trace.(types.StepDataValidator)
+	if strategy == nil {
+		strategy = NewAggressiveStrategy(maxDepth)
+	}
 	return &Agent{
+		validator:               validator,
+		metrics:                 m,
+		clock:                   cl,
+		game:                    game,
 		solver:                  solver.NewSolver(maxDepth, trace),
-		loader:                  loader,
+		strategy:                strategy,
 		responder:               responder,
 		updater:                 updater,
 		maxDepth:                maxDepth,
+		limits:                  limits,
+		gameDuration:            gameDuration,
 		agreeWithProposedOutput: agreeWithProposedOutput,
 		log:                     log,
+		resolvedClaims:          make(map[int]bool),
+		checkedCounters:         make(map[int]bool),
+		agreement:               make(map[int]bool),
 	}
 }
 
+// Reset discards the state cached from previous snapshots.
+// Called when the block those snapshots were loaded at is reorged out, as the claims they
+// reported resolved may have been resolved by transactions that are no longer canonical and
+// the claims at each contract index may have changed. Self conflicts are checked again from scratch.
+func (a *Agent) Reset() {
+	a.resolvedClaims = make(map[int]bool)
+	a.checkedCounters = make(map[int]bool)
+	a.agreement = make(map[int]bool)
+	a.selfConflict = false
+}
+
 // Act iterates the game & performs all of the next actions.
-func (a *Agent) Act(ctx context.Context) error {
-	if a.tryResolve(ctx) {
+// The claims are read from the snapshot loaded at the start of the current cycle.
+func (a *Agent) Act(ctx context.Context, snapshot *GameSnapshot) error {
+	start := a.clock.Now()
+	a.respondTime = 0
+	a.responsePending = false
+	defer func() {
+		a.metrics.RecordActDuration(a.game, phaseSolve, a.clock.Now().Sub(start)-a.respondTime)
+		a.metrics.RecordActDuration(a.game, phaseRespond, a.respondTime)
+	}()
+	resolved, resolvable := false, false
+	if a.resolveClaims(ctx, snapshot) {
+		resolved, resolvable = a.tryResolve(ctx, snapshot)
+	}
+	if resolved {
 		return nil
 	}
-	game, err := a.newGameFromContracts(ctx)
+	game, err := a.newGameFromClaims(snapshot.Claims)
 	if err != nil {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
-	// Create counter claims
-	for _, claim := range game.Claims() {
-		if err := a.move(ctx, claim, game); err != nil && !errors.Is(err, types.ErrGameDepthReached) {
-			log.Error("Failed to move", "err", err)
+	// A game that can be resolved with only the root claim has an expired root clock, so it can't be countered.
+	if resolvable && len(game.Claims()) == 1 {
+		a.log.Info("Root claim clock expired without counter claims, not moving")
+		return nil
+	}
+	a.checkSelfConflicts(ctx, game)
+	haltMoves := a.limits.HaltOnSelfConflict && a.selfConflict
+	if haltMoves {
+		a.log.Warn("Not moving in game after self conflict, only stepping and resolving")
+	}
+	// committed is the value spent on the game plus the estimated cost of the moves queued this cycle.
+	var committed *big.Int
+	if a.limits.MaxSpend != nil {
+		committed = a.responder.Spent()
+		if a.spendCapped {
+			a.log.Warn("Not moving in game after spend cap reached, only stepping and resolving", "spent", committed, "max", a.limits.MaxSpend)
 		}
 	}
-	// Step on all leaf claims
-	for _, claim := range game.Claims() {
-		if err := a.step(ctx, claim, game); err != nil {
+	actions := a.strategy.NextActions(game)
+	a.responsePending = len(actions) > 0
+	if a.limits.MaxPerCycle > 0 {
+		a.prioritizeMoves(actions, snapshot)
+	}
+	// Moves are prepared in order and sent in batches, so a step is only performed once the moves before it are sent.
+	var moves []queuedMove
+	skipped := 0
+	rateLimited := false
+	var baseFee *big.Int
+	baseFeeLoaded := false
+	finalized, finalityLoaded := false, false
+	// stepped are the contract indices of the claims stepped against this cycle, as a move against a leaf claim
+	// is made as a step which the strategy may have already requested.
+	stepped := make(map[int]bool)
+	doStep := func(claim types.Claim) {
+		a.sendMoves(ctx, moves)
+		moves = nil
+		if rateLimited || stepped[claim.ContractIndex] {
+			return
+		}
+		stepped[claim.ContractIndex] = true
+		if err := a.step(ctx, claim, game, a.clockCritical(claim, snapshot)); errors.Is(err, ratelimit.ErrRateLimited) {
+			// The game yields rather than waiting for the limit so the other games can still progress.
+			a.log.Info("Trace provider rate limited, deferring steps to the next cycle")
+			rateLimited = true
+		} else if err != nil {
 			log.Error("Failed to step", "err", err)
 		}
 	}
+	for _, action := range actions {
+		switch action.Type {
+		case ActionTypeStep:
+			doStep(action.Claim)
+		case ActionTypeMove:
+			// Claims at the max depth are countered by a step, which is still made.
+			if (haltMoves || a.spendCapped) && action.Claim.Depth() < a.maxDepth {
+				continue
+			}
+			if a.limits.MaxPerCycle > 0 && len(moves) >= a.limits.MaxPerCycle {
+				skipped++
+				continue
+			}
+			move, err := a.prepareMove(ctx, action.Claim, game)
+			if errors.Is(err, types.ErrGameDepthReached) {
+				// Claims at the max depth can only be countered by a step, never bisected further.
+				a.log.Debug("Reached maximum game depth, stepping instead of moving", "claim", action.Claim.ContractIndex, "depth", action.Claim.Depth(), "maxDepth", a.maxDepth)
+				doStep(action.Claim)
+			} else if err != nil {
+				log.Error("Failed to move", "err", err)
+			} else if move != nil {
+				if !finalityLoaded {
+					finalized = a.loadFinalized(ctx)
+					finalityLoaded = true
+				}
+				if a.deferForFinality(a.moveLogger(*move), action.Claim, snapshot, finalized) {
+					continue
+				}
+				if !baseFeeLoaded {
+					baseFee = a.loadBaseFee(ctx)
+					baseFeeLoaded = true
+				}
+				if a.deferForGasPrice(a.moveLogger(*move), action.Claim, snapshot, baseFee) {
+					continue
+				}
+				if a.exceedsSpendCap(ctx, a.moveLogger(*move), *move, committed, baseFee) {
+					continue
+				}
+				moves = append(moves, queuedMove{claim: *move, clockCritical: a.clockCritical(action.Claim, snapshot)})
+			}
+		default:
+			a.log.Warn("Ignoring unknown action from resolution strategy", "type", action.Type)
+		}
+	}
+	a.sendMoves(ctx, moves)
+	if skipped > 0 {
+		a.log.Warn("Move budget exhausted, deferring remaining moves to the next cycle", "budget", a.limits.MaxPerCycle, "deferred", skipped)
+	}
 	return nil
 }
 
+// ResponsePending returns true if the last Act found moves or steps to make, which remain pending until the game's
+// claims show they were made.
+func (a *Agent) ResponsePending() bool {
+	return a.responsePending
+}
+
+// prioritizeMoves reorders the move actions so those countering the claims with the least time left on their
+// clocks come first, leaving the other actions in place. Moves keep their order if the clocks are unknown.
+func (a *Agent) prioritizeMoves(actions []Action, snapshot *GameSnapshot) {
+	if a.gameDuration == 0 || snapshot.Block.Time == 0 {
+		return
+	}
+	var positions []int
+	var moves []Action
+	for i, action := range actions {
+		if action.Type == ActionTypeMove {
+			positions = append(positions, i)
+			moves = append(moves, action)
+		}
+	}
+	slices.SortStableFunc(moves, func(x, y Action) bool {
+		return snapshot.claimRemainingClock(x.Claim, a.gameDuration) < snapshot.claimRemainingClock(y.Claim, a.gameDuration)
+	})
+	for i, pos := range positions {
+		actions[pos] = moves[i]
+	}
+}
+
+// checkSelfConflicts checks each new counter to a claim we agree with for a value that matches our own trace at the
+// counter's position. An opponent agreeing with our trace while countering our claim suggests our trace or prestate
+// may be wrong, so it is reported with an error. The same happens when an opponent's trace diverges between the
+// counter and the claim it counters, so moves are only halted if HaltOnSelfConflict is set.
+func (a *Agent) checkSelfConflicts(ctx context.Context, game types.Game) {
+	for _, claim := range game.Claims() {
+		if claim.IsRoot() || a.checkedCounters[claim.ContractIndex] {
+			continue
+		}
+		parent := types.Claim{ClaimData: claim.Parent, ContractIndex: claim.ParentContractIndex}
+		conflict, err := a.isSelfConflict(ctx, game, parent, claim)
+		if err != nil {
+			a.log.Warn("Failed to check claim for self conflict", "claim", claim.ContractIndex, "err", err)
+			continue
+		}
+		a.checkedCounters[claim.ContractIndex] = true
+		if !conflict {
+			continue
+		}
+		a.log.Error("Opponent agrees with our trace - possible configuration error",
+			"claim", claim.ContractIndex, "parent", claim.ParentContractIndex, "value", claim.Value,
+			"depth", claim.Depth(), "trace_index", claim.TraceIndex(a.maxDepth))
+		a.metrics.RecordSelfConflict(a.game)
+		a.selfConflict = true
+	}
+}
+
+// isSelfConflict returns true if counter is an opponent's claim matching our trace that counters a claim we agree with.
+func (a *Agent) isSelfConflict(ctx context.Context, game types.Game, parent types.Claim, counter types.Claim) (bool, error) {
+	if !game.AgreeWithClaimLevel(parent) {
+		return false, nil
+	}
+	if agree, err := a.agreeWithClaim(ctx, parent); err != nil || !agree {
+		return false, err
+	}
+	return a.agreeWithClaim(ctx, counter)
+}
+
+// agreeWithClaim returns true if our trace agrees with claim, only asking the solver the first time each contract
+// index is checked.
+func (a *Agent) agreeWithClaim(ctx context.Context, claim types.Claim) (bool, error) {
+	if agree, ok := a.agreement[claim.ContractIndex]; ok {
+		return agree, nil
+	}
+	agree, err := a.solver.AgreeWithClaim(ctx, claim.ClaimData)
+	if err != nil {
+		return false, err
+	}
+	a.agreement[claim.ContractIndex] = agree
+	return agree, nil
+}
+
+// sortClaims returns a copy of claims ordered by contract index, then by value.
+func sortClaims(claims []types.Claim) []types.Claim {
+	sorted := slices.Clone(claims)
+	slices.SortStableFunc(sorted, func(a, b types.Claim) bool {
+		if a.ContractIndex != b.ContractIndex {
+			return a.ContractIndex < b.ContractIndex
+		}
+		return bytes.Compare(a.Value[:], b.Value[:]) < 0
+	})
+	return sorted
+}
+
+// validateStep checks the step data is present and, if the trace provider supports it, correctly encoded for its VM.
+func (a *Agent) validateStep(step solver.StepData) error {
+	if len(step.PreState) == 0 {
+		return errors.New("missing state data")
+	}
+	if step.ProofData == nil {
+		return errors.New("missing proof data")
+	}
+	if a.validator == nil {
+		return nil
+	}
+	return a.validator.ValidateStepData(step.PreState, step.ProofData)
+}
+
 // shouldResolve returns true if the agent should resolve the game.
 // This method will return false if the game is still in progress.
 func (a *Agent) shouldResolve(ctx context.Context, status types.GameStatus) bool {
@@ -82,30 +394,109 @@ func (a *Agent) shouldResolve(ctx context.Context, status types.GameStatus) bool
 	return expected == status
 }
 
-// tryResolve resolves the game if it is in a terminal state
-// and returns true if the game resolves successfully.
-func (a *Agent) tryResolve(ctx context.Context) bool {
+// tryResolve resolves the game if it is in a terminal state.
+// resolved is true if the game resolves successfully and resolvable is true if the game could be resolved,
+// even if it would resolve to an outcome the agent doesn't want.
+// A game with only the root claim can be resolved once the root claim's clock expires.
+// A game already resolved by another transaction is treated as resolved.
+func (a *Agent) tryResolve(ctx context.Context, snapshot *GameSnapshot) (resolved bool, resolvable bool) {
 	status, err := a.responder.CallResolve(ctx)
-	if err != nil {
-		return false
+	if responder.IsGameAlreadyResolved(err) {
+		a.log.Info("Game already resolved")
+		return true, true
+	} else if err != nil {
+		return false, false
 	}
 	if !a.shouldResolve(ctx, status) {
-		return false
+		return false, true
+	}
+	if remaining, ok := snapshot.LongestRemainingClock(a.gameDuration); ok && remaining == 0 {
+		// The status is still in progress on chain, but no claim can be countered anymore.
+		a.log.Info("Resolving expired game", "status", status)
+	} else {
+		a.log.Info("Resolving game", "status", status)
 	}
-	a.log.Info("Resolving game")
-	if err := a.responder.Resolve(ctx); err != nil {
+	sent := a.clock.Now()
+	err = a.responder.Resolve(ctx)
+	a.addRespondTime(sent)
+	if errors.Is(err, responder.ErrResolveReverted) {
+		// The game was most likely resolved by another transaction since it was checked.
+		a.log.Info("Resolve transaction reverted, assuming game already resolved", "err", err)
+		return true, true
+	} else if err != nil {
 		a.log.Error("Failed to resolve the game", "err", err)
-		return false
+		return false, true
 	}
-	return true
+	return true, true
 }
 
-// newGameFromContracts initializes a new game state from the state in the contract
-func (a *Agent) newGameFromContracts(ctx context.Context) (types.Game, error) {
-	claims, err := a.loader.FetchClaims(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch claims: %w", err)
+// resolveClaims resolves the subgames of the claims whose chess clocks have expired, for contract versions that
+// require every subgame to be resolved before the game itself.
+// A subgame can only be resolved once the subgames of all its counter claims are, so they are resolved bottom-up.
+// Resolvability is checked against the snapshot so only the claims that can be resolved are called, and claims
+// found to be resolved aren't checked again.
+// Returns true if the game can be resolved, which is once the root claim's subgame is resolved.
+func (a *Agent) resolveClaims(ctx context.Context, snapshot *GameSnapshot) bool {
+	if !resolvesSubgames(snapshot.Claims) {
+		return true
+	}
+	children := make(map[int][]int)
+	for _, claim := range snapshot.Claims[1:] {
+		children[claim.ParentContractIndex] = append(children[claim.ParentContractIndex], claim.ContractIndex)
+	}
+	clocksKnown := a.gameDuration != 0 && snapshot.Block.Time != 0
+	// Counter claims always have a higher contract index than the claim they counter.
+	for i := len(snapshot.Claims) - 1; i >= 0; i-- {
+		claim := snapshot.Claims[i]
+		if a.resolvedClaims[claim.ContractIndex] {
+			continue
+		}
+		if clocksKnown && snapshot.claimRemainingClock(claim, a.gameDuration) > 0 {
+			continue
+		}
+		if !slices.ContainsFunc(children[claim.ContractIndex], func(child int) bool { return !a.resolvedClaims[child] }) {
+			if err := a.resolveClaim(ctx, claim); err != nil {
+				a.log.Debug("Claim not resolvable yet", "claim", claim.ContractIndex, "err", err)
+				continue
+			}
+			a.resolvedClaims[claim.ContractIndex] = true
+		}
 	}
+	return a.resolvedClaims[0]
+}
+
+// resolveClaim resolves the subgame of claim, treating a subgame resolved by another transaction as success.
+func (a *Agent) resolveClaim(ctx context.Context, claim types.Claim) error {
+	claimIdx := uint64(claim.ContractIndex)
+	if err := a.responder.CallResolveClaim(ctx, claimIdx); responder.IsClaimAlreadyResolved(err) {
+		a.log.Debug("Claim already resolved", "claim", claimIdx)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	a.log.Info("Resolving claim", "claim", claimIdx)
+	sent := a.clock.Now()
+	err := a.responder.ResolveClaim(ctx, claimIdx)
+	a.addRespondTime(sent)
+	if errors.Is(err, responder.ErrResolveClaimReverted) {
+		// The claim was most likely resolved by another transaction since it was checked.
+		a.log.Info("Resolve claim transaction reverted, assuming claim already resolved", "claim", claimIdx, "err", err)
+		return nil
+	} else if err != nil {
+		a.log.Error("Failed to resolve claim", "claim", claimIdx, "err", err)
+		return err
+	}
+	return nil
+}
+
+// resolvesSubgames returns true if the claims are from a contract version that requires the subgame of each claim
+// to be resolved before the game. Only those versions record the claimant, which is never empty for the root claim.
+func resolvesSubgames(claims []types.Claim) bool {
+	return len(claims) > 0 && claims[0].Claimant != (common.Address{})
+}
+
+// newGameFromClaims initializes a new game state from the claims loaded from the contract
+func (a *Agent) newGameFromClaims(claims []types.Claim) (types.Game, error) {
 	if len(claims) == 0 {
 		return nil, errors.New("no claims")
 	}
@@ -116,30 +507,99 @@ func (a *Agent) newGameFromContracts(ctx context.Context) (types.Game, error) {
 	return game, nil
 }
 
-// move determines & executes the next move given a claim
-func (a *Agent) move(ctx context.Context, claim types.Claim, game types.Game) error {
+// prepareMove determines the next move given a claim, returning nil if no move should be made.
+func (a *Agent) prepareMove(ctx context.Context, claim types.Claim, game types.Game) (*types.Claim, error) {
 	nextMove, err := a.solver.NextMove(ctx, claim, game.AgreeWithClaimLevel(claim))
 	if err != nil {
-		return fmt.Errorf("execute next move: %w", err)
+		return nil, fmt.Errorf("execute next move: %w", err)
 	}
 	if nextMove == nil {
 		a.log.Debug("No next move")
-		return nil
+		return nil, nil
 	}
 	move := *nextMove
-	log := a.log.New("is_defend", move.DefendsParent(), "depth", move.Depth(), "index_at_depth", move.IndexAtDepth(),
-		"value", move.Value, "trace_index", move.TraceIndex(a.maxDepth),
-		"parent_value", claim.Value, "parent_trace_index", claim.TraceIndex(a.maxDepth))
+	log := a.moveLogger(move)
 	if game.IsDuplicate(move) {
 		log.Debug("Skipping duplicate move")
-		return nil
+		return nil, nil
+	}
+	if existing, ok := a.ownClaimAt(game, move); ok {
+		log.Warn("Already have claim at position", "existing", existing.ContractIndex, "existing_value", existing.Value)
+		return nil, nil
+	}
+	if a.exceedsGasCeiling(log, func() (uint64, error) { return a.responder.EstimateRespondGas(ctx, move) }) {
+		return nil, nil
+	}
+	return &move, nil
+}
+
+// ownClaimAt returns the claim made by the configured claimant at the position of move that counters the same parent.
+func (a *Agent) ownClaimAt(game types.Game, move types.Claim) (types.Claim, bool) {
+	if a.limits.Claimant == (common.Address{}) {
+		return types.Claim{}, false
+	}
+	for _, claim := range game.Claims() {
+		if claim.Claimant == a.limits.Claimant && !claim.IsRoot() &&
+			claim.ParentContractIndex == move.ParentContractIndex && claim.Position == move.Position {
+			return claim, true
+		}
 	}
-	log.Info("Performing move")
-	return a.responder.Respond(ctx, move)
+	return types.Claim{}, false
+}
+
+// queuedMove is a move waiting to be sent, and whether the clock of the claim it counters is critical.
+type queuedMove struct {
+	claim         types.Claim
+	clockCritical bool
+}
+
+// sendMoves executes the moves through the responder, with up to the configured number of transactions in flight.
+// Moves countering claims whose clock is critical are sent with urgent fees.
+func (a *Agent) sendMoves(ctx context.Context, moves []queuedMove) {
+	defer a.addRespondTime(a.clock.Now())
+	parallel := a.limits.MaxParallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for _, move := range moves {
+		move := move
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			log := a.moveLogger(move.claim)
+			log.Info("Performing move", "clock_critical", move.clockCritical)
+			moveCtx := ctx
+			if move.clockCritical {
+				moveCtx = responder.WithUrgentFees(ctx)
+			}
+			if err := a.responder.Respond(moveCtx, move.claim); err != nil {
+				log.Error("Failed to move", "err", err)
+				return
+			}
+			a.metrics.RecordGameMove(a.game)
+		}()
+	}
+	wg.Wait()
+}
+
+// addRespondTime adds the time since sent to the time spent sending transactions during the current Act.
+func (a *Agent) addRespondTime(sent time.Time) {
+	a.respondTime += a.clock.Now().Sub(sent)
+}
+
+func (a *Agent) moveLogger(move types.Claim) log.Logger {
+	return a.log.New("is_defend", move.DefendsParent(), "depth", move.Depth(), "index_at_depth", move.IndexAtDepth(),
+		"value", move.Value, "trace_index", move.TraceIndex(a.maxDepth),
+		"parent_value", move.Parent.Value, "parent_trace_index", move.Parent.TraceIndex(a.maxDepth))
 }
 
 // step determines & executes the next step against a leaf claim through the responder
-func (a *Agent) step(ctx context.Context, claim types.Claim, game types.Game) error {
+// A clock critical step is sent even if the responder is halting transactions after repeated reverts.
+func (a *Agent) step(ctx context.Context, claim types.Claim, game types.Game, clockCritical bool) error {
 	if claim.Depth() != a.maxDepth {
 		return nil
 	}
@@ -160,6 +620,10 @@ func (a *Agent) step(ctx context.Context, claim types.Claim, game types.Game) er
 	if err != nil {
 		return fmt.Errorf("attempt step: %w", err)
 	}
+	if err := a.validateStep(step); err != nil {
+		a.log.Error("Invalid step proof from trace provider", "trace_index", step.TraceIndex, "err", err)
+		return fmt.Errorf("%w at trace index %v: %w", ErrInvalidStepProof, step.TraceIndex, err)
+	}
 
 	if step.OracleData != nil {
 		a.log.Info("Updating oracle data", "oracleKey", step.OracleData.OracleKey, "oracleData", step.OracleData.OracleData)
@@ -168,13 +632,162 @@ func (a *Agent) step(ctx context.Context, claim types.Claim, game types.Game) er
 		}
 	}
 
-	a.log.Info("Performing step", "is_attack", step.IsAttack,
+	stepLog := a.log
+	if d := step.Disagreement; d != nil {
+		stepLog = a.log.New("claim", d.ClaimIndex, "trace_index", d.TraceIndex, "claimed", d.Claimed, "expected", d.Expected)
+	}
+	stepLog.Info("Performing step", "is_attack", step.IsAttack,
 		"depth", step.LeafClaim.Depth(), "index_at_depth", step.LeafClaim.IndexAtDepth(), "value", step.LeafClaim.Value)
 	callData := types.StepCallData{
 		ClaimIndex: uint64(step.LeafClaim.ContractIndex),
 		IsAttack:   step.IsAttack,
 		StateData:  step.PreState,
 		Proof:      step.ProofData,
+
+		ClockCritical: clockCritical,
+	}
+	if a.exceedsGasCeiling(a.log, func() (uint64, error) { return a.responder.EstimateStepGas(ctx, callData) }) {
+		return nil
+	}
+	sent := a.clock.Now()
+	err = a.responder.Step(ctx, callData)
+	a.addRespondTime(sent)
+	if err != nil {
+		return err
+	}
+	a.metrics.RecordGameStep(a.game)
+	return nil
+}
+
+// loadBaseFee returns the current L1 base fee if moves are limited by gas price or spend, otherwise nil.
+// Returns nil if the base fee can't be loaded so moves aren't held up by an unavailable L1 node.
+func (a *Agent) loadBaseFee(ctx context.Context) *big.Int {
+	if a.limits.MaxGasPrice == nil && a.limits.MaxSpend == nil {
+		return nil
+	}
+	baseFee, err := a.responder.BaseFee(ctx)
+	if err != nil {
+		a.log.Warn("Failed to load base fee, moving without gas price check", "err", err)
+		return nil
+	}
+	return baseFee
+}
+
+// deferForGasPrice returns true if the move countering claim should wait for the base fee to drop below the
+// gas price ceiling. Moves are forced through once the claim's remaining clock is urgent or if it is unknown.
+func (a *Agent) deferForGasPrice(log log.Logger, claim types.Claim, snapshot *GameSnapshot, baseFee *big.Int) bool {
+	if baseFee == nil || a.limits.MaxGasPrice == nil || baseFee.Cmp(a.limits.MaxGasPrice) <= 0 {
+		return false
+	}
+	if remaining, urgent := a.urgency(claim, snapshot); !urgent {
+		log.Info("Deferring move due to gas price", "baseFee", baseFee, "max", a.limits.MaxGasPrice, "remaining", remaining, "urgent", a.limits.UrgentClock)
+		a.metrics.RecordMoveDeferred(a.game, metrics.DeferGasPrice)
+		return true
+	}
+	log.Warn("Making urgent move despite gas price", "baseFee", baseFee, "max", a.limits.MaxGasPrice)
+	a.metrics.RecordMoveForced(a.game, metrics.DeferGasPrice)
+	return false
+}
+
+// exceedsSpendCap returns true if making move would take the spend on the game over the limit, after which no more
+// moves are made in the game. The move's cost is the expected bond plus its estimated gas at baseFee, or just the
+// bond if the gas cost is unknown. committed is the spend including the moves already queued, and the move's cost
+// is added to it if it is within the limit.
+func (a *Agent) exceedsSpendCap(ctx context.Context, log log.Logger, move types.Claim, committed *big.Int, baseFee *big.Int) bool {
+	if a.limits.MaxSpend == nil {
+		return false
+	}
+	cost := new(big.Int)
+	if a.limits.MoveBond != nil {
+		cost.Set(a.limits.MoveBond)
+	}
+	if baseFee != nil {
+		if gas, err := a.responder.EstimateRespondGas(ctx, move); err != nil {
+			log.Warn("Failed to estimate move gas, checking spend cap with the bond only", "err", err)
+		} else {
+			cost.Add(cost, new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gas)))
+		}
+	}
+	total := new(big.Int).Add(committed, cost)
+	if total.Cmp(a.limits.MaxSpend) > 0 {
+		log.Error("Spend cap reached", "spent", committed, "move_cost", cost, "max", a.limits.MaxSpend)
+		a.spendCapped = true
+		return true
+	}
+	committed.Set(total)
+	return false
+}
+
+// loadFinalized returns true if moves don't require finalized L2 data or the data they derive from is finalized.
+// The data is treated as unfinalized if its finality can't be checked.
+func (a *Agent) loadFinalized(ctx context.Context) bool {
+	if a.limits.Finality == nil {
+		return true
+	}
+	finalized, err := a.limits.Finality.L2BlockFinalized(ctx)
+	if err != nil {
+		a.log.Warn("Failed to check L2 finality, treating L2 data as unfinalized", "err", err)
+		return false
+	}
+	return finalized
+}
+
+// deferForFinality returns true if the move countering claim should wait for the L2 data it derives from to be
+// finalized. Urgent moves, or those with unknown clocks, are only made from unfinalized data if the risk is accepted.
+func (a *Agent) deferForFinality(log log.Logger, claim types.Claim, snapshot *GameSnapshot, finalized bool) bool {
+	if finalized {
+		return false
+	}
+	remaining, urgent := a.urgency(claim, snapshot)
+	if !urgent {
+		log.Info("Deferring move until L2 data is finalized", "remaining", remaining, "urgent", a.limits.UrgentClock)
+		a.metrics.RecordMoveDeferred(a.game, metrics.DeferL2Finality)
+		return true
+	}
+	if !a.limits.AcceptUnfinalizedRisk {
+		log.Error("Deferring urgent move until L2 data is finalized", "remaining", remaining)
+		a.metrics.RecordMoveDeferred(a.game, metrics.DeferL2Finality)
+		return true
+	}
+	log.Warn("Making urgent move from unfinalized L2 data", "remaining", remaining)
+	a.metrics.RecordMoveForced(a.game, metrics.DeferL2Finality)
+	return false
+}
+
+// urgency returns the time left on claim's clock and whether it is within the urgent threshold.
+// Claims are always urgent if the clocks are unknown.
+func (a *Agent) urgency(claim types.Claim, snapshot *GameSnapshot) (time.Duration, bool) {
+	if a.gameDuration == 0 || snapshot.Block.Time == 0 {
+		return 0, true
+	}
+	remaining := snapshot.claimRemainingClock(claim, a.gameDuration)
+	return remaining, remaining <= a.limits.UrgentClock
+}
+
+// clockCritical returns true if claim's clock is known to be within the urgent threshold.
+// Unlike [Agent.urgency], claims with unknown clocks aren't critical.
+func (a *Agent) clockCritical(claim types.Claim, snapshot *GameSnapshot) bool {
+	if a.gameDuration == 0 || snapshot.Block.Time == 0 {
+		return false
+	}
+	_, urgent := a.urgency(claim, snapshot)
+	return urgent
+}
+
+// exceedsGasCeiling returns true if the gas estimate for a transaction is above the configured maximum.
+// If the estimate fails, the transaction is assumed to be within the ceiling so it is still submitted.
+func (a *Agent) exceedsGasCeiling(log log.Logger, estimate func() (uint64, error)) bool {
+	if a.limits.MaxGas == 0 {
+		return false
+	}
+	gas, err := estimate()
+	if err != nil {
+		log.Warn("Failed to estimate gas, submitting without gas ceiling check", "err", err)
+		return false
+	}
+	if gas > a.limits.MaxGas {
+		log.Warn("Move exceeds gas ceiling", "estimate", gas, "max", a.limits.MaxGas)
+		return true
 	}
-	return a.responder.Step(ctx, callData)
+	return false
 }
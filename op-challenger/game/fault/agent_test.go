@@ -2,11 +2,29 @@ package fault
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
 	"testing"
+	"time"
 
+	faultResponder "github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/ratelimit"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 )
@@ -16,16 +34,1147 @@ func TestShouldResolve(t *testing.T) {
 	log := testlog.Logger(t, log.LvlCrit)
 
 	t.Run("AgreeWithProposedOutput", func(t *testing.T) {
-		agent := NewAgent(nil, 0, nil, nil, nil, true, log)
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{}, 0, nil, nil, nil, nil, true, log)
 		require.False(t, agent.shouldResolve(context.Background(), types.GameStatusDefenderWon))
 		require.True(t, agent.shouldResolve(context.Background(), types.GameStatusChallengerWon))
 		require.False(t, agent.shouldResolve(context.Background(), types.GameStatusInProgress))
 	})
 
 	t.Run("DisagreeWithProposedOutput", func(t *testing.T) {
-		agent := NewAgent(nil, 0, nil, nil, nil, false, log)
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{}, 0, nil, nil, nil, nil, false, log)
 		require.True(t, agent.shouldResolve(context.Background(), types.GameStatusDefenderWon))
 		require.False(t, agent.shouldResolve(context.Background(), types.GameStatusChallengerWon))
 		require.False(t, agent.shouldResolve(context.Background(), types.GameStatusInProgress))
 	})
 }
+
+// TestAct_RootOnlyGame tests resolving a game where the root claim's clock expired without any counter claims.
+func TestAct_RootOnlyGame(t *testing.T) {
+	root := types.Claim{
+		ClaimData: types.ClaimData{
+			Value:    common.Hash{0xaa},
+			Position: types.NewPosition(0, 0),
+		},
+	}
+	snapshot := &GameSnapshot{Claims: []types.Claim{root}}
+	setup := func(t *testing.T, agreeWithProposedOutput bool) (*Agent, *stubResponder, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		responder := &stubResponder{callResolveStatus: types.GameStatusDefenderWon}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 4, MoveLimits{}, 0, nil, responder, nil, nil, agreeWithProposedOutput, logger)
+		return agent, responder, handler
+	}
+
+	t.Run("ResolveAsDefender", func(t *testing.T) {
+		agent, responder, _ := setup(t, false)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, responder.resolveCount)
+		require.Zero(t, responder.respondCount)
+	})
+
+	t.Run("DoNotResolveOrMoveAsChallenger", func(t *testing.T) {
+		agent, responder, handler := setup(t, true)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Zero(t, responder.resolveCount)
+		require.Zero(t, responder.respondCount)
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Root claim clock expired without counter claims, not moving"))
+	})
+}
+
+// TestAct_ResolveClaims tests resolving the subgames of claims bottom-up before resolving the game.
+func TestAct_ResolveClaims(t *testing.T) {
+	gameDuration := 10 * time.Minute
+	now := uint64(10_000)
+	claimant := common.Address{0xcc}
+	newClaim := func(index int, parent int, pos types.Position, clock uint64) types.Claim {
+		claim := types.Claim{
+			ClaimData:           types.ClaimData{Value: common.Hash{byte(index)}, Position: pos},
+			Claimant:            claimant,
+			Clock:               clock,
+			ContractIndex:       index,
+			ParentContractIndex: parent,
+		}
+		if index == 0 {
+			claim.ParentContractIndex = math.MaxUint32
+		}
+		return claim
+	}
+	expired := now - uint64(gameDuration.Seconds())
+	root := types.NewPosition(0, 0)
+	attack := root.Attack()
+	attackAttack := attack.Attack()
+	newSnapshot := func(leafClock uint64) *GameSnapshot {
+		claims := []types.Claim{
+			newClaim(0, 0, root, expired),
+			newClaim(1, 0, attack, expired),
+			newClaim(2, 1, attackAttack, leafClock),
+			newClaim(3, 0, attack, expired),
+		}
+		for i := 1; i < len(claims); i++ {
+			claims[i].Parent = claims[claims[i].ParentContractIndex].ClaimData
+		}
+		return &GameSnapshot{Claims: claims, Block: eth.L1BlockRef{Time: now}}
+	}
+	setup := func(t *testing.T) (*Agent, *stubResponder) {
+		responder := &stubResponder{
+			callResolveStatus:    types.GameStatusDefenderWon,
+			callResolveClaimErrs: make(map[uint64]error),
+			resolveClaimErrs:     make(map[uint64]error),
+		}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 4, MoveLimits{}, gameDuration, test.NewAlphabetWithProofProvider(t, 4, nil), responder, nil, &stubStrategy{}, false, testlog.Logger(t, log.LvlCrit))
+		return agent, responder
+	}
+
+	t.Run("BottomUp", func(t *testing.T) {
+		agent, responder := setup(t)
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 3", "resolveClaim 2", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+		require.Equal(t, 1, responder.resolveCount)
+	})
+
+	t.Run("WaitForClockToExpire", func(t *testing.T) {
+		agent, responder := setup(t)
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(now-60)))
+		require.Equal(t, []string{"resolveClaim 3"}, responder.actions, "should not resolve the ancestors of an unresolved subgame")
+		require.Zero(t, responder.resolveCount)
+
+		responder.actions = nil
+		responder.callResolveClaims = nil
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []uint64{2, 1, 0}, responder.callResolveClaims, "should not check resolved claims again")
+		require.Equal(t, []string{"resolveClaim 2", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+		require.Equal(t, 1, responder.resolveCount)
+	})
+
+	t.Run("AlreadyResolved", func(t *testing.T) {
+		agent, responder := setup(t)
+		responder.callResolveClaimErrs[2] = &revertError{data: hexutil.Encode(crypto.Keccak256([]byte("ClaimAlreadyResolved()"))[:4])}
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 3", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+		require.Equal(t, 1, responder.resolveCount)
+	})
+
+	t.Run("ResolvedByAnotherTransaction", func(t *testing.T) {
+		agent, responder := setup(t)
+		responder.resolveClaimErrs[2] = fmt.Errorf("%w: 0x1234", faultResponder.ErrResolveClaimReverted)
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 3", "resolveClaim 2", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+		require.Equal(t, 1, responder.resolveCount)
+	})
+
+	t.Run("RetryFailedClaim", func(t *testing.T) {
+		agent, responder := setup(t)
+		responder.resolveClaimErrs[2] = errors.New("send failed")
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 3", "resolveClaim 2"}, responder.actions)
+		require.Zero(t, responder.resolveCount)
+
+		responder.actions = nil
+		delete(responder.resolveClaimErrs, 2)
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 2", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+		require.Equal(t, 1, responder.resolveCount)
+	})
+
+	t.Run("ResentAfterReorg", func(t *testing.T) {
+		agent, responder := setup(t)
+		responder.callResolveStatus = types.GameStatusInProgress
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 3", "resolveClaim 2", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+
+		responder.actions = nil
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Empty(t, responder.actions, "should not resend resolved claims")
+
+		// The block the resolveClaim transactions were included in is reorged out.
+		agent.Reset()
+		require.NoError(t, agent.Act(context.Background(), newSnapshot(expired)))
+		require.Equal(t, []string{"resolveClaim 3", "resolveClaim 2", "resolveClaim 1", "resolveClaim 0"}, responder.actions)
+	})
+
+	t.Run("LegacyContract", func(t *testing.T) {
+		agent, responder := setup(t)
+		snapshot := newSnapshot(expired)
+		for i := range snapshot.Claims {
+			snapshot.Claims[i].Claimant = common.Address{}
+		}
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Empty(t, responder.callResolveClaims)
+		require.Equal(t, 1, responder.resolveCount)
+	})
+}
+
+// TestAct_ResolveExpiredGame tests that a game still in progress is resolved once the clocks of its claims have
+// expired, and that a game resolved by another transaction is treated as resolved.
+func TestAct_ResolveExpiredGame(t *testing.T) {
+	maxDepth := 3
+	gameDuration := 10 * time.Minute
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	root := builder.CreateRootClaim(true)
+	attack := builder.AttackClaim(root, false)
+	attack.ContractIndex = 1
+	elapsed := uint64(gameDuration.Seconds())
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack}, Block: eth.L1BlockRef{Time: attack.Clock + elapsed}}
+	setup := func(t *testing.T, gameDuration time.Duration) (*Agent, *stubResponder, *stubStrategy, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		responder := &stubResponder{callResolveStatus: types.GameStatusDefenderWon}
+		strategy := &stubStrategy{actions: []Action{{Type: ActionTypeMove, Claim: attack}}}
+		trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, gameDuration, trace, responder, nil, strategy, false, logger)
+		return agent, responder, strategy, handler
+	}
+
+	t.Run("Expired", func(t *testing.T) {
+		agent, responder, strategy, handler := setup(t, gameDuration)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, responder.resolveCount)
+		require.Zero(t, strategy.calls, "should not move once resolved")
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Resolving expired game"))
+	})
+
+	t.Run("ClockUnknown", func(t *testing.T) {
+		agent, responder, _, handler := setup(t, 0)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, responder.resolveCount)
+		require.Nil(t, handler.FindLog(log.LvlInfo, "Resolving expired game"))
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Resolving game"))
+	})
+
+	t.Run("AlreadyResolved", func(t *testing.T) {
+		agent, responder, strategy, handler := setup(t, gameDuration)
+		responder.callResolveErr = &revertError{data: hexutil.Encode(crypto.Keccak256([]byte("GameNotInProgress()"))[:4])}
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Zero(t, responder.resolveCount)
+		require.Zero(t, strategy.calls, "should not move in a resolved game")
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Game already resolved"))
+	})
+
+	t.Run("ResolvedByAnotherTransaction", func(t *testing.T) {
+		agent, responder, strategy, handler := setup(t, gameDuration)
+		responder.resolveErr = fmt.Errorf("%w: 0x1234", faultResponder.ErrResolveReverted)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, responder.resolveCount)
+		require.Zero(t, strategy.calls, "should not move in a resolved game")
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Resolve transaction reverted, assuming game already resolved"))
+	})
+
+	t.Run("ResolveFails", func(t *testing.T) {
+		agent, responder, strategy, handler := setup(t, gameDuration)
+		responder.resolveErr = errors.New("send failed")
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, strategy.calls, "should keep playing until resolved")
+		require.NotNil(t, handler.FindLog(log.LvlError, "Failed to resolve the game"))
+	})
+}
+
+// TestAct_DeterministicOrder tests that steps are performed before moves and that both are ordered by the
+// contract index of the claim they respond to, rather than the order claims are visited in the game tree.
+func TestAct_DeterministicOrder(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	defend := withIndex(builder.DefendClaim(attack, false), 3, attack)
+	// Visited after the leaf claim at index 5 because its parent is later in the game tree.
+	defendLeaf := withIndex(builder.AttackClaim(defend, false), 4, defend)
+	counterLeaf := withIndex(builder.AttackClaim(counter, false), 5, counter)
+	// Visited before all the leaf claims because it is higher in the game tree.
+	correctAttack := withIndex(builder.AttackClaim(root, true), 6, root)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, defend, defendLeaf, counterLeaf, correctAttack}}
+
+	responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, nil, nil, false, testlog.Logger(t, log.LvlCrit))
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.Equal(t, []string{"step 4", "step 5", "move 6"}, responder.actions)
+}
+
+// TestAct_RateLimited tests that steps beyond the trace provider's rate limit are deferred to a later cycle
+// without blocking the game's moves.
+func TestAct_RateLimited(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	defend := withIndex(builder.DefendClaim(attack, false), 3, attack)
+	counterLeaf := withIndex(builder.AttackClaim(counter, false), 4, counter)
+	defendLeaf := withIndex(builder.AttackClaim(defend, false), 5, defend)
+	correctAttack := withIndex(builder.AttackClaim(root, true), 6, root)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, defend, counterLeaf, defendLeaf, correctAttack}}
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+	logger.SetHandler(handler)
+	responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	trace := ratelimit.NewTraceProvider(alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth)), limiter)
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, nil, nil, false, logger)
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.Equal(t, []string{"step 4", "move 6"}, responder.actions, "should defer the step beyond the burst")
+	require.NotNil(t, handler.FindLog(log.LvlInfo, "Trace provider rate limited, deferring steps to the next cycle"))
+
+	responder.actions = nil
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.Equal(t, []string{"move 6"}, responder.actions, "should not step until the limit allows")
+}
+
+// TestAct_GasPriceCeiling tests that moves are deferred while the base fee is above the ceiling, until the
+// countered claim's clock runs down to the urgent threshold.
+func TestAct_GasPriceCeiling(t *testing.T) {
+	maxDepth := 3
+	gameDuration := 2 * time.Hour
+	urgent := 10 * time.Minute
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	root := builder.CreateRootClaim(true)
+	attack := builder.AttackClaim(root, false)
+	attack.ContractIndex = 1
+	attack.ParentContractIndex = 0
+	attack.Clock = 1000
+	snapshotAt := func(elapsed time.Duration) *GameSnapshot {
+		return &GameSnapshot{
+			Claims: []types.Claim{root, attack},
+			Block:  eth.L1BlockRef{Time: attack.Clock + uint64(elapsed.Seconds())},
+		}
+	}
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+	logger.SetHandler(handler)
+	m := &stubMoveMetrics{Metricer: metrics.NoopMetrics}
+	responder := &stubResponder{callResolveStatus: types.GameStatusInProgress, baseFee: big.NewInt(200)}
+	limits := MoveLimits{MaxGasPrice: big.NewInt(100), UrgentClock: urgent}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	agent := NewAgent(m, clock.SystemClock, common.Address{}, maxDepth, limits, gameDuration, trace, responder, nil, nil, false, logger)
+
+	// Each side's clock has an hour, so the move is deferred until 50 minutes have elapsed.
+	for _, elapsed := range []time.Duration{time.Minute, 30 * time.Minute, 50*time.Minute - time.Second} {
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(elapsed)))
+		require.Empty(t, responder.actions, "should defer move after %v", elapsed)
+	}
+	require.Equal(t, 3, m.deferred)
+	require.Zero(t, m.forced)
+	record := handler.FindLog(log.LvlInfo, "Deferring move due to gas price")
+	require.NotNil(t, record)
+	require.Equal(t, big.NewInt(200), record.GetContextValue("baseFee"))
+	require.Equal(t, big.NewInt(100), record.GetContextValue("max"))
+
+	require.NoError(t, agent.Act(context.Background(), snapshotAt(50*time.Minute)))
+	require.Equal(t, []string{"move 1"}, responder.actions, "should force move once the clock is urgent")
+	require.Equal(t, 1, m.forced)
+	require.Equal(t, []metrics.DeferReason{metrics.DeferGasPrice, metrics.DeferGasPrice, metrics.DeferGasPrice, metrics.DeferGasPrice}, m.reasons)
+	require.NotNil(t, handler.FindLog(log.LvlWarn, "Making urgent move despite gas price"))
+
+	t.Run("BelowCeiling", func(t *testing.T) {
+		m := &stubMoveMetrics{Metricer: metrics.NoopMetrics}
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress, baseFee: big.NewInt(100)}
+		agent := NewAgent(m, clock.SystemClock, common.Address{}, maxDepth, limits, gameDuration, trace, responder, nil, nil, false, testlog.Logger(t, log.LvlCrit))
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(time.Minute)))
+		require.Equal(t, []string{"move 1"}, responder.actions)
+		require.Zero(t, m.deferred)
+		require.Zero(t, m.forced)
+	})
+
+	t.Run("BaseFeeUnavailable", func(t *testing.T) {
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		agent := NewAgent(m, clock.SystemClock, common.Address{}, maxDepth, limits, gameDuration, trace, responder, nil, nil, false, testlog.Logger(t, log.LvlCrit))
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(time.Minute)))
+		require.Equal(t, []string{"move 1"}, responder.actions, "should not hold up moves without a base fee")
+	})
+}
+
+// TestAct_SpendCap tests that no more moves are made in a game once a move would take its spend over the limit,
+// while steps continue.
+func TestAct_SpendCap(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	setup := func(t *testing.T, limits MoveLimits) (*Agent, *stubResponder, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		// Each move costs 1000 wei in gas.
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress, baseFee: big.NewInt(10), moveGas: 100}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, limits, 0, trace, responder, nil, nil, false, logger)
+		return agent, responder, handler
+	}
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack}}
+
+	t.Run("CrossCap", func(t *testing.T) {
+		agent, responder, handler := setup(t, MoveLimits{MaxSpend: big.NewInt(4000), MoveBond: big.NewInt(500)})
+		// The cap allows the 1500 wei expected for a move until 3000 wei has been spent.
+		for i := 0; i < 3; i++ {
+			require.NoError(t, agent.Act(context.Background(), snapshot))
+		}
+		require.Equal(t, 3, responder.respondCount)
+		require.Nil(t, handler.FindLog(log.LvlError, "Spend cap reached"))
+
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 3, responder.respondCount, "should not move once the cap would be exceeded")
+		record := handler.FindLog(log.LvlError, "Spend cap reached")
+		require.NotNil(t, record)
+		require.Equal(t, big.NewInt(3000), record.GetContextValue("spent"))
+		require.Equal(t, big.NewInt(1500), record.GetContextValue("move_cost"))
+
+		// Moves stay halted in later cycles but steps are still made.
+		counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+		leaf := withIndex(builder.AttackClaim(counter, false), 3, counter)
+		responder.actions = nil
+		require.NoError(t, agent.Act(context.Background(), &GameSnapshot{Claims: []types.Claim{root, attack, counter, leaf}}))
+		require.Equal(t, 3, responder.respondCount)
+		require.Equal(t, []string{"step 3"}, responder.actions, "should still step")
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Not moving in game after spend cap reached, only stepping and resolving"))
+	})
+
+	t.Run("NoLimit", func(t *testing.T) {
+		agent, responder, _ := setup(t, MoveLimits{})
+		for i := 0; i < 5; i++ {
+			require.NoError(t, agent.Act(context.Background(), snapshot))
+		}
+		require.Equal(t, 5, responder.respondCount)
+	})
+
+	t.Run("BaseFeeUnavailable", func(t *testing.T) {
+		agent, responder, handler := setup(t, MoveLimits{MaxSpend: big.NewInt(400), MoveBond: big.NewInt(500)})
+		responder.baseFee = nil
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Zero(t, responder.respondCount, "should still check the bond against the cap")
+		require.NotNil(t, handler.FindLog(log.LvlError, "Spend cap reached"))
+	})
+}
+
+// TestAct_StrictDataAvailability tests that moves are deferred until the L2 data they derive from is finalized,
+// with urgent moves only made from unfinalized data if the risk is accepted.
+func TestAct_StrictDataAvailability(t *testing.T) {
+	maxDepth := 3
+	gameDuration := 2 * time.Hour
+	urgent := 10 * time.Minute
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	root := builder.CreateRootClaim(true)
+	attack := builder.AttackClaim(root, false)
+	attack.ContractIndex = 1
+	attack.ParentContractIndex = 0
+	attack.Clock = 1000
+	snapshotAt := func(elapsed time.Duration) *GameSnapshot {
+		return &GameSnapshot{
+			Claims: []types.Claim{root, attack},
+			Block:  eth.L1BlockRef{Time: attack.Clock + uint64(elapsed.Seconds())},
+		}
+	}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	newAgent := func(t *testing.T, acceptRisk bool) (*Agent, *stubResponder, *stubL2Finality, *stubMoveMetrics, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		m := &stubMoveMetrics{Metricer: metrics.NoopMetrics}
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		finality := &stubL2Finality{}
+		limits := MoveLimits{UrgentClock: urgent, Finality: finality, AcceptUnfinalizedRisk: acceptRisk}
+		return NewAgent(m, clock.SystemClock, common.Address{}, maxDepth, limits, gameDuration, trace, responder, nil, nil, false, logger), responder, finality, m, handler
+	}
+
+	t.Run("DeferUntilFinalized", func(t *testing.T) {
+		agent, responder, finality, m, handler := newAgent(t, false)
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(time.Minute)))
+		require.Empty(t, responder.actions, "should defer move while unfinalized")
+		require.Equal(t, 1, m.deferred)
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Deferring move until L2 data is finalized"))
+
+		finality.err = errors.New("boom")
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(2*time.Minute)))
+		require.Empty(t, responder.actions, "should defer move when finality can't be checked")
+
+		finality.err = nil
+		finality.finalized = true
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(3*time.Minute)))
+		require.Equal(t, []string{"move 1"}, responder.actions, "should move once finalized")
+		require.Equal(t, 2, m.deferred)
+		require.Zero(t, m.forced)
+	})
+
+	t.Run("UrgentWithoutRiskAcceptance", func(t *testing.T) {
+		agent, responder, _, m, handler := newAgent(t, false)
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(55*time.Minute)))
+		require.Empty(t, responder.actions, "should defer urgent move without accepting the risk")
+		require.Equal(t, 1, m.deferred)
+		require.Zero(t, m.forced)
+		require.NotNil(t, handler.FindLog(log.LvlError, "Deferring urgent move until L2 data is finalized"))
+	})
+
+	t.Run("UrgentWithRiskAcceptance", func(t *testing.T) {
+		agent, responder, _, m, handler := newAgent(t, true)
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(time.Minute)))
+		require.Empty(t, responder.actions, "should defer move that isn't urgent even when accepting the risk")
+		require.NoError(t, agent.Act(context.Background(), snapshotAt(55*time.Minute)))
+		require.Equal(t, []string{"move 1"}, responder.actions, "should make urgent move when accepting the risk")
+		require.Equal(t, 1, m.deferred)
+		require.Equal(t, 1, m.forced)
+		require.Equal(t, []metrics.DeferReason{metrics.DeferL2Finality, metrics.DeferL2Finality}, m.reasons)
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Making urgent move from unfinalized L2 data"))
+	})
+}
+
+type stubL2Finality struct {
+	finalized bool
+	err       error
+}
+
+func (s *stubL2Finality) L2BlockFinalized(_ context.Context) (bool, error) {
+	return s.finalized, s.err
+}
+
+// TestAct_ResolutionStrategy tests that the agent performs the actions decided by the resolution strategy,
+// in the order the strategy returns them.
+func TestAct_ResolutionStrategy(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	leaf := withIndex(builder.AttackClaim(counter, false), 3, counter)
+	correctAttack := withIndex(builder.AttackClaim(root, true), 4, root)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, leaf, correctAttack}}
+
+	responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	strategy := &stubStrategy{actions: []Action{
+		{Type: ActionTypeMove, Claim: correctAttack},
+		{Type: ActionTypeStep, Claim: leaf},
+	}}
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, nil, strategy, false, testlog.Logger(t, log.LvlCrit))
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.Equal(t, 1, strategy.calls)
+	require.Len(t, strategy.game.Claims(), len(snapshot.Claims))
+	require.Equal(t, []string{"move 4", "step 3"}, responder.actions)
+}
+
+// TestAct_ResponsePending tests that a response is reported as pending only while the strategy has actions to take.
+func TestAct_ResponsePending(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	root := builder.CreateRootClaim(true)
+	attack := builder.AttackClaim(root, false)
+	attack.ContractIndex = 1
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack}}
+
+	responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	strategy := &stubStrategy{actions: []Action{{Type: ActionTypeMove, Claim: attack}}}
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, nil, strategy, false, testlog.Logger(t, log.LvlCrit))
+	require.False(t, agent.ResponsePending(), "should not be pending before acting")
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.True(t, agent.ResponsePending())
+
+	strategy.actions = nil
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.False(t, agent.ResponsePending(), "should not be pending once there is nothing to respond to")
+}
+
+// TestAct_StepAtMaxDepth tests that a move requested against a leaf claim steps instead of bisecting further.
+func TestAct_StepAtMaxDepth(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	leaf := withIndex(builder.AttackClaim(counter, false), 3, counter)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, leaf}}
+
+	logger := testlog.Logger(t, log.LvlDebug)
+	handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+	logger.SetHandler(handler)
+	responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	strategy := &stubStrategy{actions: []Action{{Type: ActionTypeMove, Claim: leaf}}}
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, nil, strategy, false, logger)
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+	require.Equal(t, []string{"step 3"}, responder.actions)
+	require.Zero(t, responder.respondCount, "should not move below the leaves")
+	record := handler.FindLog(log.LvlDebug, "Reached maximum game depth, stepping instead of moving")
+	require.NotNil(t, record)
+	require.Equal(t, maxDepth, record.GetContextValue("depth"))
+
+	t.Run("StepOnce", func(t *testing.T) {
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		strategy := &stubStrategy{actions: []Action{{Type: ActionTypeStep, Claim: leaf}, {Type: ActionTypeMove, Claim: leaf}}}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, nil, strategy, false, testlog.Logger(t, log.LvlCrit))
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, []string{"step 3"}, responder.actions, "should not step again for the move against the same leaf")
+	})
+
+	t.Run("ClockCritical", func(t *testing.T) {
+		gameDuration := 2 * time.Hour
+		limits := MoveLimits{UrgentClock: 10 * time.Minute}
+		tests := []struct {
+			name         string
+			gameDuration time.Duration
+			elapsed      time.Duration
+			critical     bool
+		}{
+			{name: "Urgent", gameDuration: gameDuration, elapsed: 55 * time.Minute, critical: true},
+			{name: "NotUrgent", gameDuration: gameDuration, elapsed: 10 * time.Minute, critical: false},
+			{name: "UnknownClock", gameDuration: 0, elapsed: 55 * time.Minute, critical: false},
+		}
+		for _, test := range tests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				snapshot := &GameSnapshot{Claims: snapshot.Claims, Block: eth.L1BlockRef{Time: leaf.Clock + uint64(test.elapsed.Seconds())}}
+				responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+				strategy := &stubStrategy{actions: []Action{{Type: ActionTypeStep, Claim: leaf}}}
+				agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, limits, test.gameDuration, trace, responder, nil, strategy, false, testlog.Logger(t, log.LvlCrit))
+				require.NoError(t, agent.Act(context.Background(), snapshot))
+				require.Len(t, responder.steps, 1)
+				require.Equal(t, test.critical, responder.steps[0].ClockCritical)
+			})
+		}
+	})
+}
+
+// TestAct_UrgentMoveFees tests that moves countering claims whose clock is within the urgent threshold are sent with
+// urgent fees, so their priority fee is raised.
+func TestAct_UrgentMoveFees(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	root := builder.CreateRootClaim(true)
+	attack := builder.AttackClaim(root, true)
+	attack.ContractIndex = 1
+	claims := []types.Claim{root, attack}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	limits := MoveLimits{UrgentClock: 10 * time.Minute}
+	tests := []struct {
+		name         string
+		gameDuration time.Duration
+		elapsed      time.Duration
+		urgent       bool
+	}{
+		{name: "BelowThreshold", gameDuration: 2 * time.Hour, elapsed: 55 * time.Minute, urgent: true},
+		{name: "AboveThreshold", gameDuration: 2 * time.Hour, elapsed: 10 * time.Minute, urgent: false},
+		{name: "UnknownClock", gameDuration: 0, elapsed: 55 * time.Minute, urgent: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := &GameSnapshot{Claims: claims, Block: eth.L1BlockRef{Time: attack.Clock + uint64(test.elapsed.Seconds())}}
+			responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+			strategy := &stubStrategy{actions: []Action{{Type: ActionTypeMove, Claim: attack}}}
+			agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, limits, test.gameDuration, trace, responder, nil, strategy, false, testlog.Logger(t, log.LvlCrit))
+			require.NoError(t, agent.Act(context.Background(), snapshot))
+			require.Equal(t, []string{"move 1"}, responder.actions)
+			require.Equal(t, []bool{test.urgent}, responder.urgentMoves)
+		})
+	}
+}
+
+// TestAct_SelfConflict tests that an opponent countering one of our claims with our own trace's value is reported
+// once, and that moves, but not steps, are halted if configured.
+func TestAct_SelfConflict(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	leaf := withIndex(builder.AttackClaim(counter, false), 3, counter)
+	// The opponent counters our root claim with the value our trace has at the attack position.
+	conflict := withIndex(builder.AttackClaim(root, true), 4, root)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, leaf, conflict}}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	setup := func(t *testing.T, halt bool) (*Agent, *stubResponder, *stubMoveMetrics, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		m := &stubMoveMetrics{Metricer: metrics.NoopMetrics}
+		limits := MoveLimits{HaltOnSelfConflict: halt}
+		agent := NewAgent(m, clock.SystemClock, common.Address{}, maxDepth, limits, 0, trace, responder, nil, nil, false, logger)
+		return agent, responder, m, handler
+	}
+
+	t.Run("NoConflict", func(t *testing.T) {
+		agent, responder, m, handler := setup(t, true)
+		require.NoError(t, agent.Act(context.Background(), &GameSnapshot{Claims: snapshot.Claims[:4]}))
+		require.Zero(t, m.selfConflicts)
+		require.Nil(t, handler.FindLog(log.LvlError, "Opponent agrees with our trace - possible configuration error"))
+		require.Equal(t, []string{"step 3"}, responder.actions)
+	})
+
+	t.Run("ReportOnce", func(t *testing.T) {
+		agent, responder, m, handler := setup(t, false)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		record := handler.FindLog(log.LvlError, "Opponent agrees with our trace - possible configuration error")
+		require.NotNil(t, record)
+		require.Equal(t, conflict.ContractIndex, record.GetContextValue("claim"))
+		require.Equal(t, 1, m.selfConflicts)
+		require.Equal(t, 1, responder.respondCount, "should still move against the conflicting claim")
+
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, m.selfConflicts, "should only report each conflicting claim once")
+	})
+
+	t.Run("RecheckAfterReset", func(t *testing.T) {
+		agent, responder, m, _ := setup(t, true)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, m.selfConflicts)
+
+		// The conflicting claim is reorged out.
+		agent.Reset()
+		require.NoError(t, agent.Act(context.Background(), &GameSnapshot{Claims: snapshot.Claims[:4]}))
+		require.Equal(t, 1, m.selfConflicts)
+
+		// It is included again after the reorg, so is reported and halts moves again.
+		agent.Reset()
+		responder.actions = nil
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 2, m.selfConflicts)
+		require.Zero(t, responder.respondCount)
+		require.Equal(t, []string{"step 3"}, responder.actions)
+	})
+
+	t.Run("HaltMoves", func(t *testing.T) {
+		agent, responder, m, handler := setup(t, true)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, m.selfConflicts)
+		require.Zero(t, responder.respondCount, "should not move after a self conflict")
+		require.Equal(t, []string{"step 3"}, responder.actions, "should still step")
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Not moving in game after self conflict, only stepping and resolving"))
+
+		// Moves stay halted in later cycles, even once the conflicting claim has been checked.
+		responder.actions = nil
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Zero(t, responder.respondCount)
+	})
+}
+
+// TestAct_OwnClaimAtPosition tests that a move isn't made at a position where our address already has a claim
+// countering the same parent, even if the existing claim has a different value.
+func TestAct_OwnClaimAtPosition(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	ours := common.Address{0xaa}
+	root := builder.CreateRootClaim(false)
+	// A claim we submitted against the root claim with an incorrect value at the position we'd attack it at.
+	existing := builder.AttackClaim(root, false)
+	existing.ContractIndex = 1
+	existing.Claimant = ours
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, existing}}
+	trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+	setup := func(t *testing.T, claimant common.Address) (*stubResponder, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{Claimant: claimant}, 0, trace, responder, nil, nil, true, logger)
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		return responder, handler
+	}
+
+	t.Run("SkipOwnPosition", func(t *testing.T) {
+		responder, handler := setup(t, ours)
+		require.Empty(t, responder.actions)
+		record := handler.FindLog(log.LvlWarn, "Already have claim at position")
+		require.NotNil(t, record)
+		require.Equal(t, existing.ContractIndex, record.GetContextValue("existing"))
+	})
+
+	t.Run("OtherClaimant", func(t *testing.T) {
+		responder, handler := setup(t, common.Address{0xbb})
+		require.Equal(t, []string{"move 0"}, responder.actions)
+		require.Nil(t, handler.FindLog(log.LvlWarn, "Already have claim at position"))
+	})
+
+	t.Run("NoClaimant", func(t *testing.T) {
+		responder, _ := setup(t, common.Address{})
+		require.Equal(t, []string{"move 0"}, responder.actions)
+	})
+}
+
+// TestAct_UpdateOracleBeforeStep tests that the pre-image oracle data for a step is loaded before the step is sent,
+// and that the step isn't sent if the data can't be loaded.
+func TestAct_UpdateOracleBeforeStep(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	leaf := withIndex(builder.AttackClaim(counter, false), 3, counter)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, leaf}}
+	trace := test.NewAlphabetWithProofProvider(t, maxDepth, nil)
+
+	t.Run("LoadThenStep", func(t *testing.T) {
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		updater := &stubOracleUpdater{responder: responder}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, updater, nil, false, testlog.Logger(t, log.LvlCrit))
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, []string{"updateOracle", "step 3"}, responder.actions)
+	})
+
+	t.Run("DoNotStepWhenLoadFails", func(t *testing.T) {
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		updater := &stubOracleUpdater{responder: responder, err: errors.New("boom")}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, responder, updater, nil, false, testlog.Logger(t, log.LvlCrit))
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, []string{"updateOracle"}, responder.actions)
+		require.Zero(t, responder.stepCount)
+	})
+}
+
+// stubOracleUpdater records oracle updates in the responder's actions, so they are ordered with the steps.
+type stubOracleUpdater struct {
+	responder *stubResponder
+	err       error
+}
+
+func (s *stubOracleUpdater) UpdateOracle(_ context.Context, _ *types.PreimageOracleData) error {
+	s.responder.actions = append(s.responder.actions, "updateOracle")
+	return s.err
+}
+
+// TestAct_MoveLimits tests the move budget and the number of moves sent in parallel.
+func TestAct_MoveLimits(t *testing.T) {
+	maxDepth := 4
+	gameDuration := 10 * time.Minute
+	now := uint64(10_000)
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim, clock uint64) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		claim.Clock = clock
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root, now-300)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack, now-200)
+	recent := withIndex(builder.AttackClaim(counter, false), 3, counter, now-10)
+	urgent := withIndex(builder.DefendClaim(counter, false), 4, counter, now-100)
+	snapshot := &GameSnapshot{
+		Claims: []types.Claim{root, attack, counter, recent, urgent},
+		Block:  eth.L1BlockRef{Time: now},
+	}
+	strategy := &stubStrategy{actions: []Action{
+		{Type: ActionTypeMove, Claim: recent},
+		{Type: ActionTypeMove, Claim: urgent},
+	}}
+	setup := func(t *testing.T, limits MoveLimits) (*Agent, *stubResponder, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		responder := &stubResponder{callResolveStatus: types.GameStatusInProgress}
+		trace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyz", uint64(maxDepth))
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, limits, gameDuration, trace, responder, nil, strategy, false, logger)
+		return agent, responder, handler
+	}
+
+	t.Run("NoLimit", func(t *testing.T) {
+		agent, responder, _ := setup(t, MoveLimits{})
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, []string{"move 3", "move 4"}, responder.actions, "should keep the strategy's order")
+	})
+
+	t.Run("MostUrgentFirst", func(t *testing.T) {
+		agent, responder, handler := setup(t, MoveLimits{MaxPerCycle: 1})
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, []string{"move 4"}, responder.actions, "should counter the claim with the least time remaining")
+		msg := handler.FindLog(log.LvlWarn, "Move budget exhausted, deferring remaining moves to the next cycle")
+		require.NotNil(t, msg)
+		require.Equal(t, 1, msg.GetContextValue("deferred"))
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		agent, responder, _ := setup(t, MoveLimits{MaxParallel: 2})
+		responder.release = make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- agent.Act(context.Background(), snapshot)
+		}()
+		require.Eventually(t, func() bool { return responder.InFlight() == 2 }, 10*time.Second, 10*time.Millisecond)
+		close(responder.release)
+		require.NoError(t, <-done)
+		require.Equal(t, 2, responder.maxInFlight)
+		require.Equal(t, 2, responder.respondCount)
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		agent, responder, _ := setup(t, MoveLimits{MaxParallel: 1})
+		require.NoError(t, agent.Act(context.Background(), snapshot))
+		require.Equal(t, 1, responder.maxInFlight)
+		require.Equal(t, 2, responder.respondCount)
+	})
+}
+
+type stubStrategy struct {
+	calls   int
+	game    types.Game
+	actions []Action
+}
+
+func (s *stubStrategy) NextActions(game types.Game) []Action {
+	s.calls++
+	s.game = game
+	return s.actions
+}
+
+// TestExceedsGasCeiling tests that moves are only skipped when the gas estimate is above the ceiling.
+func TestExceedsGasCeiling(t *testing.T) {
+	setup := func(t *testing.T, maxMoveGas uint64) (*Agent, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		return NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{MaxGas: maxMoveGas}, 0, nil, nil, nil, nil, true, logger), handler
+	}
+	estimate := func(gas uint64, err error) func() (uint64, error) {
+		return func() (uint64, error) {
+			return gas, err
+		}
+	}
+
+	t.Run("NoCeiling", func(t *testing.T) {
+		agent, _ := setup(t, 0)
+		require.False(t, agent.exceedsGasCeiling(agent.log, func() (uint64, error) {
+			t.Fatal("should not estimate gas")
+			return 0, nil
+		}))
+	})
+
+	t.Run("BelowCeiling", func(t *testing.T) {
+		agent, _ := setup(t, 1000)
+		require.False(t, agent.exceedsGasCeiling(agent.log, estimate(1000, nil)))
+	})
+
+	t.Run("AboveCeiling", func(t *testing.T) {
+		agent, handler := setup(t, 1000)
+		require.True(t, agent.exceedsGasCeiling(agent.log, estimate(1001, nil)))
+		msg := handler.FindLog(log.LvlWarn, "Move exceeds gas ceiling")
+		require.NotNil(t, msg)
+		require.Equal(t, uint64(1001), msg.GetContextValue("estimate"))
+	})
+
+	t.Run("EstimateFails", func(t *testing.T) {
+		agent, handler := setup(t, 1000)
+		require.False(t, agent.exceedsGasCeiling(agent.log, estimate(0, errors.New("boom"))), "should still submit")
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Failed to estimate gas, submitting without gas ceiling check"))
+	})
+}
+
+// TestValidateStep tests that step data is checked before it is submitted.
+func TestValidateStep(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	valid := solver.StepData{PreState: []byte{1}, ProofData: []byte{}}
+
+	t.Run("Valid", func(t *testing.T) {
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{}, 0, nil, nil, nil, nil, true, log)
+		require.NoError(t, agent.validateStep(valid))
+	})
+
+	t.Run("MissingStateData", func(t *testing.T) {
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{}, 0, nil, nil, nil, nil, true, log)
+		require.ErrorContains(t, agent.validateStep(solver.StepData{ProofData: []byte{}}), "missing state data")
+	})
+
+	t.Run("MissingProofData", func(t *testing.T) {
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{}, 0, nil, nil, nil, nil, true, log)
+		require.ErrorContains(t, agent.validateStep(solver.StepData{PreState: []byte{1}}), "missing proof data")
+	})
+
+	t.Run("UseTraceProviderValidator", func(t *testing.T) {
+		validatorErr := errors.New("bad proof")
+		trace := &validatingTraceProvider{err: validatorErr}
+		agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, 0, MoveLimits{}, 0, trace, nil, nil, nil, true, log)
+		require.ErrorIs(t, agent.validateStep(valid), validatorErr)
+		require.Equal(t, valid.PreState, trace.stateData)
+		require.Equal(t, valid.ProofData, trace.proofData)
+	})
+}
+
+type validatingTraceProvider struct {
+	types.TraceProvider
+	err       error
+	stateData []byte
+	proofData []byte
+}
+
+func (v *validatingTraceProvider) ValidateStepData(stateData []byte, proofData []byte) error {
+	v.stateData = stateData
+	v.proofData = proofData
+	return v.err
+}
+
+type stubResponder struct {
+	// mu guards the fields updated by moves, which may be sent in parallel.
+	mu                sync.Mutex
+	callResolveStatus types.GameStatus
+	resolveCount      int
+	respondCount      int
+	stepCount         int
+	steps             []types.StepCallData
+	actions           []string
+	// urgentMoves is whether each move was sent with urgent fees.
+	urgentMoves []bool
+
+	// callResolveClaimErrs and resolveClaimErrs are the errors returned for the claims at each index.
+	callResolveClaimErrs map[uint64]error
+	resolveClaimErrs     map[uint64]error
+	callResolveClaims    []uint64
+
+	// release blocks each move until it is closed, if set.
+	release     chan struct{}
+	inFlight    int
+	maxInFlight int
+	// delay is how long each move takes, by which clock is advanced.
+	delay time.Duration
+	clock *clock.DeterministicClock
+
+	baseFee *big.Int
+	// moveGas is the gas estimated for each move, which is added to spent at baseFee when the move is sent.
+	moveGas uint64
+	spent   *big.Int
+	// callResolveErr is returned by CallResolve, if set, as when the game isn't resolvable yet.
+	callResolveErr error
+	// resolveErr is returned by Resolve, if set.
+	resolveErr error
+}
+
+func (s *stubResponder) CallResolve(ctx context.Context) (types.GameStatus, error) {
+	return s.callResolveStatus, s.callResolveErr
+}
+
+func (s *stubResponder) Resolve(ctx context.Context) error {
+	s.resolveCount++
+	return s.resolveErr
+}
+
+func (s *stubResponder) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
+	s.callResolveClaims = append(s.callResolveClaims, claimIdx)
+	return s.callResolveClaimErrs[claimIdx]
+}
+
+func (s *stubResponder) ResolveClaim(ctx context.Context, claimIdx uint64) error {
+	s.actions = append(s.actions, fmt.Sprintf("resolveClaim %v", claimIdx))
+	return s.resolveClaimErrs[claimIdx]
+}
+
+func (s *stubResponder) Respond(ctx context.Context, response types.Claim) error {
+	s.mu.Lock()
+	s.respondCount++
+	s.actions = append(s.actions, fmt.Sprintf("move %v", response.ParentContractIndex))
+	s.urgentMoves = append(s.urgentMoves, faultResponder.UrgentFees(ctx))
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	if s.moveGas > 0 && s.baseFee != nil {
+		if s.spent == nil {
+			s.spent = new(big.Int)
+		}
+		s.spent.Add(s.spent, new(big.Int).Mul(s.baseFee, new(big.Int).SetUint64(s.moveGas)))
+	}
+	s.mu.Unlock()
+	if s.release != nil {
+		<-s.release
+	}
+	if s.clock != nil {
+		s.clock.AdvanceTime(s.delay)
+	}
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *stubResponder) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inFlight
+}
+
+func (s *stubResponder) Step(ctx context.Context, stepData types.StepCallData) error {
+	s.stepCount++
+	s.steps = append(s.steps, stepData)
+	s.actions = append(s.actions, fmt.Sprintf("step %v", stepData.ClaimIndex))
+	return nil
+}
+
+func (s *stubResponder) EstimateRespondGas(ctx context.Context, response types.Claim) (uint64, error) {
+	return s.moveGas, nil
+}
+
+func (s *stubResponder) EstimateStepGas(ctx context.Context, stepData types.StepCallData) (uint64, error) {
+	return 0, nil
+}
+
+func (s *stubResponder) BaseFee(ctx context.Context) (*big.Int, error) {
+	if s.baseFee == nil {
+		return nil, errors.New("no base fee")
+	}
+	return s.baseFee, nil
+}
+
+func (s *stubResponder) Spent() *big.Int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.spent == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(s.spent)
+}
+
+type stubMoveMetrics struct {
+	metrics.Metricer
+	deferred      int
+	forced        int
+	reasons       []metrics.DeferReason
+	selfConflicts int
+}
+
+func (m *stubMoveMetrics) RecordMoveDeferred(_ common.Address, reason metrics.DeferReason) {
+	m.deferred++
+	m.reasons = append(m.reasons, reason)
+}
+
+func (m *stubMoveMetrics) RecordMoveForced(_ common.Address, reason metrics.DeferReason) {
+	m.forced++
+	m.reasons = append(m.reasons, reason)
+}
+
+func (m *stubMoveMetrics) RecordSelfConflict(_ common.Address) {
+	m.selfConflicts++
+}
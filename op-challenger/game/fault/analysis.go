@@ -0,0 +1,134 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// Outlook is the expected result of a game for the challenger if both sides play optimally from now on.
+type Outlook string
+
+const (
+	OutlookWinning Outlook = "winning"
+	OutlookLosing  Outlook = "losing"
+	// OutlookUnknown means the result couldn't be decided within the analysis budget.
+	OutlookUnknown Outlook = "unknown"
+)
+
+// AnalysisBudget bounds the work done to analyze the position of a game.
+type AnalysisBudget struct {
+	// MaxDepth is the maximum number of levels below a claim that may still need to be played to settle it.
+	MaxDepth int
+	// Timeout is the maximum time to spend loading the trace to check the claims.
+	Timeout time.Duration
+}
+
+// DefaultAnalysisBudget is the budget used to analyze the position of games.
+var DefaultAnalysisBudget = AnalysisBudget{
+	MaxDepth: 16,
+	Timeout:  5 * time.Second,
+}
+
+// errBudgetExceeded aborts an analysis that ran out of time.
+var errBudgetExceeded = errors.New("analysis budget exceeded")
+
+// AnalyzePosition estimates the outlook of a game for a challenger that defends the root claim if defendRoot
+// is true and otherwise attacks it, assuming both sides play optimally from now on.
+// Under optimal play the challenger counters every claim at its opponent's levels, other than a correct root
+// claim, and claims at its own levels stand only if they are correct according to trace. Settling a claim
+// takes one move per level down to the max depth, so claims that still need more than budget.MaxDepth levels
+// to be played are undecided.
+// Chess clocks are ignored so both sides are assumed to have time to make their moves.
+func AnalyzePosition(ctx context.Context, trace types.TraceProvider, claims []types.Claim, maxDepth int, defendRoot bool, budget AnalysisBudget) (Outlook, error) {
+	if len(claims) == 0 {
+		return OutlookUnknown, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, budget.Timeout)
+	defer cancel()
+	a := &positionAnalyzer{
+		trace:      trace,
+		maxDepth:   maxDepth,
+		defendRoot: defendRoot,
+		budget:     budget,
+		children:   make(map[int][]types.Claim),
+	}
+	for _, claim := range claims[1:] {
+		a.children[claim.ParentContractIndex] = append(a.children[claim.ParentContractIndex], claim)
+	}
+	countered, known, err := a.countered(ctx, claims[0])
+	if errors.Is(err, errBudgetExceeded) || (err == nil && !known) {
+		return OutlookUnknown, nil
+	} else if err != nil {
+		return OutlookUnknown, err
+	}
+	// The challenger wins if the root claim is countered.
+	if countered != defendRoot {
+		return OutlookWinning, nil
+	}
+	return OutlookLosing, nil
+}
+
+type positionAnalyzer struct {
+	trace      types.TraceProvider
+	maxDepth   int
+	defendRoot bool
+	budget     AnalysisBudget
+	children   map[int][]types.Claim
+}
+
+// countered returns whether claim will be countered when the game resolves, or false for known if that
+// can't be decided within the budget.
+func (a *positionAnalyzer) countered(ctx context.Context, claim types.Claim) (countered bool, known bool, err error) {
+	// An existing counter that stands decides the claim without playing any further.
+	known = true
+	for _, child := range a.children[claim.ContractIndex] {
+		childCountered, childKnown, err := a.countered(ctx, child)
+		if err != nil {
+			return false, false, err
+		}
+		if childKnown && !childCountered {
+			return true, true, nil
+		}
+		known = known && childKnown
+	}
+	counter, err := a.willCounter(ctx, claim)
+	if err != nil {
+		return false, false, err
+	}
+	if !counter {
+		return false, known, nil
+	}
+	if a.maxDepth-claim.Depth() > a.budget.MaxDepth {
+		return false, false, nil
+	}
+	return true, true, nil
+}
+
+// willCounter returns true if optimal play counters claim with a new move.
+func (a *positionAnalyzer) willCounter(ctx context.Context, claim types.Claim) (bool, error) {
+	ourLevel := (claim.Depth()%2 == 0) == a.defendRoot
+	if !ourLevel && !claim.IsRoot() {
+		// We counter every claim at our opponent's levels.
+		return true, nil
+	}
+	// Incorrect claims are countered by the side that disagrees with them and a correct root claim isn't countered.
+	correct, err := a.correct(ctx, claim)
+	return !correct, err
+}
+
+func (a *positionAnalyzer) correct(ctx context.Context, claim types.Claim) (bool, error) {
+	if ctx.Err() != nil {
+		return false, errBudgetExceeded
+	}
+	value, err := a.trace.Get(ctx, claim.TraceIndex(a.maxDepth))
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, errBudgetExceeded
+		}
+		return false, err
+	}
+	return value == claim.Value, nil
+}
@@ -0,0 +1,133 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePosition(t *testing.T) {
+	maxDepth := 4
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	budget := AnalysisBudget{MaxDepth: maxDepth, Timeout: time.Minute}
+	// claims links each claim to its parent, which must precede it.
+	claims := func(claims ...types.Claim) []types.Claim {
+		for i := range claims {
+			claims[i].ContractIndex = i
+		}
+		return claims
+	}
+	tests := []struct {
+		name       string
+		claims     []types.Claim
+		defendRoot bool
+		budget     AnalysisBudget
+		expected   Outlook
+	}{
+		{
+			name:     "ChallengeIncorrectRoot",
+			claims:   claims(builder.CreateRootClaim(false)),
+			budget:   budget,
+			expected: OutlookWinning,
+		},
+		{
+			name:       "DefendCorrectRoot",
+			claims:     claims(builder.CreateRootClaim(true)),
+			defendRoot: true,
+			budget:     budget,
+			expected:   OutlookWinning,
+		},
+		{
+			name:     "ChallengeCorrectRoot",
+			claims:   claims(builder.CreateRootClaim(true)),
+			budget:   budget,
+			expected: OutlookLosing,
+		},
+		{
+			name:       "DefendIncorrectRoot",
+			claims:     claims(builder.CreateRootClaim(false)),
+			defendRoot: true,
+			budget:     budget,
+			expected:   OutlookLosing,
+		},
+		{
+			name: "DefendCorrectRootAgainstCounters",
+			claims: claims(
+				builder.CreateRootClaim(true),
+				withParent(builder.AttackClaim(builder.CreateRootClaim(true), false), 0),
+			),
+			defendRoot: true,
+			budget:     budget,
+			expected:   OutlookWinning,
+		},
+		{
+			name: "IncorrectCounterAtOurLevel",
+			claims: claims(
+				builder.CreateRootClaim(false),
+				withParent(builder.AttackClaim(builder.CreateRootClaim(false), false), 0),
+			),
+			budget: budget,
+			// The incorrect counter can't counter the root, but we can still counter it ourselves.
+			expected: OutlookWinning,
+		},
+		{
+			name:     "TooDeepToDecide",
+			claims:   claims(builder.CreateRootClaim(false)),
+			budget:   AnalysisBudget{MaxDepth: 2, Timeout: time.Minute},
+			expected: OutlookUnknown,
+		},
+		{
+			name: "DecidedByExistingCounterWithinBudget",
+			claims: func() []types.Claim {
+				root := builder.CreateRootClaim(false)
+				attack := withParent(builder.AttackClaim(root, true), 0)
+				counter := withParent(builder.AttackClaim(attack, false), 1)
+				return claims(root, attack, counter)
+			}(),
+			budget:   AnalysisBudget{MaxDepth: 2, Timeout: time.Minute},
+			expected: OutlookWinning,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			outlook, err := AnalyzePosition(context.Background(), builder.CorrectTraceProvider(), test.claims, maxDepth, test.defendRoot, test.budget)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, outlook)
+		})
+	}
+
+	t.Run("TimeoutIsUnknown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		outlook, err := AnalyzePosition(ctx, builder.CorrectTraceProvider(), claims(builder.CreateRootClaim(false)), maxDepth, false, budget)
+		require.NoError(t, err)
+		require.Equal(t, OutlookUnknown, outlook)
+	})
+
+	t.Run("TraceError", func(t *testing.T) {
+		provider := &errorTraceProvider{err: errors.New("boom")}
+		_, err := AnalyzePosition(context.Background(), provider, claims(builder.CreateRootClaim(false)), maxDepth, false, budget)
+		require.ErrorIs(t, err, provider.err)
+	})
+}
+
+func withParent(claim types.Claim, parent int) types.Claim {
+	claim.ParentContractIndex = parent
+	return claim
+}
+
+type errorTraceProvider struct {
+	types.TraceProvider
+	err error
+}
+
+func (p *errorTraceProvider) Get(_ context.Context, _ uint64) (common.Hash, error) {
+	return common.Hash{}, p.err
+}
@@ -0,0 +1,223 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	methodClaimData  = "claimData"
+	methodResolvedAt = "resolvedAt"
+)
+
+// counteredByClaimDataABI is the claimData accessor of newer FaultDisputeGame versions which
+// replace the boolean countered flag with the address of the claimant that countered the claim.
+const counteredByClaimDataABI = `[{
+	"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],
+	"name":"claimData",
+	"outputs":[
+		{"internalType":"uint32","name":"parentIndex","type":"uint32"},
+		{"internalType":"address","name":"counteredBy","type":"address"},
+		{"internalType":"address","name":"claimant","type":"address"},
+		{"internalType":"uint128","name":"bond","type":"uint128"},
+		{"internalType":"Claim","name":"claim","type":"bytes32"},
+		{"internalType":"Position","name":"position","type":"uint128"},
+		{"internalType":"Clock","name":"clock","type":"uint128"}
+	],
+	"stateMutability":"view",
+	"type":"function"
+}]`
+
+// resolvedAtABI is the resolution timestamp accessor of newer FaultDisputeGame versions.
+const resolvedAtABI = `[{
+	"inputs":[],
+	"name":"resolvedAt",
+	"outputs":[{"internalType":"Timestamp","name":"","type":"uint64"}],
+	"stateMutability":"view",
+	"type":"function"
+}]`
+
+var (
+	ErrUnknownClaimDataLayout = errors.New("unknown claim data layout")
+	// ErrResolvedAtUnavailable is returned when the contract version doesn't record when the game resolved.
+	ErrResolvedAtUnavailable = errors.New("resolution time not recorded by contract")
+)
+
+// ContractClaimData is the claim data read from a FaultDisputeGame contract, normalized across contract versions.
+// Fields that are not provided by a contract version are left as their zero value.
+type ContractClaimData struct {
+	ParentIndex uint32
+	Countered   bool
+	Claimant    common.Address
+	Bond        *big.Int
+	Claim       [32]byte
+	Position    *big.Int
+	Clock       *big.Int
+}
+
+// claimDataDecoder decodes the return data of claimData calls for all supported contract versions.
+type claimDataDecoder struct {
+	legacy      abi.Method
+	counteredBy abi.Method
+}
+
+func newClaimDataDecoder() (*claimDataDecoder, error) {
+	legacyAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	counteredByAbi, err := abi.JSON(strings.NewReader(counteredByClaimDataABI))
+	if err != nil {
+		return nil, err
+	}
+	return &claimDataDecoder{
+		legacy:      legacyAbi.Methods[methodClaimData],
+		counteredBy: counteredByAbi.Methods[methodClaimData],
+	}, nil
+}
+
+// Pack returns the call data to load the claim at the specified index.
+// The call data is identical for all contract versions.
+func (d *claimDataDecoder) Pack(idx *big.Int) ([]byte, error) {
+	args, err := d.legacy.Inputs.Pack(idx)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, d.legacy.ID...), args...), nil
+}
+
+// Decode decodes claimData return data, selecting the contract version based on the number of returned values.
+func (d *claimDataDecoder) Decode(data []byte) (ContractClaimData, error) {
+	switch len(data) {
+	case len(d.legacy.Outputs) * 32:
+		values, err := d.legacy.Outputs.Unpack(data)
+		if err != nil {
+			return ContractClaimData{}, fmt.Errorf("decode legacy claim data: %w", err)
+		}
+		return ContractClaimData{
+			ParentIndex: *abi.ConvertType(values[0], new(uint32)).(*uint32),
+			Countered:   *abi.ConvertType(values[1], new(bool)).(*bool),
+			Claim:       *abi.ConvertType(values[2], new([32]byte)).(*[32]byte),
+			Position:    *abi.ConvertType(values[3], new(*big.Int)).(**big.Int),
+			Clock:       *abi.ConvertType(values[4], new(*big.Int)).(**big.Int),
+		}, nil
+	case len(d.counteredBy.Outputs) * 32:
+		values, err := d.counteredBy.Outputs.Unpack(data)
+		if err != nil {
+			return ContractClaimData{}, fmt.Errorf("decode claim data: %w", err)
+		}
+		// Only whether the claim is countered is used, not who countered it.
+		counteredBy := *abi.ConvertType(values[1], new(common.Address)).(*common.Address)
+		return ContractClaimData{
+			ParentIndex: *abi.ConvertType(values[0], new(uint32)).(*uint32),
+			Countered:   counteredBy != (common.Address{}),
+			Claimant:    *abi.ConvertType(values[2], new(common.Address)).(*common.Address),
+			Bond:        *abi.ConvertType(values[3], new(*big.Int)).(**big.Int),
+			Claim:       *abi.ConvertType(values[4], new([32]byte)).(*[32]byte),
+			Position:    *abi.ConvertType(values[5], new(*big.Int)).(**big.Int),
+			Clock:       *abi.ConvertType(values[6], new(*big.Int)).(**big.Int),
+		}, nil
+	default:
+		return ContractClaimData{}, fmt.Errorf("%w: %v bytes", ErrUnknownClaimDataLayout, len(data))
+	}
+}
+
+// gameCaller adapts a [bindings.FaultDisputeGameCaller] to the [MinimalFaultDisputeGameCaller] interface,
+// reading claim data in a way that supports all contract versions.
+type gameCaller struct {
+	*bindings.FaultDisputeGameCaller
+	addr       common.Address
+	client     bind.ContractCaller
+	decoder    *claimDataDecoder
+	resolvedAt abi.Method
+}
+
+func newGameCaller(addr common.Address, client bind.ContractCaller) (*gameCaller, error) {
+	caller, err := bindings.NewFaultDisputeGameCaller(addr, client)
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := newClaimDataDecoder()
+	if err != nil {
+		return nil, err
+	}
+	resolvedAtAbi, err := abi.JSON(strings.NewReader(resolvedAtABI))
+	if err != nil {
+		return nil, err
+	}
+	return &gameCaller{
+		FaultDisputeGameCaller: caller,
+		addr:                   addr,
+		client:                 client,
+		decoder:                decoder,
+		resolvedAt:             resolvedAtAbi.Methods[methodResolvedAt],
+	}, nil
+}
+
+// ClaimData loads the claim at the specified index.
+func (c *gameCaller) ClaimData(opts *bind.CallOpts, idx *big.Int) (ContractClaimData, error) {
+	callData, err := c.decoder.Pack(idx)
+	if err != nil {
+		return ContractClaimData{}, err
+	}
+	result, err := c.call(opts, callData)
+	if err != nil {
+		return ContractClaimData{}, err
+	}
+	return c.decoder.Decode(result)
+}
+
+// ResolvedAt loads the timestamp the game resolved at, which is 0 if it hasn't resolved.
+// Returns [ErrResolvedAtUnavailable] if the contract version doesn't record it.
+func (c *gameCaller) ResolvedAt(opts *bind.CallOpts) (uint64, error) {
+	result, err := c.call(opts, c.resolvedAt.ID)
+	if isRevert(err) {
+		// Contract versions without the method revert, as they have no fallback function.
+		return 0, fmt.Errorf("%w: %w", ErrResolvedAtUnavailable, err)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to call resolved at: %w", err)
+	}
+	if len(result) != 32 {
+		return 0, fmt.Errorf("%w: %v bytes returned", ErrResolvedAtUnavailable, len(result))
+	}
+	values, err := c.resolvedAt.Outputs.Unpack(result)
+	if err != nil {
+		return 0, fmt.Errorf("decode resolved at: %w", err)
+	}
+	return *abi.ConvertType(values[0], new(uint64)).(*uint64), nil
+}
+
+// isRevert returns true if err is from a call that reverted, rather than one that couldn't be made.
+func isRevert(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dataErr rpc.DataError
+	return errors.As(err, &dataErr) || strings.Contains(err.Error(), vm.ErrExecutionReverted.Error())
+}
+
+func (c *gameCaller) call(opts *bind.CallOpts, callData []byte) ([]byte, error) {
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.client.CallContract(ctx, ethereum.CallMsg{
+		From: opts.From,
+		To:   &c.addr,
+		Data: callData,
+	}, opts.BlockNumber)
+}
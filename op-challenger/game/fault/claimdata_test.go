@@ -0,0 +1,182 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimDataDecoder_LegacyLayout(t *testing.T) {
+	decoder, err := newClaimDataDecoder()
+	require.NoError(t, err)
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+
+	data, err := fdgAbi.Methods[methodClaimData].Outputs.Pack(
+		uint32(3),
+		true,
+		[32]byte{0xaa},
+		big.NewInt(5),
+		big.NewInt(1234),
+	)
+	require.NoError(t, err)
+
+	claim, err := decoder.Decode(data)
+	require.NoError(t, err)
+	require.Equal(t, ContractClaimData{
+		ParentIndex: 3,
+		Countered:   true,
+		Claim:       [32]byte{0xaa},
+		Position:    big.NewInt(5),
+		Clock:       big.NewInt(1234),
+	}, claim)
+}
+
+func TestClaimDataDecoder_CounteredByLayout(t *testing.T) {
+	decoder, err := newClaimDataDecoder()
+	require.NoError(t, err)
+	counteredByAbi, err := abi.JSON(strings.NewReader(counteredByClaimDataABI))
+	require.NoError(t, err)
+
+	t.Run("Countered", func(t *testing.T) {
+		data, err := counteredByAbi.Methods[methodClaimData].Outputs.Pack(
+			uint32(3),
+			common.Address{0xbb},
+			common.Address{0xcc},
+			big.NewInt(100),
+			[32]byte{0xaa},
+			big.NewInt(5),
+			big.NewInt(1234),
+		)
+		require.NoError(t, err)
+
+		claim, err := decoder.Decode(data)
+		require.NoError(t, err)
+		require.Equal(t, ContractClaimData{
+			ParentIndex: 3,
+			Countered:   true,
+			Claimant:    common.Address{0xcc},
+			Bond:        big.NewInt(100),
+			Claim:       [32]byte{0xaa},
+			Position:    big.NewInt(5),
+			Clock:       big.NewInt(1234),
+		}, claim)
+	})
+
+	t.Run("NotCountered", func(t *testing.T) {
+		data, err := counteredByAbi.Methods[methodClaimData].Outputs.Pack(
+			uint32(3),
+			common.Address{},
+			common.Address{0xcc},
+			big.NewInt(100),
+			[32]byte{0xaa},
+			big.NewInt(5),
+			big.NewInt(1234),
+		)
+		require.NoError(t, err)
+
+		claim, err := decoder.Decode(data)
+		require.NoError(t, err)
+		require.False(t, claim.Countered)
+	})
+}
+
+func TestClaimDataDecoder_UnknownLayout(t *testing.T) {
+	decoder, err := newClaimDataDecoder()
+	require.NoError(t, err)
+	_, err = decoder.Decode(make([]byte, 64))
+	require.ErrorIs(t, err, ErrUnknownClaimDataLayout)
+}
+
+func TestGameCaller_ClaimData(t *testing.T) {
+	counteredByAbi, err := abi.JSON(strings.NewReader(counteredByClaimDataABI))
+	require.NoError(t, err)
+	data, err := counteredByAbi.Methods[methodClaimData].Outputs.Pack(
+		uint32(0),
+		common.Address{0xbb},
+		common.Address{0xcc},
+		big.NewInt(100),
+		[32]byte{0xaa},
+		big.NewInt(1),
+		big.NewInt(1234),
+	)
+	require.NoError(t, err)
+	gameAddr := common.Address{0x12}
+	client := &stubContractCaller{result: data}
+	caller, err := newGameCaller(gameAddr, client)
+	require.NoError(t, err)
+
+	claim, err := caller.ClaimData(&bind.CallOpts{Context: context.Background()}, big.NewInt(7))
+	require.NoError(t, err)
+	require.True(t, claim.Countered)
+
+	expectedCallData, err := counteredByAbi.Pack(methodClaimData, big.NewInt(7))
+	require.NoError(t, err)
+	require.Equal(t, expectedCallData, client.lastCall.Data)
+	require.Equal(t, &gameAddr, client.lastCall.To)
+}
+
+func TestGameCaller_ResolvedAt(t *testing.T) {
+	resolvedAtAbi, err := abi.JSON(strings.NewReader(resolvedAtABI))
+	require.NoError(t, err)
+	data, err := resolvedAtAbi.Methods[methodResolvedAt].Outputs.Pack(uint64(1234))
+	require.NoError(t, err)
+	client := &stubContractCaller{result: data}
+	caller, err := newGameCaller(common.Address{0x12}, client)
+	require.NoError(t, err)
+
+	resolvedAt, err := caller.ResolvedAt(&bind.CallOpts{Context: context.Background()})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), resolvedAt)
+	require.Equal(t, resolvedAtAbi.Methods[methodResolvedAt].ID, client.lastCall.Data)
+
+	t.Run("Unavailable", func(t *testing.T) {
+		client.result = nil
+		_, err := caller.ResolvedAt(&bind.CallOpts{Context: context.Background()})
+		require.ErrorIs(t, err, ErrResolvedAtUnavailable)
+
+		client.err = errors.New("execution reverted")
+		_, err = caller.ResolvedAt(&bind.CallOpts{Context: context.Background()})
+		require.ErrorIs(t, err, ErrResolvedAtUnavailable)
+
+		client.err = panicRevert(0x01)
+		_, err = caller.ResolvedAt(&bind.CallOpts{Context: context.Background()})
+		require.ErrorIs(t, err, ErrResolvedAtUnavailable)
+	})
+
+	t.Run("CallFailed", func(t *testing.T) {
+		client.err = errors.New("connection refused")
+		_, err := caller.ResolvedAt(&bind.CallOpts{Context: context.Background()})
+		require.ErrorContains(t, err, "connection refused")
+		require.NotErrorIs(t, err, ErrResolvedAtUnavailable)
+
+		client.err = context.Canceled
+		_, err = caller.ResolvedAt(&bind.CallOpts{Context: context.Background()})
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrResolvedAtUnavailable)
+	})
+}
+
+type stubContractCaller struct {
+	result   []byte
+	err      error
+	lastCall ethereum.CallMsg
+}
+
+func (s *stubContractCaller) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (s *stubContractCaller) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	s.lastCall = call
+	return s.result, s.err
+}
@@ -0,0 +1,64 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type claimTreeLoader interface {
+	BuildClaimTree(ctx context.Context) (*types.ClaimTree, error)
+	FetchGameDepth(ctx context.Context) (uint64, error)
+	FetchGameType(ctx context.Context) (uint8, error)
+}
+
+// LoadClaimTree loads the claim tree of the game at addr, annotated with the trace index of each claim.
+// If cfg is not nil, each claim is also compared with the game's trace from the trace provider configured for its
+// game type, which is generated in dir if needed.
+func LoadClaimTree(ctx context.Context, logger log.Logger, cfg *config.Config, dir string, addr common.Address, client L1Client) (*types.ClaimTree, error) {
+	loader, err := NewLoaderFromBindings(logger, addr, client, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
+	}
+	var selector TraceProviderSelector
+	if cfg != nil {
+		// Trace providers only need a transaction manager to update the oracle, which doesn't happen here.
+		selector = NewTraceProviders(logger, cfg, dir, addr, nil, client, nil)
+	}
+	return loadClaimTree(ctx, loader, selector)
+}
+
+// loadClaimTree loads the claim tree from loader, comparing each claim with the trace from selector if it is not nil.
+func loadClaimTree(ctx context.Context, loader claimTreeLoader, selector TraceProviderSelector) (*types.ClaimTree, error) {
+	tree, err := loader.BuildClaimTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to build the claim tree: %w", ErrLoader, err)
+	}
+	gameDepth, err := loader.FetchGameDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch the game depth: %w", ErrLoader, err)
+	}
+	var trace types.TraceProvider
+	if selector != nil {
+		gameType, err := loader.FetchGameType(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to fetch the game type: %w", ErrLoader, err)
+		}
+		trace, err = createTraceProvider(ctx, selector, gameType, gameDepth)
+		if err != nil {
+			return nil, err
+		}
+		if closer, ok := trace.(io.Closer); ok {
+			defer closer.Close()
+		}
+	}
+	if err := tree.Annotate(ctx, int(gameDepth), trace); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTraceProvider, err)
+	}
+	return tree, nil
+}
@@ -0,0 +1,83 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadClaimTree(t *testing.T) {
+	maxDepth := uint64(2)
+	trace := alphabet.NewTraceProvider("abcd", maxDepth)
+	correct, err := trace.Get(context.Background(), 1)
+	require.NoError(t, err)
+	setup := func() *mockCaller {
+		return &mockCaller{
+			maxGameDepth: maxDepth,
+			gameType:     1,
+			returnClaims: []ContractClaimData{
+				{Claim: [32]byte{0xff}, Position: big.NewInt(1), ParentIndex: math.MaxUint32, Clock: big.NewInt(0)},
+				{Claim: correct, Position: big.NewInt(2), ParentIndex: 0, Clock: big.NewInt(0)},
+				{Claim: [32]byte{0xee}, Position: big.NewInt(5), ParentIndex: 1, Clock: big.NewInt(0)},
+			},
+		}
+	}
+	selector := TraceProviders{
+		1: func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error) {
+			require.Equal(t, maxDepth, gameDepth)
+			return trace, nil, nil
+		},
+	}
+
+	t.Run("WithoutTrace", func(t *testing.T) {
+		tree, err := loadClaimTree(context.Background(), NewLoader(setup(), nil), nil)
+		require.NoError(t, err)
+		require.Len(t, tree.Nodes, 3)
+		require.Equal(t, uint64(3), tree.Nodes[0].TraceIndex)
+		require.Equal(t, uint64(1), tree.Nodes[1].TraceIndex)
+		require.Equal(t, uint64(1), tree.Nodes[2].TraceIndex)
+		for _, node := range tree.Nodes {
+			require.Nil(t, node.Agrees)
+		}
+	})
+
+	t.Run("WithTrace", func(t *testing.T) {
+		tree, err := loadClaimTree(context.Background(), NewLoader(setup(), nil), selector)
+		require.NoError(t, err)
+		require.False(t, *tree.Nodes[0].Agrees)
+		require.True(t, *tree.Nodes[1].Agrees)
+		require.False(t, *tree.Nodes[2].Agrees)
+	})
+
+	t.Run("UnsupportedGameType", func(t *testing.T) {
+		caller := setup()
+		caller.gameType = 2
+		_, err := loadClaimTree(context.Background(), NewLoader(caller, nil), selector)
+		require.ErrorIs(t, err, ErrUnsupportedGameType)
+	})
+
+	t.Run("TraceProviderError", func(t *testing.T) {
+		expected := errors.New("boom")
+		failing := TraceProviders{
+			1: func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error) {
+				return nil, nil, expected
+			},
+		}
+		_, err := loadClaimTree(context.Background(), NewLoader(setup(), nil), failing)
+		require.ErrorIs(t, err, ErrTraceProvider)
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("LoadError", func(t *testing.T) {
+		caller := setup()
+		caller.maxGameDepthError = true
+		_, err := loadClaimTree(context.Background(), NewLoader(caller, nil), nil)
+		require.ErrorIs(t, err, ErrLoader)
+	})
+}
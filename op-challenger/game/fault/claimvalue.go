@@ -0,0 +1,31 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrNoClaimValue is returned by [ClaimValueAt] for the last possible trace index, which has no following step
+// to take its pre-state from.
+var ErrNoClaimValue = errors.New("no claim value after the last trace index")
+
+// ClaimValueAt returns the claim value at index in the trace, derived from the step data rather than taken from
+// [types.TraceProvider.Get]. The pre-state of the step at index+1 is the state after index, so the claim value is
+// its hash. It matches Get for any provider that meets the TraceProvider requirements, so it can be used to verify
+// the values a provider returns.
+func ClaimValueAt(ctx context.Context, trace types.TraceProvider, index uint64) (common.Hash, error) {
+	if index == math.MaxUint64 {
+		return common.Hash{}, ErrNoClaimValue
+	}
+	prestate, _, _, err := trace.GetStepData(ctx, index+1)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to get the step data after trace index %v: %w", ErrTraceProvider, index, err)
+	}
+	return crypto.Keccak256Hash(prestate), nil
+}
@@ -0,0 +1,47 @@
+package fault
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimValueAt(t *testing.T) {
+	ctx := context.Background()
+	trace := newMockTraceProvider(false, []byte{0x00})
+	trace.states = [][]byte{{0x01}, {0x02}, {0x03}}
+
+	t.Run("HashesStateAfterIndex", func(t *testing.T) {
+		for i, state := range trace.states {
+			value, err := ClaimValueAt(ctx, trace, uint64(i))
+			require.NoError(t, err)
+			require.Equal(t, crypto.Keccak256Hash(state), value)
+		}
+	})
+
+	t.Run("StepDataErrors", func(t *testing.T) {
+		_, err := ClaimValueAt(ctx, trace, uint64(len(trace.states)))
+		require.ErrorIs(t, err, ErrTraceProvider)
+		require.ErrorIs(t, err, mockTraceProviderError)
+	})
+
+	t.Run("LastIndex", func(t *testing.T) {
+		_, err := ClaimValueAt(ctx, trace, math.MaxUint64)
+		require.ErrorIs(t, err, ErrNoClaimValue)
+	})
+
+	t.Run("MatchesGet", func(t *testing.T) {
+		provider := alphabet.NewTraceProvider("abcdefgh", 4)
+		for i := uint64(0); i < 16; i++ {
+			expected, err := provider.Get(ctx, i)
+			require.NoError(t, err)
+			value, err := ClaimValueAt(ctx, provider, i)
+			require.NoError(t, err)
+			require.Equal(t, expected, value, "trace index %v", i)
+		}
+	})
+}
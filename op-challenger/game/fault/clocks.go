@@ -0,0 +1,62 @@
+package fault
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+// GameDeadline is the time the soonest expiring chess clock of a game runs out.
+type GameDeadline struct {
+	Game     common.Address
+	Deadline time.Time
+}
+
+// ClockTracker records the soonest chess clock deadline of each game being progressed.
+// It is safe for concurrent use by the players of different games.
+type ClockTracker struct {
+	mu        sync.Mutex
+	deadlines map[common.Address]time.Time
+}
+
+func NewClockTracker() *ClockTracker {
+	return &ClockTracker{
+		deadlines: make(map[common.Address]time.Time),
+	}
+}
+
+// Update records the soonest chess clock deadline of game.
+func (t *ClockTracker) Update(game common.Address, deadline time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deadlines[game] = deadline
+}
+
+// Remove stops tracking game, either because it has no running clock or it is complete.
+func (t *ClockTracker) Remove(game common.Address) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.deadlines, game)
+}
+
+// ExpiringBetween returns the games with a deadline after from and no later than until, soonest first.
+func (t *ClockTracker) ExpiringBetween(from time.Time, until time.Time) []GameDeadline {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var expiring []GameDeadline
+	for game, deadline := range t.deadlines {
+		if deadline.After(from) && !deadline.After(until) {
+			expiring = append(expiring, GameDeadline{Game: game, Deadline: deadline})
+		}
+	}
+	slices.SortFunc(expiring, func(a, b GameDeadline) bool {
+		if !a.Deadline.Equal(b.Deadline) {
+			return a.Deadline.Before(b.Deadline)
+		}
+		return bytes.Compare(a.Game[:], b.Game[:]) < 0
+	})
+	return expiring
+}
@@ -0,0 +1,28 @@
+package fault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockTracker_ExpiringBetween(t *testing.T) {
+	tracker := NewClockTracker()
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	game3 := common.Address{0xcc}
+	tracker.Update(game1, time.Unix(300, 0))
+	tracker.Update(game2, time.Unix(200, 0))
+	tracker.Update(game3, time.Unix(100, 0))
+
+	require.Equal(t, []GameDeadline{
+		{Game: game2, Deadline: time.Unix(200, 0)},
+		{Game: game1, Deadline: time.Unix(300, 0)},
+	}, tracker.ExpiringBetween(time.Unix(100, 0), time.Unix(300, 0)), "should exclude passed deadlines and sort soonest first")
+
+	tracker.Update(game1, time.Unix(400, 0))
+	tracker.Remove(game2)
+	require.Empty(t, tracker.ExpiringBetween(time.Unix(100, 0), time.Unix(300, 0)))
+}
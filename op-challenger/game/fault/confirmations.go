@@ -0,0 +1,63 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// StatusAtLoader loads a game's status as of a specific L1 block.
+type StatusAtLoader interface {
+	GetGameStatusAt(ctx context.Context, block *big.Int) (types.GameStatus, error)
+}
+
+// confirmedStatusInfo is a [GameInfo] that only reports a terminal game status once the game has been resolved
+// for at least confirmations L1 blocks, so a resolution that is reorged out isn't treated as final.
+// Until then the game is reported as in progress.
+type confirmedStatusInfo struct {
+	GameInfo
+	logger        log.Logger
+	statusAt      StatusAtLoader
+	headers       HeaderSource
+	confirmations uint64
+}
+
+func newConfirmedStatusInfo(logger log.Logger, info GameInfo, statusAt StatusAtLoader, headers HeaderSource, confirmations uint64) *confirmedStatusInfo {
+	return &confirmedStatusInfo{
+		GameInfo:      info,
+		logger:        logger,
+		statusAt:      statusAt,
+		headers:       headers,
+		confirmations: confirmations,
+	}
+}
+
+// GetGameStatus returns the game's status at the chain head if the game had the same status confirmations blocks
+// earlier, or [types.GameStatusInProgress] otherwise.
+func (c *confirmedStatusInfo) GetGameStatus(ctx context.Context) (types.GameStatus, error) {
+	status, err := c.GameInfo.GetGameStatus(ctx)
+	if err != nil || status == types.GameStatusInProgress {
+		return status, err
+	}
+	head, err := c.headers.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head.Number.Uint64() < c.confirmations {
+		c.logger.Debug("Waiting for game resolution to be confirmed", "status", status, "head", head.Number)
+		return types.GameStatusInProgress, nil
+	}
+	confirmedBlock := new(big.Int).SetUint64(head.Number.Uint64() - c.confirmations)
+	confirmed, err := c.statusAt.GetGameStatusAt(ctx, confirmedBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch game status at block %v: %w", confirmedBlock, err)
+	}
+	if confirmed != status {
+		c.logger.Debug("Waiting for game resolution to be confirmed", "status", status, "confirmed_status", confirmed, "confirmed_block", confirmedBlock)
+		return types.GameStatusInProgress, nil
+	}
+	return status, nil
+}
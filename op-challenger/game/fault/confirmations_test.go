@@ -0,0 +1,91 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmedStatusInfo(t *testing.T) {
+	setup := func(head uint64, status types.GameStatus, history map[uint64]types.GameStatus) (*confirmedStatusInfo, *stubStatusAtLoader) {
+		statusAt := &stubStatusAtLoader{statuses: history}
+		headers := &stubHeaderSource{head: &ethtypes.Header{Number: new(big.Int).SetUint64(head)}}
+		info := newConfirmedStatusInfo(testlog.Logger(t, log.LvlCrit), &stubGameState{status: status}, statusAt, headers, 10)
+		return info, statusAt
+	}
+
+	t.Run("InProgress", func(t *testing.T) {
+		info, statusAt := setup(100, types.GameStatusInProgress, nil)
+		status, err := info.GetGameStatus(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, types.GameStatusInProgress, status)
+		require.Empty(t, statusAt.requested, "should not check confirmations of games in progress")
+	})
+
+	t.Run("Confirmed", func(t *testing.T) {
+		info, statusAt := setup(100, types.GameStatusChallengerWon, map[uint64]types.GameStatus{90: types.GameStatusChallengerWon})
+		status, err := info.GetGameStatus(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, types.GameStatusChallengerWon, status)
+		require.Equal(t, []uint64{90}, statusAt.requested)
+	})
+
+	t.Run("Unconfirmed", func(t *testing.T) {
+		info, _ := setup(100, types.GameStatusDefenderWon, map[uint64]types.GameStatus{90: types.GameStatusInProgress})
+		status, err := info.GetGameStatus(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, types.GameStatusInProgress, status, "should report resolutions without enough confirmations as in progress")
+	})
+
+	t.Run("ChainShorterThanConfirmations", func(t *testing.T) {
+		info, statusAt := setup(5, types.GameStatusDefenderWon, nil)
+		status, err := info.GetGameStatus(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, types.GameStatusInProgress, status)
+		require.Empty(t, statusAt.requested)
+	})
+
+	t.Run("StatusAtError", func(t *testing.T) {
+		info, statusAt := setup(100, types.GameStatusDefenderWon, nil)
+		statusAt.err = errors.New("boom")
+		_, err := info.GetGameStatus(context.Background())
+		require.ErrorIs(t, err, statusAt.err)
+	})
+}
+
+// TestProgressGame_WaitForConfirmedResolution tests that a game isn't completed until its resolution is confirmed.
+func TestProgressGame_WaitForConfirmedResolution(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	gameState.status = types.GameStatusChallengerWon
+	statusAt := &stubStatusAtLoader{statuses: map[uint64]types.GameStatus{90: types.GameStatusInProgress}}
+	headers := &stubHeaderSource{head: &ethtypes.Header{Number: big.NewInt(100)}}
+	game.loader = newConfirmedStatusInfo(game.logger, gameState, statusAt, headers, 10)
+
+	require.False(t, game.ProgressGame(context.Background()), "should not be done until the resolution is confirmed")
+	require.Equal(t, 1, gameState.callCount, "should keep acting on the game")
+
+	statusAt.statuses[100] = types.GameStatusChallengerWon
+	headers.head = &ethtypes.Header{Number: big.NewInt(110)}
+	require.True(t, game.ProgressGame(context.Background()))
+}
+
+type stubStatusAtLoader struct {
+	statuses  map[uint64]types.GameStatus
+	requested []uint64
+	err       error
+}
+
+func (s *stubStatusAtLoader) GetGameStatusAt(_ context.Context, block *big.Int) (types.GameStatus, error) {
+	s.requested = append(s.requested, block.Uint64())
+	if s.err != nil {
+		return 0, s.err
+	}
+	return s.statuses[block.Uint64()], nil
+}
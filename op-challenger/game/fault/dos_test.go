@@ -0,0 +1,453 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cache"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	spamMaxDepth     = 5
+	spamGameDuration = 20 * time.Minute
+	spamBlockTime    = 12
+	// spamPerBlock is the number of claims the spammer posts against the root claim in each block of the spam window.
+	spamPerBlock = 8
+	spamBlocks   = 5
+	// spamMaxCycles bounds the number of times the agent is run, enough for every clock to expire many times over.
+	spamMaxCycles = 500
+	// minerIdleTimeout is how long a pending transaction waits for others to share its block before it is mined.
+	minerIdleTimeout = 5 * time.Millisecond
+)
+
+var (
+	honestClaimant = common.Address{0xaa}
+	spamClaimant   = common.Address{0xbb}
+
+	errClockTimeExceeded   = errors.New("clock time exceeded")
+	errClaimAlreadyExists  = errors.New("claim already exists")
+	errInvalidMove         = errors.New("invalid move")
+	errInvalidStep         = errors.New("invalid step")
+	errGameNotInProgress   = errors.New("game not in progress")
+	errResolveClaimMissing = errors.New("resolveClaim not supported")
+)
+
+// TestClaimSpam tests that an honest defender with realistic move limits wins a game against a spammer posting
+// many claims in every block, countering each of them before its clock expires.
+// The spammer attacks the root claim with a new value for each claim, and counters every honest claim with the same
+// incorrect value so that matching claims counter different parents, playing each of them down to a step.
+func TestClaimSpam(t *testing.T) {
+	limits := MoveLimits{MaxPerCycle: 32, MaxParallel: 16}
+	game := playClaimSpamGame(t, limits)
+
+	require.Equal(t, types.GameStatusDefenderWon, game.Result())
+	require.Zero(t, game.honestClockExceeded, "should make every honest move before its clock expired")
+	spam := 0
+	for i, claim := range game.claims {
+		if claim.claimant == spamClaimant {
+			spam++
+			require.Truef(t, claim.countered, "spam claim %v not countered", i)
+		}
+	}
+	require.Greater(t, spam, spamPerBlock*spamBlocks, "should have spammed the root claim and countered honest claims")
+
+	t.Run("SequentialMovesLose", func(t *testing.T) {
+		// Shows the spam is enough to win against an agent that sends one move at a time without a budget.
+		game := playClaimSpamGame(t, MoveLimits{})
+		status, _ := game.Outcome()
+		require.Equal(t, types.GameStatusChallengerWon, status)
+		require.NotZero(t, game.honestClockExceeded)
+	})
+}
+
+// playClaimSpamGame plays a game defended by an honest agent with limits until it is resolved.
+func playClaimSpamGame(t *testing.T, limits MoveLimits) *simulatedGame {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlCrit)
+	correctTrace := alphabet.NewTraceProvider("abcdefghijklmnopqrstuvwxyzABCDEF", spamMaxDepth)
+	game := newSimulatedGame(t, correctTrace, limits.MaxParallel)
+	game.spammer = spamClaims
+
+	// The honest agent's trace is served through a cache limited to a fraction of the trace data.
+	trace := cache.NewTraceProvider(correctTrace, 1024, nil)
+	loader := NewLoader(game, game)
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, spamMaxDepth, limits, spamGameDuration, trace, game, nil, nil, false, logger)
+	// Play until the agent has had a chance to resolve the game after every clock expired.
+	over := false
+	for i := 0; i < spamMaxCycles && game.Result() == types.GameStatusInProgress && !over; i++ {
+		_, over = game.Outcome()
+		claims, block, err := loader.FetchClaims(ctx)
+		require.NoError(t, err)
+		require.NoError(t, agent.Act(ctx, &GameSnapshot{Claims: claims, Block: block}))
+		// The agent acts again on the next block.
+		game.Mine()
+	}
+	_, over = game.Outcome()
+	require.True(t, over, "game not over")
+	return game
+}
+
+// spamClaims posts the spammer's claims for the block being mined.
+func spamClaims(g *simulatedGame) {
+	if g.number <= spamBlocks {
+		root := g.claims[0].position
+		for i := 0; i < spamPerBlock; i++ {
+			value := common.Hash{0xde, byte(g.number), byte(i)}
+			_ = g.post(0, root.Attack(), value, spamClaimant)
+		}
+	}
+	for i := range g.claims {
+		claim := &g.claims[i]
+		if claim.claimant != honestClaimant || claim.countered || claim.position.Depth() == spamMaxDepth {
+			continue
+		}
+		_ = g.post(i, claim.position.Attack(), common.Hash{0xde, 0xad}, spamClaimant)
+	}
+}
+
+type simulatedClaim struct {
+	parent    int
+	value     common.Hash
+	position  types.Position
+	claimant  common.Address
+	countered bool
+	stepped   bool
+	// duration is the time used on the claimant's chess clock when the claim was made.
+	duration  uint64
+	timestamp uint64
+}
+
+type simulatedTx struct {
+	move   *types.Claim
+	step   *types.StepCallData
+	result chan error
+}
+
+// simulatedGame is an in-memory fault dispute game on a simulated chain, following the rules of the contract that
+// matter to the honest agent: the chess clocks, duplicate claims, steps against incorrect leaf claims and subgame
+// resolution. It provides the contract calls used by the [loader] along with the [Responder] used by the agent.
+// As with a transaction manager, each transaction blocks until it is mined. A block is mined once the expected
+// number of transactions are pending, or once no more have been sent for a while, with the spammer's claims
+// included at the start of each block.
+type simulatedGame struct {
+	t     *testing.T
+	trace types.TraceProvider
+	// txsPerBlock is the number of pending transactions that are mined without waiting for more.
+	txsPerBlock int
+	spammer     func(g *simulatedGame)
+
+	mu                  sync.Mutex
+	number              uint64
+	timestamp           uint64
+	claims              []simulatedClaim
+	status              types.GameStatus
+	pending             []simulatedTx
+	lastSent            time.Time
+	honestClockExceeded int
+}
+
+func newSimulatedGame(t *testing.T, trace types.TraceProvider, txsPerBlock int) *simulatedGame {
+	if txsPerBlock < 1 {
+		txsPerBlock = 1
+	}
+	root := types.NewPosition(0, 0)
+	rootValue, err := trace.Get(context.Background(), root.TraceIndex(spamMaxDepth))
+	require.NoError(t, err)
+	timestamp := uint64(1_000_000)
+	return &simulatedGame{
+		t:           t,
+		trace:       trace,
+		txsPerBlock: txsPerBlock,
+		timestamp:   timestamp,
+		claims:      []simulatedClaim{{parent: -1, value: rootValue, position: root, timestamp: timestamp}},
+	}
+}
+
+func (g *simulatedGame) Result() types.GameStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.status
+}
+
+// Outcome returns the result the game resolves to, and false if it can't be resolved yet.
+func (g *simulatedGame) Outcome() (types.GameStatus, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.outcome()
+}
+
+// Mine mines the next block.
+func (g *simulatedGame) Mine() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mine()
+}
+
+func (g *simulatedGame) mine() {
+	g.number++
+	g.timestamp += spamBlockTime
+	if g.spammer != nil && g.status == types.GameStatusInProgress {
+		g.spammer(g)
+	}
+	for _, tx := range g.pending {
+		tx.result <- g.apply(tx)
+	}
+	g.pending = nil
+}
+
+func (g *simulatedGame) send(ctx context.Context, tx simulatedTx) error {
+	tx.result = make(chan error, 1)
+	g.mu.Lock()
+	g.pending = append(g.pending, tx)
+	g.lastSent = time.Now()
+	if len(g.pending) >= g.txsPerBlock {
+		g.mine()
+	}
+	g.mu.Unlock()
+	for {
+		select {
+		case err := <-tx.result:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(minerIdleTimeout):
+			g.mu.Lock()
+			if len(g.pending) > 0 && time.Since(g.lastSent) >= minerIdleTimeout {
+				g.mine()
+			}
+			g.mu.Unlock()
+		}
+	}
+}
+
+func (g *simulatedGame) apply(tx simulatedTx) error {
+	if g.status != types.GameStatusInProgress {
+		return errGameNotInProgress
+	}
+	if tx.move != nil {
+		err := g.post(tx.move.ParentContractIndex, tx.move.Position, tx.move.Value, honestClaimant)
+		if errors.Is(err, errClockTimeExceeded) {
+			g.honestClockExceeded++
+		}
+		return err
+	}
+	return g.applyStep(*tx.step)
+}
+
+// post adds a claim countering the claim at parentIdx, as the contract's move does.
+func (g *simulatedGame) post(parentIdx int, pos types.Position, value common.Hash, claimant common.Address) error {
+	if parentIdx < 0 || parentIdx >= len(g.claims) {
+		return fmt.Errorf("%w: no parent %v", errInvalidMove, parentIdx)
+	}
+	parent := &g.claims[parentIdx]
+	if pos.Depth() > spamMaxDepth || (pos != parent.position.Attack() && (parentIdx == 0 || pos != parent.position.Defend())) {
+		return fmt.Errorf("%w: position %v against %v", errInvalidMove, pos.ToGIndex(), parent.position.ToGIndex())
+	}
+	for _, claim := range g.claims {
+		if claim.parent == parentIdx && claim.position == pos && claim.value == value {
+			return errClaimAlreadyExists
+		}
+	}
+	duration, err := g.counterClock(parentIdx)
+	if err != nil {
+		return err
+	}
+	parent.countered = true
+	g.claims = append(g.claims, simulatedClaim{
+		parent:    parentIdx,
+		value:     value,
+		position:  pos,
+		claimant:  claimant,
+		duration:  duration,
+		timestamp: g.timestamp,
+	})
+	return nil
+}
+
+func (g *simulatedGame) applyStep(step types.StepCallData) error {
+	if step.ClaimIndex >= uint64(len(g.claims)) {
+		return fmt.Errorf("%w: no claim %v", errInvalidStep, step.ClaimIndex)
+	}
+	claim := &g.claims[step.ClaimIndex]
+	correct, err := g.trace.Get(context.Background(), claim.position.TraceIndex(spamMaxDepth))
+	require.NoError(g.t, err)
+	if claim.position.Depth() != spamMaxDepth || claim.stepped || claim.value == correct {
+		return fmt.Errorf("%w: against claim %v", errInvalidStep, step.ClaimIndex)
+	}
+	if _, err := g.counterClock(int(step.ClaimIndex)); err != nil {
+		g.honestClockExceeded++
+		return err
+	}
+	claim.countered = true
+	claim.stepped = true
+	return nil
+}
+
+// counterClock returns the time used by the clock of the side countering the claim at idx, or
+// [errClockTimeExceeded] if it has run out.
+func (g *simulatedGame) counterClock(idx int) (uint64, error) {
+	claim := &g.claims[idx]
+	var used uint64
+	if claim.parent >= 0 {
+		used = g.claims[claim.parent].duration
+	}
+	used += g.timestamp - claim.timestamp
+	if used > uint64(spamGameDuration.Seconds())/2 {
+		return 0, errClockTimeExceeded
+	}
+	return used, nil
+}
+
+// outcome returns the result of the game once every clock has expired.
+// Each subgame is won by the claimant unless a counter claim's subgame was won or the claim was stepped against.
+func (g *simulatedGame) outcome() (types.GameStatus, bool) {
+	for i := range g.claims {
+		if _, err := g.counterClock(i); err == nil {
+			return types.GameStatusInProgress, false
+		}
+	}
+	won := make([]bool, len(g.claims))
+	for i := range won {
+		won[i] = !g.claims[i].stepped
+	}
+	// Counter claims always come after the claim they counter.
+	for i := len(g.claims) - 1; i > 0; i-- {
+		if won[i] {
+			won[g.claims[i].parent] = false
+		}
+	}
+	if won[0] {
+		return types.GameStatusDefenderWon, true
+	}
+	return types.GameStatusChallengerWon, true
+}
+
+func (g *simulatedGame) ClaimData(_ *bind.CallOpts, arg0 *big.Int) (ContractClaimData, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	idx := arg0.Uint64()
+	if idx >= uint64(len(g.claims)) {
+		return ContractClaimData{}, panicRevert(panicArrayOutOfBounds)
+	}
+	claim := g.claims[idx]
+	parent := uint32(claim.parent)
+	if claim.parent < 0 {
+		parent = ^uint32(0)
+	}
+	clock := new(big.Int).Lsh(new(big.Int).SetUint64(claim.duration), 64)
+	clock.Or(clock, new(big.Int).SetUint64(claim.timestamp))
+	return ContractClaimData{
+		ParentIndex: parent,
+		Countered:   claim.countered,
+		Claimant:    claim.claimant,
+		Bond:        big.NewInt(0),
+		Claim:       claim.value,
+		Position:    new(big.Int).SetUint64(claim.position.ToGIndex()),
+		Clock:       clock,
+	}, nil
+}
+
+func (g *simulatedGame) Status(_ *bind.CallOpts) (uint8, error) {
+	return uint8(g.Result()), nil
+}
+
+func (g *simulatedGame) ClaimDataLen(_ *bind.CallOpts) (*big.Int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return big.NewInt(int64(len(g.claims))), nil
+}
+
+func (g *simulatedGame) MAXGAMEDEPTH(_ *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(spamMaxDepth), nil
+}
+
+func (g *simulatedGame) ABSOLUTEPRESTATE(_ *bind.CallOpts) ([32]byte, error) {
+	prestate, err := g.trace.AbsolutePreStateCommitment(context.Background())
+	return prestate, err
+}
+
+func (g *simulatedGame) GameType(_ *bind.CallOpts) (uint8, error) {
+	return 0, nil
+}
+
+func (g *simulatedGame) GAMEDURATION(_ *bind.CallOpts) (uint64, error) {
+	return uint64(spamGameDuration.Seconds()), nil
+}
+
+func (g *simulatedGame) ResolvedAt(_ *bind.CallOpts) (uint64, error) {
+	return 0, ErrResolvedAtUnavailable
+}
+
+func (g *simulatedGame) HeaderByNumber(_ context.Context, _ *big.Int) (*ethtypes.Header, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return &ethtypes.Header{Number: new(big.Int).SetUint64(g.number), Time: g.timestamp}, nil
+}
+
+func (g *simulatedGame) CallResolve(_ context.Context) (types.GameStatus, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.status != types.GameStatusInProgress {
+		return types.GameStatusInProgress, errGameNotInProgress
+	}
+	status, _ := g.outcome()
+	return status, nil
+}
+
+func (g *simulatedGame) Resolve(_ context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	status, ok := g.outcome()
+	if !ok || g.status != types.GameStatusInProgress {
+		return errGameNotInProgress
+	}
+	g.status = status
+	return nil
+}
+
+func (g *simulatedGame) CallResolveClaim(_ context.Context, _ uint64) error {
+	return errResolveClaimMissing
+}
+
+func (g *simulatedGame) ResolveClaim(_ context.Context, _ uint64) error {
+	return errResolveClaimMissing
+}
+
+func (g *simulatedGame) Respond(ctx context.Context, response types.Claim) error {
+	return g.send(ctx, simulatedTx{move: &response})
+}
+
+func (g *simulatedGame) Step(ctx context.Context, stepData types.StepCallData) error {
+	return g.send(ctx, simulatedTx{step: &stepData})
+}
+
+func (g *simulatedGame) EstimateRespondGas(_ context.Context, _ types.Claim) (uint64, error) {
+	return 0, nil
+}
+
+func (g *simulatedGame) EstimateStepGas(_ context.Context, _ types.StepCallData) (uint64, error) {
+	return 0, nil
+}
+
+func (g *simulatedGame) BaseFee(_ context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (g *simulatedGame) Spent() *big.Int {
+	return big.NewInt(0)
+}
@@ -0,0 +1,78 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type gameTypeLoader interface {
+	FetchGameDepth(ctx context.Context) (uint64, error)
+	FetchGameType(ctx context.Context) (uint8, error)
+}
+
+// LoadExpectedRootClaim returns the root claim the challenger configured by cfg expects for the game at addr.
+// The game's trace is generated in dir if needed.
+func LoadExpectedRootClaim(ctx context.Context, logger log.Logger, cfg *config.Config, dir string, addr common.Address, client L1Client) (common.Hash, error) {
+	loader, err := NewLoaderFromBindings(logger, addr, client, 0)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
+	}
+	// Trace providers only need a transaction manager to update the oracle, which doesn't happen here.
+	selector := NewTraceProviders(logger, cfg, dir, addr, nil, client, nil)
+	return loadExpectedRootClaim(ctx, loader, selector)
+}
+
+func loadExpectedRootClaim(ctx context.Context, loader gameTypeLoader, selector TraceProviderSelector) (common.Hash, error) {
+	gameType, err := loader.FetchGameType(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to fetch the game type: %w", ErrLoader, err)
+	}
+	gameDepth, err := loader.FetchGameDepth(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to fetch the game depth: %w", ErrLoader, err)
+	}
+	return ExpectedRootClaim(ctx, selector, gameType, gameDepth)
+}
+
+// ExpectedRootClaim returns the root claim value that a game of gameType and gameDepth must have for the challenger
+// to agree with it, using the trace provider selected for the game type. It is the value [AgreeWithRootClaim]
+// compares the root claim with, so the challenger defends a root claim with this value and attacks any other.
+func ExpectedRootClaim(ctx context.Context, selector TraceProviderSelector, gameType uint8, gameDepth uint64) (common.Hash, error) {
+	trace, err := createTraceProvider(ctx, selector, gameType, gameDepth)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if closer, ok := trace.(io.Closer); ok {
+		defer closer.Close()
+	}
+	return expectedRootClaim(ctx, trace, gameDepth)
+}
+
+// createTraceProvider creates the trace provider selected for gameType.
+func createTraceProvider(ctx context.Context, selector TraceProviderSelector, gameType uint8, gameDepth uint64) (types.TraceProvider, error) {
+	createProvider, ok := selector.SelectTraceProvider(gameType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedGameType, gameType)
+	}
+	trace, _, err := createProvider(ctx, gameDepth)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTraceProvider, err)
+	}
+	return trace, nil
+}
+
+// expectedRootClaim returns the trace's value at the root claim's trace index.
+func expectedRootClaim(ctx context.Context, trace types.TraceProvider, gameDepth uint64) (common.Hash, error) {
+	root := types.NewPositionFromGIndex(1)
+	value, err := trace.Get(ctx, root.TraceIndex(int(gameDepth)))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to get the trace at the root claim: %w", ErrTraceProvider, err)
+	}
+	return value, nil
+}
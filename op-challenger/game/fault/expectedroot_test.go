@@ -0,0 +1,88 @@
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedRootClaim(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	newSelector := func(t *testing.T, traceType config.TraceType) TraceProviders {
+		cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8545", []config.TraceType{traceType}, true, t.TempDir())
+		cfg.AlphabetTrace = "abcdefgh"
+		cfg.TraceFile = writeRootTestTrace(t)
+		return NewTraceProviders(logger, &cfg, t.TempDir(), common.Address{0xaa}, nil, nil, nil)
+	}
+
+	t.Run("Alphabet", func(t *testing.T) {
+		// The hash of the last alphabet state, h, at trace index 7.
+		expected := common.HexToHash("0x6e57c6880317692d1e241d4e588624849a414af4c7f958811b533447ec42aec8")
+		root, err := ExpectedRootClaim(context.Background(), newSelector(t, config.TraceTypeAlphabet), config.AlphabetFaultGameID, 3)
+		require.NoError(t, err)
+		require.Equal(t, expected, root)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		root, err := ExpectedRootClaim(context.Background(), newSelector(t, config.TraceTypeFile), config.AlphabetFaultGameID, 2)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0x03}, root)
+	})
+
+	t.Run("UnsupportedGameType", func(t *testing.T) {
+		_, err := ExpectedRootClaim(context.Background(), newSelector(t, config.TraceTypeAlphabet), config.CannonFaultGameID, 3)
+		require.ErrorIs(t, err, ErrUnsupportedGameType)
+	})
+
+	t.Run("TraceTooShort", func(t *testing.T) {
+		_, err := ExpectedRootClaim(context.Background(), newSelector(t, config.TraceTypeFile), config.AlphabetFaultGameID, 3)
+		require.ErrorIs(t, err, ErrTraceProvider)
+	})
+
+	t.Run("MatchesAgreeWithRootClaim", func(t *testing.T) {
+		selector := newSelector(t, config.TraceTypeAlphabet)
+		caller := &mockCaller{gameType: config.AlphabetFaultGameID, maxGameDepth: 3}
+		root, err := loadExpectedRootClaim(context.Background(), NewLoader(caller, nil), selector)
+		require.NoError(t, err)
+		caller.returnClaims = []ContractClaimData{{Claim: root, Position: common.Big1, ParentIndex: math.MaxUint32, Clock: common.Big0}}
+		trace, err := createTraceProvider(context.Background(), selector, config.AlphabetFaultGameID, 3)
+		require.NoError(t, err)
+		agree, err := AgreeWithRootClaim(context.Background(), trace, NewLoader(caller, nil), 3)
+		require.NoError(t, err)
+		require.True(t, agree)
+	})
+
+	t.Run("LoadError", func(t *testing.T) {
+		caller := &mockCaller{gameTypeError: true}
+		_, err := loadExpectedRootClaim(context.Background(), NewLoader(caller, nil), newSelector(t, config.TraceTypeAlphabet))
+		require.ErrorIs(t, err, ErrLoader)
+	})
+}
+
+// writeRootTestTrace writes a trace file with four proofs, where the claim at trace index i is the hash with i as its
+// first byte.
+func writeRootTestTrace(t *testing.T) string {
+	type proof struct {
+		Post      common.Hash `json:"post"`
+		StateData string      `json:"state-data"`
+	}
+	var proofs []proof
+	for i := 0; i < 4; i++ {
+		proofs = append(proofs, proof{Post: common.Hash{byte(i)}, StateData: "0xaa"})
+	}
+	proofs[0].Post = common.Hash{0xff}
+	data, err := json.Marshal(map[string]any{"absolute-pre-state": "0xaa", "proofs": proofs})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "trace.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
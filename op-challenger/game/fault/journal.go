@@ -0,0 +1,416 @@
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+const pendingActionsFile = "pending-actions.json"
+
+type PendingActionType string
+
+const (
+	PendingAttack PendingActionType = "attack"
+	PendingDefend PendingActionType = "defend"
+	PendingStep   PendingActionType = "step"
+)
+
+// PendingAction is a move or step sent to a game that may not have been mined yet.
+type PendingAction struct {
+	// ParentIndex is the contract index of the claim countered or stepped against.
+	ParentIndex int               `json:"parentIndex"`
+	Type        PendingActionType `json:"type"`
+	// Value is the claim posted by a move, zero for steps.
+	Value common.Hash `json:"value"`
+	// TxHash is the hash of the last transaction sent for the action, nil if it isn't known to have been sent.
+	TxHash *common.Hash `json:"txHash,omitempty"`
+}
+
+// matches returns true if a and o are the same action, regardless of the transactions sent for them.
+func (a PendingAction) matches(o PendingAction) bool {
+	return a.ParentIndex == o.ParentIndex && a.Type == o.Type && a.Value == o.Value
+}
+
+type ReconcileOutcome string
+
+const (
+	// ReconcileLanded is the outcome for actions whose transaction was mined, whether or not it succeeded.
+	ReconcileLanded ReconcileOutcome = "landed"
+	// ReconcileOnChain is the outcome for actions that are already on chain, such as from another challenger.
+	ReconcileOnChain ReconcileOutcome = "on_chain"
+	// ReconcilePending is the outcome for actions whose transaction is still waiting to be mined, so they stay in the
+	// journal and aren't made again while they may still land.
+	ReconcilePending ReconcileOutcome = "pending"
+	// ReconcileResubmit is the outcome for actions that didn't land, so the agent makes them again.
+	ReconcileResubmit ReconcileOutcome = "resubmit"
+)
+
+// ReconciledAction is a pending action and the outcome of reconciling it with the game's claims.
+type ReconciledAction struct {
+	PendingAction
+	Outcome ReconcileOutcome
+}
+
+// TxSource loads the receipts of transactions and whether they are still pending.
+type TxSource interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (tx *ethtypes.Transaction, isPending bool, err error)
+}
+
+// ActionJournal records the moves and steps sent to each game until they are mined. Each game's pending actions
+// are persisted in the game's data directory, so actions sent before a restart can be reconciled with the game's
+// claims instead of being sent again while the original transaction may still land.
+// It is safe for concurrent use by the players of different games.
+type ActionJournal struct {
+	logger     log.Logger
+	txs        TxSource
+	fdgAbi     *abi.ABI
+	dirForGame func(addr common.Address) string
+
+	mu sync.Mutex
+	// games caches the pending actions of each game that has been loaded.
+	games map[common.Address][]PendingAction
+}
+
+func NewActionJournal(logger log.Logger, txs TxSource, dirForGame func(addr common.Address) string) (*ActionJournal, error) {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &ActionJournal{
+		logger:     logger,
+		txs:        txs,
+		fdgAbi:     fdgAbi,
+		dirForGame: dirForGame,
+		games:      make(map[common.Address][]PendingAction),
+	}, nil
+}
+
+// Add records that action is about to be sent to game.
+func (j *ActionJournal) Add(game common.Address, action PendingAction) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	actions, err := j.load(game)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(actions, action.matches) {
+		return nil
+	}
+	return j.save(game, append(slices.Clone(actions), action))
+}
+
+// Remove records that action is no longer pending, as its transaction was mined or won't be.
+func (j *ActionJournal) Remove(game common.Address, action PendingAction) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	actions, err := j.load(game)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(actions, action.matches)
+	if i < 0 {
+		return nil
+	}
+	return j.save(game, slices.Delete(slices.Clone(actions), i, i+1))
+}
+
+// Pending returns the pending actions of game.
+func (j *ActionJournal) Pending(game common.Address) ([]PendingAction, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	actions, err := j.load(game)
+	return slices.Clone(actions), err
+}
+
+// RecordSent records the hash of tx against the pending action it was sent for, if any.
+// Transactions that aren't moves or steps of a game with pending actions are ignored.
+func (j *ActionJournal) RecordSent(tx *ethtypes.Transaction) {
+	if tx.To() == nil {
+		return
+	}
+	game := *tx.To()
+	action, ok := j.decodeAction(tx.Data())
+	if !ok {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	actions, err := j.load(game)
+	if err != nil {
+		j.logger.Error("Failed to load pending actions", "game", game, "err", err)
+		return
+	}
+	i := slices.IndexFunc(actions, action.matches)
+	if i < 0 {
+		return
+	}
+	updated := slices.Clone(actions)
+	txHash := tx.Hash()
+	updated[i].TxHash = &txHash
+	if err := j.save(game, updated); err != nil {
+		j.logger.Error("Failed to record pending action transaction", "game", game, "tx", txHash, "err", err)
+	}
+}
+
+// Reconcile decides what became of each of game's pending actions given the game's current claims, and removes
+// them from the journal unless their transaction is still pending. Actions whose transaction was mined or that are
+// already on chain are done, pending actions are reconciled again later and the others are to be made again by the
+// agent.
+// Actions are left in the journal if a transaction can't be loaded, so they are reconciled again later.
+func (j *ActionJournal) Reconcile(ctx context.Context, game common.Address, claims []types.Claim) ([]ReconciledAction, error) {
+	pending, err := j.Pending(game)
+	if err != nil {
+		return nil, err
+	}
+	reconciled := make([]ReconciledAction, 0, len(pending))
+	for _, action := range pending {
+		outcome, err := j.reconcile(ctx, action, claims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconcile %v of claim %v: %w", action.Type, action.ParentIndex, err)
+		}
+		reconciled = append(reconciled, ReconciledAction{PendingAction: action, Outcome: outcome})
+	}
+	for _, action := range reconciled {
+		if action.Outcome == ReconcilePending {
+			continue
+		}
+		if err := j.Remove(game, action.PendingAction); err != nil {
+			return nil, err
+		}
+	}
+	return reconciled, nil
+}
+
+func (j *ActionJournal) reconcile(ctx context.Context, action PendingAction, claims []types.Claim) (ReconcileOutcome, error) {
+	if action.TxHash != nil {
+		receipt, err := j.txs.TransactionReceipt(ctx, *action.TxHash)
+		if err == nil && receipt != nil {
+			return ReconcileLanded, nil
+		} else if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return "", fmt.Errorf("load receipt of %v: %w", *action.TxHash, err)
+		}
+	}
+	if onChain(action, claims) {
+		return ReconcileOnChain, nil
+	}
+	if action.TxHash != nil {
+		// Without a receipt the transaction may still be in the mempool, or mined since the receipt was loaded.
+		_, isPending, err := j.txs.TransactionByHash(ctx, *action.TxHash)
+		if err == nil {
+			if isPending {
+				return ReconcilePending, nil
+			}
+			return ReconcileLanded, nil
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return "", fmt.Errorf("load transaction %v: %w", *action.TxHash, err)
+		}
+	}
+	return ReconcileResubmit, nil
+}
+
+// onChain returns true if the game's claims already include the effect of action.
+func onChain(action PendingAction, claims []types.Claim) bool {
+	if action.ParentIndex < 0 || action.ParentIndex >= len(claims) {
+		return false
+	}
+	parent := claims[action.ParentIndex]
+	var position types.Position
+	switch action.Type {
+	case PendingStep:
+		return parent.Countered
+	case PendingAttack:
+		position = parent.Attack()
+	case PendingDefend:
+		position = parent.Defend()
+	default:
+		return false
+	}
+	return slices.ContainsFunc(claims, func(claim types.Claim) bool {
+		return claim.ParentContractIndex == action.ParentIndex && claim.Position == position && claim.Value == action.Value
+	})
+}
+
+// Clear removes all of game's pending actions, such as once the game is resolved.
+func (j *ActionJournal) Clear(game common.Address) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.games, game)
+	if err := os.Remove(filepath.Join(j.dirForGame(game), pendingActionsFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove pending actions: %w", err)
+	}
+	return nil
+}
+
+// decodeAction returns the pending action a transaction's data sends, or false if it isn't a move or step.
+func (j *ActionJournal) decodeAction(data []byte) (PendingAction, bool) {
+	if len(data) < 4 {
+		return PendingAction{}, false
+	}
+	method, err := j.fdgAbi.MethodById(data[:4])
+	if err != nil {
+		return PendingAction{}, false
+	}
+	var actionType PendingActionType
+	switch method.Name {
+	case "attack":
+		actionType = PendingAttack
+	case "defend":
+		actionType = PendingDefend
+	case "step":
+		actionType = PendingStep
+	default:
+		return PendingAction{}, false
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil || len(args) < 2 {
+		return PendingAction{}, false
+	}
+	index, ok := args[0].(*big.Int)
+	if !ok || !index.IsInt64() {
+		return PendingAction{}, false
+	}
+	action := PendingAction{ParentIndex: int(index.Int64()), Type: actionType}
+	if actionType != PendingStep {
+		value, ok := args[1].([32]byte)
+		if !ok {
+			return PendingAction{}, false
+		}
+		action.Value = value
+	}
+	return action, true
+}
+
+// load returns the cached pending actions of game, loading them from its data directory if not cached.
+func (j *ActionJournal) load(game common.Address) ([]PendingAction, error) {
+	if actions, ok := j.games[game]; ok {
+		return actions, nil
+	}
+	data, err := os.ReadFile(filepath.Join(j.dirForGame(game), pendingActionsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		j.games[game] = nil
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read pending actions: %w", err)
+	}
+	var actions []PendingAction
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return nil, fmt.Errorf("failed to parse pending actions: %w", err)
+	}
+	j.games[game] = actions
+	return actions, nil
+}
+
+func (j *ActionJournal) save(game common.Address, actions []PendingAction) error {
+	dir := j.dirForGame(game)
+	path := filepath.Join(dir, pendingActionsFile)
+	if len(actions) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove pending actions: %w", err)
+		}
+		j.games[game] = nil
+		return nil
+	}
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return fmt.Errorf("failed to encode pending actions: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create game dir: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write pending actions: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write pending actions: %w", err)
+	}
+	j.games[game] = actions
+	return nil
+}
+
+// journalingResponder records the moves and steps the agent makes in an [ActionJournal] until they are mined.
+// Actions reconciled as landed or pending aren't sent again, as the game's claims may not include them yet.
+type journalingResponder struct {
+	Responder
+	journal *ActionJournal
+	game    common.Address
+	logger  log.Logger
+
+	mu sync.Mutex
+	// sent are the actions sent before the player was created that landed or may still land.
+	sent []PendingAction
+}
+
+// Reconciled records the outcome of reconciling the actions sent before the player was created.
+func (r *journalingResponder) Reconciled(reconciled []ReconciledAction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, action := range reconciled {
+		i := slices.IndexFunc(r.sent, action.matches)
+		switch action.Outcome {
+		case ReconcileLanded, ReconcilePending:
+			if i < 0 {
+				r.sent = append(r.sent, action.PendingAction)
+			}
+		case ReconcileResubmit:
+			if i >= 0 {
+				r.sent = slices.Delete(r.sent, i, i+1)
+			}
+		}
+	}
+}
+
+func (r *journalingResponder) alreadySent(action PendingAction) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.ContainsFunc(r.sent, action.matches)
+}
+
+func (r *journalingResponder) Respond(ctx context.Context, response types.Claim) error {
+	action := PendingAction{ParentIndex: response.ParentContractIndex, Type: PendingAttack, Value: response.Value}
+	if response.DefendsParent() {
+		action.Type = PendingDefend
+	}
+	return r.send(ctx, action, func() error { return r.Responder.Respond(ctx, response) })
+}
+
+func (r *journalingResponder) Step(ctx context.Context, stepData types.StepCallData) error {
+	action := PendingAction{ParentIndex: int(stepData.ClaimIndex), Type: PendingStep}
+	return r.send(ctx, action, func() error { return r.Responder.Step(ctx, stepData) })
+}
+
+// send journals action while it is sent. The action stays in the journal if sending is interrupted by ctx, as the
+// transaction may still be mined after the challenger stops.
+func (r *journalingResponder) send(ctx context.Context, action PendingAction, send func() error) error {
+	if r.alreadySent(action) {
+		r.logger.Debug("Not resending action sent before restart", "type", action.Type, "parent", action.ParentIndex)
+		return nil
+	}
+	if err := r.journal.Add(r.game, action); err != nil {
+		r.logger.Error("Failed to journal pending action", "type", action.Type, "parent", action.ParentIndex, "err", err)
+	}
+	err := send()
+	if ctx.Err() != nil {
+		return err
+	}
+	if err := r.journal.Remove(r.game, action); err != nil {
+		r.logger.Error("Failed to remove pending action from journal", "type", action.Type, "parent", action.ParentIndex, "err", err)
+	}
+	return err
+}
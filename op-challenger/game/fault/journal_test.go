@@ -0,0 +1,261 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionJournal_Reconcile(t *testing.T) {
+	game := common.Address{0xaa}
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(1)}}
+	attack := types.Claim{
+		ClaimData:     types.ClaimData{Value: common.Hash{0x02}, Position: root.Attack()},
+		Countered:     true,
+		Parent:        root.ClaimData,
+		ContractIndex: 1,
+	}
+	claims := []types.Claim{root, attack}
+	landedTx := common.Hash{0xdd}
+	droppedTx := common.Hash{0xee}
+	landed := PendingAction{ParentIndex: 0, Type: PendingDefend, Value: common.Hash{0x03}, TxHash: &landedTx}
+	onChainMove := PendingAction{ParentIndex: 0, Type: PendingAttack, Value: attack.Value}
+	onChainStep := PendingAction{ParentIndex: 1, Type: PendingStep}
+	dropped := PendingAction{ParentIndex: 1, Type: PendingAttack, Value: common.Hash{0x04}, TxHash: &droppedTx}
+	differentValue := PendingAction{ParentIndex: 0, Type: PendingAttack, Value: common.Hash{0x05}}
+
+	receipts := &stubTxSource{receipts: map[common.Hash]*ethtypes.Receipt{landedTx: {TxHash: landedTx}}}
+	dir := t.TempDir()
+	journal := newTestActionJournal(t, receipts, dir)
+	for _, action := range []PendingAction{landed, onChainMove, onChainStep, dropped, differentValue} {
+		require.NoError(t, journal.Add(game, action))
+	}
+
+	// Reload from disk as after a restart.
+	journal = newTestActionJournal(t, receipts, dir)
+	reconciled, err := journal.Reconcile(context.Background(), game, claims)
+	require.NoError(t, err)
+	require.Equal(t, []ReconciledAction{
+		{PendingAction: landed, Outcome: ReconcileLanded},
+		{PendingAction: onChainMove, Outcome: ReconcileOnChain},
+		{PendingAction: onChainStep, Outcome: ReconcileOnChain},
+		{PendingAction: dropped, Outcome: ReconcileResubmit},
+		{PendingAction: differentValue, Outcome: ReconcileResubmit},
+	}, reconciled)
+	pending, err := journal.Pending(game)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should remove reconciled actions")
+	require.NoFileExists(t, filepath.Join(dir, game.Hex(), pendingActionsFile))
+
+	t.Run("KeepPendingTransaction", func(t *testing.T) {
+		pendingTx := common.Hash{0xff}
+		inMempool := PendingAction{ParentIndex: 1, Type: PendingDefend, Value: common.Hash{0x06}, TxHash: &pendingTx}
+		txs := &stubTxSource{pending: map[common.Hash]bool{pendingTx: true}}
+		dir := t.TempDir()
+		journal := newTestActionJournal(t, txs, dir)
+		require.NoError(t, journal.Add(game, dropped))
+		require.NoError(t, journal.Add(game, inMempool))
+
+		journal = newTestActionJournal(t, txs, dir)
+		reconciled, err := journal.Reconcile(context.Background(), game, claims)
+		require.NoError(t, err)
+		require.Equal(t, []ReconciledAction{
+			{PendingAction: dropped, Outcome: ReconcileResubmit},
+			{PendingAction: inMempool, Outcome: ReconcilePending},
+		}, reconciled)
+		pending, err := journal.Pending(game)
+		require.NoError(t, err)
+		require.Equal(t, []PendingAction{inMempool}, pending, "should keep actions with a pending transaction")
+
+		// Once the transaction is mined the action is done.
+		txs.receipts = map[common.Hash]*ethtypes.Receipt{pendingTx: {TxHash: pendingTx}}
+		reconciled, err = journal.Reconcile(context.Background(), game, claims)
+		require.NoError(t, err)
+		require.Equal(t, []ReconciledAction{{PendingAction: inMempool, Outcome: ReconcileLanded}}, reconciled)
+		pending, err = journal.Pending(game)
+		require.NoError(t, err)
+		require.Empty(t, pending)
+	})
+
+	t.Run("KeepWhenReceiptUnavailable", func(t *testing.T) {
+		txs := &stubTxSource{err: errors.New("boom")}
+		journal := newTestActionJournal(t, txs, t.TempDir())
+		require.NoError(t, journal.Add(game, landed))
+		_, err := journal.Reconcile(context.Background(), game, claims)
+		require.ErrorIs(t, err, txs.err)
+		pending, err := journal.Pending(game)
+		require.NoError(t, err)
+		require.Equal(t, []PendingAction{landed}, pending)
+	})
+}
+
+func TestActionJournal_RecordSent(t *testing.T) {
+	game := common.Address{0xaa}
+	journal := newTestActionJournal(t, &stubTxSource{}, t.TempDir())
+	attack := PendingAction{ParentIndex: 2, Type: PendingAttack, Value: common.Hash{0x01}}
+	step := PendingAction{ParentIndex: 3, Type: PendingStep}
+	require.NoError(t, journal.Add(game, attack))
+	require.NoError(t, journal.Add(game, step))
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	newTx := func(to common.Address, nonce uint64, method string, args ...interface{}) *ethtypes.Transaction {
+		data, err := fdgAbi.Pack(method, args...)
+		require.NoError(t, err)
+		return ethtypes.NewTx(&ethtypes.LegacyTx{To: &to, Nonce: nonce, Data: data})
+	}
+	attackTx := newTx(game, 1, "attack", big.NewInt(2), common.Hash{0x01})
+	stepTx := newTx(game, 2, "step", big.NewInt(3), true, []byte{1}, []byte{2})
+	journal.RecordSent(attackTx)
+	journal.RecordSent(stepTx)
+	// Transactions for other actions or games are ignored.
+	journal.RecordSent(newTx(game, 3, "defend", big.NewInt(2), common.Hash{0x01}))
+	journal.RecordSent(newTx(common.Address{0xbb}, 4, "attack", big.NewInt(2), common.Hash{0x01}))
+	journal.RecordSent(ethtypes.NewTx(&ethtypes.LegacyTx{To: &game, Nonce: 5, Data: []byte{1, 2}}))
+
+	pending, err := journal.Pending(game)
+	require.NoError(t, err)
+	attackHash, stepHash := attackTx.Hash(), stepTx.Hash()
+	attack.TxHash = &attackHash
+	step.TxHash = &stepHash
+	require.Equal(t, []PendingAction{attack, step}, pending)
+
+	require.NoError(t, journal.Clear(game))
+	pending, err = journal.Pending(game)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func TestJournalingResponder(t *testing.T) {
+	game := common.Address{0xaa}
+	dir := t.TempDir()
+	response := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(2)},
+		ParentContractIndex: 0,
+	}
+	newResponder := func(inner Responder) (*journalingResponder, *ActionJournal) {
+		journal := newTestActionJournal(t, &stubTxSource{}, dir)
+		return &journalingResponder{Responder: inner, journal: journal, game: game, logger: testlog.Logger(t, log.LvlCrit)}, journal
+	}
+
+	t.Run("RemoveOnceSent", func(t *testing.T) {
+		inner := &journalCheckingResponder{}
+		responder, journal := newResponder(inner)
+		inner.journal = journal
+		require.NoError(t, responder.Respond(context.Background(), response))
+		require.NoError(t, responder.Step(context.Background(), types.StepCallData{ClaimIndex: 1}))
+		require.Equal(t, [][]PendingAction{
+			{{ParentIndex: 0, Type: PendingAttack, Value: response.Value}},
+			{{ParentIndex: 1, Type: PendingStep}},
+		}, inner.pending, "should journal actions while they are sent")
+		pending, err := journal.Pending(game)
+		require.NoError(t, err)
+		require.Empty(t, pending)
+	})
+
+	t.Run("KeepWhenInterrupted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		inner := &journalCheckingResponder{err: context.Canceled, onSend: cancel}
+		responder, journal := newResponder(inner)
+		inner.journal = journal
+		require.ErrorIs(t, responder.Respond(ctx, response), context.Canceled)
+		pending, err := newTestActionJournal(t, &stubTxSource{}, dir).Pending(game)
+		require.NoError(t, err)
+		require.Equal(t, []PendingAction{{ParentIndex: 0, Type: PendingAttack, Value: response.Value}}, pending)
+	})
+
+	t.Run("SkipReconciledActions", func(t *testing.T) {
+		inner := &journalCheckingResponder{}
+		responder, journal := newResponder(inner)
+		inner.journal = journal
+		attack := PendingAction{ParentIndex: 0, Type: PendingAttack, Value: response.Value}
+		step := PendingAction{ParentIndex: 1, Type: PendingStep}
+		responder.Reconciled([]ReconciledAction{
+			{PendingAction: attack, Outcome: ReconcilePending},
+			{PendingAction: step, Outcome: ReconcileLanded},
+		})
+		require.NoError(t, responder.Respond(context.Background(), response))
+		require.NoError(t, responder.Step(context.Background(), types.StepCallData{ClaimIndex: 1}))
+		require.Empty(t, inner.pending, "should not resend actions that landed or are pending")
+
+		// A pending transaction that is dropped is sent again.
+		responder.Reconciled([]ReconciledAction{{PendingAction: attack, Outcome: ReconcileResubmit}})
+		require.NoError(t, responder.Respond(context.Background(), response))
+		require.Len(t, inner.pending, 1)
+	})
+}
+
+func newTestActionJournal(t *testing.T, txs TxSource, dir string) *ActionJournal {
+	journal, err := NewActionJournal(testlog.Logger(t, log.LvlCrit), txs, func(addr common.Address) string {
+		return filepath.Join(dir, addr.Hex())
+	})
+	require.NoError(t, err)
+	return journal
+}
+
+type stubTxSource struct {
+	receipts map[common.Hash]*ethtypes.Receipt
+	// pending are the transactions without receipts that are still pending.
+	pending map[common.Hash]bool
+	err     error
+}
+
+func (s *stubTxSource) TransactionByHash(_ context.Context, txHash common.Hash) (*ethtypes.Transaction, bool, error) {
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	if s.pending[txHash] {
+		return ethtypes.NewTx(&ethtypes.LegacyTx{}), true, nil
+	}
+	return nil, false, ethereum.NotFound
+}
+
+func (s *stubTxSource) TransactionReceipt(_ context.Context, txHash common.Hash) (*ethtypes.Receipt, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if receipt, ok := s.receipts[txHash]; ok {
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// journalCheckingResponder records the journal's pending actions each time an action is sent.
+type journalCheckingResponder struct {
+	Responder
+	journal *ActionJournal
+	pending [][]PendingAction
+	err     error
+	onSend  func()
+}
+
+func (r *journalCheckingResponder) record() error {
+	pending, err := r.journal.Pending(common.Address{0xaa})
+	if err != nil {
+		return err
+	}
+	r.pending = append(r.pending, pending)
+	if r.onSend != nil {
+		r.onSend()
+	}
+	return r.err
+}
+
+func (r *journalCheckingResponder) Respond(_ context.Context, _ types.Claim) error {
+	return r.record()
+}
+
+func (r *journalCheckingResponder) Step(_ context.Context, _ types.StepCallData) error {
+	return r.record()
+}
@@ -1,51 +1,102 @@
 package fault
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
-	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/exp/slices"
 )
 
+// ErrClaimIndexOutOfRange is returned when reading a claim reverts because its index is beyond the claims in the game.
+var (
+	ErrClaimIndexOutOfRange = errors.New("claim index out of range")
+	// ErrResolvingBlockUnknown is returned when the contract doesn't record when the game resolved and the game
+	// wasn't seen in progress, so there is no block to search for the resolving block from.
+	ErrResolvingBlockUnknown = errors.New("resolving block unknown")
+)
+
+// panicArrayOutOfBounds is the code of the Solidity Panic(uint256) raised when accessing an array out of bounds.
+const panicArrayOutOfBounds = 0x32
+
+// panicSelector is the selector of the Solidity Panic(uint256) error.
+var panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
 // MinimalFaultDisputeGameCaller is a minimal interface around [bindings.FaultDisputeGameCaller].
 // This needs to be updated if the [bindings.FaultDisputeGameCaller] interface changes.
+// ClaimData returns [ContractClaimData] so that claims can be read from all supported contract versions.
 type MinimalFaultDisputeGameCaller interface {
-	ClaimData(opts *bind.CallOpts, arg0 *big.Int) (struct {
-		ParentIndex uint32
-		Countered   bool
-		Claim       [32]byte
-		Position    *big.Int
-		Clock       *big.Int
-	}, error)
+	ClaimData(opts *bind.CallOpts, arg0 *big.Int) (ContractClaimData, error)
 	Status(opts *bind.CallOpts) (uint8, error)
 	ClaimDataLen(opts *bind.CallOpts) (*big.Int, error)
 	MAXGAMEDEPTH(opts *bind.CallOpts) (*big.Int, error)
 	ABSOLUTEPRESTATE(opts *bind.CallOpts) ([32]byte, error)
+	GameType(opts *bind.CallOpts) (uint8, error)
+	GAMEDURATION(opts *bind.CallOpts) (uint64, error)
+	// ResolvedAt returns [ErrResolvedAtUnavailable] for contract versions that don't record the resolution time.
+	ResolvedAt(opts *bind.CallOpts) (uint64, error)
+}
+
+// HeaderSource provides L1 block headers so claims can be loaded at a specific block.
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
+// L1Client is the L1 client used to read game contracts and the L1 chain.
+type L1Client interface {
+	bind.ContractCaller
+	HeaderSource
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 }
 
 // loader pulls in fault dispute game claim data periodically and over subscriptions.
 type loader struct {
-	caller MinimalFaultDisputeGameCaller
+	log     log.Logger
+	caller  MinimalFaultDisputeGameCaller
+	headers HeaderSource
+	// concurrency is the maximum number of claims to fetch at once.
+	concurrency uint
 }
 
-// NewLoader creates a new [loader].
-func NewLoader(caller MinimalFaultDisputeGameCaller) *loader {
+// NewLoader creates a new [loader] that fetches up to [config.DefaultClaimLoadConcurrency] claims at once.
+// If headers is nil, claims are loaded from the latest block without being pinned to a specific block.
+func NewLoader(caller MinimalFaultDisputeGameCaller, headers HeaderSource) *loader {
 	return &loader{
-		caller: caller,
+		log:         log.Root(),
+		caller:      caller,
+		headers:     headers,
+		concurrency: config.DefaultClaimLoadConcurrency,
 	}
 }
 
 // NewLoaderFromBindings creates a new [loader] from a [bindings.FaultDisputeGameCaller].
-func NewLoaderFromBindings(fdgAddr common.Address, client bind.ContractCaller) (*loader, error) {
-	caller, err := bindings.NewFaultDisputeGameCaller(fdgAddr, client)
+// Up to concurrency claims are fetched at once, or [config.DefaultClaimLoadConcurrency] if it is 0.
+func NewLoaderFromBindings(logger log.Logger, fdgAddr common.Address, client L1Client, concurrency uint) (*loader, error) {
+	caller, err := newGameCaller(fdgAddr, client)
 	if err != nil {
 		return nil, err
 	}
-	return NewLoader(caller), nil
+	loader := NewLoader(caller, client)
+	loader.log = logger
+	if concurrency > 0 {
+		loader.concurrency = concurrency
+	}
+	return loader, nil
 }
 
 // GetGameStatus returns the current game status.
@@ -54,6 +105,51 @@ func (l *loader) GetGameStatus(ctx context.Context) (types.GameStatus, error) {
 	return types.GameStatus(status), err
 }
 
+// GetGameStatusAt returns the game status as of the L1 block with the given number.
+func (l *loader) GetGameStatusAt(ctx context.Context, block *big.Int) (types.GameStatus, error) {
+	status, err := l.caller.Status(&bind.CallOpts{Context: ctx, BlockNumber: block})
+	return types.GameStatus(status), err
+}
+
+// FetchResolvedAt returns when the game resolved, given it had resolved by L1 block to.
+// The contract's resolvedAt timestamp is used where the contract version records it. Otherwise the first block from
+// from onwards with a terminal status is found by searching the game status and its timestamp is returned, so from
+// must be a block the game hadn't resolved at for the result to be exact. If from is 0 the game wasn't seen in
+// progress, so [ErrResolvingBlockUnknown] is returned rather than searching from genesis, which needs archive state.
+func (l *loader) FetchResolvedAt(ctx context.Context, from uint64, to uint64) (time.Time, error) {
+	resolvedAt, err := l.caller.ResolvedAt(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(to)})
+	if err == nil && resolvedAt > 0 {
+		return time.Unix(int64(resolvedAt), 0), nil
+	} else if err != nil && !errors.Is(err, ErrResolvedAtUnavailable) {
+		return time.Time{}, fmt.Errorf("failed to fetch resolved at: %w", err)
+	}
+	if from == 0 {
+		return time.Time{}, ErrResolvingBlockUnknown
+	}
+	if l.headers == nil {
+		return time.Time{}, errors.New("no header source to find the resolving block")
+	}
+	// The status can only change from in progress to resolved so the resolving block is found by bisection.
+	lo, hi := from, to
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		status, err := l.GetGameStatusAt(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to fetch game status at block %v: %w", mid, err)
+		}
+		if status == types.GameStatusInProgress {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	header, err := l.headers.HeaderByNumber(ctx, new(big.Int).SetUint64(hi))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch L1 block %v: %w", hi, err)
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
 // GetClaimCount returns the number of claims in the game.
 func (l *loader) GetClaimCount(ctx context.Context) (uint64, error) {
 	count, err := l.caller.ClaimDataLen(&bind.CallOpts{Context: ctx})
@@ -63,6 +159,20 @@ func (l *loader) GetClaimCount(ctx context.Context) (uint64, error) {
 	return count.Uint64(), nil
 }
 
+// FetchGameDuration fetches the total duration of the game, which is split equally between the two chess clocks.
+func (l *loader) FetchGameDuration(ctx context.Context) (time.Duration, error) {
+	duration, err := l.caller.GAMEDURATION(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(duration) * time.Second, nil
+}
+
+// FetchGameType fetches the game type identifying the kind of trace used by the game.
+func (l *loader) FetchGameType(ctx context.Context) (uint8, error) {
+	return l.caller.GameType(&bind.CallOpts{Context: ctx})
+}
+
 // FetchGameDepth fetches the game depth from the fault dispute game.
 func (l *loader) FetchGameDepth(ctx context.Context) (uint64, error) {
 	callOpts := bind.CallOpts{
@@ -78,13 +188,17 @@ func (l *loader) FetchGameDepth(ctx context.Context) (uint64, error) {
 }
 
 // fetchClaim fetches a single [Claim] with a hydrated parent.
-func (l *loader) fetchClaim(ctx context.Context, arrIndex uint64) (types.Claim, error) {
+// Returns [ErrClaimIndexOutOfRange] if the read reverts because arrIndex is beyond the claims in the game.
+func (l *loader) fetchClaim(ctx context.Context, block *big.Int, arrIndex uint64) (types.Claim, error) {
 	callOpts := bind.CallOpts{
-		Context: ctx,
+		Context:     ctx,
+		BlockNumber: block,
 	}
 
 	fetchedClaim, err := l.caller.ClaimData(&callOpts, new(big.Int).SetUint64(arrIndex))
-	if err != nil {
+	if isIndexOutOfRange(err) {
+		return types.Claim{}, fmt.Errorf("%w: %w", ErrClaimIndexOutOfRange, err)
+	} else if err != nil {
 		return types.Claim{}, err
 	}
 
@@ -94,7 +208,10 @@ func (l *loader) fetchClaim(ctx context.Context, arrIndex uint64) (types.Claim,
 			Position: types.NewPositionFromGIndex(fetchedClaim.Position.Uint64()),
 		},
 		Countered:           fetchedClaim.Countered,
+		Claimant:            fetchedClaim.Claimant,
+		Bond:                fetchedClaim.Bond,
 		Clock:               fetchedClaim.Clock.Uint64(),
+		ClockDuration:       time.Duration(new(big.Int).Rsh(fetchedClaim.Clock, 64).Uint64()) * time.Second,
 		ContractIndex:       int(arrIndex),
 		ParentContractIndex: int(fetchedClaim.ParentIndex),
 	}
@@ -114,27 +231,178 @@ func (l *loader) fetchClaim(ctx context.Context, arrIndex uint64) (types.Claim,
 	return claim, nil
 }
 
+// FetchRootClaim fetches the root claim of the fault dispute game.
+func (l *loader) FetchRootClaim(ctx context.Context) (types.Claim, error) {
+	return l.fetchClaim(ctx, nil, 0)
+}
+
 // FetchClaims fetches all claims from the fault dispute game.
-func (l *loader) FetchClaims(ctx context.Context) ([]types.Claim, error) {
+// All claims are loaded at the same L1 block which is returned so callers can detect reorgs.
+// The returned block is empty if the loader has no [HeaderSource].
+func (l *loader) FetchClaims(ctx context.Context) ([]types.Claim, eth.L1BlockRef, error) {
+	var block eth.L1BlockRef
+	var blockNum *big.Int
+	if l.headers != nil {
+		head, err := l.headers.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 head: %w", err)
+		}
+		block = eth.L1BlockRef{
+			Hash:       head.Hash(),
+			Number:     head.Number.Uint64(),
+			ParentHash: head.ParentHash,
+			Time:       head.Time,
+		}
+		blockNum = head.Number
+	}
+
 	// Get the current claim count.
 	claimCount, err := l.caller.ClaimDataLen(&bind.CallOpts{
-		Context: ctx,
+		Context:     ctx,
+		BlockNumber: blockNum,
 	})
+	if err != nil {
+		return nil, eth.L1BlockRef{}, err
+	}
+
+	// Only the claims counted above are fetched, even if more are added while loading.
+	count := claimCount.Uint64()
+	claimList, truncated, err := l.fetchClaims(ctx, blockNum, count)
+	if err != nil {
+		return nil, eth.L1BlockRef{}, err
+	}
+	if truncated {
+		l.log.Warn("Claim index out of range, using the claims loaded before it", "count", count, "loaded", len(claimList))
+	}
+	return claimList, block, nil
+}
+
+// BuildClaimTree fetches all claims from the fault dispute game and returns them as a tree, with each claim a child
+// of the claim it counters.
+func (l *loader) BuildClaimTree(ctx context.Context) (*types.ClaimTree, error) {
+	claims, _, err := l.FetchClaims(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return types.NewClaimTree(claims)
+}
 
-	// Fetch each claim and build a list.
-	claimList := make([]types.Claim, claimCount.Uint64())
-	for i := uint64(0); i < claimCount.Uint64(); i++ {
-		claim, err := l.fetchClaim(ctx, i)
-		if err != nil {
-			return nil, err
+// fetchClaims fetches the first count claims using up to l.concurrency concurrent calls.
+// The claims are returned in index order. The first failure cancels the remaining fetches and the returned
+// error wraps it, listing every index that failed before the fetches stopped.
+// If reading a claim reverts because its index is out of range, the claims before it are returned and truncated
+// is true. This happens when the count and claims are read at different blocks and the game has fewer claims
+// when the claims are read.
+func (l *loader) fetchClaims(ctx context.Context, block *big.Int, count uint64) (claims []types.Claim, truncated bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	claimList := make([]types.Claim, count)
+	var mu sync.Mutex
+	var firstErr error
+	var failed []uint64
+	// truncateAt is the lowest index found to be out of range. Claims from it onwards aren't fetched.
+	truncateAt := count
+	beyondTruncation := func(i uint64) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return i >= truncateAt
+	}
+	fail := func(i uint64, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if errors.Is(err, ErrClaimIndexOutOfRange) {
+			if i < truncateAt {
+				truncateAt = i
+			}
+			return
+		}
+		if firstErr != nil && errors.Is(err, context.Canceled) {
+			// Cancelled because an earlier fetch failed.
+			return
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("claim %v: %w", i, err)
+			cancel()
 		}
-		claimList[i] = claim
+		failed = append(failed, i)
 	}
 
-	return claimList, nil
+	indices := make(chan uint64)
+	var wg sync.WaitGroup
+	workers := uint64(l.concurrency)
+	if workers == 0 {
+		workers = 1
+	}
+	if workers > count {
+		workers = count
+	}
+	for w := uint64(0); w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if ctx.Err() != nil || beyondTruncation(i) {
+					continue
+				}
+				claim, err := l.fetchClaim(ctx, block, i)
+				if err != nil {
+					fail(i, err)
+					continue
+				}
+				claimList[i] = claim
+			}
+		}()
+	}
+dispatch:
+	for i := uint64(0); i < count; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if firstErr != nil {
+		slices.Sort(failed)
+		return nil, false, fmt.Errorf("failed to load claims at indices %v: %w", failed, firstErr)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	return claimList[:truncateAt], truncateAt < count, nil
+}
+
+// isIndexOutOfRange returns true if err is a revert caused by accessing an array out of bounds.
+func isIndexOutOfRange(err error) bool {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil || len(data) != len(panicSelector)+32 || !bytes.Equal(data[:len(panicSelector)], panicSelector) {
+		return false
+	}
+	code := new(big.Int).SetBytes(data[len(panicSelector):])
+	return code.IsUint64() && code.Uint64() == panicArrayOutOfBounds
+}
+
+// BlockHashAt returns the hash of the canonical L1 block at the specified number.
+func (l *loader) BlockHashAt(ctx context.Context, number uint64) (common.Hash, error) {
+	if l.headers == nil {
+		return common.Hash{}, fmt.Errorf("no header source to load block %v", number)
+	}
+	header, err := l.headers.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch L1 block %v: %w", number, err)
+	}
+	return header.Hash(), nil
 }
 
 // FetchAbsolutePrestateHash fetches the hashed absolute prestate from the fault dispute game.
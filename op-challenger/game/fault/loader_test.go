@@ -3,13 +3,21 @@ package fault
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,6 +27,7 @@ var (
 	mockMaxGameDepthError = fmt.Errorf("max game depth errored")
 	mockPrestateError     = fmt.Errorf("prestate errored")
 	mockStatusError       = fmt.Errorf("status errored")
+	mockGameTypeError     = fmt.Errorf("game type errored")
 )
 
 // TestLoader_GetGameStatus tests fetching the game status.
@@ -51,7 +60,7 @@ func TestLoader_GetGameStatus(t *testing.T) {
 			mockCaller := newMockCaller()
 			mockCaller.status = test.status
 			mockCaller.statusError = test.expectedError
-			loader := NewLoader(mockCaller)
+			loader := NewLoader(mockCaller, nil)
 			status, err := loader.GetGameStatus(context.Background())
 			if test.expectedError {
 				require.ErrorIs(t, err, mockStatusError)
@@ -63,12 +72,23 @@ func TestLoader_GetGameStatus(t *testing.T) {
 	}
 }
 
+// TestLoader_GetGameStatusAt tests fetching the game status at a specific block.
+func TestLoader_GetGameStatusAt(t *testing.T) {
+	mockCaller := newMockCaller()
+	mockCaller.status = uint8(types.GameStatusDefenderWon)
+	loader := NewLoader(mockCaller, nil)
+	status, err := loader.GetGameStatusAt(context.Background(), big.NewInt(42))
+	require.NoError(t, err)
+	require.Equal(t, types.GameStatusDefenderWon, status)
+	require.Equal(t, []*big.Int{big.NewInt(42)}, mockCaller.blockNumbers)
+}
+
 // TestLoader_FetchGameDepth tests fetching the game depth.
 func TestLoader_FetchGameDepth(t *testing.T) {
 	t.Run("Succeeds", func(t *testing.T) {
 		mockCaller := newMockCaller()
 		mockCaller.maxGameDepth = 10
-		loader := NewLoader(mockCaller)
+		loader := NewLoader(mockCaller, nil)
 		depth, err := loader.FetchGameDepth(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, uint64(10), depth)
@@ -77,18 +97,38 @@ func TestLoader_FetchGameDepth(t *testing.T) {
 	t.Run("Errors", func(t *testing.T) {
 		mockCaller := newMockCaller()
 		mockCaller.maxGameDepthError = true
-		loader := NewLoader(mockCaller)
+		loader := NewLoader(mockCaller, nil)
 		depth, err := loader.FetchGameDepth(context.Background())
 		require.ErrorIs(t, mockMaxGameDepthError, err)
 		require.Equal(t, depth, uint64(0))
 	})
 }
 
+// TestLoader_FetchGameType tests fetching the game type.
+func TestLoader_FetchGameType(t *testing.T) {
+	t.Run("Succeeds", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.gameType = 255
+		loader := NewLoader(mockCaller, nil)
+		gameType, err := loader.FetchGameType(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint8(255), gameType)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.gameTypeError = true
+		loader := NewLoader(mockCaller, nil)
+		_, err := loader.FetchGameType(context.Background())
+		require.ErrorIs(t, err, mockGameTypeError)
+	})
+}
+
 // TestLoader_FetchAbsolutePrestateHash tests fetching the absolute prestate hash.
 func TestLoader_FetchAbsolutePrestateHash(t *testing.T) {
 	t.Run("Succeeds", func(t *testing.T) {
 		mockCaller := newMockCaller()
-		loader := NewLoader(mockCaller)
+		loader := NewLoader(mockCaller, nil)
 		prestate, err := loader.FetchAbsolutePrestateHash(context.Background())
 		require.NoError(t, err)
 		require.ElementsMatch(t, common.HexToHash("0xdEad"), prestate)
@@ -97,20 +137,30 @@ func TestLoader_FetchAbsolutePrestateHash(t *testing.T) {
 	t.Run("Errors", func(t *testing.T) {
 		mockCaller := newMockCaller()
 		mockCaller.prestateError = true
-		loader := NewLoader(mockCaller)
+		loader := NewLoader(mockCaller, nil)
 		prestate, err := loader.FetchAbsolutePrestateHash(context.Background())
-		require.Error(t, err)
+		require.ErrorIs(t, err, mockPrestateError)
 		require.ElementsMatch(t, common.Hash{}, prestate)
 	})
 }
 
+// TestLoader_FetchRootClaim tests fetching the root claim.
+func TestLoader_FetchRootClaim(t *testing.T) {
+	mockCaller := newMockCaller()
+	loader := NewLoader(mockCaller, nil)
+	root, err := loader.FetchRootClaim(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, common.Hash(mockCaller.returnClaims[0].Claim), root.Value)
+	require.Equal(t, 0, root.ContractIndex)
+}
+
 // TestLoader_FetchClaims tests fetching claims.
 func TestLoader_FetchClaims(t *testing.T) {
 	t.Run("Succeeds", func(t *testing.T) {
 		mockCaller := newMockCaller()
 		expectedClaims := mockCaller.returnClaims
-		loader := NewLoader(mockCaller)
-		claims, err := loader.FetchClaims(context.Background())
+		loader := NewLoader(mockCaller, nil)
+		claims, _, err := loader.FetchClaims(context.Background())
 		require.NoError(t, err)
 		require.ElementsMatch(t, []types.Claim{
 			{
@@ -158,8 +208,8 @@ func TestLoader_FetchClaims(t *testing.T) {
 	t.Run("Claim Data Errors", func(t *testing.T) {
 		mockCaller := newMockCaller()
 		mockCaller.claimDataError = true
-		loader := NewLoader(mockCaller)
-		claims, err := loader.FetchClaims(context.Background())
+		loader := NewLoader(mockCaller, nil)
+		claims, _, err := loader.FetchClaims(context.Background())
 		require.ErrorIs(t, err, mockClaimDataError)
 		require.Empty(t, claims)
 	})
@@ -167,40 +217,291 @@ func TestLoader_FetchClaims(t *testing.T) {
 	t.Run("Claim Len Errors", func(t *testing.T) {
 		mockCaller := newMockCaller()
 		mockCaller.claimLenError = true
-		loader := NewLoader(mockCaller)
-		claims, err := loader.FetchClaims(context.Background())
+		loader := NewLoader(mockCaller, nil)
+		claims, _, err := loader.FetchClaims(context.Background())
 		require.ErrorIs(t, err, mockClaimLenError)
 		require.Empty(t, claims)
 	})
 }
 
+// TestLoader_FetchClaims_PinnedToBlock tests that all claims are loaded at the current L1 head.
+func TestLoader_FetchClaims_PinnedToBlock(t *testing.T) {
+	mockCaller := newMockCaller()
+	head := &ethtypes.Header{Number: big.NewInt(42)}
+	loader := NewLoader(mockCaller, &stubHeaderSource{headers: map[uint64]*ethtypes.Header{42: head}, head: head})
+	claims, block, err := loader.FetchClaims(context.Background())
+	require.NoError(t, err)
+	require.Len(t, claims, 3)
+	require.Equal(t, eth.L1BlockRef{Hash: head.Hash(), Number: 42}, block)
+	require.Len(t, mockCaller.blockNumbers, 4)
+	for _, num := range mockCaller.blockNumbers {
+		require.Equal(t, big.NewInt(42), num)
+	}
+
+	hash, err := loader.BlockHashAt(context.Background(), 42)
+	require.NoError(t, err)
+	require.Equal(t, head.Hash(), hash)
+}
+
+// TestLoader_FetchClaims_Concurrent tests that claims fetched concurrently are returned in index order.
+func TestLoader_FetchClaims_Concurrent(t *testing.T) {
+	mockCaller := newMockCallerWithClaims(25)
+	for _, concurrency := range []uint{1, 4, 10, 100} {
+		concurrency := concurrency
+		t.Run(fmt.Sprintf("Concurrency-%v", concurrency), func(t *testing.T) {
+			loader := NewLoader(mockCaller, nil)
+			loader.concurrency = concurrency
+			claims, _, err := loader.FetchClaims(context.Background())
+			require.NoError(t, err)
+			require.Len(t, claims, 25)
+			for i, claim := range claims {
+				require.Equal(t, mockCaller.returnClaims[i].Claim, [32]byte(claim.Value))
+				require.Equal(t, i, claim.ContractIndex)
+			}
+		})
+	}
+}
+
+// TestLoader_FetchClaims_CancelOnFailure tests that a failed fetch stops the remaining claims being fetched.
+func TestLoader_FetchClaims_CancelOnFailure(t *testing.T) {
+	mockCaller := newMockCallerWithClaims(25)
+	mockCaller.failClaims = map[uint64]bool{2: true}
+	loader := NewLoader(mockCaller, nil)
+	loader.concurrency = 1
+	claims, _, err := loader.FetchClaims(context.Background())
+	require.ErrorIs(t, err, mockClaimDataError)
+	require.ErrorContains(t, err, "indices [2]")
+	require.Empty(t, claims)
+	// One call for the claim count and one for each claim up to the failure.
+	require.Len(t, mockCaller.blockNumbers, 4)
+}
+
+// TestLoader_FetchClaims_TruncateOutOfRange tests that claims up to an index that is out of range are returned.
+func TestLoader_FetchClaims_TruncateOutOfRange(t *testing.T) {
+	for _, concurrency := range []uint{1, 4, 100} {
+		concurrency := concurrency
+		t.Run(fmt.Sprintf("Concurrency-%v", concurrency), func(t *testing.T) {
+			mockCaller := newMockCallerWithClaims(25)
+			mockCaller.revertClaims = map[uint64]bool{12: true, 20: true}
+			loader := NewLoader(mockCaller, nil)
+			loader.concurrency = concurrency
+
+			claims, truncated, err := loader.fetchClaims(context.Background(), nil, 25)
+			require.NoError(t, err)
+			require.True(t, truncated)
+			require.Len(t, claims, 12)
+			for i, claim := range claims {
+				require.Equal(t, mockCaller.returnClaims[i].Claim, [32]byte(claim.Value))
+			}
+		})
+	}
+
+	t.Run("LogTruncation", func(t *testing.T) {
+		mockCaller := newMockCallerWithClaims(5)
+		mockCaller.revertClaims = map[uint64]bool{2: true}
+		loader := NewLoader(mockCaller, nil)
+		logger := testlog.Logger(t, log.LvlInfo)
+		logs := testlog.Capture(logger)
+		loader.log = logger
+
+		claims, _, err := loader.FetchClaims(context.Background())
+		require.NoError(t, err)
+		require.Len(t, claims, 2)
+		msg := logs.FindLog(log.LvlWarn, "Claim index out of range, using the claims loaded before it")
+		require.NotNil(t, msg)
+		require.EqualValues(t, 5, msg.GetContextValue("count"))
+		require.EqualValues(t, 2, msg.GetContextValue("loaded"))
+	})
+
+	t.Run("NotTruncatedWhenAllLoaded", func(t *testing.T) {
+		loader := NewLoader(newMockCallerWithClaims(5), nil)
+		claims, truncated, err := loader.fetchClaims(context.Background(), nil, 5)
+		require.NoError(t, err)
+		require.False(t, truncated)
+		require.Len(t, claims, 5)
+	})
+
+	t.Run("FailOnOtherErrors", func(t *testing.T) {
+		mockCaller := newMockCallerWithClaims(5)
+		mockCaller.revertClaims = map[uint64]bool{3: true}
+		mockCaller.failClaims = map[uint64]bool{1: true}
+		loader := NewLoader(mockCaller, nil)
+		loader.concurrency = 1
+		_, _, err := loader.FetchClaims(context.Background())
+		require.ErrorIs(t, err, mockClaimDataError)
+	})
+}
+
+func TestIsIndexOutOfRange(t *testing.T) {
+	require.True(t, isIndexOutOfRange(panicRevert(panicArrayOutOfBounds)))
+	require.True(t, isIndexOutOfRange(fmt.Errorf("wrapped: %w", panicRevert(panicArrayOutOfBounds))))
+	require.False(t, isIndexOutOfRange(nil))
+	require.False(t, isIndexOutOfRange(mockClaimDataError))
+	require.False(t, isIndexOutOfRange(panicRevert(0x11)), "should not match other panics")
+	require.False(t, isIndexOutOfRange(&revertError{data: "0x08c379a0"}), "should not match other reverts")
+	require.False(t, isIndexOutOfRange(&revertError{data: "not hex"}))
+}
+
+// TestLoader_FetchClaims_CountGrows tests that only the claims counted at the start of the load are fetched.
+func TestLoader_FetchClaims_CountGrows(t *testing.T) {
+	mockCaller := newMockCaller()
+	mockCaller.addedClaims = []ContractClaimData{{Claim: [32]byte{0x03}, Position: big.NewInt(1), Clock: big.NewInt(0)}}
+	loader := NewLoader(mockCaller, nil)
+	claims, _, err := loader.FetchClaims(context.Background())
+	require.NoError(t, err)
+	require.Len(t, claims, 3)
+}
+
+// TestLoader_FetchClaims_Clock tests that the packed chess clock is split into its timestamp and duration.
+func TestLoader_FetchClaims_Clock(t *testing.T) {
+	mockCaller := newMockCaller()
+	clock := new(big.Int).Lsh(big.NewInt(300), 64)
+	clock.Or(clock, big.NewInt(1234))
+	mockCaller.returnClaims[1].Clock = clock
+	loader := NewLoader(mockCaller, nil)
+	claims, _, err := loader.FetchClaims(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), claims[1].Clock)
+	require.Equal(t, 300*time.Second, claims[1].ClockDuration)
+}
+
+// TestLoader_FetchGameDuration tests fetching the game duration.
+func TestLoader_FetchGameDuration(t *testing.T) {
+	mockCaller := newMockCaller()
+	mockCaller.gameDuration = 600
+	loader := NewLoader(mockCaller, nil)
+	duration, err := loader.FetchGameDuration(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, duration)
+}
+
+// TestLoader_FetchClaims_Claimant tests that the claimant and bond are populated.
+func TestLoader_FetchClaims_Claimant(t *testing.T) {
+	mockCaller := newMockCaller()
+	mockCaller.returnClaims[0].Countered = true
+	mockCaller.returnClaims[0].Claimant = common.Address{0xcc}
+	mockCaller.returnClaims[0].Bond = big.NewInt(100)
+	loader := NewLoader(mockCaller, nil)
+	claims, _, err := loader.FetchClaims(context.Background())
+	require.NoError(t, err)
+	require.True(t, claims[0].Countered)
+	require.Equal(t, common.Address{0xcc}, claims[0].Claimant)
+	require.Equal(t, big.NewInt(100), claims[0].Bond)
+	require.Equal(t, common.Address{}, claims[1].Claimant)
+}
+
+func TestLoader_BuildClaimTree(t *testing.T) {
+	t.Run("Tree", func(t *testing.T) {
+		mockCaller := &mockCaller{
+			returnClaims: []ContractClaimData{
+				{Claim: [32]byte{0x00}, Position: big.NewInt(1), ParentIndex: math.MaxUint32, Claimant: common.Address{0xaa}, Clock: big.NewInt(0)},
+				{Claim: [32]byte{0x01}, Position: big.NewInt(2), ParentIndex: 0, Claimant: common.Address{0xbb}, Clock: big.NewInt(0)},
+				{Claim: [32]byte{0x02}, Position: big.NewInt(4), ParentIndex: 1, Claimant: common.Address{0xaa}, Clock: big.NewInt(0)},
+				{Claim: [32]byte{0x03}, Position: big.NewInt(2), ParentIndex: 0, Claimant: common.Address{0xcc}, Clock: big.NewInt(0)},
+			},
+		}
+		loader := NewLoader(mockCaller, nil)
+		tree, err := loader.BuildClaimTree(context.Background())
+		require.NoError(t, err)
+		require.Len(t, tree.Nodes, 4)
+		require.Equal(t, types.NoParent, tree.Root.ParentIndex)
+		require.Equal(t, common.Address{0xaa}, tree.Root.Claimant)
+		require.Equal(t, []*types.ClaimTreeNode{tree.Nodes[1], tree.Nodes[3]}, tree.Root.Children)
+		require.Equal(t, []*types.ClaimTreeNode{tree.Nodes[2]}, tree.Nodes[1].Children)
+		require.Equal(t, 1, tree.Nodes[2].ParentIndex)
+		require.Equal(t, 2, tree.Nodes[2].Depth)
+		require.Equal(t, uint64(4), tree.Nodes[2].Position)
+		require.Equal(t, common.Address{0xcc}, tree.Nodes[3].Claimant)
+	})
+
+	t.Run("FetchError", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.claimLenError = true
+		loader := NewLoader(mockCaller, nil)
+		_, err := loader.BuildClaimTree(context.Background())
+		require.ErrorIs(t, err, mockClaimLenError)
+	})
+}
+
+// TestLoader_FetchResolvedAt tests loading when a game resolved from contracts that do and don't record it.
+func TestLoader_FetchResolvedAt(t *testing.T) {
+	t.Run("Recorded", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.resolvedAt = 1234
+		loader := NewLoader(mockCaller, nil)
+		resolvedAt, err := loader.FetchResolvedAt(context.Background(), 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(1234, 0), resolvedAt)
+	})
+
+	t.Run("SearchResolvingBlock", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.status = uint8(types.GameStatusChallengerWon)
+		mockCaller.resolvedBlock = 37
+		headers := &stubHeaderSource{headers: map[uint64]*ethtypes.Header{37: {Time: 5000}}}
+		loader := NewLoader(mockCaller, headers)
+		resolvedAt, err := loader.FetchResolvedAt(context.Background(), 10, 100)
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(5000, 0), resolvedAt)
+		require.LessOrEqual(t, len(mockCaller.blockNumbers), 7, "should bisect the blocks")
+	})
+
+	t.Run("NotSeenInProgress", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.status = uint8(types.GameStatusChallengerWon)
+		mockCaller.resolvedBlock = 37
+		loader := NewLoader(mockCaller, &stubHeaderSource{headers: map[uint64]*ethtypes.Header{37: {Time: 5000}}})
+		_, err := loader.FetchResolvedAt(context.Background(), 0, 100)
+		require.ErrorIs(t, err, ErrResolvingBlockUnknown)
+		require.Empty(t, mockCaller.blockNumbers, "should not search for the resolving block from genesis")
+	})
+
+	t.Run("NoHeaders", func(t *testing.T) {
+		loader := NewLoader(newMockCaller(), nil)
+		_, err := loader.FetchResolvedAt(context.Background(), 10, 100)
+		require.Error(t, err)
+	})
+
+	t.Run("StatusError", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.statusError = true
+		loader := NewLoader(mockCaller, &stubHeaderSource{})
+		_, err := loader.FetchResolvedAt(context.Background(), 10, 100)
+		require.ErrorIs(t, err, mockStatusError)
+	})
+}
+
 type mockCaller struct {
 	claimDataError    bool
 	claimLenError     bool
 	maxGameDepthError bool
 	prestateError     bool
 	statusError       bool
+	gameTypeError     bool
+	gameType          uint8
+	gameDuration      uint64
 	maxGameDepth      uint64
-	currentIndex      uint64
 	status            uint8
-	returnClaims      []struct {
-		ParentIndex uint32
-		Countered   bool
-		Claim       [32]byte
-		Position    *big.Int
-		Clock       *big.Int
-	}
+	returnClaims      []ContractClaimData
+	// failClaims lists the claim indices that fail to load.
+	failClaims map[uint64]bool
+	// revertClaims lists the claim indices that revert with an array out of bounds panic.
+	revertClaims map[uint64]bool
+	// addedClaims are added to returnClaims after the claim count is read.
+	addedClaims  []ContractClaimData
+	delay        time.Duration
+	mu           sync.Mutex
+	blockNumbers []*big.Int
+	// resolvedAt is returned by ResolvedAt, which returns ErrResolvedAtUnavailable if it is 0.
+	resolvedAt uint64
+	// resolvedBlock is the first block status is returned at when loading the status at a block.
+	// The game is in progress at earlier blocks. 0 returns status at every block.
+	resolvedBlock uint64
 }
 
 func newMockCaller() *mockCaller {
 	return &mockCaller{
-		returnClaims: []struct {
-			ParentIndex uint32
-			Countered   bool
-			Claim       [32]byte
-			Position    *big.Int
-			Clock       *big.Int
-		}{
+		returnClaims: []ContractClaimData{
 			{
 				Claim:     [32]byte{0x00},
 				Position:  big.NewInt(0),
@@ -223,39 +524,66 @@ func newMockCaller() *mockCaller {
 	}
 }
 
-func (m *mockCaller) ClaimData(opts *bind.CallOpts, arg0 *big.Int) (struct {
-	ParentIndex uint32
-	Countered   bool
-	Claim       [32]byte
-	Position    *big.Int
-	Clock       *big.Int
-}, error) {
-	if m.claimDataError {
-		return struct {
-			ParentIndex uint32
-			Countered   bool
-			Claim       [32]byte
-			Position    *big.Int
-			Clock       *big.Int
-		}{}, mockClaimDataError
+// newMockCallerWithClaims creates a [mockCaller] with count distinct claims.
+func newMockCallerWithClaims(count int) *mockCaller {
+	m := &mockCaller{}
+	for i := 0; i < count; i++ {
+		m.returnClaims = append(m.returnClaims, ContractClaimData{
+			Claim:    [32]byte{byte(i + 1)},
+			Position: big.NewInt(1),
+			Clock:    big.NewInt(0),
+		})
+	}
+	return m
+}
+
+func (m *mockCaller) ClaimData(opts *bind.CallOpts, arg0 *big.Int) (ContractClaimData, error) {
+	m.recordBlock(opts)
+	if m.delay > 0 {
+		time.Sleep(m.delay)
 	}
-	returnClaim := m.returnClaims[m.currentIndex]
-	m.currentIndex++
-	return returnClaim, nil
+	if m.claimDataError || m.failClaims[arg0.Uint64()] {
+		return ContractClaimData{}, mockClaimDataError
+	}
+	if m.revertClaims[arg0.Uint64()] {
+		return ContractClaimData{}, panicRevert(panicArrayOutOfBounds)
+	}
+	return m.returnClaims[arg0.Uint64()], nil
+}
+
+func (m *mockCaller) recordBlock(opts *bind.CallOpts) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.blockNumbers = append(m.blockNumbers, opts.BlockNumber)
 }
 
 func (m *mockCaller) Status(opts *bind.CallOpts) (uint8, error) {
+	m.recordBlock(opts)
 	if m.statusError {
 		return 0, mockStatusError
 	}
+	if opts.BlockNumber != nil && opts.BlockNumber.Uint64() < m.resolvedBlock {
+		return uint8(types.GameStatusInProgress), nil
+	}
 	return m.status, nil
 }
 
+func (m *mockCaller) ResolvedAt(opts *bind.CallOpts) (uint64, error) {
+	if m.resolvedAt == 0 {
+		return 0, ErrResolvedAtUnavailable
+	}
+	return m.resolvedAt, nil
+}
+
 func (m *mockCaller) ClaimDataLen(opts *bind.CallOpts) (*big.Int, error) {
+	m.recordBlock(opts)
 	if m.claimLenError {
 		return big.NewInt(0), mockClaimLenError
 	}
-	return big.NewInt(int64(len(m.returnClaims))), nil
+	count := len(m.returnClaims)
+	// Simulate claims added to the game after the count is read.
+	m.returnClaims = append(m.returnClaims, m.addedClaims...)
+	return big.NewInt(int64(count)), nil
 }
 
 func (m *mockCaller) MAXGAMEDEPTH(opts *bind.CallOpts) (*big.Int, error) {
@@ -271,3 +599,68 @@ func (m *mockCaller) ABSOLUTEPRESTATE(opts *bind.CallOpts) ([32]byte, error) {
 	}
 	return common.HexToHash("0xdEad"), nil
 }
+
+func (m *mockCaller) GAMEDURATION(opts *bind.CallOpts) (uint64, error) {
+	return m.gameDuration, nil
+}
+
+func (m *mockCaller) GameType(opts *bind.CallOpts) (uint8, error) {
+	if m.gameTypeError {
+		return 0, mockGameTypeError
+	}
+	return m.gameType, nil
+}
+
+type stubHeaderSource struct {
+	head    *ethtypes.Header
+	headers map[uint64]*ethtypes.Header
+}
+
+func (s *stubHeaderSource) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	if number == nil {
+		return s.head, nil
+	}
+	header, ok := s.headers[number.Uint64()]
+	if !ok {
+		return nil, fmt.Errorf("unknown block %v", number)
+	}
+	return header, nil
+}
+
+// BenchmarkFetchClaims loads a game with many claims from a slow RPC at different concurrency limits.
+func BenchmarkFetchClaims(b *testing.B) {
+	mockCaller := newMockCallerWithClaims(100)
+	mockCaller.delay = time.Millisecond
+	for _, concurrency := range []uint{1, 10, 50} {
+		concurrency := concurrency
+		b.Run(fmt.Sprintf("Concurrency-%v", concurrency), func(b *testing.B) {
+			loader := NewLoader(mockCaller, nil)
+			loader.concurrency = concurrency
+			for i := 0; i < b.N; i++ {
+				if _, _, err := loader.FetchClaims(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// revertError is a JSON-RPC error carrying revert data, as returned by eth_call when a call reverts.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// panicRevert creates the error returned when a call reverts with a Solidity Panic(uint256) of code.
+func panicRevert(code uint64) error {
+	data := append([]byte{}, panicSelector...)
+	data = append(data, common.BigToHash(new(big.Int).SetUint64(code)).Bytes()...)
+	return &revertError{data: hexutil.Encode(data)}
+}
@@ -0,0 +1,47 @@
+package fault
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// monitorResponder is a [Responder] for players that only monitor games. The transactions the agent would send are
+// logged instead of sent, while calls and gas estimates are still made so the agent decides what to do as it would
+// if it were playing.
+type monitorResponder struct {
+	Responder
+	logger log.Logger
+}
+
+func (r *monitorResponder) Resolve(_ context.Context) error {
+	r.logger.Info("Monitor only, not resolving game")
+	return nil
+}
+
+func (r *monitorResponder) ResolveClaim(_ context.Context, claimIdx uint64) error {
+	r.logger.Info("Monitor only, not resolving claim", "claim", claimIdx)
+	return nil
+}
+
+func (r *monitorResponder) Respond(_ context.Context, response types.Claim) error {
+	r.logger.Info("Monitor only, not moving", "parent", response.ParentContractIndex, "is_defend", response.DefendsParent(), "value", response.Value)
+	return nil
+}
+
+func (r *monitorResponder) Step(_ context.Context, stepData types.StepCallData) error {
+	r.logger.Info("Monitor only, not stepping", "claim", stepData.ClaimIndex, "is_attack", stepData.IsAttack)
+	return nil
+}
+
+// monitorOracleUpdater is a [types.OracleUpdater] for players that only monitor games, which logs the oracle
+// updates the agent would make instead of making them.
+type monitorOracleUpdater struct {
+	logger log.Logger
+}
+
+func (u *monitorOracleUpdater) UpdateOracle(_ context.Context, data *types.PreimageOracleData) error {
+	u.logger.Info("Monitor only, not updating oracle", "oracleKey", data.OracleKey)
+	return nil
+}
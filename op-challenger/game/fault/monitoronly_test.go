@@ -0,0 +1,91 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMonitorOnly tests that the agent sends no transactions through a monitor only responder, even though the
+// game has claims to counter.
+func TestMonitorOnly(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	withIndex := func(claim types.Claim, index int, parent types.Claim) types.Claim {
+		claim.ContractIndex = index
+		claim.ParentContractIndex = parent.ContractIndex
+		return claim
+	}
+	root := builder.CreateRootClaim(true)
+	attack := withIndex(builder.AttackClaim(root, false), 1, root)
+	counter := withIndex(builder.AttackClaim(attack, true), 2, attack)
+	leaf := withIndex(builder.AttackClaim(counter, false), 3, counter)
+	// Countered by a move as the agent disagrees with the proposed output.
+	correctAttack := withIndex(builder.AttackClaim(root, true), 4, root)
+	snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter, leaf, correctAttack}}
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+	logger.SetHandler(handler)
+	txMgr := &sendCountingTxManager{}
+	faultResponder, err := responder.NewFaultResponder(logger, txMgr, &stubL1Reader{}, common.Address{0xaa})
+	require.NoError(t, err)
+	updater := &monitorOracleUpdater{logger: logger}
+	trace := test.NewAlphabetWithProofProvider(t, maxDepth, nil)
+	agent := NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, maxDepth, MoveLimits{}, 0, trace, &monitorResponder{Responder: faultResponder, logger: logger}, updater, nil, false, logger)
+	require.NoError(t, agent.Act(context.Background(), snapshot))
+
+	require.Zero(t, txMgr.sends)
+	require.NotNil(t, handler.FindLog(log.LvlInfo, "Monitor only, not updating oracle"))
+	require.NotNil(t, handler.FindLog(log.LvlInfo, "Monitor only, not stepping"))
+	record := handler.FindLog(log.LvlInfo, "Monitor only, not moving")
+	require.NotNil(t, record)
+	require.Equal(t, correctAttack.ContractIndex, record.GetContextValue("parent"))
+}
+
+// sendCountingTxManager is a [txmgr.TxManager] that counts the transactions sent. Calls fail, so the game never
+// appears resolvable.
+type sendCountingTxManager struct {
+	sends int
+}
+
+func (m *sendCountingTxManager) Send(_ context.Context, _ txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	m.sends++
+	return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}, nil
+}
+
+func (m *sendCountingTxManager) Call(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return nil, errors.New("execution reverted")
+}
+
+func (m *sendCountingTxManager) From() common.Address {
+	return common.Address{}
+}
+
+func (m *sendCountingTxManager) BlockNumber(_ context.Context) (uint64, error) {
+	return 0, nil
+}
+
+type stubL1Reader struct{}
+
+func (s *stubL1Reader) EstimateGas(_ context.Context, _ ethereum.CallMsg) (uint64, error) {
+	return 50_000, nil
+}
+
+func (s *stubL1Reader) HeaderByNumber(_ context.Context, _ *big.Int) (*ethtypes.Header, error) {
+	return &ethtypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(1)}, nil
+}
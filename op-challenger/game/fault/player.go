@@ -1,160 +1,1293 @@
 package fault
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
 
-	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cache"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/ratelimit"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+	"golang.org/x/time/rate"
 )
 
+// finalSnapshotAttempts is the number of times the final state of a resolved game is loaded before giving up
+// until the game is next progressed.
+const finalSnapshotAttempts = 3
+
+var (
+	ErrClaimCountDecreased = errors.New("claim count decreased")
+	// ErrPrestateMismatch is returned when the trace provider's absolute prestate doesn't match the game's.
+	ErrPrestateMismatch = errors.New("absolute prestate mismatch")
+	// ErrPrestateNotPlayed is returned when the game's absolute prestate is an accepted prestate that isn't the
+	// trace provider's, so the game is valid but must be played with a different trace provider.
+	ErrPrestateNotPlayed = errors.New("absolute prestate not played")
+	// ErrPrestateInvalidLength is returned when the game's absolute prestate hash isn't 32 bytes.
+	ErrPrestateInvalidLength = errors.New("prestate hash has invalid length")
+	// ErrTraceProvider wraps errors from the trace provider.
+	ErrTraceProvider = errors.New("trace provider error")
+	// ErrLoader wraps errors loading game data from the contract.
+	ErrLoader = errors.New("loader error")
+)
+
+// PrestateMismatchError is returned when the trace provider's absolute prestate doesn't match the game's, with both
+// prestates so the mismatch can be investigated. It wraps [ErrPrestateMismatch].
+type PrestateMismatchError struct {
+	Onchain  common.Hash
+	Provider common.Hash
+}
+
+func (e *PrestateMismatchError) Error() string {
+	return fmt.Sprintf("%v: game %v, trace provider %v", ErrPrestateMismatch, e.Onchain, e.Provider)
+}
+
+func (e *PrestateMismatchError) Unwrap() error {
+	return ErrPrestateMismatch
+}
+
 type Actor interface {
-	Act(ctx context.Context) error
+	Act(ctx context.Context, snapshot *GameSnapshot) error
+	// Reset discards any state learnt from previous snapshots, as they may no longer be canonical.
+	Reset()
+}
+
+// pendingResponder is implemented by actors that report whether they have a response to make in the game, so games
+// are only reported as stale while the challenger is failing to respond.
+type pendingResponder interface {
+	ResponsePending() bool
+}
+
+type ClaimLoader interface {
+	// FetchClaims loads all claims and returns the L1 block they were loaded at.
+	// The claims are loaded as a unit: any claims returned with an error are incomplete and must not be used.
+	FetchClaims(ctx context.Context) ([]types.Claim, eth.L1BlockRef, error)
 }
 
 type GameInfo interface {
 	GetGameStatus(context.Context) (types.GameStatus, error)
-	GetClaimCount(context.Context) (uint64, error)
+	BlockHashAt(ctx context.Context, number uint64) (common.Hash, error)
+	ClaimLoader
+}
+
+// BondClaimer claims the bonds credited to the challenger by a game.
+// Claiming must be idempotent so it can be retried against a game that was already claimed.
+type BondClaimer interface {
+	// ClaimBonds returns the amount claimed, which is zero if there was nothing to claim.
+	ClaimBonds(ctx context.Context) (*big.Int, error)
+}
+
+// ResolutionLoader loads when a game resolved.
+type ResolutionLoader interface {
+	// FetchResolvedAt returns when the game resolved, given it was in progress at L1 block from and resolved by
+	// block to. From is 0 if the game wasn't seen in progress.
+	FetchResolvedAt(ctx context.Context, from uint64, to uint64) (time.Time, error)
+}
+
+// GameTypeLoader loads the type of a game.
+type GameTypeLoader interface {
+	FetchGameType(ctx context.Context) (uint8, error)
+}
+
+// GameSnapshot is the game state loaded once at the start of each ProgressGame cycle.
+// It is shared by the agent and the status logging so the loader is only queried once per cycle.
+type GameSnapshot struct {
+	Claims []types.Claim
+	// Block is the L1 block the claims were loaded at.
+	// It is empty if the loader does not pin claims to a block.
+	Block eth.L1BlockRef
+}
+
+// ClaimCount returns the number of claims in the snapshot.
+func (s *GameSnapshot) ClaimCount() uint64 {
+	return uint64(len(s.Claims))
+}
+
+// RemainingClock returns the least time remaining at the snapshot's block to counter any uncountered claim.
+// Each side's chess clock has half the game duration. The clock of the side countering a claim holds the
+// duration used by the claim's parent plus the time elapsed since the claim was made.
+// Returns false if the game duration or block time is unknown so the clocks can't be calculated.
+func (s *GameSnapshot) RemainingClock(gameDuration time.Duration) (time.Duration, bool) {
+	if gameDuration == 0 || s.Block.Time == 0 {
+		return 0, false
+	}
+	found := false
+	var minRemaining time.Duration
+	for _, claim := range s.Claims {
+		if claim.Countered {
+			continue
+		}
+		remaining := s.claimRemainingClock(claim, gameDuration)
+		if !found || remaining < minRemaining {
+			minRemaining = remaining
+			found = true
+		}
+	}
+	return minRemaining, found
+}
+
+// LongestRemainingClock returns the most time remaining at the snapshot's block to counter any uncountered claim.
+// If it is too short to make a move, no move can be made before the game resolves.
+// Returns false if the game duration or block time is unknown so the clocks can't be calculated.
+func (s *GameSnapshot) LongestRemainingClock(gameDuration time.Duration) (time.Duration, bool) {
+	if gameDuration == 0 || s.Block.Time == 0 {
+		return 0, false
+	}
+	found := false
+	var maxRemaining time.Duration
+	for _, claim := range s.Claims {
+		if claim.Countered {
+			continue
+		}
+		remaining := s.claimRemainingClock(claim, gameDuration)
+		if !found || remaining > maxRemaining {
+			maxRemaining = remaining
+			found = true
+		}
+	}
+	return maxRemaining, found
+}
+
+// claimRemainingClock returns the time remaining at the snapshot's block to counter claim.
+func (s *GameSnapshot) claimRemainingClock(claim types.Claim, gameDuration time.Duration) time.Duration {
+	var used time.Duration
+	if !claim.IsRoot() && claim.ParentContractIndex >= 0 && claim.ParentContractIndex < len(s.Claims) {
+		used = s.Claims[claim.ParentContractIndex].ClockDuration
+	}
+	if s.Block.Time > claim.Clock {
+		used += time.Duration(s.Block.Time-claim.Clock) * time.Second
+	}
+	remaining := gameDuration/2 - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining
+}
+
+// ResolvedCallback is notified once when a game reaches a terminal status.
+// won is true if the status is the outcome the challenger was playing for.
+type ResolvedCallback func(game common.Address, status types.GameStatus, won bool)
+
+// HealthRecorder is notified of the outcome of each game's claim loads and prestate validation.
+// It is called on every cycle of every game so implementations must not block.
+type HealthRecorder interface {
+	RecordClaimLoad(game common.Address, err error)
+	RecordPrestateValidation(game common.Address, err error)
+	// RemoveGame stops tracking game once it is no longer played.
+	RemoveGame(game common.Address)
 }
 
 type GamePlayer struct {
-	agent                   Actor
-	agreeWithProposedOutput bool
-	loader                  GameInfo
-	logger                  log.Logger
+	addr             common.Address
+	metrics          metrics.Metricer
+	loader           GameInfo
+	logger           log.Logger
+	onResolved       ResolvedCallback
+	resolvedNotified bool
+	// claimer claims the challenger's bonds once the game is won. Nil if bonds aren't claimed.
+	claimer BondClaimer
+	// claimPending is true if the game is won but the bonds haven't been claimed yet.
+	claimPending bool
+	// bondClaimDelay is the time after the game resolves before its bonds are claimed. 0 claims them immediately.
+	bondClaimDelay time.Duration
+	// resolutions loads when the game resolved. Nil if the resolution time isn't loaded.
+	resolutions ResolutionLoader
+	// resolvedAt is when the game resolved. Zero if it hasn't or the time is unknown.
+	resolvedAt time.Time
+	// firstSeen is when the player was created, which the time to resolution is measured from. Zero if the time to
+	// resolution isn't recorded.
+	firstSeen time.Time
+	// gameTypes loads the game type to label the time to resolution with, if the agent hasn't loaded it.
+	gameTypes GameTypeLoader
+	// gameType is the game type loaded by the agent, nil if it hasn't been loaded.
+	gameType *uint8
+	clock    clock.Clock
+	// clocks records the game's soonest chess clock deadline. Nil if deadlines aren't tracked.
+	clocks *ClockTracker
+	// soonestDeadline is when the soonest chess clock loaded by the last ProgressGame runs out, zero if unknown
+	// or the game is abandoned.
+	soonestDeadline time.Time
+	// progress records when the game's claim count last changed. Nil if progress isn't tracked.
+	progress *ProgressTracker
+	// events receives the game's events. Nil if events aren't emitted.
+	events types.EventSink
+	// abandoned records the games the challenger has stopped participating in. Nil if games can't be abandoned.
+	abandoned *AbandonedGames
+	// statuses records a summary of the game's state. Nil if summaries aren't recorded.
+	statuses *StatusRegistry
+	// journal records the game's moves and steps until they are mined. Nil if actions aren't journaled.
+	journal *ActionJournal
+	// journalReconciled is true once the actions journaled before the player was created have been reconciled and
+	// none are still pending.
+	journalReconciled bool
+	// reconciled is told the outcome of reconciling the journal so it doesn't resend actions that may land.
+	// Nil until the agent is created or if actions aren't journaled.
+	reconciled *journalingResponder
+	// health records the outcome of claim loads and prestate validation. Nil if health isn't tracked.
+	health HealthRecorder
+	// analyze estimates the outlook of the game from its claims. Nil if games aren't analyzed.
+	analyze func(ctx context.Context, claims []types.Claim) (Outlook, error)
+	// analyzedClaims is the claim count the outlook was last analyzed at. The claim tree only changes when claims
+	// are added so the outlook is only recomputed when the count changes.
+	analyzedClaims uint64
+	// pregen loads step data in the background. Nil if step data isn't pre-generated.
+	pregen *StepPregenerator
+	// pregenerate requests the step data likely to be needed for the claims be pre-generated. Nil if step data
+	// isn't pre-generated for the game.
+	pregenerate func(claims []types.Claim)
+	// abandonReason is set once the game is found to be abandoned. The game is still monitored and its bonds
+	// claimed, but no trace is loaded and no moves are made.
+	abandonReason string
+	// defendRoot is true if the challenger defends the root claim, so the game is won when the defender wins.
+	// It is set when the agent is created if the root claim matches the trace, even if we agree with the output.
+	defendRoot bool
+
+	// agent is created by createAgent on the first call to ProgressGame as the game type must be loaded first.
+	agent       Actor
+	createAgent func(ctx context.Context) (Actor, error)
+	// closeTrace releases the agent's trace provider, if it holds resources shared with other games.
+	closeTrace func() error
 
 	completed bool
+	// status is the game status loaded by the last ProgressGame.
+	status types.GameStatus
+	// progressErr is the last error that occurred during the last ProgressGame, nil if there was none.
+	progressErr error
+	// lastClaimCount is the claim count observed in the previous cycle.
+	// Claims can't be removed from a game so a lower count indicates an L1 reorg.
+	lastClaimCount uint64
+	// lastBlock is the L1 block the previous cycle's claims were loaded at.
+	lastBlock eth.BlockID
+	// inProgressBlock is the latest L1 block the game is known to have been in progress at.
+	// It bounds the search for when the game resolved. 0 if the game hasn't been seen in progress.
+	inProgressBlock uint64
+
+	// gameDuration is the total duration of the game, 0 if unknown.
+	gameDuration    time.Duration
+	urgentThreshold time.Duration
+	relaxedInterval time.Duration
+	// contestedInterval is the poll interval of games that had claims added since the previous cycle, when shorter
+	// than the interval they would otherwise be polled at.
+	contestedInterval time.Duration
+	// terminalInterval is the poll interval of games that are no longer in progress but still scheduled, such as
+	// to retry claiming bonds.
+	terminalInterval time.Duration
+	nextCheckDelay   time.Duration
+	// claimsAdded is true if the claim count increased since the previous cycle.
+	claimsAdded bool
+	// minMoveClock is the remaining clock time needed to make a move. 0 disables the check.
+	minMoveClock time.Duration
+	// actBudget is the time loading and acting on the game may take before a warning is logged. 0 disables it.
+	actBudget time.Duration
+	// infoInterval is the time between "Game info" logs while the claim count and status are unchanged.
+	// 0 only logs changes.
+	infoInterval time.Duration
+	// infoLogged is the claim count and status last logged by "Game info" and when. Nil if none has been logged.
+	infoLogged *gameInfo
+}
+
+// GamePlayerDeps are the optional collaborators of a [GamePlayer], which are shared between the games being played.
+// Those left nil are disabled.
+type GamePlayerDeps struct {
+	// Registry shares cannon traces between games with the same trace.
+	Registry *cannon.ProviderRegistry
+	// Clocks records the soonest chess clock deadline of each game.
+	Clocks *ClockTracker
+	// Progress records when the claim count of each game last changed.
+	Progress *ProgressTracker
+	// Events receives the events of each game.
+	Events types.EventSink
+	// Abandoned records the games the challenger has stopped participating in.
+	Abandoned *AbandonedGames
+	// Statuses records a summary of the state of each game.
+	Statuses *StatusRegistry
+	// Journal records moves and steps until they are mined.
+	Journal *ActionJournal
+	// Pregen loads step data in the background.
+	Pregen *StepPregenerator
+	// TraceLimiter limits the rate traces are loaded at.
+	TraceLimiter *rate.Limiter
+	// Breaker halts non-critical transactions after repeated failed transactions.
+	Breaker *responder.CircuitBreaker
+	// Health records the outcome of claim loads and prestate validation.
+	Health HealthRecorder
+	// Strategy chooses the order claims are resolved in. The agent's default is used if nil.
+	Strategy ResolutionStrategy
+	// OnResolved is called once the game is resolved.
+	OnResolved ResolvedCallback
 }
 
 func NewGamePlayer(
 	ctx context.Context,
 	logger log.Logger,
+	m metrics.Metricer,
 	cfg *config.Config,
 	dir string,
 	addr common.Address,
 	txMgr txmgr.TxManager,
-	client bind.ContractCaller,
+	client L1Client,
+	cl clock.Clock,
+	deps GamePlayerDeps,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
-	contract, err := bindings.NewFaultDisputeGameCaller(addr, client)
+	if deps.Events == nil {
+		deps.Events = types.NoopEventSink{}
+	}
+	loader, err := NewLoaderFromBindings(logger, addr, client, cfg.ClaimLoadConcurrency)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
 	}
 
-	loader := NewLoader(contract)
-
 	gameDepth, err := loader.FetchGameDepth(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch the game depth: %w", err)
+		return nil, fmt.Errorf("%w: failed to fetch the game depth: %w", ErrLoader, err)
 	}
 
-	var provider types.TraceProvider
-	var updater types.OracleUpdater
-	switch cfg.TraceType {
-	case config.TraceTypeCannon:
-		cannonProvider, err := cannon.NewTraceProvider(ctx, logger, cfg, client, dir, addr)
-		if err != nil {
-			return nil, fmt.Errorf("create cannon trace provider: %w", err)
-		}
-		provider = cannonProvider
-		updater, err = cannon.NewOracleUpdater(ctx, logger, txMgr, addr, client)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create the cannon updater: %w", err)
-		}
-	case config.TraceTypeAlphabet:
-		provider = alphabet.NewTraceProvider(cfg.AlphabetTrace, gameDepth)
-		updater = alphabet.NewOracleUpdater(logger)
-	default:
-		return nil, fmt.Errorf("unsupported trace type: %v", cfg.TraceType)
+	// The poll interval falls back to checking every block if the duration is unavailable so don't fail.
+	gameDuration, err := loader.FetchGameDuration(ctx)
+	if err != nil {
+		logger.Warn("Failed to fetch the game duration", "err", err)
+		gameDuration = 0
 	}
 
-	if err := ValidateAbsolutePrestate(ctx, provider, loader); err != nil {
-		return nil, fmt.Errorf("failed to validate absolute prestate: %w", err)
+	var claimer BondClaimer
+	if cfg.AutoClaimBonds && !cfg.MonitorOnly {
+		claimer, err = responder.NewBondClaimer(logger, txMgr, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the bond claimer: %w", err)
+		}
 	}
 
-	responder, err := responder.NewFaultResponder(logger, txMgr, addr)
+	responder, err := responder.NewFaultResponderWithEvents(logger, txMgr, client, addr, deps.Events, m, deps.Breaker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
+	var info GameInfo = loader
+	if cfg.StatusConfirmations > 0 {
+		info = newConfirmedStatusInfo(logger, loader, loader, client, cfg.StatusConfirmations)
+	}
+
+	player := &GamePlayer{
+		addr:              addr,
+		metrics:           m,
+		defendRoot:        !cfg.AgreeWithProposedOutput,
+		loader:            info,
+		logger:            logger,
+		onResolved:        deps.OnResolved,
+		claimer:           claimer,
+		bondClaimDelay:    cfg.BondClaimDelay,
+		resolutions:       loader,
+		firstSeen:         cl.Now(),
+		gameTypes:         loader,
+		clock:             cl,
+		clocks:            deps.Clocks,
+		progress:          deps.Progress,
+		events:            deps.Events,
+		abandoned:         deps.Abandoned,
+		statuses:          deps.Statuses,
+		journal:           deps.Journal,
+		health:            deps.Health,
+		pregen:            deps.Pregen,
+		gameDuration:      gameDuration,
+		urgentThreshold:   cfg.UrgentClockThreshold,
+		relaxedInterval:   cfg.RelaxedPollInterval,
+		contestedInterval: cfg.ContestedPollInterval,
+		terminalInterval:  cfg.TerminalPollInterval,
+		minMoveClock:      cfg.MinMoveClock,
+		actBudget:         cfg.ActTimeBudget,
+		infoInterval:      cfg.GameInfoInterval,
+	}
+	selector := NewTraceProviders(logger, cfg, dir, addr, txMgr, client, deps.Registry)
+	player.createAgent = func(ctx context.Context) (Actor, error) {
+		gameType, err := loader.FetchGameType(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to fetch the game type: %w", ErrLoader, err)
+		}
+		player.gameType = &gameType
+		createProvider, ok := selector.SelectTraceProvider(gameType)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedGameType, gameType)
+		}
+		opts, err := cfg.OptionsForGameType(gameType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve options for game type %v: %w", gameType, err)
+		}
+		provider, updater, err := createProvider(ctx, gameDepth)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTraceProvider, err)
+		}
+		if closer, ok := provider.(io.Closer); ok {
+			player.closeTrace = closer.Close
+		}
+		if deps.TraceLimiter != nil {
+			// Limited before the caches so that cached data is served without using the limit.
+			provider = ratelimit.NewTraceProvider(provider, deps.TraceLimiter)
+		}
+		if cfg.TraceDiskCacheDir != "" {
+			root, err := loader.FetchRootClaim(ctx)
+			if err != nil {
+				player.releaseTrace()
+				return nil, fmt.Errorf("%w: failed to fetch the root claim: %w", ErrLoader, err)
+			}
+			provider = cache.NewDiskTraceProvider(logger, provider, cache.DiskCachePath(cfg.TraceDiskCacheDir, addr), root.Value, cfg.TraceDiskCacheSize)
+		}
+		if opts.TraceCacheSize > 0 {
+			provider = cache.NewTraceProvider(provider, opts.TraceCacheSize, func(bytes uint64) {
+				m.RecordTraceCacheUsage(addr, bytes)
+			})
+		}
+		if err := ValidateAbsolutePrestate(ctx, logger, provider, loader, cfg.AcceptedPrestates); err != nil {
+			if !errors.Is(err, ratelimit.ErrRateLimited) && !errors.Is(err, ErrPrestateNotPlayed) {
+				player.emitEvent(types.Event{Type: types.EventPrestateValidated, Error: err.Error()})
+			}
+			// The game's prestate is set by whoever created it, so a game we don't play isn't a subsystem failure.
+			if !errors.Is(err, ratelimit.ErrRateLimited) && !unplayablePrestate(err) {
+				player.recordPrestateValidation(err)
+			}
+			player.releaseTrace()
+			return nil, fmt.Errorf("failed to validate absolute prestate: %w", err)
+		}
+		player.emitEvent(types.Event{Type: types.EventPrestateValidated})
+		player.recordPrestateValidation(nil)
+		if !player.defendRoot {
+			// Even when we agree with the proposed output, a root claim that matches our trace must be defended
+			// against invalid counter claims rather than attacked.
+			agree, err := AgreeWithRootClaim(ctx, provider, loader, gameDepth)
+			if err != nil {
+				player.releaseTrace()
+				return nil, fmt.Errorf("failed to check root claim: %w", err)
+			}
+			if agree {
+				logger.Info("Agree with root claim, defending it")
+				player.defendRoot = true
+			}
+		}
+		if deps.Pregen != nil {
+			if opts.TraceCacheSize > 0 || cfg.TraceDiskCacheDir != "" {
+				cached := provider
+				player.pregenerate = func(claims []types.Claim) {
+					deps.Pregen.Request(addr, cached, stepCandidates(claims, int(gameDepth), int(cfg.StepPregenDepth)))
+				}
+			} else {
+				logger.Warn("Not pre-generating step data because the trace is not cached")
+			}
+		}
+		if cfg.AnalyzeGames {
+			player.analyze = func(ctx context.Context, claims []types.Claim) (Outlook, error) {
+				return AnalyzePosition(ctx, provider, claims, int(gameDepth), player.defendRoot, DefaultAnalysisBudget)
+			}
+		}
+		limits := MoveLimits{
+			MaxGas:      opts.MaxMoveGas,
+			MaxPerCycle: int(cfg.MaxMovesPerCycle),
+			MaxParallel: int(cfg.MaxParallelMoves),
+			MaxGasPrice: cfg.MaxMoveGasPriceWei(),
+			UrgentClock: cfg.UrgentClockThreshold,
+
+			AcceptUnfinalizedRisk: cfg.AcceptUnfinalizedRisk,
+			HaltOnSelfConflict:    cfg.HaltOnSelfConflict,
+			Claimant:              txMgr.From(),
+			MaxSpend:              cfg.MaxSpendPerGameWei(),
+			MoveBond:              cfg.MoveBondWei(),
+		}
+		if cfg.StrictDataAvailability && gameType == config.CannonFaultGameID {
+			if cfg.CannonL2 == "" {
+				logger.Warn("No L2 node to check finality with, moving without strict data availability")
+			} else {
+				finality, err := cannon.NewL2FinalityChecker(ctx, cfg, client, addr)
+				if err != nil {
+					player.releaseTrace()
+					return nil, fmt.Errorf("failed to create the L2 finality checker: %w", err)
+				}
+				limits.Finality = finality
+				closeTrace := player.closeTrace
+				player.closeTrace = func() error {
+					err := finality.Close()
+					if closeTrace != nil {
+						err = errors.Join(closeTrace(), err)
+					}
+					return err
+				}
+			}
+		}
+		var agentResponder Responder = responder
+		if cfg.MonitorOnly {
+			// Nothing is sent so there is nothing to journal.
+			agentResponder = &monitorResponder{Responder: responder, logger: logger}
+			updater = &monitorOracleUpdater{logger: logger}
+		} else if deps.Journal != nil {
+			journaled := &journalingResponder{Responder: responder, journal: deps.Journal, game: addr, logger: logger}
+			player.reconciled = journaled
+			agentResponder = journaled
+		}
+		return NewAgent(m, player.clock, addr, int(gameDepth), limits, gameDuration, provider, agentResponder, updater, deps.Strategy, !player.defendRoot, logger), nil
+	}
+
+	player.emitEvent(types.Event{Type: types.EventGameDiscovered})
+	return player, nil
+}
+
+// NewLocalGamePlayer creates a player for a game that isn't loaded from a contract, such as one simulated in memory,
+// that acts on it with agent. Only the game's status and claims are loaded, and none of the optional trackers are
+// used. defendRoot is whether the agent defends the root claim.
+func NewLocalGamePlayer(logger log.Logger, m metrics.Metricer, cl clock.Clock, addr common.Address, game GameInfo, agent Actor, defendRoot bool) *GamePlayer {
 	return &GamePlayer{
-		agent:                   NewAgent(loader, int(gameDepth), provider, responder, updater, cfg.AgreeWithProposedOutput, logger),
-		agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
-		loader:                  loader,
-		logger:                  logger,
-	}, nil
+		addr:       addr,
+		metrics:    m,
+		loader:     game,
+		logger:     logger.New("game", addr),
+		clock:      cl,
+		agent:      agent,
+		defendRoot: defendRoot,
+	}
 }
 
 func (g *GamePlayer) ProgressGame(ctx context.Context) bool {
+	g.progressErr = nil
 	if g.completed {
+		if g.claimPending {
+			return g.claimBonds(ctx)
+		}
 		// Game is already complete so don't try to perform further actions.
 		g.logger.Trace("Skipping completed game")
 		return true
 	}
-	g.logger.Trace("Checking if actions are required")
-	if err := g.agent.Act(ctx); err != nil {
-		g.logger.Error("Error when acting on game", "err", err)
+	if err := g.checkAbandoned(); err != nil {
+		g.logger.Error("Failed to check if game is abandoned", "err", err)
+		g.recordError(err)
+		return false
+	}
+	if g.agent == nil && g.abandonReason == "" {
+		agent, err := g.createAgent(ctx)
+		if errors.Is(err, ErrUnsupportedGameType) {
+			g.logger.Warn("Unsupported game type", "err", err)
+			g.completed = true
+			return true
+		} else if errors.Is(err, ErrPrestateNotPlayed) {
+			g.logger.Info("Game has an accepted absolute prestate played by a different trace provider, skipping game", "err", err)
+			g.completed = true
+			return true
+		} else if unplayablePrestate(err) {
+			g.logger.Warn("Game's absolute prestate doesn't match the trace provider, skipping game", "err", err)
+			g.completed = true
+			return true
+		} else if errors.Is(err, ratelimit.ErrRateLimited) {
+			g.logger.Info("Trace provider rate limited, creating agent next cycle")
+			return false
+		} else if errors.Is(err, types.ErrOutputNotAvailable) {
+			// The L2 node is expected to catch up so this isn't an error, but the game can't be played until then.
+			g.logger.Debug("L2 output not available yet, skipping game", "err", err)
+			return false
+		} else if err != nil {
+			g.logger.Error("Failed to create agent", "err", err)
+			g.recordError(err)
+			return false
+		}
+		g.agent = agent
+	}
+	// Check again next block unless the snapshot shows the game isn't urgent.
+	g.nextCheckDelay = 0
+	start := g.clock.Now()
+	snapshot, err := g.loadSnapshot(ctx)
+	loadTime := g.clock.Now().Sub(start)
+	g.metrics.RecordActDuration(g.addr, phaseLoad, loadTime)
+	if g.health != nil {
+		g.health.RecordClaimLoad(g.addr, err)
+	}
+	if errors.Is(err, ErrClaimCountDecreased) {
+		g.logger.Warn("Possible L1 reorg detected", "err", err)
+		g.recordError(err)
+		return false
+	} else if err != nil {
+		g.logger.Error("Failed to load game state", "err", err)
+		g.recordError(err)
+		return false
+	}
+	g.metrics.RecordGameClaims(g.addr, snapshot.ClaimCount())
+	g.updateNextCheckDelay(snapshot)
+	g.updateClockDeadline(snapshot)
+	if err := g.reconcileJournal(ctx, snapshot); err != nil {
+		// The agent could duplicate a pending action so it doesn't act until they are reconciled.
+		g.logger.Error("Failed to reconcile pending actions", "err", err)
+		g.recordError(err)
+		return false
+	}
+	if g.abandonReason != "" {
+		g.logger.Trace("Not acting on abandoned game")
+	} else if remaining, ok := g.insufficientClock(snapshot); ok {
+		g.logger.Warn("Insufficient clock remaining, conceding", "remaining", remaining, "min", g.minMoveClock)
+	} else {
+		g.logger.Trace("Checking if actions are required")
+		if err := g.agent.Act(ctx, snapshot); err != nil {
+			g.logger.Error("Error when acting on game", "err", err)
+			g.recordError(err)
+		}
+		g.recordActTime(g.clock.Now().Sub(start), loadTime)
+		if g.pregenerate != nil {
+			g.pregenerate(snapshot.Claims)
+		}
 	}
-	if status, err := g.loader.GetGameStatus(ctx); err != nil {
+	g.recordProgress(snapshot)
+	status, err := g.loader.GetGameStatus(ctx)
+	if err != nil {
 		g.logger.Warn("Unable to retrieve game status", "err", err)
+		g.recordError(err)
+		return false
+	}
+	if status == types.GameStatusInProgress {
+		g.status = status
+		// The status was loaded after the claims so the game was in progress at their block too.
+		g.inProgressBlock = snapshot.Block.Number
+		g.metrics.RecordGameStatus(g.addr, uint8(status))
+		g.recordState(snapshot, status)
+		g.updateOutlook(ctx, snapshot)
+		g.logGameInfo(snapshot.ClaimCount(), status)
+		return false
+	}
+	// The outcome is only reported once the whole final state has loaded so the logs, metrics and events
+	// agree with each other. Until then the game is retried as if it were still in progress.
+	final, err := g.loadFinalSnapshot(ctx, status)
+	if err != nil {
+		g.logger.Warn("Unable to load final game state, will retry", "status", status, "err", err)
+		g.recordError(err)
+		g.nextCheckDelay = g.terminalInterval
+		return false
+	}
+	g.completed = true
+	g.status = status
+	if g.clocks != nil {
+		g.clocks.Remove(g.addr)
+	}
+	if g.progress != nil {
+		g.progress.Remove(g.addr)
+	}
+	if g.health != nil {
+		g.health.RemoveGame(g.addr)
+	}
+	if g.journal != nil {
+		if err := g.journal.Clear(g.addr); err != nil {
+			g.logger.Error("Failed to clear pending actions", "err", err)
+		}
+	}
+	g.releaseTrace()
+	g.metrics.RecordGameClaims(g.addr, final.ClaimCount())
+	g.metrics.RecordGameStatus(g.addr, uint8(status))
+	g.recordState(final.GameSnapshot, status)
+	g.resolvedAt = final.resolvedAt
+	if g.statuses != nil {
+		g.statuses.RecordResolution(g.addr, final.resolvedAt, final.winner())
+	}
+	g.logGameResult(final)
+	g.recordTimeToResolution(ctx, status)
+	g.emitResolved(final)
+	g.checkResolution(final)
+	g.notifyResolved(status)
+	if g.claimer != nil && g.won(status) {
+		// Keep the game scheduled until the bonds are claimed.
+		g.claimPending = true
+		return g.claimBonds(ctx)
+	}
+	return true
+}
+
+// gameInfo is the state of a game logged by "Game info".
+type gameInfo struct {
+	claims uint64
+	status types.GameStatus
+	at     time.Time
+}
+
+// logGameInfo logs the claim count and status of the game if either changed since they were last logged, or the
+// info interval has passed, so idle games don't flood the logs every cycle.
+func (g *GamePlayer) logGameInfo(claims uint64, status types.GameStatus) {
+	now := g.clock.Now()
+	if last := g.infoLogged; last != nil && last.claims == claims && last.status == status {
+		if g.infoInterval <= 0 || now.Sub(last.at) < g.infoInterval {
+			g.logger.Trace("Game unchanged", "claims", claims, "status", status)
+			return
+		}
+	}
+	g.infoLogged = &gameInfo{claims: claims, status: status, at: now}
+	g.logger.Info("Game info", "claims", claims, "status", status)
+}
+
+// finalSnapshot is the state of a resolved game. It is loaded as a whole before the outcome is reported so
+// the status, claims and deciding claim all come from a consistent view of the game.
+type finalSnapshot struct {
+	*GameSnapshot
+	status types.GameStatus
+	// decidingClaim is the claim that decided the outcome.
+	decidingClaim types.Claim
+	// resolvedAt is when the game resolved. Zero if unknown.
+	resolvedAt time.Time
+}
+
+// winner returns the address the resolution credits with the root claim's bond, which is the claimant of the
+// deciding claim. Zero if the contract version doesn't record claimants.
+func (f *finalSnapshot) winner() common.Address {
+	return f.decidingClaim.Claimant
+}
+
+// loadFinalSnapshot loads the final state of a game that resolved with status, retrying failed loads up to
+// finalSnapshotAttempts times.
+func (g *GamePlayer) loadFinalSnapshot(ctx context.Context, status types.GameStatus) (*finalSnapshot, error) {
+	var err error
+	for attempt := 1; attempt <= finalSnapshotAttempts; attempt++ {
+		var final *finalSnapshot
+		if final, err = g.tryLoadFinalSnapshot(ctx, status); err == nil {
+			return final, nil
+		}
+		g.logger.Debug("Failed to load final game state", "attempt", attempt, "err", err)
+	}
+	return nil, err
+}
+
+func (g *GamePlayer) tryLoadFinalSnapshot(ctx context.Context, status types.GameStatus) (*finalSnapshot, error) {
+	claims, block, err := g.loader.FetchClaims(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch claims: %w", ErrLoader, err)
+	}
+	if len(claims) == 0 {
+		return nil, fmt.Errorf("%w: no claims", ErrLoader)
+	}
+	// The status may be served by a different node to the claims so check it hasn't changed.
+	current, err := g.loader.GetGameStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch game status: %w", ErrLoader, err)
+	}
+	if current != status {
+		return nil, fmt.Errorf("%w: game status changed from %v to %v", ErrLoader, status, current)
+	}
+	return &finalSnapshot{
+		GameSnapshot:  &GameSnapshot{Claims: claims, Block: block},
+		status:        status,
+		decidingClaim: decidingClaim(claims, status),
+		resolvedAt:    g.fetchResolvedAt(ctx, block),
+	}, nil
+}
+
+// fetchResolvedAt returns when the game resolved, given it had resolved by block.
+// The outcome is still reported if the time can't be loaded, such as when an archive node is needed to find the
+// resolving block, so failures are logged and a zero time returned.
+func (g *GamePlayer) fetchResolvedAt(ctx context.Context, block eth.L1BlockRef) time.Time {
+	if g.resolutions == nil || block.Number == 0 {
+		return time.Time{}
+	}
+	resolvedAt, err := g.resolutions.FetchResolvedAt(ctx, g.inProgressBlock, block.Number)
+	if errors.Is(err, ErrResolvingBlockUnknown) {
+		// Expected for games that had already resolved when the challenger started.
+		g.logger.Debug("Resolution time unknown for game not seen in progress", "err", err)
+		return time.Time{}
+	} else if err != nil {
+		g.logger.Warn("Unable to determine when game resolved", "err", err)
+		return time.Time{}
+	}
+	return resolvedAt
+}
+
+// decidingClaim returns the claim that decided the outcome of a resolved game.
+// The challenger wins when the root claim is countered, which is decided by the uncountered claim attacking it.
+// Otherwise the root claim stood.
+func decidingClaim(claims []types.Claim, status types.GameStatus) types.Claim {
+	if status == types.GameStatusChallengerWon {
+		for _, claim := range claims[1:] {
+			if claim.ParentContractIndex == 0 && !claim.Countered {
+				return claim
+			}
+		}
+	}
+	return claims[0]
+}
+
+// resolveClaims returns the status a game with claims resolves with according to the rules of the dispute game.
+// A claim is countered if any of its children is uncountered, or if it was stepped against when it has no
+// children. The challenger wins if the root claim is countered.
+func resolveClaims(claims []types.Claim) types.GameStatus {
+	children := make(map[int][]int)
+	for i, claim := range claims[1:] {
+		children[claim.ParentContractIndex] = append(children[claim.ParentContractIndex], i+1)
+	}
+	countered := make([]bool, len(claims))
+	// Children are always added after their parent so resolve from the last claim back to the root.
+	for i := len(claims) - 1; i >= 0; i-- {
+		if len(children[i]) == 0 {
+			countered[i] = claims[i].Countered
+			continue
+		}
+		countered[i] = slices.ContainsFunc(children[i], func(child int) bool { return !countered[child] })
+	}
+	if countered[0] {
+		return types.GameStatusChallengerWon
+	}
+	return types.GameStatusDefenderWon
+}
+
+// insufficientClock returns true and the longest remaining clock if every uncountered claim has too little time
+// left to counter before the game resolves. Once the clocks have expired the agent still acts so it can resolve the game.
+func (g *GamePlayer) insufficientClock(snapshot *GameSnapshot) (time.Duration, bool) {
+	if g.minMoveClock == 0 {
+		return 0, false
+	}
+	remaining, ok := snapshot.LongestRemainingClock(g.gameDuration)
+	if !ok || remaining == 0 || remaining >= g.minMoveClock {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// loadSnapshot loads the game state for the current cycle.
+// If the block the previous cycle was loaded at is no longer canonical, everything learnt from
+// previous cycles is discarded and the game is reloaded from scratch.
+// Returns ErrClaimCountDecreased if fewer claims are loaded than in the previous cycle.
+func (g *GamePlayer) loadSnapshot(ctx context.Context) (*GameSnapshot, error) {
+	reorged, err := g.checkCanonical(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims, block, err := g.loader.FetchClaims(ctx)
+	if err != nil {
+		// The state from previous cycles is left unchanged so the next cycle retries the same load.
+		return nil, fmt.Errorf("%w: failed to fetch claims: %w", ErrLoader, err)
+	}
+	if reorged {
+		g.lastClaimCount = 0
+		if g.agent != nil {
+			g.agent.Reset()
+		}
+	}
+	snapshot := &GameSnapshot{Claims: claims, Block: block}
+	count := snapshot.ClaimCount()
+	prevCount := g.lastClaimCount
+	g.lastClaimCount = count
+	// The first load has nothing to compare against, so isn't counted as claims being added.
+	g.claimsAdded = prevCount > 0 && count > prevCount
+	g.lastBlock = block.ID()
+	if count < prevCount {
+		return nil, fmt.Errorf("%w from %v to %v", ErrClaimCountDecreased, prevCount, count)
+	}
+	return snapshot, nil
+}
+
+// checkCanonical verifies the block the previous snapshot was loaded at is still canonical.
+// When it isn't, reorged is true and the state from previous cycles must be reset once the next load succeeds.
+func (g *GamePlayer) checkCanonical(ctx context.Context) (reorged bool, err error) {
+	if g.lastBlock == (eth.BlockID{}) {
+		return false, nil
+	}
+	hash, err := g.loader.BlockHashAt(ctx, g.lastBlock.Number)
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to check previous block is canonical: %w", ErrLoader, err)
+	}
+	if hash != g.lastBlock.Hash {
+		g.logger.Warn("Reorg detected", "number", g.lastBlock.Number, "old", g.lastBlock.Hash, "new", hash)
+		return true, nil
+	}
+	return false, nil
+}
+
+// recordActTime records the total time taken to load and act on the game, warning if it exceeds the budget.
+func (g *GamePlayer) recordActTime(total time.Duration, loadTime time.Duration) {
+	g.metrics.RecordActDuration(g.addr, phaseTotal, total)
+	if g.actBudget > 0 && total > g.actBudget {
+		g.logger.Warn("Acting on game exceeded time budget", "duration", total, "budget", g.actBudget, "load", loadTime)
+	}
+}
+
+// Status returns the game status loaded by the last ProgressGame, or the terminal status once the game is complete.
+func (g *GamePlayer) Status() types.GameStatus {
+	return g.status
+}
+
+// ClockDeadline returns when the soonest expiring chess clock loaded by the last ProgressGame runs out, or false if
+// it is unknown, no clock is running or the game is abandoned.
+func (g *GamePlayer) ClockDeadline() (time.Time, bool) {
+	return g.soonestDeadline, !g.soonestDeadline.IsZero()
+}
+
+// ProgressError returns the last error that occurred during the last ProgressGame, nil if there was none.
+func (g *GamePlayer) ProgressError() error {
+	return g.progressErr
+}
+
+// ClaimCount returns the number of claims loaded by the last ProgressGame.
+func (g *GamePlayer) ClaimCount() uint64 {
+	return g.lastClaimCount
+}
+
+// NextCheckDelay returns how long to wait before the game should next be progressed.
+// It is 0 if the game should be checked again at the next block.
+func (g *GamePlayer) NextCheckDelay() time.Duration {
+	return g.nextCheckDelay
+}
+
+// updateNextCheckDelay relaxes the poll interval when no chess clock in the snapshot is close to expiring, unless
+// claims were added since the previous cycle and the contested interval is shorter.
+func (g *GamePlayer) updateNextCheckDelay(snapshot *GameSnapshot) {
+	if g.relaxedInterval == 0 {
+		return
+	}
+	remaining, ok := snapshot.RemainingClock(g.gameDuration)
+	if !ok {
+		g.logger.Debug("Chess clock unavailable, using default poll interval")
+		return
+	}
+	if remaining > g.urgentThreshold {
+		g.nextCheckDelay = g.relaxedInterval
+	}
+	if g.claimsAdded && g.contestedInterval < g.nextCheckDelay {
+		g.nextCheckDelay = g.contestedInterval
+	}
+	g.logger.Trace("Updated next check delay", "remaining", remaining, "contested", g.claimsAdded, "delay", g.nextCheckDelay)
+}
+
+// updateClockDeadline records when the soonest expiring chess clock in the snapshot runs out.
+// Abandoned games aren't tracked as there is no intention to respond before their clocks expire.
+func (g *GamePlayer) updateClockDeadline(snapshot *GameSnapshot) {
+	deadline, ok := g.clockDeadline(snapshot)
+	if !ok || g.abandonReason != "" {
+		deadline = time.Time{}
+	}
+	g.soonestDeadline = deadline
+	if g.clocks == nil {
+		return
+	}
+	if deadline.IsZero() {
+		g.clocks.Remove(g.addr)
+		return
+	}
+	g.clocks.Update(g.addr, deadline)
+}
+
+// clockDeadline returns when the soonest expiring chess clock in the snapshot runs out, or false if none is running.
+func (g *GamePlayer) clockDeadline(snapshot *GameSnapshot) (time.Time, bool) {
+	remaining, ok := snapshot.RemainingClock(g.gameDuration)
+	if !ok || remaining == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(snapshot.Block.Time), 0).Add(remaining), true
+}
+
+// recordState records a summary of the game's state in the status registry, if any.
+func (g *GamePlayer) recordState(snapshot *GameSnapshot, status types.GameStatus) {
+	if g.statuses == nil {
+		return
+	}
+	deadline, _ := g.clockDeadline(snapshot)
+	g.statuses.RecordState(g.addr, status, snapshot.ClaimCount(), deadline)
+}
+
+// updateOutlook analyzes the outlook of the game if its claim tree changed since it was last analyzed.
+func (g *GamePlayer) updateOutlook(ctx context.Context, snapshot *GameSnapshot) {
+	if g.analyze == nil || g.statuses == nil || snapshot.ClaimCount() == g.analyzedClaims {
+		return
+	}
+	outlook, err := g.analyze(ctx, snapshot.Claims)
+	if err != nil {
+		g.logger.Warn("Failed to analyze game position", "err", err)
+		return
+	}
+	g.analyzedClaims = snapshot.ClaimCount()
+	g.logger.Debug("Analyzed game position", "outlook", outlook)
+	g.statuses.RecordOutlook(g.addr, outlook)
+}
+
+// recordError records the most recent error progressing the game, reported by ProgressError and in the status
+// registry, if any.
+func (g *GamePlayer) recordError(err error) {
+	g.progressErr = err
+	if g.statuses == nil {
+		return
+	}
+	g.statuses.RecordError(g.addr, err)
+}
+
+// logGameResult logs and records whether the resolved game was won.
+func (g *GamePlayer) logGameResult(final *finalSnapshot) {
+	ctx := []interface{}{"status", final.status, "claims", final.ClaimCount(), "deciding_claim", final.decidingClaim.ContractIndex}
+	if !final.resolvedAt.IsZero() {
+		ctx = append(ctx, "resolved_at", final.resolvedAt)
+	}
+	if winner := final.winner(); winner != (common.Address{}) {
+		ctx = append(ctx, "winner", winner)
+	}
+	if g.abandonReason != "" {
+		ctx = append(ctx, "abandoned_reason", g.abandonReason)
+	}
+	if g.won(final.status) {
+		g.metrics.RecordGameWon(g.addr)
+		g.logger.Info("Game won", ctx...)
 	} else {
-		g.logGameStatus(ctx, status)
-		g.completed = status != types.GameStatusInProgress
-		return g.completed
+		g.metrics.RecordGameLost(g.addr)
+		g.logger.Error("Game lost", ctx...)
 	}
-	return false
 }
 
-func (g *GamePlayer) logGameStatus(ctx context.Context, status types.GameStatus) {
-	if status == types.GameStatusInProgress {
-		claimCount, err := g.loader.GetClaimCount(ctx)
+// recordTimeToResolution records the time from when the game was first observed until it was seen resolved.
+// Games that had already resolved when first observed aren't recorded, as the time says nothing about how long
+// they took to resolve.
+func (g *GamePlayer) recordTimeToResolution(ctx context.Context, status types.GameStatus) {
+	if g.firstSeen.IsZero() || g.inProgressBlock == 0 {
+		return
+	}
+	if g.gameType == nil {
+		gameType, err := g.gameTypes.FetchGameType(ctx)
 		if err != nil {
-			g.logger.Error("Failed to get claim count for in progress game", "err", err)
+			g.logger.Warn("Failed to fetch the game type, not recording time to resolution", "err", err)
 			return
 		}
-		g.logger.Info("Game info", "claims", claimCount, "status", status)
+		g.gameType = &gameType
+	}
+	outcome := strings.ToLower(strings.ReplaceAll(status.String(), " ", "_"))
+	g.metrics.RecordTimeToResolution(*g.gameType, outcome, g.clock.Now().Sub(g.firstSeen))
+}
+
+// emitResolved emits the resolution of the game, including when it resolved and who it credits where known.
+func (g *GamePlayer) emitResolved(final *finalSnapshot) {
+	decidingIndex := final.decidingClaim.ContractIndex
+	event := types.Event{Type: types.EventGameResolved, Status: final.status.String(), ClaimIndex: &decidingIndex, Reason: g.abandonReason}
+	if !final.resolvedAt.IsZero() {
+		event.ResolvedAt = &final.resolvedAt
+	}
+	if winner := final.winner(); winner != (common.Address{}) {
+		event.Winner = &winner
+	}
+	g.emitEvent(event)
+}
+
+// checkResolution alerts if the game resolved contrary to the outcome expected from the output validation, or to
+// the outcome its final claims resolve to, and records the game as having a disputed resolution.
+// Such a resolution may be the result of a vulnerability so needs to be investigated rather than treated as done.
+func (g *GamePlayer) checkResolution(final *finalSnapshot) {
+	expected := types.GameStatusChallengerWon
+	if g.defendRoot {
+		expected = types.GameStatusDefenderWon
+	}
+	resolved := resolveClaims(final.Claims)
+	var reasons []string
+	if final.status != expected {
+		reasons = append(reasons, fmt.Sprintf("output validation expected %v", expected))
+	}
+	if final.status != resolved {
+		reasons = append(reasons, fmt.Sprintf("claims resolve to %v", resolved))
+		// The claims are what the contract resolves so they take precedence over our view of the output.
+		expected = resolved
+	}
+	if len(reasons) == 0 {
 		return
 	}
-	var expectedStatus types.GameStatus
-	if g.agreeWithProposedOutput {
-		expectedStatus = types.GameStatusChallengerWon
-	} else {
-		expectedStatus = types.GameStatusDefenderWon
+	reason := strings.Join(reasons, ", ")
+	g.logger.Error("Game resolution disputed", "status", final.status, "expected", expected, "reason", reason)
+	g.metrics.RecordDisputedResolution(g.addr)
+	g.emitEvent(types.Event{Type: types.EventResolutionDisputed, Status: final.status.String(), Reason: reason})
+	if g.statuses != nil {
+		g.statuses.RecordDisputedResolution(g.addr, expected)
 	}
-	if expectedStatus == status {
-		g.logger.Info("Game won", "status", status)
-	} else {
-		g.logger.Error("Game lost", "status", status)
+}
+
+// won returns true if the terminal status is the outcome the challenger was playing for.
+func (g *GamePlayer) won(status types.GameStatus) bool {
+	if g.defendRoot {
+		return status == types.GameStatusDefenderWon
+	}
+	return status == types.GameStatusChallengerWon
+}
+
+// checkAbandoned checks if the game has been abandoned, releasing the trace provider when it first is.
+func (g *GamePlayer) checkAbandoned() error {
+	if g.abandoned == nil || g.abandonReason != "" {
+		return nil
+	}
+	record, ok, err := g.abandoned.Abandoned(g.addr)
+	if err != nil || !ok {
+		return err
+	}
+	g.abandonReason = record.Reason
+	g.logger.Warn("Game abandoned, no longer making moves", "reason", record.Reason, "abandoned_at", record.Time)
+	g.releaseTrace()
+	g.emitEvent(types.Event{Type: types.EventGameAbandoned, Time: record.Time, Reason: record.Reason})
+	return nil
+}
+
+// claimBonds claims the challenger's bonds from the won game. Returns true once they are claimed.
+// If the bonds are held for a delay after the game resolved, they aren't claimed and the game isn't checked again
+// until it elapses.
+func (g *GamePlayer) claimBonds(ctx context.Context) bool {
+	if g.bondClaimDelay > 0 && !g.resolvedAt.IsZero() {
+		unlock := g.resolvedAt.Add(g.bondClaimDelay)
+		if wait := unlock.Sub(g.clock.Now()); wait > 0 {
+			g.logger.Debug("Bonds locked until claim delay elapses", "resolved_at", g.resolvedAt, "unlock", unlock)
+			g.nextCheckDelay = wait
+			return false
+		}
+		g.nextCheckDelay = 0
+	}
+	claimed, err := g.claimer.ClaimBonds(ctx)
+	if errors.Is(err, responder.ErrBondsLocked) {
+		g.logger.Info("Bonds not yet claimable, will retry", "err", err)
+		g.nextCheckDelay = g.terminalInterval
+		return false
+	} else if err != nil {
+		g.logger.Error("Failed to claim bonds, will retry", "err", err)
+		g.recordError(err)
+		g.nextCheckDelay = g.terminalInterval
+		return false
+	}
+	if claimed.Sign() > 0 {
+		g.metrics.RecordBondsClaimed(g.addr, claimed)
+	}
+	g.claimPending = false
+	return true
+}
+
+// reconcileJournal reconciles the actions journaled before the player was created, such as before a restart, with
+// the game's claims the first time they are loaded, and again while any are pending. Actions that didn't land are
+// left for the agent to make again, while the agent's responder skips those that landed or are pending.
+func (g *GamePlayer) reconcileJournal(ctx context.Context, snapshot *GameSnapshot) error {
+	if g.journal == nil || g.journalReconciled {
+		return nil
+	}
+	reconciled, err := g.journal.Reconcile(ctx, g.addr, snapshot.Claims)
+	if err != nil {
+		return err
+	}
+	pending := false
+	for _, action := range reconciled {
+		g.logger.Info("Reconciled pending action", "type", action.Type, "parent", action.ParentIndex, "value", action.Value, "tx", action.TxHash, "outcome", action.Outcome)
+		pending = pending || action.Outcome == ReconcilePending
+	}
+	if g.reconciled != nil {
+		g.reconciled.Reconciled(reconciled)
+	}
+	// Pending actions are reconciled again each cycle until they land or are dropped.
+	g.journalReconciled = !pending
+	return nil
+}
+
+// releaseTrace releases the trace provider's shared resources, if any. Only the first call has any effect.
+func (g *GamePlayer) releaseTrace() {
+	if g.pregen != nil {
+		g.pregen.Stop(g.addr)
+	}
+	if g.closeTrace == nil {
+		return
+	}
+	if err := g.closeTrace(); err != nil {
+		g.logger.Error("Failed to release trace provider", "err", err)
+	}
+	g.closeTrace = nil
+}
+
+// notifyResolved invokes the resolved callback, if any, the first time it is called.
+// A panicking callback is logged rather than stopping the game from being progressed.
+func (g *GamePlayer) notifyResolved(status types.GameStatus) {
+	if g.onResolved == nil || g.resolvedNotified {
+		return
+	}
+	g.resolvedNotified = true
+	defer func() {
+		if r := recover(); r != nil {
+			g.logger.Error("Resolved callback panicked", "status", status, "panic", r)
+		}
+	}()
+	g.onResolved(g.addr, status, g.won(status))
+}
+
+// emitEvent emits event for the game, if events are being emitted.
+func (g *GamePlayer) emitEvent(event types.Event) {
+	if g.events == nil {
+		return
+	}
+	event.Game = g.addr
+	g.events.Emit(event)
+}
+
+// recordProgress records the game's claim count and whether the agent has a response pending, which it doesn't once
+// the game is abandoned or conceded as it no longer responds.
+func (g *GamePlayer) recordProgress(snapshot *GameSnapshot) {
+	if g.progress == nil {
+		return
+	}
+	pending := false
+	if responder, ok := g.agent.(pendingResponder); ok && g.abandonReason == "" {
+		if _, conceded := g.insufficientClock(snapshot); !conceded {
+			pending = responder.ResponsePending()
+		}
 	}
+	g.progress.Record(g.addr, snapshot.ClaimCount(), pending)
+}
+
+// unplayablePrestate returns true if err reports that the game's absolute prestate can't be played by the trace
+// provider, rather than a failure to load either prestate.
+func unplayablePrestate(err error) bool {
+	return errors.Is(err, ErrPrestateMismatch) || errors.Is(err, ErrPrestateInvalidLength)
+}
+
+func (g *GamePlayer) recordPrestateValidation(err error) {
+	if g.health == nil {
+		return
+	}
+	g.health.RecordPrestateValidation(g.addr, err)
 }
 
 type PrestateLoader interface {
 	FetchAbsolutePrestateHash(ctx context.Context) ([]byte, error)
 }
 
+type RootClaimLoader interface {
+	FetchRootClaim(ctx context.Context) (types.Claim, error)
+}
+
+// AgreeWithRootClaim returns true if the game's root claim matches the trace provider's output.
+func AgreeWithRootClaim(ctx context.Context, trace types.TraceProvider, loader RootClaimLoader, gameDepth uint64) (bool, error) {
+	root, err := loader.FetchRootClaim(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to get the root claim: %w", ErrLoader, err)
+	}
+	expected, err := expectedRootClaim(ctx, trace, gameDepth)
+	if err != nil {
+		return false, err
+	}
+	return expected == root.Value, nil
+}
+
 // ValidateAbsolutePrestate validates the absolute prestate of the fault game.
-func ValidateAbsolutePrestate(ctx context.Context, trace types.TraceProvider, loader PrestateLoader) error {
-	providerPrestate, err := trace.AbsolutePreState(ctx)
+// The trace provider's prestate must match the onchain prestate. While the prestate is being upgraded, games with
+// one of the accepted prestates are valid but are played by a trace provider with that prestate, so
+// [ErrPrestateNotPlayed] is returned for them instead of a mismatch.
+func ValidateAbsolutePrestate(ctx context.Context, logger log.Logger, trace types.TraceProvider, loader PrestateLoader, accepted []common.Hash) error {
+	providerPrestateHash, err := trace.AbsolutePreStateCommitment(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get the trace provider's absolute prestate: %w", err)
+		return fmt.Errorf("%w: failed to get the absolute prestate: %w", ErrTraceProvider, err)
 	}
-	providerPrestateHash := crypto.Keccak256(providerPrestate)
 	onchainPrestate, err := loader.FetchAbsolutePrestateHash(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get the onchain absolute prestate: %w", err)
+		return fmt.Errorf("%w: failed to get the onchain absolute prestate: %w", ErrLoader, err)
 	}
-	if !bytes.Equal(providerPrestateHash, onchainPrestate) {
-		return fmt.Errorf("trace provider's absolute prestate does not match onchain absolute prestate")
+	// BytesToHash would pad or truncate the hash, which would only be reported as a mismatch.
+	if len(onchainPrestate) != common.HashLength {
+		return fmt.Errorf("%w: %v bytes, expected %v", ErrPrestateInvalidLength, len(onchainPrestate), common.HashLength)
 	}
-	return nil
+	onchain := common.BytesToHash(onchainPrestate)
+	if onchain == providerPrestateHash {
+		logger.Info("Absolute prestate matched", "prestate", onchain)
+		return nil
+	}
+	if slices.Contains(accepted, onchain) {
+		return fmt.Errorf("%w: game %v is accepted, trace provider %v", ErrPrestateNotPlayed, onchain, providerPrestateHash)
+	}
+	return &PrestateMismatchError{Onchain: onchain, Provider: providerPrestateHash}
 }
@@ -4,11 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
@@ -35,6 +48,1207 @@ func TestProgressGame_LogErrorFromAct(t *testing.T) {
 	require.Equal(t, uint64(1), msg.GetContextValue("claims"))
 }
 
+func TestProgressGame_ReportProgressError(t *testing.T) {
+	_, game, actor := setupProgressGameTest(t, true)
+	actor.actErr = errors.New("boom")
+	require.False(t, game.ProgressGame(context.Background()))
+	require.ErrorIs(t, game.ProgressError(), actor.actErr)
+
+	actor.actErr = nil
+	require.False(t, game.ProgressGame(context.Background()))
+	require.NoError(t, game.ProgressError(), "should clear the error once a progression succeeds")
+}
+
+func TestProgressGame_LoadClaimsOncePerCycle(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	gameState.claimCount = 3
+	done := game.ProgressGame(context.Background())
+	require.False(t, done, "should not be done")
+	require.Equal(t, 1, gameState.fetchCount, "should load claims once")
+	require.Equal(t, uint64(3), gameState.actSnapshot.ClaimCount(), "should act on loaded claims")
+
+	msg := handler.FindLog(log.LvlInfo, "Game info")
+	require.NotNil(t, msg)
+	require.Equal(t, uint64(3), msg.GetContextValue("claims"))
+	require.Equal(t, uint64(3), game.metrics.(*stubGameMetrics).claims)
+}
+
+func TestProgressGame_DedupeGameInfo(t *testing.T) {
+	infoLogs := func(handler *testlog.CapturingHandler) int {
+		count := 0
+		for _, record := range handler.Logs {
+			if record.Lvl == log.LvlInfo && record.Msg == "Game info" {
+				count++
+			}
+		}
+		return count
+	}
+
+	t.Run("SkipUnchanged", func(t *testing.T) {
+		handler, game, _ := setupProgressGameTest(t, true)
+		game.infoInterval = time.Hour
+		game.ProgressGame(context.Background())
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, infoLogs(handler), "should not log unchanged game again")
+	})
+
+	t.Run("LogClaimCountChange", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		game.infoInterval = time.Hour
+		game.ProgressGame(context.Background())
+		gameState.claimCount = 2
+		game.ProgressGame(context.Background())
+		require.Equal(t, 2, infoLogs(handler))
+		last := &testlog.HelperRecord{Record: handler.Logs[len(handler.Logs)-1]}
+		require.Equal(t, uint64(2), last.GetContextValue("claims"))
+	})
+
+	t.Run("LogAfterInterval", func(t *testing.T) {
+		handler, game, _ := setupProgressGameTest(t, true)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		game.clock = cl
+		game.infoInterval = time.Hour
+		game.ProgressGame(context.Background())
+		cl.AdvanceTime(59 * time.Minute)
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, infoLogs(handler))
+		cl.AdvanceTime(time.Minute)
+		game.ProgressGame(context.Background())
+		require.Equal(t, 2, infoLogs(handler), "should log unchanged game once the interval passes")
+	})
+
+	t.Run("OnlyLogChangesWithoutInterval", func(t *testing.T) {
+		handler, game, _ := setupProgressGameTest(t, true)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		game.clock = cl
+		game.ProgressGame(context.Background())
+		cl.AdvanceTime(24 * time.Hour)
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, infoLogs(handler))
+	})
+
+	t.Run("AlwaysLogResolution", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		game.infoInterval = time.Hour
+		game.ProgressGame(context.Background())
+		gameState.status = types.GameStatusChallengerWon
+		game.ProgressGame(context.Background())
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Game won"))
+	})
+
+	t.Run("AlwaysLogErrors", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		game.infoInterval = time.Hour
+		gameState.actErr = errors.New("boom")
+		game.ProgressGame(context.Background())
+		game.ProgressGame(context.Background())
+		errs := 0
+		for _, record := range handler.Logs {
+			if record.Msg == "Error when acting on game" {
+				errs++
+			}
+		}
+		require.Equal(t, 2, errs)
+		require.Equal(t, 1, infoLogs(handler))
+	})
+}
+
+func TestProgressGame_SkipActingWhenLoadFails(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	gameState.fetchErr = errors.New("boom")
+	done := game.ProgressGame(context.Background())
+	require.False(t, done, "should not be done")
+	require.Zero(t, gameState.callCount, "should not act")
+	errLog := handler.FindLog(log.LvlError, "Failed to load game state")
+	require.NotNil(t, errLog, "should log error")
+	require.ErrorIs(t, errLog.GetContextValue("err").(error), ErrLoader)
+	require.ErrorIs(t, errLog.GetContextValue("err").(error), gameState.fetchErr)
+}
+
+func TestProgressGame_AbortCycleWhenClaimCountDecreases(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	gameState.claimCount = 5
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, gameState.callCount)
+
+	// Claims can't be removed so a decrease indicates an L1 reorg.
+	gameState.claimCount = 4
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, gameState.callCount, "should not act on reorged data")
+	msg := handler.FindLog(log.LvlWarn, "Possible L1 reorg detected")
+	require.NotNil(t, msg)
+	require.ErrorIs(t, msg.GetContextValue("err").(error), ErrClaimCountDecreased)
+
+	// The next cycle uses the new count as the baseline.
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 2, gameState.callCount, "should act again once count is stable")
+}
+
+func TestProgressGame_ReloadWhenPreviousBlockReorged(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	oldBlock := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+	newBlock := eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 10}
+	gameState.claims = []types.Claim{{ClaimData: types.ClaimData{Value: common.Hash{0x01}}}}
+	gameState.block = oldBlock
+	gameState.canonical = map[uint64]common.Hash{10: oldBlock.Hash}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Nil(t, handler.FindLog(log.LvlWarn, "Reorg detected"))
+	require.Zero(t, gameState.resetCount)
+
+	// Same number of claims but a different claim set at a replacement block.
+	reorgedClaims := []types.Claim{{ClaimData: types.ClaimData{Value: common.Hash{0x02}}}}
+	gameState.claims = reorgedClaims
+	gameState.block = newBlock
+	gameState.canonical = map[uint64]common.Hash{10: newBlock.Hash}
+	require.False(t, game.ProgressGame(context.Background()))
+
+	msg := handler.FindLog(log.LvlWarn, "Reorg detected")
+	require.NotNil(t, msg)
+	require.Equal(t, oldBlock.Hash, msg.GetContextValue("old"))
+	require.Equal(t, newBlock.Hash, msg.GetContextValue("new"))
+	require.Equal(t, 2, gameState.callCount)
+	require.Equal(t, 1, gameState.resetCount)
+	require.Equal(t, reorgedClaims, gameState.actSnapshot.Claims)
+	require.Equal(t, newBlock, gameState.actSnapshot.Block)
+}
+
+func TestProgressGame_ReorgResetsClaimCount(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	gameState.claimCount = 5
+	gameState.block = eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+	gameState.canonical = map[uint64]common.Hash{10: common.Hash{0xaa}}
+	require.False(t, game.ProgressGame(context.Background()))
+
+	// The reorg removed a claim but, as the previous block was detected as reorged, loading starts from scratch.
+	gameState.claimCount = 4
+	gameState.block = eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 10}
+	gameState.canonical = map[uint64]common.Hash{10: common.Hash{0xbb}}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.NotNil(t, handler.FindLog(log.LvlWarn, "Reorg detected"))
+	require.Nil(t, handler.FindLog(log.LvlWarn, "Possible L1 reorg detected"))
+	require.Equal(t, 2, gameState.callCount)
+	require.Equal(t, uint64(4), gameState.actSnapshot.ClaimCount())
+}
+
+// TestProgressGame_PartialLoadFailure tests that a failed load is discarded as a whole, even if some claims were
+// loaded, and leaves the game's state unchanged so the next cycle retries it.
+func TestProgressGame_PartialLoadFailure(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	m := newStubGameMetrics()
+	game.metrics = m
+	gameState.claimCount = 5
+	gameState.block = eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+	gameState.canonical = map[uint64]common.Hash{10: common.Hash{0xaa}}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, gameState.statusCount)
+
+	// The previous block is reorged out and the load fails after returning some of the claims.
+	gameState.claimCount = 3
+	gameState.block = eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 10}
+	gameState.canonical = map[uint64]common.Hash{10: common.Hash{0xbb}}
+	gameState.partialClaims = make([]types.Claim, 2)
+	gameState.fetchErr = errors.New("boom")
+	require.False(t, game.ProgressGame(context.Background()))
+	msg := handler.FindLog(log.LvlError, "Failed to load game state")
+	require.NotNil(t, msg)
+	require.ErrorIs(t, msg.GetContextValue("err").(error), gameState.fetchErr)
+	require.Equal(t, 1, gameState.callCount, "should not act on a partial load")
+	require.Equal(t, 1, gameState.statusCount, "should not check the game status after a failed load")
+	require.Equal(t, uint64(5), m.claims)
+	require.Equal(t, uint64(5), game.lastClaimCount)
+	require.Equal(t, common.Hash{0xaa}, game.lastBlock.Hash)
+
+	// The retry detects the reorg again and loads from scratch.
+	gameState.fetchErr = nil
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Nil(t, handler.FindLog(log.LvlWarn, "Possible L1 reorg detected"))
+	require.Equal(t, 2, gameState.callCount)
+	require.Equal(t, uint64(3), gameState.actSnapshot.ClaimCount())
+	require.Equal(t, 2, gameState.statusCount)
+}
+
+// TestProgressGame_ResolveAfterClockExpires tests that the agent starts resolving the game once the clock is
+// advanced past the expiry of the chess clock of the uncountered attack on the root claim.
+func TestProgressGame_ResolveAfterClockExpires(t *testing.T) {
+	maxDepth := 4
+	gameDuration := 10 * time.Minute
+	_, game, gameState := setupProgressGameTest(t, false)
+	gameState.clock.AdvanceTime(10_000 * time.Second)
+	gameState.blockAtClock = true
+	made := uint64(gameState.clock.Now().Unix())
+	root := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPosition(0, 0)},
+		Claimant:            common.Address{0xcc},
+		Clock:               made,
+		ParentContractIndex: math.MaxUint32,
+	}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xbb}, Position: root.Position.Attack()},
+		Parent:              root.ClaimData,
+		Claimant:            common.Address{0xdd},
+		Clock:               made,
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+	}
+	gameState.claims = []types.Claim{root, attack}
+	responder := &stubResponder{callResolveStatus: types.GameStatusDefenderWon}
+	game.agent = NewAgent(metrics.NoopMetrics, game.clock, game.addr, maxDepth, MoveLimits{}, gameDuration, test.NewAlphabetWithProofProvider(t, maxDepth, nil), responder, nil, &stubStrategy{}, false, game.logger)
+
+	gameState.clock.AdvanceTime(gameDuration/2 - time.Second)
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Empty(t, responder.actions, "should not resolve before the clock expires")
+	require.Zero(t, responder.resolveCount)
+
+	gameState.clock.AdvanceTime(time.Second)
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, []string{"resolveClaim 1", "resolveClaim 0"}, responder.actions)
+	require.Equal(t, 1, responder.resolveCount)
+}
+
+// TestProgressGame_ActDurations tests that the time taken by each phase of acting on a game is recorded and that
+// the phases account for the total.
+func TestProgressGame_ActDurations(t *testing.T) {
+	maxDepth := 4
+	handler, game, gameState := setupProgressGameTest(t, true)
+	m := &stubDurationMetrics{Metricer: metrics.NoopMetrics, durations: make(map[string]time.Duration)}
+	game.metrics = m
+	game.actBudget = time.Millisecond
+	gameState.claims = []types.Claim{test.NewAlphabetClaimBuilder(t, maxDepth).CreateRootClaim(false)}
+	gameState.fetchDelay = 10 * time.Millisecond
+	responder := &stubResponder{callResolveErr: errors.New("not resolvable"), delay: 20 * time.Millisecond, clock: gameState.clock}
+	game.agent = NewAgent(m, game.clock, game.addr, maxDepth, MoveLimits{}, 0, test.NewAlphabetWithProofProvider(t, maxDepth, nil), responder, nil, nil, true, game.logger)
+
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, responder.respondCount)
+	require.Equal(t, gameState.fetchDelay, m.durations[phaseLoad])
+	require.Equal(t, responder.delay, m.durations[phaseRespond])
+	require.Zero(t, m.durations[phaseSolve])
+	require.Equal(t, gameState.fetchDelay+responder.delay, m.durations[phaseTotal])
+
+	msg := handler.FindLog(log.LvlWarn, "Acting on game exceeded time budget")
+	require.NotNil(t, msg)
+	require.Equal(t, m.durations[phaseTotal], msg.GetContextValue("duration"))
+	require.Equal(t, game.actBudget, msg.GetContextValue("budget"))
+
+	t.Run("WithinBudget", func(t *testing.T) {
+		handler, game, _ := setupProgressGameTest(t, true)
+		game.actBudget = time.Minute
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Nil(t, handler.FindLog(log.LvlWarn, "Acting on game exceeded time budget"))
+	})
+}
+
+type stubDurationMetrics struct {
+	metrics.Metricer
+	durations map[string]time.Duration
+}
+
+func (s *stubDurationMetrics) RecordActDuration(_ common.Address, phase string, duration time.Duration) {
+	s.durations[phase] = duration
+}
+
+func TestProgressGame_NextCheckDelay(t *testing.T) {
+	// Root claim made at time 100 with a 1000 second game so each side has 500 seconds.
+	claims := []types.Claim{{Clock: 100}}
+	tests := []struct {
+		name            string
+		relaxedInterval time.Duration
+		gameDuration    time.Duration
+		blockTime       uint64
+		expected        time.Duration
+	}{
+		{"Disabled", 0, 1000 * time.Second, 150, 0},
+		{"Relaxed", time.Minute, 1000 * time.Second, 150, time.Minute},
+		{"Urgent", time.Minute, 1000 * time.Second, 550, 0},
+		{"GameDurationUnavailable", time.Minute, 0, 150, 0},
+		{"BlockTimeUnavailable", time.Minute, 1000 * time.Second, 0, 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, game, gameState := setupProgressGameTest(t, true)
+			game.relaxedInterval = test.relaxedInterval
+			game.urgentThreshold = 100 * time.Second
+			game.gameDuration = test.gameDuration
+			gameState.claims = claims
+			gameState.block = eth.L1BlockRef{Time: test.blockTime}
+			require.False(t, game.ProgressGame(context.Background()))
+			require.Equal(t, test.expected, game.NextCheckDelay())
+		})
+	}
+}
+
+func TestProgressGame_ConcedeWithInsufficientClock(t *testing.T) {
+	// Root claim made at time 100 with a 1000 second game so each side has 500 seconds.
+	claims := []types.Claim{{Clock: 100}}
+	tests := []struct {
+		name         string
+		minMoveClock time.Duration
+		gameDuration time.Duration
+		blockTime    uint64
+		concede      bool
+	}{
+		{"Disabled", 0, 1000 * time.Second, 550, false},
+		{"SufficientClock", 100 * time.Second, 1000 * time.Second, 450, false},
+		{"InsufficientClock", 100 * time.Second, 1000 * time.Second, 550, true},
+		{"ClockExpired", 100 * time.Second, 1000 * time.Second, 700, false},
+		{"GameDurationUnavailable", 100 * time.Second, 0, 550, false},
+		{"BlockTimeUnavailable", 100 * time.Second, 1000 * time.Second, 0, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			handler, game, gameState := setupProgressGameTest(t, true)
+			game.minMoveClock = test.minMoveClock
+			game.gameDuration = test.gameDuration
+			gameState.claims = claims
+			gameState.block = eth.L1BlockRef{Time: test.blockTime}
+			require.False(t, game.ProgressGame(context.Background()))
+			msg := handler.FindLog(log.LvlWarn, "Insufficient clock remaining, conceding")
+			if test.concede {
+				require.NotNil(t, msg)
+				require.Zero(t, gameState.callCount, "should not act")
+			} else {
+				require.Nil(t, msg)
+				require.Equal(t, 1, gameState.callCount, "should act")
+			}
+		})
+	}
+
+	t.Run("UsesLongestRemainingClock", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		game.minMoveClock = 100 * time.Second
+		game.gameDuration = 1000 * time.Second
+		// The root claim has 50 seconds left to counter but the later claim has 450 seconds.
+		gameState.claims = []types.Claim{
+			{Clock: 100},
+			{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(2)}, Clock: 500, ContractIndex: 1},
+		}
+		gameState.block = eth.L1BlockRef{Time: 550}
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 1, gameState.callCount, "should act")
+	})
+}
+
+func TestProgressGame_ContestedNextCheckDelay(t *testing.T) {
+	setup := func(t *testing.T) (*GamePlayer, *stubGameState) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		game.relaxedInterval = time.Minute
+		game.contestedInterval = 10 * time.Second
+		game.urgentThreshold = 100 * time.Second
+		game.gameDuration = 1000 * time.Second
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 150}
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, time.Minute, game.NextCheckDelay(), "first load should not count as contested")
+		return game, gameState
+	}
+
+	t.Run("ClaimsAdded", func(t *testing.T) {
+		game, gameState := setup(t)
+		gameState.claims = append(gameState.claims, types.Claim{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(2)}, Clock: 140, ContractIndex: 1})
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 10*time.Second, game.NextCheckDelay())
+
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, time.Minute, game.NextCheckDelay(), "should relax once no claims are added")
+	})
+
+	t.Run("UrgentNotSlowed", func(t *testing.T) {
+		game, gameState := setup(t)
+		gameState.claims = append(gameState.claims, types.Claim{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(2)}, Clock: 140, ContractIndex: 1})
+		gameState.block = eth.L1BlockRef{Time: 590}
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Zero(t, game.NextCheckDelay(), "urgent games should still be checked every block")
+	})
+}
+
+func TestProgressGame_TerminalNextCheckDelay(t *testing.T) {
+	t.Run("BondsLocked", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, false)
+		game.terminalInterval = time.Hour
+		game.claimer = &stubBondClaimer{err: responder.ErrBondsLocked}
+		gameState.status = types.GameStatusDefenderWon
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, time.Hour, game.NextCheckDelay(), "should not check a resolved game again until the terminal interval")
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, time.Hour, game.NextCheckDelay())
+	})
+
+	t.Run("ClaimFailed", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, false)
+		game.terminalInterval = time.Hour
+		game.claimer = &stubBondClaimer{err: errors.New("boom")}
+		gameState.status = types.GameStatusDefenderWon
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, time.Hour, game.NextCheckDelay())
+	})
+
+	t.Run("FinalStateUnavailable", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, false)
+		game.terminalInterval = time.Hour
+		gameState.status = types.GameStatusDefenderWon
+		failures := make([]error, finalSnapshotAttempts)
+		for i := range failures {
+			failures[i] = errors.New("boom")
+		}
+		gameState.fetchErrs = append([]error{nil}, failures...)
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, time.Hour, game.NextCheckDelay())
+	})
+}
+
+func TestProgressGame_ResetNextCheckDelayWhenLoadFails(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	game.relaxedInterval = time.Minute
+	game.gameDuration = 1000 * time.Second
+	gameState.claims = []types.Claim{{Clock: 100}}
+	gameState.block = eth.L1BlockRef{Time: 150}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, time.Minute, game.NextCheckDelay())
+
+	gameState.fetchErr = errors.New("boom")
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Zero(t, game.NextCheckDelay())
+}
+
+func TestProgressGame_TrackClockDeadline(t *testing.T) {
+	t.Run("RecordSoonestDeadline", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		game.clocks = NewClockTracker()
+		game.gameDuration = 1000 * time.Second
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 150}
+		require.False(t, game.ProgressGame(context.Background()))
+		expected := []GameDeadline{{Game: game.addr, Deadline: time.Unix(600, 0)}}
+		require.Equal(t, expected, game.clocks.ExpiringBetween(time.Unix(0, 0), time.Unix(1000, 0)))
+		deadline, ok := game.ClockDeadline()
+		require.True(t, ok)
+		require.Equal(t, time.Unix(600, 0), deadline)
+		require.Equal(t, uint64(1), game.ClaimCount())
+	})
+
+	t.Run("ReportDeadlineWithoutTracker", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 150}
+		require.False(t, game.ProgressGame(context.Background()))
+		_, ok := game.ClockDeadline()
+		require.False(t, ok, "should not know the deadline without the game duration")
+
+		game.gameDuration = 1000 * time.Second
+		require.False(t, game.ProgressGame(context.Background()))
+		deadline, ok := game.ClockDeadline()
+		require.True(t, ok)
+		require.Equal(t, time.Unix(600, 0), deadline)
+	})
+
+	t.Run("RemoveWhenClockExpired", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		game.clocks = NewClockTracker()
+		game.clocks.Update(game.addr, time.Unix(600, 0))
+		game.gameDuration = 1000 * time.Second
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 700}
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Empty(t, game.clocks.ExpiringBetween(time.Unix(0, 0), time.Unix(1000, 0)))
+		_, ok := game.ClockDeadline()
+		require.False(t, ok)
+	})
+
+	t.Run("RemoveWhenComplete", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		game.clocks = NewClockTracker()
+		game.gameDuration = 1000 * time.Second
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 150}
+		gameState.status = types.GameStatusChallengerWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Empty(t, game.clocks.ExpiringBetween(time.Unix(0, 0), time.Unix(1000, 0)))
+	})
+}
+
+func TestProgressGame_TrackProgress(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	_, game, gameState := setupProgressGameTest(t, true)
+	game.progress = NewProgressTracker(cl)
+	gameState.claims = []types.Claim{{Clock: 100}}
+	gameState.responsePending = true
+	require.False(t, game.ProgressGame(context.Background()))
+	cl.AdvanceTime(2 * time.Minute)
+	require.Equal(t, []common.Address{game.addr}, game.progress.GetStaleGames(time.Minute))
+
+	gameState.claims = append(gameState.claims, types.Claim{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(2)}, ContractIndex: 1})
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Empty(t, game.progress.GetStaleGames(time.Minute), "should not be stale when claims are added")
+
+	cl.AdvanceTime(2 * time.Minute)
+	gameState.status = types.GameStatusChallengerWon
+	require.True(t, game.ProgressGame(context.Background()))
+	require.Empty(t, game.progress.GetStaleGames(time.Minute), "should exclude resolved games")
+}
+
+func TestProgressGame_NotStaleWithoutResponsePending(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	_, game, gameState := setupProgressGameTest(t, true)
+	game.progress = NewProgressTracker(cl)
+	gameState.claims = []types.Claim{{Clock: 100}}
+	require.False(t, game.ProgressGame(context.Background()))
+	cl.AdvanceTime(2 * time.Hour)
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Empty(t, game.progress.GetStaleGames(time.Minute), "should not be stale while uncontested")
+
+	gameState.responsePending = true
+	game.abandonReason = "stuck"
+	require.False(t, game.ProgressGame(context.Background()))
+	cl.AdvanceTime(2 * time.Minute)
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Empty(t, game.progress.GetStaleGames(time.Minute), "should not be stale once abandoned")
+}
+
+func TestProgressGame_ReconcileJournal(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	gameState.claims = []types.Claim{{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(1)}}}
+	txHash := common.Hash{0xdd}
+	action := PendingAction{ParentIndex: 0, Type: PendingAttack, Value: common.Hash{0x01}, TxHash: &txHash}
+	txs := &stubTxSource{pending: map[common.Hash]bool{txHash: true}}
+	game.journal = newTestActionJournal(t, txs, t.TempDir())
+	require.NoError(t, game.journal.Add(game.addr, action))
+	responder := &journalingResponder{journal: game.journal, game: game.addr, logger: testlog.Logger(t, log.LvlCrit)}
+	game.reconciled = responder
+
+	require.False(t, game.ProgressGame(context.Background()))
+	require.True(t, responder.alreadySent(action), "should not resend the pending action")
+	require.False(t, game.journalReconciled, "should reconcile again while actions are pending")
+
+	txs.receipts = map[common.Hash]*ethtypes.Receipt{txHash: {TxHash: txHash}}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.True(t, responder.alreadySent(action), "should not resend the landed action")
+	require.True(t, game.journalReconciled)
+	pending, err := game.journal.Pending(game.addr)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func TestProgressGame_RecordClaimLoadHealth(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	health := &stubHealthRecorder{}
+	game.health = health
+	gameState.fetchErr = errors.New("boom")
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Len(t, health.claimLoads, 1)
+	require.ErrorIs(t, health.claimLoads[0], gameState.fetchErr)
+
+	gameState.fetchErr = nil
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Len(t, health.claimLoads, 2)
+	require.NoError(t, health.claimLoads[1])
+	require.Empty(t, health.removed)
+
+	gameState.status = types.GameStatusDefenderWon
+	require.True(t, game.ProgressGame(context.Background()))
+	require.Equal(t, []common.Address{game.addr}, health.removed, "should stop tracking resolved games")
+}
+
+type stubHealthRecorder struct {
+	claimLoads []error
+	removed    []common.Address
+}
+
+func (s *stubHealthRecorder) RecordClaimLoad(_ common.Address, err error) {
+	s.claimLoads = append(s.claimLoads, err)
+}
+
+func (s *stubHealthRecorder) RecordPrestateValidation(_ common.Address, _ error) {}
+
+func (s *stubHealthRecorder) RemoveGame(game common.Address) {
+	s.removed = append(s.removed, game)
+}
+
+func TestProgressGame_RecordStatus(t *testing.T) {
+	t.Run("State", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		game.statuses = NewStatusRegistry(cl)
+		game.gameDuration = 1000 * time.Second
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 150}
+		game.ProgressGame(context.Background())
+		deadline := time.Unix(600, 0)
+		require.Equal(t, []types.GameSummary{{
+			Game:          game.addr,
+			Status:        types.GameStatusInProgress.String(),
+			Claims:        1,
+			ClockDeadline: &deadline,
+			Updated:       cl.Now(),
+		}}, game.statuses.Summaries())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		game.statuses = NewStatusRegistry(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		gameState.actErr = errors.New("boom")
+		game.ProgressGame(context.Background())
+		summaries := game.statuses.Summaries()
+		require.Len(t, summaries, 1)
+		require.Equal(t, "boom", summaries[0].LastError)
+	})
+}
+
+func TestProgressGame_PregenerateSteps(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	gameState.claimCount = 2
+	var requested [][]types.Claim
+	game.pregenerate = func(claims []types.Claim) {
+		requested = append(requested, claims)
+	}
+	game.ProgressGame(context.Background())
+	require.Len(t, requested, 1)
+	require.Len(t, requested[0], 2, "should request steps for the loaded claims")
+
+	game.abandonReason = "bad prestate"
+	game.ProgressGame(context.Background())
+	require.Len(t, requested, 1, "should not request steps for abandoned games")
+}
+
+func TestProgressGame_AnalyzeOutlook(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	game.statuses = NewStatusRegistry(clock.NewDeterministicClock(time.Unix(1000, 0)))
+	var analyzed []int
+	var analyzeErr error
+	game.analyze = func(_ context.Context, claims []types.Claim) (Outlook, error) {
+		analyzed = append(analyzed, len(claims))
+		return OutlookWinning, analyzeErr
+	}
+
+	game.ProgressGame(context.Background())
+	game.ProgressGame(context.Background())
+	require.Equal(t, []int{1}, analyzed, "should only analyze when the claim tree changes")
+	require.Equal(t, "winning", game.statuses.Summaries()[0].Outlook)
+
+	gameState.claimCount = 2
+	analyzeErr = errors.New("boom")
+	game.ProgressGame(context.Background())
+	analyzeErr = nil
+	game.ProgressGame(context.Background())
+	game.ProgressGame(context.Background())
+	require.Equal(t, []int{1, 2, 2}, analyzed, "should retry failed analysis")
+}
+
+func TestProgressGame_EmitResolvedEvent(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	events := &stubEventSink{}
+	game.events = events
+	gameState.claims = []types.Claim{{Clock: 100}, {ContractIndex: 1, ParentContractIndex: 0}}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Empty(t, events.events, "should not emit events while in progress")
+
+	gameState.status = types.GameStatusChallengerWon
+	require.True(t, game.ProgressGame(context.Background()))
+	require.True(t, game.ProgressGame(context.Background()))
+	counterIndex := 1
+	require.Equal(t, []types.Event{{Type: types.EventGameResolved, Game: game.addr, ClaimIndex: &counterIndex, Status: "Challenger Won"}}, events.events)
+}
+
+func TestProgressGame_TimeToResolution(t *testing.T) {
+	setup := func(t *testing.T) (*GamePlayer, *stubGameState, *stubGameMetrics, *clock.DeterministicClock) {
+		_, game, gameState := setupProgressGameTest(t, true)
+		m := newStubGameMetrics()
+		game.metrics = m
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		game.clock = cl
+		game.firstSeen = cl.Now()
+		game.gameTypes = &stubGameTypeLoader{gameType: 1}
+		gameState.block = eth.L1BlockRef{Number: 10, Hash: common.Hash{0x10}}
+		gameState.canonical = map[uint64]common.Hash{10: {0x10}}
+		return game, gameState, m, cl
+	}
+
+	t.Run("RecordWhenResolved", func(t *testing.T) {
+		game, gameState, m, cl := setup(t)
+		gameType := uint8(0)
+		game.gameType = &gameType
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Zero(t, m.resolutions, "should not record while in progress")
+
+		cl.AdvanceTime(5 * time.Minute)
+		gameState.status = types.GameStatusChallengerWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 1, m.resolutions, "should record once")
+		require.Equal(t, uint8(0), m.resolutionGameType)
+		require.Equal(t, "challenger_won", m.resolutionOutcome)
+		require.Equal(t, 5*time.Minute, m.timeToResolution)
+	})
+
+	t.Run("LoadGameTypeIfUnknown", func(t *testing.T) {
+		game, gameState, m, cl := setup(t)
+		require.False(t, game.ProgressGame(context.Background()))
+		cl.AdvanceTime(time.Minute)
+		gameState.status = types.GameStatusDefenderWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, uint8(1), m.resolutionGameType)
+		require.Equal(t, "defender_won", m.resolutionOutcome)
+		require.Equal(t, time.Minute, m.timeToResolution)
+	})
+
+	t.Run("SkipGameTypeLoadFailure", func(t *testing.T) {
+		game, gameState, m, _ := setup(t)
+		game.gameTypes = &stubGameTypeLoader{err: errors.New("boom")}
+		require.False(t, game.ProgressGame(context.Background()))
+		gameState.status = types.GameStatusDefenderWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Zero(t, m.resolutions)
+	})
+
+	t.Run("SkipAlreadyResolved", func(t *testing.T) {
+		game, gameState, m, _ := setup(t)
+		gameState.status = types.GameStatusChallengerWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Zero(t, m.resolutions, "should not record games that resolved before they were observed")
+	})
+}
+
+type stubGameTypeLoader struct {
+	gameType uint8
+	err      error
+}
+
+func (s *stubGameTypeLoader) FetchGameType(_ context.Context) (uint8, error) {
+	return s.gameType, s.err
+}
+
+// TestProgressGame_DisputedResolution tests that games resolving contrary to the output validation or to their
+// claims are alerted on and recorded as disputed rather than plainly done.
+func TestProgressGame_DisputedResolution(t *testing.T) {
+	uncounteredRoot := []types.Claim{{}}
+	counteredRoot := []types.Claim{{}, {ContractIndex: 1, ParentContractIndex: 0}}
+	setup := func(t *testing.T, status types.GameStatus, claims []types.Claim) (*testlog.CapturingHandler, *GamePlayer, *stubEventSink) {
+		handler, game, gameState := setupProgressGameTest(t, false)
+		gameState.status = status
+		gameState.claims = claims
+		events := &stubEventSink{}
+		game.events = events
+		game.statuses = NewStatusRegistry(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		require.True(t, game.ProgressGame(context.Background()))
+		return handler, game, events
+	}
+	disputeEvents := func(events *stubEventSink) []types.Event {
+		var disputes []types.Event
+		for _, event := range events.events {
+			if event.Type == types.EventResolutionDisputed {
+				disputes = append(disputes, event)
+			}
+		}
+		return disputes
+	}
+
+	t.Run("Agree", func(t *testing.T) {
+		handler, game, events := setup(t, types.GameStatusDefenderWon, uncounteredRoot)
+		require.Nil(t, handler.FindLog(log.LvlError, "Game resolution disputed"))
+		require.Zero(t, game.metrics.(*stubGameMetrics).disputed)
+		require.Empty(t, disputeEvents(events))
+		require.Empty(t, game.statuses.DisputedResolutions())
+	})
+
+	t.Run("ContraryToOutputValidation", func(t *testing.T) {
+		handler, game, events := setup(t, types.GameStatusChallengerWon, counteredRoot)
+		record := handler.FindLog(log.LvlError, "Game resolution disputed")
+		require.NotNil(t, record)
+		require.Equal(t, types.GameStatusDefenderWon, record.GetContextValue("expected"))
+		require.Equal(t, 1, game.metrics.(*stubGameMetrics).disputed)
+		require.Equal(t, []types.Event{{
+			Type:   types.EventResolutionDisputed,
+			Game:   game.addr,
+			Status: types.GameStatusChallengerWon.String(),
+			Reason: "output validation expected Defender Won",
+		}}, disputeEvents(events))
+		disputed := game.statuses.DisputedResolutions()
+		require.Len(t, disputed, 1)
+		require.Equal(t, types.GameStatusDefenderWon.String(), disputed[0].ExpectedStatus)
+	})
+
+	t.Run("ContraryToClaims", func(t *testing.T) {
+		// The game was won but the contract resolved it contrary to its claims.
+		handler, game, events := setup(t, types.GameStatusDefenderWon, counteredRoot)
+		record := handler.FindLog(log.LvlError, "Game resolution disputed")
+		require.NotNil(t, record)
+		require.Equal(t, "claims resolve to Challenger Won", record.GetContextValue("reason"))
+		require.Equal(t, 1, game.metrics.(*stubGameMetrics).won)
+		require.Len(t, disputeEvents(events), 1)
+		disputed := game.statuses.DisputedResolutions()
+		require.Len(t, disputed, 1)
+		require.Equal(t, types.GameStatusChallengerWon.String(), disputed[0].ExpectedStatus)
+	})
+}
+
+func TestResolveClaims(t *testing.T) {
+	claim := func(parent int, countered bool) types.Claim {
+		return types.Claim{ParentContractIndex: parent, Countered: countered}
+	}
+	tests := []struct {
+		name     string
+		claims   []types.Claim
+		expected types.GameStatus
+	}{
+		{"RootOnly", []types.Claim{claim(0, false)}, types.GameStatusDefenderWon},
+		{"UncounteredAttack", []types.Claim{claim(0, false), claim(0, false)}, types.GameStatusChallengerWon},
+		{"CounteredAttack", []types.Claim{claim(0, false), claim(0, false), claim(1, false)}, types.GameStatusDefenderWon},
+		{"SteppedLeaf", []types.Claim{claim(0, false), claim(0, false), claim(1, true)}, types.GameStatusChallengerWon},
+		{"OneUncounteredAttack", []types.Claim{claim(0, false), claim(0, false), claim(1, false), claim(0, false)}, types.GameStatusChallengerWon},
+		// The countered flag of claims with children is ignored as it is set by the contract's own resolution.
+		{"IgnoreFlagOfParents", []types.Claim{claim(0, true), claim(0, true), claim(1, false)}, types.GameStatusDefenderWon},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, resolveClaims(test.claims))
+		})
+	}
+}
+
+func TestProgressGame_FinalSnapshot(t *testing.T) {
+	t.Run("PartialFailure", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		events := &stubEventSink{}
+		game.events = events
+		gameState.status = types.GameStatusChallengerWon
+		gameState.claims = []types.Claim{{}, {ContractIndex: 1, ParentContractIndex: 0}}
+		// The claims load for the cycle succeeds but every attempt to load the final state fails.
+		failures := make([]error, finalSnapshotAttempts)
+		for i := range failures {
+			failures[i] = errors.New("boom")
+		}
+		gameState.fetchErrs = append([]error{nil}, failures...)
+
+		require.False(t, game.ProgressGame(context.Background()), "should not be done until final state loads")
+		require.Equal(t, 1+finalSnapshotAttempts, gameState.fetchCount)
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Unable to load final game state, will retry"))
+		require.Nil(t, handler.FindLog(log.LvlInfo, "Game won"))
+		m := game.metrics.(*stubGameMetrics)
+		require.Zero(t, m.won)
+		require.Zero(t, m.lost)
+		require.Zero(t, m.status, "should not record terminal status")
+		require.Empty(t, events.events)
+
+		require.True(t, game.ProgressGame(context.Background()), "should be done once final state loads")
+		require.Equal(t, 1, m.won)
+		require.Equal(t, uint8(types.GameStatusChallengerWon), m.status)
+		require.Len(t, events.events, 1)
+	})
+
+	t.Run("RetryWithinCycle", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		gameState.status = types.GameStatusDefenderWon
+		gameState.fetchErrs = []error{nil, errors.New("boom")}
+
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 3, gameState.fetchCount)
+		require.Nil(t, handler.FindLog(log.LvlWarn, "Unable to load final game state, will retry"))
+		require.NotNil(t, handler.FindLog(log.LvlError, "Game lost"))
+		require.Equal(t, 1, game.metrics.(*stubGameMetrics).lost)
+	})
+
+	t.Run("DecidingClaim", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		events := &stubEventSink{}
+		game.events = events
+		gameState.status = types.GameStatusChallengerWon
+		gameState.claims = []types.Claim{
+			{ContractIndex: 0, Countered: true},
+			{ContractIndex: 1, ParentContractIndex: 0, Countered: true},
+			{ContractIndex: 2, ParentContractIndex: 1},
+			{ContractIndex: 3, ParentContractIndex: 0},
+		}
+
+		require.True(t, game.ProgressGame(context.Background()))
+		won := handler.FindLog(log.LvlInfo, "Game won")
+		require.NotNil(t, won)
+		require.Equal(t, 3, won.GetContextValue("deciding_claim"))
+		require.Equal(t, uint64(4), won.GetContextValue("claims"))
+		require.Equal(t, 3, *events.events[0].ClaimIndex)
+		require.Equal(t, uint64(4), game.metrics.(*stubGameMetrics).claims)
+	})
+}
+
+func TestGameSnapshot_RemainingClock(t *testing.T) {
+	gameDuration := 1000 * time.Second
+	root := types.Claim{Clock: 100, Countered: true}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Position: types.NewPositionFromGIndex(2)},
+		Clock:               200,
+		ClockDuration:       100 * time.Second,
+		ParentContractIndex: 0,
+		ContractIndex:       1,
+		Countered:           true,
+	}
+	counter := types.Claim{
+		ClaimData:           types.ClaimData{Position: types.NewPositionFromGIndex(4)},
+		Clock:               300,
+		ClockDuration:       100 * time.Second,
+		ParentContractIndex: 1,
+		ContractIndex:       2,
+	}
+
+	t.Run("UsesParentClockDuration", func(t *testing.T) {
+		snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter}, Block: eth.L1BlockRef{Time: 350}}
+		remaining, ok := snapshot.RemainingClock(gameDuration)
+		require.True(t, ok)
+		// Countering the latest claim uses the attack claim's 100s plus 50s elapsed.
+		require.Equal(t, 350*time.Second, remaining)
+	})
+
+	t.Run("UsesMinimumOfUncounteredClaims", func(t *testing.T) {
+		uncounteredRoot := root
+		uncounteredRoot.Countered = false
+		snapshot := &GameSnapshot{Claims: []types.Claim{uncounteredRoot, attack, counter}, Block: eth.L1BlockRef{Time: 350}}
+		remaining, ok := snapshot.RemainingClock(gameDuration)
+		require.True(t, ok)
+		require.Equal(t, 250*time.Second, remaining)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter}, Block: eth.L1BlockRef{Time: 10_000}}
+		remaining, ok := snapshot.RemainingClock(gameDuration)
+		require.True(t, ok)
+		require.Zero(t, remaining)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		snapshot := &GameSnapshot{Claims: []types.Claim{root, attack, counter}}
+		_, ok := snapshot.RemainingClock(gameDuration)
+		require.False(t, ok)
+		snapshot.Block.Time = 350
+		_, ok = snapshot.RemainingClock(0)
+		require.False(t, ok)
+	})
+}
+
+func TestProgressGame_CreateAgentOnFirstUse(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	createCount := 0
+	game.agent = nil
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		createCount++
+		return gameState, nil
+	}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, createCount, "should only create agent once")
+	require.Equal(t, 2, gameState.callCount)
+}
+
+func TestProgressGame_RetryCreateAgent(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	game.agent = nil
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		return nil, errors.New("boom")
+	}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.NotNil(t, handler.FindLog(log.LvlError, "Failed to create agent"))
+	require.Zero(t, gameState.fetchCount, "should not load game state")
+
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		return gameState, nil
+	}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, gameState.callCount)
+}
+
+func TestProgressGame_SkipWhenOutputNotAvailable(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	game.statuses = NewStatusRegistry(clock.NewDeterministicClock(time.Unix(1000, 0)))
+	game.agent = nil
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		return nil, fmt.Errorf("%w: %w", ErrTraceProvider, types.ErrOutputNotAvailable)
+	}
+	require.False(t, game.ProgressGame(context.Background()), "should retry the game")
+	msg := handler.FindLog(log.LvlDebug, "L2 output not available yet, skipping game")
+	require.NotNil(t, msg)
+	require.ErrorIs(t, msg.GetContextValue("err").(error), types.ErrOutputNotAvailable)
+	require.Nil(t, handler.FindLog(log.LvlError, "Failed to create agent"), "should not treat as a failure")
+	require.Empty(t, game.statuses.Summaries(), "should not record an error")
+	require.Zero(t, gameState.fetchCount, "should not load game state")
+
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		return gameState, nil
+	}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, gameState.callCount, "should play the game once the output is available")
+}
+
+func TestProgressGame_SkipUnsupportedGameType(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	game.agent = nil
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedGameType, 42)
+	}
+	require.True(t, game.ProgressGame(context.Background()), "should treat game as skipped")
+	msg := handler.FindLog(log.LvlWarn, "Unsupported game type")
+	require.NotNil(t, msg)
+	require.ErrorIs(t, msg.GetContextValue("err").(error), ErrUnsupportedGameType)
+	require.Zero(t, gameState.callCount, "should not act")
+	require.Zero(t, gameState.fetchCount, "should not load game state")
+}
+
+func TestProgressGame_SkipPrestateNotPlayed(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	game.agent = nil
+	game.createAgent = func(ctx context.Context) (Actor, error) {
+		return nil, fmt.Errorf("failed to validate absolute prestate: %w", ErrPrestateNotPlayed)
+	}
+	require.True(t, game.ProgressGame(context.Background()), "should treat game as skipped")
+	msg := handler.FindLog(log.LvlInfo, "Game has an accepted absolute prestate played by a different trace provider, skipping game")
+	require.NotNil(t, msg)
+	require.Zero(t, gameState.callCount, "should not act")
+	require.Zero(t, gameState.fetchCount, "should not load game state")
+}
+
+func TestProgressGame_SkipPrestateMismatch(t *testing.T) {
+	for _, expected := range []error{&PrestateMismatchError{Onchain: common.Hash{0xaa}, Provider: common.Hash{0xbb}}, ErrPrestateInvalidLength} {
+		expected := expected
+		t.Run(expected.Error(), func(t *testing.T) {
+			handler, game, gameState := setupProgressGameTest(t, true)
+			game.agent = nil
+			game.createAgent = func(ctx context.Context) (Actor, error) {
+				return nil, fmt.Errorf("failed to validate absolute prestate: %w", expected)
+			}
+			require.True(t, game.ProgressGame(context.Background()), "should treat game as skipped")
+			msg := handler.FindLog(log.LvlWarn, "Game's absolute prestate doesn't match the trace provider, skipping game")
+			require.NotNil(t, msg)
+			require.ErrorIs(t, msg.GetContextValue("err").(error), expected)
+			require.NoError(t, game.ProgressError(), "should not report an error for a game that isn't played")
+			require.Zero(t, gameState.callCount, "should not act")
+		})
+	}
+}
+
+func TestTraceProviders(t *testing.T) {
+	providers := NewTraceProviders(testlog.Logger(t, log.LvlInfo), &config.Config{TraceTypes: []config.TraceType{config.TraceTypeAlphabet}, AlphabetTrace: "abcdefgh"}, "", common.Address{}, nil, nil, nil)
+	_, ok := providers.SelectTraceProvider(config.CannonFaultGameID)
+	require.False(t, ok, "should not support cannon games")
+
+	create, ok := providers.SelectTraceProvider(config.AlphabetFaultGameID)
+	require.True(t, ok)
+	provider, updater, err := create(context.Background(), 3)
+	require.NoError(t, err)
+	require.NotNil(t, provider)
+	require.NotNil(t, updater)
+}
+
+func TestTraceProviders_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+	providers := NewTraceProviders(testlog.Logger(t, log.LvlInfo), &config.Config{TraceTypes: []config.TraceType{config.TraceTypeFile}, TraceFile: path}, "", common.Address{}, nil, nil, nil)
+	_, ok := providers.SelectTraceProvider(config.CannonFaultGameID)
+	require.True(t, ok, "should support cannon games")
+
+	create, ok := providers.SelectTraceProvider(config.AlphabetFaultGameID)
+	require.True(t, ok, "should support alphabet games")
+	_, _, err := create(context.Background(), 3)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestTraceProviders_MultipleTypes(t *testing.T) {
+	cfg := &config.Config{
+		TraceTypes:    []config.TraceType{config.TraceTypeCannon, config.TraceTypeAlphabet},
+		AlphabetTrace: "abcdefgh",
+	}
+	providers := NewTraceProviders(testlog.Logger(t, log.LvlInfo), cfg, "", common.Address{}, nil, nil, nil)
+	_, ok := providers.SelectTraceProvider(config.CannonFaultGameID)
+	require.True(t, ok, "should support cannon games")
+
+	create, ok := providers.SelectTraceProvider(config.AlphabetFaultGameID)
+	require.True(t, ok, "should support alphabet games")
+	provider, _, err := create(context.Background(), 3)
+	require.NoError(t, err)
+	require.IsType(t, &alphabet.AlphabetTraceProvider{}, provider)
+}
+
+// TestProgressGame_Resolution tests when the game resolved and who it credits are reported once it resolves.
+func TestProgressGame_Resolution(t *testing.T) {
+	setup := func(t *testing.T) (*testlog.CapturingHandler, *GamePlayer, *stubGameState, *stubResolutionLoader, *stubEventSink) {
+		handler, game, gameState := setupProgressGameTest(t, true)
+		resolutions := &stubResolutionLoader{resolvedAt: time.Unix(5000, 0)}
+		game.resolutions = resolutions
+		events := &stubEventSink{}
+		game.events = events
+		game.statuses = NewStatusRegistry(clock.NewDeterministicClock(time.Unix(6000, 0)))
+		gameState.claims = []types.Claim{
+			{ContractIndex: 0, Claimant: common.Address{0xaa}},
+			{ContractIndex: 1, ParentContractIndex: 0, Claimant: common.Address{0xbb}},
+		}
+		gameState.block = eth.L1BlockRef{Number: 10, Hash: common.Hash{0x0a}}
+		gameState.canonical = map[uint64]common.Hash{10: {0x0a}}
+		require.False(t, game.ProgressGame(context.Background()))
+		gameState.status = types.GameStatusChallengerWon
+		gameState.block = eth.L1BlockRef{Number: 20}
+		return handler, game, gameState, resolutions, events
+	}
+
+	t.Run("Resolved", func(t *testing.T) {
+		handler, game, _, resolutions, events := setup(t)
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, [][2]uint64{{10, 20}}, resolutions.calls, "should search from the last block seen in progress")
+
+		resolvedAt := time.Unix(5000, 0)
+		winner := common.Address{0xbb}
+		won := handler.FindLog(log.LvlInfo, "Game won")
+		require.NotNil(t, won)
+		require.Equal(t, resolvedAt, won.GetContextValue("resolved_at"))
+		require.Equal(t, winner, won.GetContextValue("winner"))
+		require.Len(t, events.events, 1)
+		require.Equal(t, &resolvedAt, events.events[0].ResolvedAt)
+		require.Equal(t, &winner, events.events[0].Winner)
+		summaries := game.statuses.Summaries()
+		require.Len(t, summaries, 1)
+		require.Equal(t, &resolvedAt, summaries[0].ResolvedAt)
+		require.Equal(t, &winner, summaries[0].Winner)
+	})
+
+	t.Run("DefenderCredited", func(t *testing.T) {
+		_, game, gameState, _, events := setup(t)
+		gameState.status = types.GameStatusDefenderWon
+		gameState.claims[1].Countered = true
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, common.Address{0xaa}, *events.events[0].Winner)
+	})
+
+	t.Run("NotSeenInProgress", func(t *testing.T) {
+		handler, game, _, resolutions, _ := setup(t)
+		game.inProgressBlock = 0
+		resolutions.err = ErrResolvingBlockUnknown
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, [][2]uint64{{0, 20}}, resolutions.calls)
+		require.Nil(t, handler.FindLog(log.LvlWarn, "Unable to determine when game resolved"), "should not warn about games resolved before they were seen")
+		won := handler.FindLog(log.LvlInfo, "Game won")
+		require.NotNil(t, won)
+		require.Nil(t, won.GetContextValue("resolved_at"))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		handler, game, _, resolutions, events := setup(t)
+		resolutions.err = errors.New("boom")
+		require.True(t, game.ProgressGame(context.Background()), "should report the outcome without the resolution time")
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Unable to determine when game resolved"))
+		won := handler.FindLog(log.LvlInfo, "Game won")
+		require.NotNil(t, won)
+		require.Nil(t, won.GetContextValue("resolved_at"))
+		require.Nil(t, events.events[0].ResolvedAt)
+		require.Nil(t, game.statuses.Summaries()[0].ResolvedAt)
+	})
+}
+
+type stubResolutionLoader struct {
+	resolvedAt time.Time
+	err        error
+	calls      [][2]uint64
+}
+
+func (s *stubResolutionLoader) FetchResolvedAt(_ context.Context, from uint64, to uint64) (time.Time, error) {
+	s.calls = append(s.calls, [2]uint64{from, to})
+	return s.resolvedAt, s.err
+}
+
 func TestProgressGame_LogGameStatus(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -42,6 +1256,8 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 		agreeWithOutput bool
 		logLevel        log.Lvl
 		logMsg          string
+		won             int
+		lost            int
 	}{
 		{
 			name:            "GameLostAsDefender",
@@ -49,6 +1265,7 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			agreeWithOutput: false,
 			logLevel:        log.LvlError,
 			logMsg:          "Game lost",
+			lost:            1,
 		},
 		{
 			name:            "GameLostAsChallenger",
@@ -56,6 +1273,7 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			agreeWithOutput: true,
 			logLevel:        log.LvlError,
 			logMsg:          "Game lost",
+			lost:            1,
 		},
 		{
 			name:            "GameWonAsDefender",
@@ -63,6 +1281,7 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			agreeWithOutput: false,
 			logLevel:        log.LvlInfo,
 			logMsg:          "Game won",
+			won:             1,
 		},
 		{
 			name:            "GameWonAsChallenger",
@@ -70,6 +1289,7 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			agreeWithOutput: true,
 			logLevel:        log.LvlInfo,
 			logMsg:          "Game won",
+			won:             1,
 		},
 		{
 			name:            "GameInProgress",
@@ -91,6 +1311,65 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			errLog := handler.FindLog(test.logLevel, test.logMsg)
 			require.NotNil(t, errLog, "should log game result")
 			require.Equal(t, test.status, errLog.GetContextValue("status"))
+
+			m := game.metrics.(*stubGameMetrics)
+			require.Equal(t, test.won, m.won)
+			require.Equal(t, test.lost, m.lost)
+			require.Equal(t, uint8(test.status), m.status)
+		})
+	}
+}
+
+// TestProgressGame_LogGameStatusDefendingAgreedRoot tests the game result is classified by the side
+// the challenger acts for when it agrees with the proposed output but defends a root claim that matches its trace.
+func TestProgressGame_LogGameStatusDefendingAgreedRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   types.GameStatus
+		logLevel log.Lvl
+		logMsg   string
+		won      int
+		lost     int
+	}{
+		{
+			name:     "GameWon",
+			status:   types.GameStatusDefenderWon,
+			logLevel: log.LvlInfo,
+			logMsg:   "Game won",
+			won:      1,
+		},
+		{
+			name:     "GameLost",
+			status:   types.GameStatusChallengerWon,
+			logLevel: log.LvlError,
+			logMsg:   "Game lost",
+			lost:     1,
+		},
+		{
+			name:     "GameInProgress",
+			status:   types.GameStatusInProgress,
+			logLevel: log.LvlInfo,
+			logMsg:   "Game info",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			handler, game, gameState := setupProgressGameTest(t, true)
+			game.defendRoot = true
+			gameState.status = test.status
+
+			done := game.ProgressGame(context.Background())
+			require.Equal(t, 1, gameState.callCount, "should perform next actions")
+			require.Equal(t, test.status != types.GameStatusInProgress, done, "should be done when not in progress")
+			errLog := handler.FindLog(test.logLevel, test.logMsg)
+			require.NotNil(t, errLog, "should log game result")
+			require.Equal(t, test.status, errLog.GetContextValue("status"))
+
+			m := game.metrics.(*stubGameMetrics)
+			require.Equal(t, test.won, m.won)
+			require.Equal(t, test.lost, m.lost)
+			require.Equal(t, uint8(test.status), m.status)
 		})
 	}
 }
@@ -113,6 +1392,305 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestProgressGame_NotifyResolvedOnce(t *testing.T) {
+	tests := []struct {
+		name     string
+		agree    bool
+		status   types.GameStatus
+		expected bool
+	}{
+		{"AgreeChallengerWon", true, types.GameStatusChallengerWon, true},
+		{"AgreeDefenderWon", true, types.GameStatusDefenderWon, false},
+		{"DisagreeChallengerWon", false, types.GameStatusChallengerWon, false},
+		{"DisagreeDefenderWon", false, types.GameStatusDefenderWon, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, game, gameState := setupProgressGameTest(t, test.agree)
+			var calls []resolvedCall
+			game.onResolved = func(addr common.Address, status types.GameStatus, won bool) {
+				calls = append(calls, resolvedCall{addr, status, won})
+			}
+			gameState.status = types.GameStatusInProgress
+			require.False(t, game.ProgressGame(context.Background()))
+			require.Empty(t, calls, "should not notify while in progress")
+
+			gameState.status = test.status
+			require.True(t, game.ProgressGame(context.Background()))
+			require.True(t, game.ProgressGame(context.Background()))
+			require.Equal(t, []resolvedCall{{game.addr, test.status, test.expected}}, calls)
+		})
+	}
+}
+
+func TestProgressGame_ReleaseTraceWhenResolved(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, false)
+	closed := 0
+	game.closeTrace = func() error {
+		closed++
+		return nil
+	}
+	require.False(t, game.ProgressGame(context.Background()))
+	require.Zero(t, closed, "should not release trace while in progress")
+
+	gameState.status = types.GameStatusDefenderWon
+	require.True(t, game.ProgressGame(context.Background()))
+	require.True(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, closed, "should release trace once")
+}
+
+func TestProgressGame_RecoverFromResolvedCallbackPanic(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, true)
+	calls := 0
+	game.onResolved = func(common.Address, types.GameStatus, bool) {
+		calls++
+		panic("boom")
+	}
+	gameState.status = types.GameStatusChallengerWon
+	require.True(t, game.ProgressGame(context.Background()))
+	require.True(t, game.ProgressGame(context.Background()))
+	require.Equal(t, 1, calls)
+	msg := handler.FindLog(log.LvlError, "Resolved callback panicked")
+	require.NotNil(t, msg)
+	require.Equal(t, "boom", msg.GetContextValue("panic"))
+}
+
+func TestProgressGame_ClaimBonds(t *testing.T) {
+	tests := []struct {
+		name              string
+		agreeWithOutput   bool
+		status            types.GameStatus
+		expectClaimsCalls int
+	}{
+		{"InProgress", false, types.GameStatusInProgress, 0},
+		{"DefenderWonAsDefender", false, types.GameStatusDefenderWon, 1},
+		{"ChallengerWonAsDefender", false, types.GameStatusChallengerWon, 0},
+		{"ChallengerWonAsChallenger", true, types.GameStatusChallengerWon, 1},
+		{"DefenderWonAsChallenger", true, types.GameStatusDefenderWon, 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, game, gameState := setupProgressGameTest(t, test.agreeWithOutput)
+			claimer := &stubBondClaimer{}
+			game.claimer = claimer
+			gameState.status = test.status
+			game.ProgressGame(context.Background())
+			game.ProgressGame(context.Background())
+			require.Equal(t, test.expectClaimsCalls, claimer.calls)
+		})
+	}
+
+	t.Run("RecordClaimedAmount", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, false)
+		m := newStubGameMetrics()
+		game.metrics = m
+		game.claimer = &stubBondClaimer{claimed: big.NewInt(1000)}
+		gameState.status = types.GameStatusDefenderWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, big.NewInt(1000), m.bondsClaimed)
+	})
+
+	t.Run("RetryUntilClaimed", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, false)
+		claimer := &stubBondClaimer{err: responder.ErrBondsLocked}
+		game.claimer = claimer
+		gameState.status = types.GameStatusDefenderWon
+		require.False(t, game.ProgressGame(context.Background()), "should stay scheduled while bonds are locked")
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Bonds not yet claimable, will retry"))
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 2, claimer.calls)
+		require.Equal(t, 1, gameState.callCount, "should not act once the game is complete")
+
+		claimer.err = nil
+		require.True(t, game.ProgressGame(context.Background()))
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 3, claimer.calls, "should not claim again once claimed")
+	})
+
+	t.Run("WaitForClaimDelay", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, false)
+		claimer := &stubBondClaimer{}
+		game.claimer = claimer
+		game.resolutions = &stubResolutionLoader{resolvedAt: time.Unix(1000, 0)}
+		cl := clock.NewDeterministicClock(time.Unix(1600, 0))
+		game.clock = cl
+		game.bondClaimDelay = time.Hour
+		gameState.status = types.GameStatusDefenderWon
+		gameState.block = eth.L1BlockRef{Number: 10}
+		require.False(t, game.ProgressGame(context.Background()), "should wait for the delay after resolution")
+		require.Zero(t, claimer.calls)
+		require.Equal(t, 50*time.Minute, game.NextCheckDelay(), "should not check again until the delay elapses")
+
+		cl.AdvanceTime(50 * time.Minute)
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 1, claimer.calls)
+	})
+
+	t.Run("ClaimDelayWithUnknownResolution", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t, false)
+		claimer := &stubBondClaimer{}
+		game.claimer = claimer
+		game.bondClaimDelay = time.Hour
+		gameState.status = types.GameStatusDefenderWon
+		require.True(t, game.ProgressGame(context.Background()), "should rely on the claim reverting")
+		require.Equal(t, 1, claimer.calls)
+	})
+
+	t.Run("RetryFailure", func(t *testing.T) {
+		handler, game, gameState := setupProgressGameTest(t, false)
+		game.claimer = &stubBondClaimer{err: errors.New("boom")}
+		gameState.status = types.GameStatusDefenderWon
+		require.False(t, game.ProgressGame(context.Background()))
+		require.NotNil(t, handler.FindLog(log.LvlError, "Failed to claim bonds, will retry"))
+	})
+}
+
+type stubBondClaimer struct {
+	calls   int
+	claimed *big.Int
+	err     error
+}
+
+func (s *stubBondClaimer) ClaimBonds(ctx context.Context) (*big.Int, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.claimed == nil {
+		return big.NewInt(0), nil
+	}
+	return s.claimed, nil
+}
+
+func TestProgressGame_Abandoned(t *testing.T) {
+	setup := func(t *testing.T) (*testlog.CapturingHandler, *GamePlayer, *stubGameState, *AbandonedGames) {
+		handler, game, gameState := setupProgressGameTest(t, false)
+		dir := t.TempDir()
+		abandoned := NewAbandonedGames(clock.NewDeterministicClock(time.Unix(1000, 0)), func(common.Address) string {
+			return dir
+		})
+		game.abandoned = abandoned
+		return handler, game, gameState, abandoned
+	}
+
+	t.Run("StopActing", func(t *testing.T) {
+		handler, game, gameState, abandoned := setup(t)
+		events := &stubEventSink{}
+		game.events = events
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, gameState.callCount)
+
+		require.NoError(t, abandoned.Abandon(game.addr, "bad prestate"))
+		game.ProgressGame(context.Background())
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, gameState.callCount, "should not act once abandoned")
+		require.Equal(t, 3, gameState.fetchCount, "should keep loading the game")
+		msg := handler.FindLog(log.LvlWarn, "Game abandoned, no longer making moves")
+		require.NotNil(t, msg)
+		require.Equal(t, "bad prestate", msg.GetContextValue("reason"))
+		require.Len(t, events.events, 1)
+		require.Equal(t, types.EventGameAbandoned, events.events[0].Type)
+		require.Equal(t, "bad prestate", events.events[0].Reason)
+	})
+
+	t.Run("SkipCreatingAgent", func(t *testing.T) {
+		_, game, gameState, abandoned := setup(t)
+		game.agent = nil
+		game.createAgent = func(ctx context.Context) (Actor, error) {
+			t.Fatal("should not create agent for abandoned game")
+			return nil, nil
+		}
+		require.NoError(t, abandoned.Abandon(game.addr, "bad prestate"))
+		require.False(t, game.ProgressGame(context.Background()))
+		require.Equal(t, 1, gameState.fetchCount)
+	})
+
+	t.Run("MonitorUntilResolved", func(t *testing.T) {
+		handler, game, gameState, abandoned := setup(t)
+		claimer := &stubBondClaimer{}
+		game.claimer = claimer
+		events := &stubEventSink{}
+		game.events = events
+		require.NoError(t, abandoned.Abandon(game.addr, "bad prestate"))
+		require.False(t, game.ProgressGame(context.Background()))
+
+		gameState.status = types.GameStatusDefenderWon
+		require.True(t, game.ProgressGame(context.Background()))
+		require.Zero(t, gameState.callCount)
+		require.Equal(t, 1, claimer.calls, "should claim bonds of abandoned game")
+		msg := handler.FindLog(log.LvlInfo, "Game won")
+		require.NotNil(t, msg)
+		require.Equal(t, "bad prestate", msg.GetContextValue("abandoned_reason"))
+		resolved := events.events[len(events.events)-1]
+		require.Equal(t, types.EventGameResolved, resolved.Type)
+		require.Equal(t, "bad prestate", resolved.Reason)
+	})
+
+	t.Run("StopTrackingClock", func(t *testing.T) {
+		_, game, gameState, abandoned := setup(t)
+		game.clocks = NewClockTracker()
+		game.clocks.Update(game.addr, time.Unix(600, 0))
+		game.gameDuration = 1000 * time.Second
+		gameState.claims = []types.Claim{{Clock: 100}}
+		gameState.block = eth.L1BlockRef{Time: 150}
+		require.NoError(t, abandoned.Abandon(game.addr, "bad prestate"))
+		game.ProgressGame(context.Background())
+		require.Empty(t, game.clocks.ExpiringBetween(time.Unix(0, 0), time.Unix(1000, 0)))
+	})
+}
+
+// TestAgreeWithRootClaim tests the root claim is compared to the trace at the root position.
+func TestAgreeWithRootClaim(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+
+	t.Run("Agree", func(t *testing.T) {
+		loader := &stubRootClaimLoader{root: builder.CreateRootClaim(true)}
+		agree, err := AgreeWithRootClaim(context.Background(), builder.CorrectTraceProvider(), loader, uint64(maxDepth))
+		require.NoError(t, err)
+		require.True(t, agree)
+	})
+
+	t.Run("Disagree", func(t *testing.T) {
+		loader := &stubRootClaimLoader{root: builder.CreateRootClaim(false)}
+		agree, err := AgreeWithRootClaim(context.Background(), builder.CorrectTraceProvider(), loader, uint64(maxDepth))
+		require.NoError(t, err)
+		require.False(t, agree)
+	})
+
+	t.Run("LoaderErrors", func(t *testing.T) {
+		loader := &stubRootClaimLoader{err: mockLoaderError}
+		_, err := AgreeWithRootClaim(context.Background(), builder.CorrectTraceProvider(), loader, uint64(maxDepth))
+		require.ErrorIs(t, err, ErrLoader)
+		require.ErrorIs(t, err, mockLoaderError)
+	})
+}
+
+type stubEventSink struct {
+	events []types.Event
+}
+
+func (s *stubEventSink) Emit(event types.Event) {
+	s.events = append(s.events, event)
+}
+
+type stubRootClaimLoader struct {
+	root types.Claim
+	err  error
+}
+
+func (s *stubRootClaimLoader) FetchRootClaim(ctx context.Context) (types.Claim, error) {
+	return s.root, s.err
+}
+
+type resolvedCall struct {
+	addr   common.Address
+	status types.GameStatus
+	won    bool
+}
+
 // TestValidateAbsolutePrestate tests that the absolute prestate is validated
 // correctly by the service component.
 func TestValidateAbsolutePrestate(t *testing.T) {
@@ -121,7 +1699,7 @@ func TestValidateAbsolutePrestate(t *testing.T) {
 		prestateHash := crypto.Keccak256(prestate)
 		mockTraceProvider := newMockTraceProvider(false, prestate)
 		mockLoader := newMockPrestateLoader(false, prestateHash)
-		err := ValidateAbsolutePrestate(context.Background(), mockTraceProvider, mockLoader)
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, nil)
 		require.NoError(t, err)
 	})
 
@@ -129,7 +1707,8 @@ func TestValidateAbsolutePrestate(t *testing.T) {
 		prestate := []byte{0x00, 0x01, 0x02, 0x03}
 		mockTraceProvider := newMockTraceProvider(true, prestate)
 		mockLoader := newMockPrestateLoader(false, prestate)
-		err := ValidateAbsolutePrestate(context.Background(), mockTraceProvider, mockLoader)
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, nil)
+		require.ErrorIs(t, err, ErrTraceProvider)
 		require.ErrorIs(t, err, mockTraceProviderError)
 	})
 
@@ -137,15 +1716,64 @@ func TestValidateAbsolutePrestate(t *testing.T) {
 		prestate := []byte{0x00, 0x01, 0x02, 0x03}
 		mockTraceProvider := newMockTraceProvider(false, prestate)
 		mockLoader := newMockPrestateLoader(true, prestate)
-		err := ValidateAbsolutePrestate(context.Background(), mockTraceProvider, mockLoader)
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, nil)
+		require.ErrorIs(t, err, ErrLoader)
 		require.ErrorIs(t, err, mockLoaderError)
 	})
 
 	t.Run("PrestateMismatch", func(t *testing.T) {
 		mockTraceProvider := newMockTraceProvider(false, []byte{0x00, 0x01, 0x02, 0x03})
-		mockLoader := newMockPrestateLoader(false, []byte{0x00})
-		err := ValidateAbsolutePrestate(context.Background(), mockTraceProvider, mockLoader)
-		require.Error(t, err)
+		mockLoader := newMockPrestateLoader(false, common.Hash{0xcc}.Bytes())
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, nil)
+		require.ErrorIs(t, err, ErrPrestateMismatch)
+		require.ErrorContains(t, err, common.Hash{0xcc}.Hex())
+		require.ErrorContains(t, err, crypto.Keccak256Hash([]byte{0x00, 0x01, 0x02, 0x03}).Hex())
+		var mismatch *PrestateMismatchError
+		require.ErrorAs(t, err, &mismatch)
+		require.Equal(t, common.Hash{0xcc}, mismatch.Onchain)
+		require.Equal(t, crypto.Keccak256Hash([]byte{0x00, 0x01, 0x02, 0x03}), mismatch.Provider)
+	})
+
+	t.Run("InvalidPrestateLength", func(t *testing.T) {
+		prestate := []byte{0x00, 0x01, 0x02, 0x03}
+		truncated := crypto.Keccak256(prestate)[:31]
+		mockTraceProvider := newMockTraceProvider(false, prestate)
+		mockLoader := newMockPrestateLoader(false, truncated)
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, nil)
+		require.ErrorIs(t, err, ErrPrestateInvalidLength)
+		require.NotErrorIs(t, err, ErrPrestateMismatch)
+	})
+
+	t.Run("LogsMatch", func(t *testing.T) {
+		prestate := []byte{0x00, 0x01, 0x02, 0x03}
+		logger := testlog.Logger(t, log.LvlInfo)
+		logs := testlog.Capture(logger)
+		mockTraceProvider := newMockTraceProvider(false, prestate)
+		mockLoader := newMockPrestateLoader(false, crypto.Keccak256(prestate))
+		err := ValidateAbsolutePrestate(context.Background(), logger, mockTraceProvider, mockLoader, []common.Hash{{0xaa}})
+		require.NoError(t, err)
+		msg := logs.FindLog(log.LvlInfo, "Absolute prestate matched")
+		require.NotNil(t, msg)
+		require.Equal(t, crypto.Keccak256Hash(prestate), msg.GetContextValue("prestate"))
+	})
+
+	t.Run("AcceptedOnchainPrestateNotPlayed", func(t *testing.T) {
+		mockTraceProvider := newMockTraceProvider(false, []byte{0x00, 0x01, 0x02, 0x03})
+		mockLoader := newMockPrestateLoader(false, common.Hash{0xcc}.Bytes())
+		accepted := []common.Hash{{0xaa}, {0xcc}}
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, accepted)
+		require.ErrorIs(t, err, ErrPrestateNotPlayed)
+		require.NotErrorIs(t, err, ErrPrestateMismatch)
+	})
+
+	t.Run("AcceptedProviderPrestateMismatch", func(t *testing.T) {
+		// Accepting the trace provider's prestate doesn't allow it to play games with a different prestate.
+		prestate := []byte{0x00, 0x01, 0x02, 0x03}
+		mockTraceProvider := newMockTraceProvider(false, prestate)
+		mockLoader := newMockPrestateLoader(false, common.Hash{0xcc}.Bytes())
+		accepted := []common.Hash{{0xaa}, crypto.Keccak256Hash(prestate)}
+		err := ValidateAbsolutePrestate(context.Background(), testlog.Logger(t, log.LvlInfo), mockTraceProvider, mockLoader, accepted)
+		require.ErrorIs(t, err, ErrPrestateMismatch)
 	})
 }
 
@@ -155,12 +1783,16 @@ func setupProgressGameTest(t *testing.T, agreeWithProposedRoot bool) (*testlog.C
 		Delegate: logger.GetHandler(),
 	}
 	logger.SetHandler(handler)
-	gameState := &stubGameState{claimCount: 1}
+	cl := clock.NewDeterministicClock(time.Unix(0, 0))
+	gameState := &stubGameState{claimCount: 1, clock: cl}
 	game := &GamePlayer{
-		agent:                   gameState,
-		agreeWithProposedOutput: agreeWithProposedRoot,
-		loader:                  gameState,
-		logger:                  logger,
+		addr:       common.Address{0xaa},
+		metrics:    newStubGameMetrics(),
+		agent:      gameState,
+		defendRoot: !agreeWithProposedRoot,
+		loader:     gameState,
+		logger:     logger,
+		clock:      cl,
 	}
 	return handler, game, gameState
 }
@@ -169,26 +1801,135 @@ type stubGameState struct {
 	status     types.GameStatus
 	claimCount uint64
 	callCount  int
+	resetCount int
+	fetchCount int
 	actErr     error
-	Err        error
+	fetchErr   error
+	// fetchErrs are returned by successive FetchClaims calls, in order, before falling back to fetchErr.
+	fetchErrs   []error
+	actSnapshot *GameSnapshot
+	// responsePending is reported by ResponsePending.
+	responsePending bool
+	Err             error
+	// claims overrides the claims returned by FetchClaims when set.
+	claims    []types.Claim
+	block     eth.L1BlockRef
+	canonical map[uint64]common.Hash
+	// partialClaims are returned along with fetchErr, as a loader that failed part way through might.
+	partialClaims []types.Claim
+	statusCount   int
+	// fetchDelay is how long FetchClaims takes, by which clock is advanced.
+	fetchDelay time.Duration
+	clock      *clock.DeterministicClock
+	// blockAtClock sets the time of the block claims are loaded at to the clock's time, as if L1 follows the clock.
+	blockAtClock bool
+}
+
+func (s *stubGameState) Reset() {
+	s.resetCount++
 }
 
-func (s *stubGameState) Act(ctx context.Context) error {
+func (s *stubGameState) ResponsePending() bool {
+	return s.responsePending
+}
+
+func (s *stubGameState) Act(ctx context.Context, snapshot *GameSnapshot) error {
 	s.callCount++
+	s.actSnapshot = snapshot
 	return s.actErr
 }
 
 func (s *stubGameState) GetGameStatus(ctx context.Context) (types.GameStatus, error) {
+	s.statusCount++
 	return s.status, nil
 }
 
-func (s *stubGameState) GetClaimCount(ctx context.Context) (uint64, error) {
-	return s.claimCount, nil
+func (s *stubGameState) FetchClaims(ctx context.Context) ([]types.Claim, eth.L1BlockRef, error) {
+	s.fetchCount++
+	if s.clock != nil {
+		s.clock.AdvanceTime(s.fetchDelay)
+		if s.blockAtClock {
+			s.block.Time = uint64(s.clock.Now().Unix())
+		}
+	}
+	if len(s.fetchErrs) > 0 {
+		err := s.fetchErrs[0]
+		s.fetchErrs = s.fetchErrs[1:]
+		if err != nil {
+			return nil, eth.L1BlockRef{}, err
+		}
+	} else if s.fetchErr != nil {
+		return s.partialClaims, s.block, s.fetchErr
+	}
+	if s.claims != nil {
+		return s.claims, s.block, nil
+	}
+	return make([]types.Claim, s.claimCount), s.block, nil
+}
+
+func (s *stubGameState) BlockHashAt(ctx context.Context, number uint64) (common.Hash, error) {
+	hash, ok := s.canonical[number]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("unknown block %v", number)
+	}
+	return hash, nil
+}
+
+type stubGameMetrics struct {
+	metrics.Metricer
+	claims       uint64
+	status       uint8
+	won          int
+	lost         int
+	disputed     int
+	bondsClaimed *big.Int
+
+	resolutions        int
+	resolutionGameType uint8
+	resolutionOutcome  string
+	timeToResolution   time.Duration
+}
+
+func newStubGameMetrics() *stubGameMetrics {
+	return &stubGameMetrics{Metricer: metrics.NoopMetrics}
+}
+
+func (s *stubGameMetrics) RecordGameClaims(_ common.Address, count uint64) {
+	s.claims = count
+}
+
+func (s *stubGameMetrics) RecordGameStatus(_ common.Address, status uint8) {
+	s.status = status
+}
+
+func (s *stubGameMetrics) RecordGameWon(_ common.Address) {
+	s.won++
+}
+
+func (s *stubGameMetrics) RecordGameLost(_ common.Address) {
+	s.lost++
+}
+
+func (s *stubGameMetrics) RecordDisputedResolution(_ common.Address) {
+	s.disputed++
+}
+
+func (s *stubGameMetrics) RecordTimeToResolution(gameType uint8, outcome string, duration time.Duration) {
+	s.resolutions++
+	s.resolutionGameType = gameType
+	s.resolutionOutcome = outcome
+	s.timeToResolution = duration
+}
+
+func (s *stubGameMetrics) RecordBondsClaimed(_ common.Address, amount *big.Int) {
+	s.bondsClaimed = amount
 }
 
 type mockTraceProvider struct {
 	prestateErrors bool
 	prestate       []byte
+	// states are the states after each trace index, returned as the pre-states of the following steps.
+	states [][]byte
 }
 
 func newMockTraceProvider(prestateErrors bool, prestate []byte) *mockTraceProvider {
@@ -201,7 +1942,14 @@ func (m *mockTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, err
 	panic("not implemented")
 }
 func (m *mockTraceProvider) GetStepData(ctx context.Context, i uint64) (prestate []byte, proofData []byte, preimageData *types.PreimageOracleData, err error) {
-	panic("not implemented")
+	if i == 0 {
+		prestate, err := m.AbsolutePreState(ctx)
+		return prestate, nil, nil, err
+	}
+	if i > uint64(len(m.states)) {
+		return nil, nil, nil, mockTraceProviderError
+	}
+	return m.states[i-1], nil, nil, nil
 }
 func (m *mockTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
 	if m.prestateErrors {
@@ -209,6 +1957,13 @@ func (m *mockTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error
 	}
 	return m.prestate, nil
 }
+func (m *mockTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	prestate, err := m.AbsolutePreState(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(prestate), nil
+}
 
 type mockLoader struct {
 	prestateError bool
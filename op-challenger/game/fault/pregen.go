@@ -0,0 +1,204 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/ratelimit"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// stepCandidatesPerClaim is the maximum number of step indices pre-generated for each frontier claim.
+const stepCandidatesPerClaim = 4
+
+// stepPregenQueueSize is the maximum number of pre-generation requests waiting for a worker.
+// Requests are dropped when the queue is full and requested again the next time the game is progressed.
+const stepPregenQueueSize = 64
+
+type stepRequest struct {
+	ctx      context.Context
+	game     common.Address
+	provider types.TraceProvider
+	index    uint64
+}
+
+type stepKey struct {
+	game  common.Address
+	index uint64
+}
+
+// StepPregenerator loads step data in the background ahead of games reaching the max depth, so that generating
+// the proof, which can take minutes with cannon, doesn't delay the step. The provider must cache the step data
+// so the step is served from the cache once it is required.
+// A fixed number of workers is shared by all games so pre-generation can't starve the games being progressed.
+type StepPregenerator struct {
+	logger   log.Logger
+	ctx      context.Context
+	cancel   context.CancelFunc
+	requests chan stepRequest
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	queued map[stepKey]bool
+	// games holds the context of each game's requests, cancelled when the game is stopped.
+	games map[common.Address]gameContext
+	// finished records the requests that have already been loaded so they aren't queued again.
+	finished map[stepKey]bool
+}
+
+type gameContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewStepPregenerator creates a [StepPregenerator] and starts its workers.
+func NewStepPregenerator(logger log.Logger, workers int) *StepPregenerator {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &StepPregenerator{
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		requests: make(chan stepRequest, stepPregenQueueSize),
+		queued:   make(map[stepKey]bool),
+		games:    make(map[common.Address]gameContext),
+		finished: make(map[stepKey]bool),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+	return p
+}
+
+// Request queues the step data at each of indices to be loaded from provider for game.
+// Indices that are already queued or were already loaded are skipped.
+func (p *StepPregenerator) Request(game common.Address, provider types.TraceProvider, indices []uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx.Err() != nil {
+		return
+	}
+	gameCtx, ok := p.games[game]
+	if !ok {
+		ctx, cancel := context.WithCancel(p.ctx)
+		gameCtx = gameContext{ctx: ctx, cancel: cancel}
+		p.games[game] = gameCtx
+	}
+	for _, index := range indices {
+		key := stepKey{game: game, index: index}
+		if p.queued[key] || p.finished[key] {
+			continue
+		}
+		select {
+		case p.requests <- stepRequest{ctx: gameCtx.ctx, game: game, provider: provider, index: index}:
+			p.queued[key] = true
+		default:
+			p.logger.Debug("Step pre-generation queue full", "game", game, "index", index)
+			return
+		}
+	}
+}
+
+// Stop cancels the pre-generation requests of game, killing any trace generation in progress for it.
+func (p *StepPregenerator) Stop(game common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if gameCtx, ok := p.games[game]; ok {
+		gameCtx.cancel()
+		delete(p.games, game)
+	}
+	for key := range p.finished {
+		if key.game == game {
+			delete(p.finished, key)
+		}
+	}
+}
+
+// Close cancels all pre-generation requests and waits for the workers to exit.
+func (p *StepPregenerator) Close() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+func (p *StepPregenerator) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case req := <-p.requests:
+			p.generate(req)
+		}
+	}
+}
+
+func (p *StepPregenerator) generate(req stepRequest) {
+	key := stepKey{game: req.game, index: req.index}
+	if req.ctx.Err() != nil {
+		p.done(key, false)
+		return
+	}
+	_, _, _, err := req.provider.GetStepData(req.ctx, req.index)
+	if errors.Is(err, context.Canceled) || req.ctx.Err() != nil {
+		p.logger.Debug("Cancelled step pre-generation", "game", req.game, "index", req.index)
+		p.done(key, false)
+		return
+	} else if errors.Is(err, ratelimit.ErrRateLimited) {
+		// Requested again the next time the game is progressed.
+		p.logger.Debug("Step pre-generation rate limited", "game", req.game, "index", req.index)
+		p.done(key, false)
+		return
+	} else if err != nil {
+		p.logger.Warn("Failed to pre-generate step data", "game", req.game, "index", req.index, "err", err)
+		p.done(key, false)
+		return
+	}
+	p.logger.Debug("Pre-generated step data", "game", req.game, "index", req.index)
+	p.done(key, true)
+}
+
+func (p *StepPregenerator) done(key stepKey, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.queued, key)
+	if _, active := p.games[key.game]; ok && active {
+		p.finished[key] = true
+	}
+}
+
+// stepCandidates returns the trace indices of the steps likely to be needed to resolve the frontier claims deeper
+// than minDepth. The frontier claims are the uncountered claims without any counter claims yet.
+// A step at the max depth uses the pre-state of the leaf's trace index or the next one, so the candidates of each
+// claim are the indices adjacent to the end of its subtree's trace range, nearest first.
+func stepCandidates(claims []types.Claim, maxDepth int, minDepth int) []uint64 {
+	hasChild := make(map[int]bool)
+	for _, claim := range claims {
+		if !claim.IsRoot() {
+			hasChild[claim.ParentContractIndex] = true
+		}
+	}
+	traceLength := uint64(1) << maxDepth
+	seen := make(map[uint64]bool)
+	var indices []uint64
+	for _, claim := range claims {
+		if claim.Countered || hasChild[claim.ContractIndex] || claim.Depth() <= minDepth {
+			continue
+		}
+		last := claim.TraceIndex(maxDepth)
+		first := last + 1 - uint64(1)<<(maxDepth-claim.Depth())
+		// Step indices range from the first leaf of the subtree to one past its last leaf.
+		for i, index := 0, last+1; i < stepCandidatesPerClaim && index >= first; i, index = i+1, index-1 {
+			if index < traceLength && !seen[index] {
+				seen[index] = true
+				indices = append(indices, index)
+			}
+			if index == 0 {
+				break
+			}
+		}
+	}
+	return indices
+}
@@ -0,0 +1,180 @@
+package fault
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cache"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepPregenerator_ServeStepFromCache(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	slow := &slowTraceProvider{TraceProvider: builder.CorrectTraceProvider(), delay: 20 * time.Millisecond}
+	cached := cache.NewTraceProvider(slow, 1024*1024, nil)
+	pregen := NewStepPregenerator(testlog.Logger(t, log.LvlDebug), 1)
+	t.Cleanup(pregen.Close)
+
+	root := builder.CreateRootClaim(false)
+	frontier := builder.Seq(false).Attack(true).Attack(false).Get()
+	frontier.ContractIndex = 1
+	claims := []types.Claim{root, frontier}
+	candidates := stepCandidates(claims, maxDepth, 1)
+	require.NotEmpty(t, candidates)
+	pregen.Request(common.Address{0xaa}, cached, candidates)
+	require.Eventually(t, func() bool {
+		return slow.StepCalls() == len(candidates)
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// The opponent counters the frontier claim at the max depth, so a step is now required.
+	leaf := builder.AttackClaim(frontier, false)
+	step, err := solver.NewSolver(maxDepth, cached).AttemptStep(context.Background(), leaf, false)
+	require.NoError(t, err)
+	require.Contains(t, candidates, step.TraceIndex)
+	require.Equal(t, builder.CorrectPreState(step.TraceIndex), step.PreState)
+	require.Equal(t, len(candidates), slow.StepCalls(), "should serve step from cache")
+}
+
+func TestStepPregenerator_SkipLoadedIndices(t *testing.T) {
+	provider := &slowTraceProvider{TraceProvider: alphabetProvider(t)}
+	pregen := NewStepPregenerator(testlog.Logger(t, log.LvlDebug), 1)
+	t.Cleanup(pregen.Close)
+	game := common.Address{0xaa}
+
+	pregen.Request(game, provider, []uint64{1, 2})
+	require.Eventually(t, func() bool { return provider.StepCalls() == 2 }, 5*time.Second, time.Millisecond)
+	pregen.Request(game, provider, []uint64{1, 2, 3})
+	require.Eventually(t, func() bool { return provider.StepCalls() == 3 }, 5*time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, 3, provider.StepCalls(), "should not reload indices")
+
+	// Stopping the game forgets what was loaded for it.
+	pregen.Stop(game)
+	pregen.Request(game, provider, []uint64{1})
+	require.Eventually(t, func() bool { return provider.StepCalls() == 4 }, 5*time.Second, time.Millisecond)
+}
+
+func TestStepPregenerator_StopCancelsGeneration(t *testing.T) {
+	provider := &blockingTraceProvider{started: make(chan struct{}), stopped: make(chan error, 1)}
+	pregen := NewStepPregenerator(testlog.Logger(t, log.LvlDebug), 1)
+	t.Cleanup(pregen.Close)
+	game := common.Address{0xaa}
+
+	pregen.Request(game, provider, []uint64{5})
+	<-provider.started
+	pregen.Stop(game)
+	select {
+	case err := <-provider.stopped:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("generation was not cancelled")
+	}
+}
+
+func TestStepPregenerator_CloseCancelsGeneration(t *testing.T) {
+	provider := &blockingTraceProvider{started: make(chan struct{}), stopped: make(chan error, 1)}
+	pregen := NewStepPregenerator(testlog.Logger(t, log.LvlDebug), 1)
+
+	pregen.Request(common.Address{0xaa}, provider, []uint64{5})
+	<-provider.started
+	pregen.Close()
+	require.ErrorIs(t, <-provider.stopped, context.Canceled)
+
+	// Requests after closing are ignored.
+	pregen.Request(common.Address{0xaa}, provider, []uint64{6})
+}
+
+func TestStepCandidates(t *testing.T) {
+	maxDepth := 4
+	position := func(depth int, indexAtDepth int) types.ClaimData {
+		return types.ClaimData{Position: types.NewPosition(depth, indexAtDepth)}
+	}
+	root := types.Claim{ClaimData: position(0, 0)}
+
+	t.Run("SkipShallowClaims", func(t *testing.T) {
+		claims := []types.Claim{root, {ClaimData: position(2, 1), ContractIndex: 1}}
+		require.Empty(t, stepCandidates(claims, maxDepth, 2))
+	})
+
+	t.Run("AdjacentToSubtreeEnd", func(t *testing.T) {
+		// The subtree at depth 3, index 2 covers trace indices 4 and 5.
+		claims := []types.Claim{root, {ClaimData: position(3, 2), ContractIndex: 1}}
+		require.Equal(t, []uint64{6, 5, 4}, stepCandidates(claims, maxDepth, 2))
+	})
+
+	t.Run("LimitCandidatesPerClaim", func(t *testing.T) {
+		// The subtree at depth 1, index 0 covers trace indices 0 to 7.
+		claims := []types.Claim{root, {ClaimData: position(1, 0), ContractIndex: 1}}
+		require.Equal(t, []uint64{8, 7, 6, 5}, stepCandidates(claims, maxDepth, 0))
+	})
+
+	t.Run("SkipCounteredAndNonFrontierClaims", func(t *testing.T) {
+		claims := []types.Claim{
+			root,
+			{ClaimData: position(3, 2), ContractIndex: 1},
+			{ClaimData: position(4, 4), ContractIndex: 2, ParentContractIndex: 1},
+			{ClaimData: position(4, 0), ContractIndex: 3, Countered: true},
+		}
+		require.Equal(t, []uint64{5, 4}, stepCandidates(claims, maxDepth, 2), "should only use the leaf")
+	})
+
+	t.Run("ExcludeIndicesPastTraceEnd", func(t *testing.T) {
+		claims := []types.Claim{root, {ClaimData: position(4, 15), ContractIndex: 1}}
+		require.Equal(t, []uint64{15}, stepCandidates(claims, maxDepth, 2))
+	})
+
+	t.Run("StopAtFirstIndex", func(t *testing.T) {
+		claims := []types.Claim{root, {ClaimData: position(4, 0), ContractIndex: 1}}
+		require.Equal(t, []uint64{1, 0}, stepCandidates(claims, maxDepth, 2))
+	})
+}
+
+func alphabetProvider(t *testing.T) types.TraceProvider {
+	return test.NewAlphabetClaimBuilder(t, 4).CorrectTraceProvider()
+}
+
+// slowTraceProvider delays loading step data to simulate generating a cannon proof.
+type slowTraceProvider struct {
+	types.TraceProvider
+	delay time.Duration
+
+	mu        sync.Mutex
+	stepCalls int
+}
+
+func (s *slowTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	time.Sleep(s.delay)
+	s.mu.Lock()
+	s.stepCalls++
+	s.mu.Unlock()
+	return s.TraceProvider.GetStepData(ctx, i)
+}
+
+func (s *slowTraceProvider) StepCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stepCalls
+}
+
+// blockingTraceProvider loads step data until its context is cancelled.
+type blockingTraceProvider struct {
+	types.TraceProvider
+	started chan struct{}
+	stopped chan error
+}
+
+func (b *blockingTraceProvider) GetStepData(ctx context.Context, _ uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	close(b.started)
+	<-ctx.Done()
+	b.stopped <- ctx.Err()
+	return nil, nil, nil, ctx.Err()
+}
@@ -0,0 +1,87 @@
+package fault
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+type gameProgress struct {
+	claimCount uint64
+	// pending is true if the challenger has a response to make in the game.
+	pending bool
+	// since is when the claim count last changed or the response became pending, whichever is later.
+	since time.Time
+}
+
+// ProgressTracker records how long each game being progressed has had a response pending without its claim count
+// changing, so that games the challenger is failing to respond in can be detected. Games without a pending
+// response, such as uncontested games, legitimately stop changing so are never stale.
+// It is safe for concurrent use by the players of different games.
+type ProgressTracker struct {
+	clock    clock.Clock
+	mu       sync.Mutex
+	progress map[common.Address]gameProgress
+}
+
+func NewProgressTracker(cl clock.Clock) *ProgressTracker {
+	return &ProgressTracker{
+		clock:    cl,
+		progress: make(map[common.Address]gameProgress),
+	}
+}
+
+// Record records the current claim count of game and whether the challenger has a response pending in it.
+// The game is considered to have progressed if it wasn't already tracked, its claim count changed or the response
+// has only just become pending.
+func (t *ProgressTracker) Record(game common.Address, claimCount uint64, pending bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	progress, ok := t.progress[game]
+	if !ok || progress.claimCount != claimCount || (pending && !progress.pending) {
+		progress.since = t.clock.Now()
+	}
+	progress.claimCount = claimCount
+	progress.pending = pending
+	t.progress[game] = progress
+}
+
+// Remove stops tracking game. Resolved games are removed as they legitimately stop changing.
+func (t *ProgressTracker) Remove(game common.Address) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.progress, game)
+}
+
+// RemoveAllExcept stops tracking the games not in keep, typically because they are no longer being played.
+func (t *ProgressTracker) RemoveAllExcept(keep []common.Address) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for game := range t.progress {
+		if !slices.Contains(keep, game) {
+			delete(t.progress, game)
+		}
+	}
+}
+
+// GetStaleGames returns the games that have had a response pending without their claim count changing for longer
+// than threshold, ordered by address.
+func (t *ProgressTracker) GetStaleGames(threshold time.Duration) []common.Address {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	var stale []common.Address
+	for game, progress := range t.progress {
+		if progress.pending && now.Sub(progress.since) > threshold {
+			stale = append(stale, game)
+		}
+	}
+	slices.SortFunc(stale, func(a, b common.Address) bool {
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	return stale
+}
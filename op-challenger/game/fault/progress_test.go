@@ -0,0 +1,64 @@
+package fault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker_GetStaleGames(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	tracker := NewProgressTracker(cl)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	tracker.Record(game2, 1, true)
+	tracker.Record(game1, 1, true)
+	require.Empty(t, tracker.GetStaleGames(time.Minute))
+
+	cl.AdvanceTime(time.Minute + time.Second)
+	require.Equal(t, []common.Address{game1, game2}, tracker.GetStaleGames(time.Minute))
+
+	// Recording the same claim count doesn't count as progress.
+	tracker.Record(game1, 1, true)
+	tracker.Record(game2, 2, true)
+	require.Equal(t, []common.Address{game1}, tracker.GetStaleGames(time.Minute))
+
+	tracker.Remove(game1)
+	require.Empty(t, tracker.GetStaleGames(time.Minute))
+}
+
+func TestProgressTracker_OnlyStaleWithResponsePending(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	tracker := NewProgressTracker(cl)
+	game := common.Address{0xaa}
+	tracker.Record(game, 1, false)
+	cl.AdvanceTime(time.Hour)
+	tracker.Record(game, 1, false)
+	require.Empty(t, tracker.GetStaleGames(time.Minute), "should not be stale while uncontested")
+
+	// The threshold starts when the response becomes pending, not when the claim count last changed.
+	tracker.Record(game, 1, true)
+	require.Empty(t, tracker.GetStaleGames(time.Minute))
+	cl.AdvanceTime(time.Minute + time.Second)
+	tracker.Record(game, 1, true)
+	require.Equal(t, []common.Address{game}, tracker.GetStaleGames(time.Minute))
+
+	tracker.Record(game, 1, false)
+	require.Empty(t, tracker.GetStaleGames(time.Minute), "should not be stale once no response is pending")
+}
+
+func TestProgressTracker_RemoveAllExcept(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	tracker := NewProgressTracker(cl)
+	keep := common.Address{0xaa}
+	dropped := common.Address{0xbb}
+	tracker.Record(keep, 1, true)
+	tracker.Record(dropped, 1, true)
+	cl.AdvanceTime(time.Minute + time.Second)
+
+	tracker.RemoveAllExcept([]common.Address{keep})
+	require.Equal(t, []common.Address{keep}, tracker.GetStaleGames(time.Minute))
+}
@@ -0,0 +1,149 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrCircuitOpen is returned instead of sending a transaction while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open after repeated transaction failures")
+
+// CircuitBreaker halts non-critical transactions once too many consecutive transactions fail, across all games
+// sharing it. A transaction fails if it reverts or can't be sent, such as when the account is out of funds or the
+// L1 endpoint is unavailable. Repeated failures usually mean the challenger is misconfigured or out of sync with the
+// contracts, and further transactions would only waste gas or flood the logs with errors.
+// While open, a single probe transaction is allowed every probe interval and the breaker closes once one succeeds.
+// A nil *CircuitBreaker never opens.
+type CircuitBreaker struct {
+	logger  log.Logger
+	clock   clock.Clock
+	metrics metrics.Metricer
+
+	threshold     int
+	window        time.Duration
+	probeInterval time.Duration
+
+	mu sync.Mutex
+	// failures are the times of the consecutive failures within the window.
+	failures  []time.Time
+	open      bool
+	lastProbe time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker returns a [CircuitBreaker] that opens after threshold consecutive failures within window.
+// Failures never expire if window is 0.
+func NewCircuitBreaker(logger log.Logger, cl clock.Clock, m metrics.Metricer, threshold uint, window time.Duration, probeInterval time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		logger:        logger,
+		clock:         cl,
+		metrics:       m,
+		threshold:     int(threshold),
+		window:        window,
+		probeInterval: probeInterval,
+	}
+}
+
+// Open returns true if non-critical transactions are currently halted.
+func (b *CircuitBreaker) Open() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
+
+// acquire checks a transaction can be sent, returning [ErrCircuitOpen] if it can't.
+// Critical transactions are always allowed. Otherwise, while the breaker is open only one probe transaction is
+// allowed at a time, at most once per probe interval. The caller must call release with the returned probe value.
+func (b *CircuitBreaker) acquire(critical bool) (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return false, nil
+	}
+	if critical {
+		b.logger.Warn("Sending clock critical transaction despite open circuit breaker", "failures", len(b.failures))
+		return false, nil
+	}
+	now := b.clock.Now()
+	if b.probing || now.Sub(b.lastProbe) < b.probeInterval {
+		return false, ErrCircuitOpen
+	}
+	b.logger.Info("Sending probe transaction through open circuit breaker")
+	b.probing = true
+	b.lastProbe = now
+	return true, nil
+}
+
+// release records the result of a transaction allowed by acquire, where err is the error sending it.
+// Sends cancelled by their context, such as on shutdown, don't count towards or reset the consecutive failures.
+func (b *CircuitBreaker) release(probe bool, receipt *ethtypes.Receipt, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		b.recordFailure(probe, "err", err)
+		return
+	}
+	if receipt == nil {
+		return
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		b.recordFailure(probe, "tx_hash", receipt.TxHash)
+		return
+	}
+	if b.open {
+		b.logger.Info("Closing circuit breaker after successful transaction", "tx_hash", receipt.TxHash)
+		b.metrics.RecordCircuitBreakerOpen(false)
+	}
+	b.failures = nil
+	b.open = false
+}
+
+// recordFailure counts a failed transaction, opening the breaker once the threshold is reached.
+// ctx is logged to identify the failure. b.mu must be held.
+func (b *CircuitBreaker) recordFailure(probe bool, ctx ...any) {
+	now := b.clock.Now()
+	if b.window > 0 {
+		i := 0
+		for i < len(b.failures) && now.Sub(b.failures[i]) > b.window {
+			i++
+		}
+		b.failures = b.failures[i:]
+	}
+	b.failures = append(b.failures, now)
+	if b.open {
+		if probe {
+			b.logger.Warn("Probe transaction failed, circuit breaker remains open", ctx...)
+		}
+		return
+	}
+	if len(b.failures) >= b.threshold {
+		// Only non-critical transactions are halted while open.
+		b.logger.Error("Circuit breaker open",
+			append([]any{"failures", len(b.failures), "window", b.window, "probe_interval", b.probeInterval}, ctx...)...)
+		b.open = true
+		b.lastProbe = now
+		b.metrics.RecordCircuitBreakerOpen(true)
+	}
+}
@@ -0,0 +1,240 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	revertedReceipt   = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed}
+	successfulReceipt = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	setup := func(t *testing.T) (*CircuitBreaker, *clock.DeterministicClock, *stubBreakerMetrics) {
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		m := &stubBreakerMetrics{Metricer: metrics.NoopMetrics}
+		return NewCircuitBreaker(testlog.Logger(t, log.LvlError), cl, m, 3, time.Minute, 10*time.Second), cl, m
+	}
+	send := func(t *testing.T, b *CircuitBreaker, critical bool, receipt *ethtypes.Receipt) error {
+		probe, err := b.acquire(critical)
+		if err != nil {
+			return err
+		}
+		b.release(probe, receipt, nil)
+		return nil
+	}
+	sendErr := func(t *testing.T, b *CircuitBreaker, err error) error {
+		probe, acquireErr := b.acquire(false)
+		if acquireErr != nil {
+			return acquireErr
+		}
+		b.release(probe, nil, err)
+		return nil
+	}
+
+	t.Run("Trips", func(t *testing.T) {
+		b, _, m := setup(t)
+		for i := 0; i < 2; i++ {
+			require.NoError(t, send(t, b, false, revertedReceipt))
+		}
+		require.False(t, b.Open())
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.True(t, b.Open())
+		require.True(t, m.open)
+		require.ErrorIs(t, send(t, b, false, successfulReceipt), ErrCircuitOpen)
+	})
+
+	t.Run("SuccessResetsCount", func(t *testing.T) {
+		b, _, _ := setup(t)
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, successfulReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.False(t, b.Open(), "should only count consecutive reverts")
+	})
+
+	t.Run("CancelledDoesNotCount", func(t *testing.T) {
+		b, _, _ := setup(t)
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, sendErr(t, b, fmt.Errorf("send aborted: %w", context.Canceled)))
+		require.False(t, b.Open())
+	})
+
+	t.Run("SendFailuresTrip", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlError)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		b := NewCircuitBreaker(logger, cl, metrics.NoopMetrics, 3, time.Minute, 10*time.Second)
+		require.NoError(t, sendErr(t, b, errors.New("insufficient funds for gas * price + value")))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.False(t, b.Open())
+		require.NoError(t, sendErr(t, b, errors.New("connection refused")))
+		require.True(t, b.Open(), "should count reverts and failed sends together")
+		require.NotNil(t, handler.FindLog(log.LvlError, "Circuit breaker open"))
+		require.ErrorIs(t, sendErr(t, b, nil), ErrCircuitOpen)
+
+		cl.AdvanceTime(10 * time.Second)
+		require.NoError(t, sendErr(t, b, errors.New("connection refused")), "should half-open after the cooldown")
+		require.True(t, b.Open(), "should stay open when the probe can't be sent")
+		require.ErrorIs(t, send(t, b, false, successfulReceipt), ErrCircuitOpen, "should wait another cooldown after a failed probe")
+
+		cl.AdvanceTime(10 * time.Second)
+		require.NoError(t, send(t, b, false, successfulReceipt))
+		require.False(t, b.Open(), "should close when the probe succeeds")
+		require.NoError(t, sendErr(t, b, errors.New("connection refused")))
+		require.NoError(t, sendErr(t, b, errors.New("connection refused")))
+		require.False(t, b.Open(), "should reset the failures on recovery")
+	})
+
+	t.Run("RevertsExpire", func(t *testing.T) {
+		b, cl, _ := setup(t)
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		cl.AdvanceTime(time.Minute + time.Second)
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.False(t, b.Open(), "should not count reverts outside the window")
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.True(t, b.Open())
+	})
+
+	t.Run("Probe", func(t *testing.T) {
+		b, cl, _ := setup(t)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, send(t, b, false, revertedReceipt))
+		}
+		cl.AdvanceTime(5 * time.Second)
+		_, err := b.acquire(false)
+		require.ErrorIs(t, err, ErrCircuitOpen, "should not probe before the interval")
+
+		cl.AdvanceTime(5 * time.Second)
+		probe, err := b.acquire(false)
+		require.NoError(t, err)
+		require.True(t, probe)
+		_, err = b.acquire(false)
+		require.ErrorIs(t, err, ErrCircuitOpen, "should only send one probe at a time")
+		b.release(probe, revertedReceipt, nil)
+		require.True(t, b.Open(), "should stay open when the probe reverts")
+
+		_, err = b.acquire(false)
+		require.ErrorIs(t, err, ErrCircuitOpen, "should wait another interval after a failed probe")
+		cl.AdvanceTime(10 * time.Second)
+		probe, err = b.acquire(false)
+		require.NoError(t, err)
+		require.True(t, probe)
+		b.release(probe, nil, context.Canceled)
+		probe, err = b.acquire(false)
+		require.ErrorIs(t, err, ErrCircuitOpen, "should wait an interval after a cancelled probe")
+		require.False(t, probe)
+	})
+
+	t.Run("Recovers", func(t *testing.T) {
+		b, cl, m := setup(t)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, send(t, b, false, revertedReceipt))
+		}
+		cl.AdvanceTime(10 * time.Second)
+		require.NoError(t, send(t, b, false, successfulReceipt))
+		require.False(t, b.Open())
+		require.False(t, m.open)
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.NoError(t, send(t, b, false, revertedReceipt))
+		require.False(t, b.Open(), "should reset the reverts on recovery")
+	})
+
+	t.Run("CriticalBypass", func(t *testing.T) {
+		b, _, _ := setup(t)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, send(t, b, false, revertedReceipt))
+		}
+		require.NoError(t, send(t, b, true, revertedReceipt))
+		require.NoError(t, send(t, b, true, revertedReceipt))
+		require.True(t, b.Open())
+		require.NoError(t, send(t, b, true, successfulReceipt))
+		require.False(t, b.Open(), "should close when a critical transaction succeeds")
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var b *CircuitBreaker
+		for i := 0; i < 5; i++ {
+			require.NoError(t, send(t, b, false, revertedReceipt))
+		}
+		require.False(t, b.Open())
+	})
+}
+
+// TestCircuitBreaker_Responder tests that the responder halts non-critical transactions once the breaker opens.
+func TestCircuitBreaker_Responder(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	breaker := NewCircuitBreaker(logger, cl, metrics.NoopMetrics, 2, time.Minute, time.Minute)
+	mockTxMgr := &mockTxManager{reverts: true}
+	responder, err := NewFaultResponderWithEvents(logger, mockTxMgr, mockTxMgr, mockFdgAddress, types.NoopEventSink{}, metrics.NoopMetrics, breaker)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, responder.Respond(ctx, generateMockResponseClaim()))
+	require.NoError(t, responder.Step(ctx, types.StepCallData{}))
+	require.True(t, breaker.Open())
+
+	require.ErrorIs(t, responder.Respond(ctx, generateMockResponseClaim()), ErrCircuitOpen)
+	require.ErrorIs(t, responder.Step(ctx, types.StepCallData{}), ErrCircuitOpen)
+	require.ErrorIs(t, responder.Resolve(ctx), ErrCircuitOpen)
+	require.ErrorIs(t, responder.ResolveClaim(ctx, 0), ErrCircuitOpen)
+	require.Equal(t, 2, mockTxMgr.sends)
+
+	require.NoError(t, responder.Step(ctx, types.StepCallData{ClockCritical: true}))
+	require.Equal(t, 3, mockTxMgr.sends, "should send clock critical steps")
+
+	mockTxMgr.reverts = false
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, responder.Resolve(ctx))
+	require.False(t, breaker.Open())
+	require.NoError(t, responder.Respond(ctx, generateMockResponseClaim()))
+	require.Equal(t, 5, mockTxMgr.sends)
+}
+
+// TestCircuitBreaker_ResponderSendFailures tests that transactions that can't be sent trip the breaker.
+func TestCircuitBreaker_ResponderSendFailures(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	breaker := NewCircuitBreaker(logger, cl, metrics.NoopMetrics, 2, time.Minute, time.Minute)
+	mockTxMgr := &mockTxManager{sendFails: true}
+	responder, err := NewFaultResponderWithEvents(logger, mockTxMgr, mockTxMgr, mockFdgAddress, types.NoopEventSink{}, metrics.NoopMetrics, breaker)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.ErrorIs(t, responder.Resolve(ctx), mockSendError)
+	require.ErrorIs(t, responder.Resolve(ctx), mockSendError)
+	require.True(t, breaker.Open())
+	require.ErrorIs(t, responder.Resolve(ctx), ErrCircuitOpen)
+
+	mockTxMgr.sendFails = false
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, responder.Resolve(ctx))
+	require.False(t, breaker.Open())
+}
+
+type stubBreakerMetrics struct {
+	metrics.Metricer
+	open bool
+}
+
+func (s *stubBreakerMetrics) RecordCircuitBreakerOpen(open bool) {
+	s.open = open
+}
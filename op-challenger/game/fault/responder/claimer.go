@@ -0,0 +1,136 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	methodCredit      = "credit"
+	methodClaimCredit = "claimCredit"
+)
+
+// creditABI is the bond credit accessors of newer FaultDisputeGame versions, which credit the bonds of
+// countered claims to the claimant that countered them once the game resolves.
+const creditABI = `[{
+	"inputs":[{"internalType":"address","name":"","type":"address"}],
+	"name":"credit",
+	"outputs":[{"internalType":"uint256","name":"","type":"uint256"}],
+	"stateMutability":"view",
+	"type":"function"
+},{
+	"inputs":[{"internalType":"address","name":"_recipient","type":"address"}],
+	"name":"claimCredit",
+	"outputs":[],
+	"stateMutability":"nonpayable",
+	"type":"function"
+}]`
+
+var (
+	ErrClaimCreditReverted = errors.New("claim credit transaction reverted")
+	// ErrBondsLocked is returned when there is credit to claim but claiming it would revert,
+	// typically because the game's withdrawal delay hasn't elapsed yet.
+	ErrBondsLocked = errors.New("bonds not yet claimable")
+)
+
+// BondClaimer claims the bonds credited to the [txmgr] account by a fault dispute game.
+type BondClaimer struct {
+	log log.Logger
+
+	txMgr txmgr.TxManager
+
+	fdgAddr   common.Address
+	creditAbi abi.ABI
+}
+
+// NewBondClaimer returns a new [BondClaimer].
+func NewBondClaimer(logger log.Logger, txMgr txmgr.TxManager, fdgAddr common.Address) (*BondClaimer, error) {
+	creditAbi, err := abi.JSON(strings.NewReader(creditABI))
+	if err != nil {
+		return nil, err
+	}
+	return &BondClaimer{
+		log:       logger,
+		txMgr:     txMgr,
+		fdgAddr:   fdgAddr,
+		creditAbi: creditAbi,
+	}, nil
+}
+
+// ClaimBonds claims the credit owed to the [txmgr] account and returns the amount claimed.
+// It does nothing if there is no credit to claim so it is safe to call again for a game that was already claimed.
+// Returns [ErrBondsLocked] without sending a transaction if the claim would revert.
+func (c *BondClaimer) ClaimBonds(ctx context.Context) (*big.Int, error) {
+	recipient := c.txMgr.From()
+	credit, err := c.fetchCredit(ctx, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch credit: %w", err)
+	}
+	if credit.Sign() == 0 {
+		c.log.Debug("No bonds to claim", "recipient", recipient)
+		return credit, nil
+	}
+	txData, err := c.creditAbi.Pack(methodClaimCredit, recipient)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.txMgr.Call(ctx, ethereum.CallMsg{
+		From: recipient,
+		To:   &c.fdgAddr,
+		Data: txData,
+	}, nil); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBondsLocked, err)
+	}
+	receipt, err := c.txMgr.Send(ctx, txmgr.TxCandidate{
+		To:       &c.fdgAddr,
+		TxData:   txData,
+		GasLimit: 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		// The credit may have been withdrawn by another transaction since it was checked.
+		remaining, err := c.fetchCredit(ctx, recipient)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v: failed to fetch remaining credit: %w", ErrClaimCreditReverted, receipt.TxHash, err)
+		}
+		if remaining.Sign() == 0 {
+			c.log.Info("Bonds already claimed", "recipient", recipient, "tx_hash", receipt.TxHash)
+			return remaining, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrClaimCreditReverted, receipt.TxHash)
+	}
+	c.log.Info("Claimed bonds", "recipient", recipient, "amount", credit, "tx_hash", receipt.TxHash)
+	return credit, nil
+}
+
+// fetchCredit returns the credit the game owes to recipient.
+func (c *BondClaimer) fetchCredit(ctx context.Context, recipient common.Address) (*big.Int, error) {
+	callData, err := c.creditAbi.Pack(methodCredit, recipient)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.txMgr.Call(ctx, ethereum.CallMsg{
+		To:   &c.fdgAddr,
+		Data: callData,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var credit *big.Int
+	if err := c.creditAbi.UnpackIntoInterface(&credit, methodCredit, res); err != nil {
+		return nil, err
+	}
+	return credit, nil
+}
@@ -0,0 +1,124 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var mockClaimCreditError = errors.New("mock claim credit error")
+
+// TestClaimBonds tests the [BondClaimer.ClaimBonds] method.
+func TestClaimBonds(t *testing.T) {
+	recipient := common.Address{0xaa}
+	setup := func(t *testing.T, credit int64) (*BondClaimer, *creditTxManager) {
+		mockTxMgr := &creditTxManager{mockTxManager: &mockTxManager{from: recipient}, credit: big.NewInt(credit)}
+		claimer, err := NewBondClaimer(testlog.Logger(t, log.LvlError), mockTxMgr, mockFdgAddress)
+		require.NoError(t, err)
+		mockTxMgr.creditSelector = claimer.creditAbi.Methods[methodCredit].ID
+		return claimer, mockTxMgr
+	}
+
+	t.Run("ClaimCredit", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 1000)
+		claimed, err := claimer.ClaimBonds(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1000), claimed)
+		require.Equal(t, 1, mockTxMgr.sends)
+		expected, err := claimer.creditAbi.Pack(methodClaimCredit, recipient)
+		require.NoError(t, err)
+		require.Equal(t, expected, mockTxMgr.sendData)
+		require.Equal(t, [][]byte{expected}, mockTxMgr.claimCalls, "should check the claim succeeds before sending")
+	})
+
+	t.Run("NothingToClaim", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 0)
+		for i := 0; i < 2; i++ {
+			claimed, err := claimer.ClaimBonds(context.Background())
+			require.NoError(t, err)
+			require.Zero(t, claimed.Sign())
+		}
+		require.Empty(t, mockTxMgr.claimCalls)
+		require.Zero(t, mockTxMgr.sends)
+	})
+
+	t.Run("FetchCreditFails", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 1000)
+		mockTxMgr.callFails = true
+		_, err := claimer.ClaimBonds(context.Background())
+		require.ErrorIs(t, err, mockCallError)
+		require.Zero(t, mockTxMgr.sends)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 1000)
+		mockTxMgr.claimErr = mockClaimCreditError
+		_, err := claimer.ClaimBonds(context.Background())
+		require.ErrorIs(t, err, ErrBondsLocked)
+		require.ErrorIs(t, err, mockClaimCreditError)
+		require.Zero(t, mockTxMgr.sends, "should not send a transaction that would revert")
+	})
+
+	t.Run("SendFails", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 1000)
+		mockTxMgr.sendFails = true
+		_, err := claimer.ClaimBonds(context.Background())
+		require.ErrorIs(t, err, mockSendError)
+	})
+
+	t.Run("Reverted", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 1000)
+		mockTxMgr.reverts = true
+		_, err := claimer.ClaimBonds(context.Background())
+		require.ErrorIs(t, err, ErrClaimCreditReverted)
+	})
+
+	t.Run("RevertedAlreadyWithdrawn", func(t *testing.T) {
+		claimer, mockTxMgr := setup(t, 1000)
+		mockTxMgr.reverts = true
+		mockTxMgr.creditAfterSend = big.NewInt(0)
+		claimed, err := claimer.ClaimBonds(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, claimed.Sign())
+	})
+}
+
+// creditTxManager is a [mockTxManager] that responds to credit and claimCredit calls.
+type creditTxManager struct {
+	*mockTxManager
+	creditSelector []byte
+	credit         *big.Int
+	// creditAfterSend replaces credit once a transaction is sent, if set.
+	creditAfterSend *big.Int
+	claimErr        error
+	claimCalls      [][]byte
+}
+
+func (m *creditTxManager) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if bytes.HasPrefix(msg.Data, m.creditSelector) {
+		if _, err := m.mockTxManager.Call(ctx, msg, blockNumber); err != nil {
+			return nil, err
+		}
+		return common.BigToHash(m.credit).Bytes(), nil
+	}
+	m.claimCalls = append(m.claimCalls, msg.Data)
+	return nil, m.claimErr
+}
+
+func (m *creditTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	receipt, err := m.mockTxManager.Send(ctx, candidate)
+	if err == nil && m.creditAfterSend != nil {
+		m.credit = m.creditAfterSend
+	}
+	return receipt, err
+}
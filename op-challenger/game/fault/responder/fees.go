@@ -0,0 +1,64 @@
+package responder
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+type urgentFeesKey struct{}
+
+// WithUrgentFees returns a copy of ctx marking the transactions sent with it as urgent, so their priority fee is
+// raised by the [FeePolicy] urgent multiplier.
+func WithUrgentFees(ctx context.Context) context.Context {
+	return context.WithValue(ctx, urgentFeesKey{}, true)
+}
+
+// UrgentFees returns true if ctx was marked by [WithUrgentFees].
+func UrgentFees(ctx context.Context) bool {
+	urgent, _ := ctx.Value(urgentFeesKey{}).(bool)
+	return urgent
+}
+
+// FeePolicy adjusts the priority fee suggested by the L1 node for the challenger's transactions.
+type FeePolicy struct {
+	// MinTip is the lowest priority fee paid, in wei. Nil for no minimum.
+	MinTip *big.Int
+	// MaxTip is the highest priority fee paid, in wei. Nil for no maximum.
+	MaxTip *big.Int
+	// UrgentMultiplier multiplies the priority fee of urgent transactions before it is limited to MaxTip.
+	// Values of 1 or less leave urgent transactions at the suggested fee.
+	UrgentMultiplier float64
+}
+
+// Tip returns the priority fee to pay given the suggested fee and whether the transaction is urgent.
+func (p FeePolicy) Tip(suggested *big.Int, urgent bool) *big.Int {
+	tip := new(big.Int).Set(suggested)
+	if urgent && p.UrgentMultiplier > 1 {
+		tip, _ = new(big.Float).Mul(new(big.Float).SetInt(suggested), big.NewFloat(p.UrgentMultiplier)).Int(nil)
+	}
+	if p.MinTip != nil && tip.Cmp(p.MinTip) < 0 {
+		tip.Set(p.MinTip)
+	}
+	if p.MaxTip != nil && tip.Cmp(p.MaxTip) > 0 {
+		tip.Set(p.MaxTip)
+	}
+	return tip
+}
+
+// FeePolicyBackend wraps a [txmgr.ETHBackend] to apply a [FeePolicy] to the suggested priority fee, which the
+// transaction manager uses both when crafting transactions and when bumping the fee of transactions not yet mined.
+// A transaction already sent with a higher fee than the policy allows is still bumped above it to replace it.
+type FeePolicyBackend struct {
+	txmgr.ETHBackend
+	Policy FeePolicy
+}
+
+func (b *FeePolicyBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	tip, err := b.ETHBackend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.Policy.Tip(tip, UrgentFees(ctx)), nil
+}
@@ -0,0 +1,77 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeePolicyTip(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    FeePolicy
+		suggested int64
+		urgent    bool
+		expected  int64
+	}{
+		{name: "NoPolicy", policy: FeePolicy{}, suggested: 100, urgent: true, expected: 100},
+		{name: "NotUrgent", policy: FeePolicy{UrgentMultiplier: 2.5}, suggested: 100, expected: 100},
+		{name: "Urgent", policy: FeePolicy{UrgentMultiplier: 2.5}, suggested: 100, urgent: true, expected: 250},
+		{name: "RaisedToMin", policy: FeePolicy{MinTip: big.NewInt(150)}, suggested: 100, expected: 150},
+		{name: "LimitedToMax", policy: FeePolicy{MaxTip: big.NewInt(80)}, suggested: 100, expected: 80},
+		{name: "UrgentLimitedToMax", policy: FeePolicy{MaxTip: big.NewInt(200), UrgentMultiplier: 3}, suggested: 100, urgent: true, expected: 200},
+		{name: "UrgentMultipliesBeforeMin", policy: FeePolicy{MinTip: big.NewInt(150), UrgentMultiplier: 2}, suggested: 50, urgent: true, expected: 150},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			suggested := big.NewInt(test.suggested)
+			require.Equal(t, big.NewInt(test.expected), test.policy.Tip(suggested, test.urgent))
+			require.Equal(t, big.NewInt(test.suggested), suggested, "should not modify the suggested tip")
+		})
+	}
+}
+
+// TestFeePolicyBackend tests that the multiplier is only applied to the tips suggested for urgent transactions.
+func TestFeePolicyBackend(t *testing.T) {
+	backend := &FeePolicyBackend{
+		ETHBackend: &stubTipBackend{tip: big.NewInt(1000)},
+		Policy:     FeePolicy{UrgentMultiplier: 1.5},
+	}
+	tip, err := backend.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), tip)
+
+	tip, err = backend.SuggestGasTipCap(WithUrgentFees(context.Background()))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1500), tip)
+
+	backend.ETHBackend = &stubTipBackend{err: errors.New("boom")}
+	_, err = backend.SuggestGasTipCap(context.Background())
+	require.ErrorContains(t, err, "boom")
+}
+
+// TestCriticalStepUrgentFees tests that only steps against claims whose clock is critical are sent with urgent fees.
+func TestCriticalStepUrgentFees(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t)
+	require.NoError(t, responder.Step(context.Background(), types.StepCallData{}))
+	require.False(t, mockTxMgr.urgent)
+
+	require.NoError(t, responder.Step(context.Background(), types.StepCallData{ClockCritical: true}))
+	require.True(t, mockTxMgr.urgent)
+}
+
+type stubTipBackend struct {
+	txmgr.ETHBackend
+	tip *big.Int
+	err error
+}
+
+func (s *stubTipBackend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return s.tip, s.err
+}
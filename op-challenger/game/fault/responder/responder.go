@@ -1,41 +1,119 @@
 package responder
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+const methodResolveClaim = "resolveClaim"
+
+// The action types the gas used by transactions is recorded by.
+const (
+	actionMove         = "move"
+	actionStep         = "step"
+	actionResolve      = "resolve"
+	actionResolveClaim = "resolve_claim"
+)
+
+// resolveClaimABI is the subgame resolution function of newer FaultDisputeGame versions, which require the
+// subgame of every claim to be resolved before the game itself can be resolved.
+const resolveClaimABI = `[{
+	"inputs":[{"internalType":"uint256","name":"_claimIndex","type":"uint256"}],
+	"name":"resolveClaim",
+	"outputs":[],
+	"stateMutability":"nonpayable",
+	"type":"function"
+}]`
+
+var (
+	ErrResolveReverted      = errors.New("resolve transaction reverted")
+	ErrResolveClaimReverted = errors.New("resolve claim transaction reverted")
+
+	// claimAlreadyResolvedSelector is the selector of the ClaimAlreadyResolved() error.
+	claimAlreadyResolvedSelector = crypto.Keccak256([]byte("ClaimAlreadyResolved()"))[:4]
+	// gameNotInProgressSelector is the selector of the GameNotInProgress() error, which resolving a game that is
+	// already resolved reverts with.
+	gameNotInProgressSelector = crypto.Keccak256([]byte("GameNotInProgress()"))[:4]
+)
+
+// GasEstimator estimates the gas required to execute a transaction.
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// L1Reader estimates gas and reads the L1 chain head for the responder.
+type L1Reader interface {
+	GasEstimator
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
 // faultResponder implements the [Responder] interface to send onchain transactions.
 type faultResponder struct {
 	log log.Logger
 
 	txMgr txmgr.TxManager
+	l1    L1Reader
+
+	fdgAddr         common.Address
+	fdgAbi          *abi.ABI
+	resolveClaimAbi abi.ABI
+
+	events  types.EventSink
+	metrics metrics.Metricer
+	breaker *CircuitBreaker
 
-	fdgAddr common.Address
-	fdgAbi  *abi.ABI
+	spentLock sync.Mutex
+	// spent is the total value in wei spent on the game's mined transactions.
+	spent *big.Int
 }
 
-// NewFaultResponder returns a new [faultResponder].
-func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, fdgAddr common.Address) (*faultResponder, error) {
+// NewFaultResponder returns a new [faultResponder] that doesn't emit events or record metrics.
+func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, l1 L1Reader, fdgAddr common.Address) (*faultResponder, error) {
+	return NewFaultResponderWithEvents(logger, txManagr, l1, fdgAddr, types.NoopEventSink{}, metrics.NoopMetrics, nil)
+}
+
+// NewFaultResponderWithEvents returns a new [faultResponder] that emits an event to events for each mined move and step.
+// The gas used and fee paid by each of its transactions are recorded to m.
+// Transactions are halted while breaker is open, unless it is nil.
+func NewFaultResponderWithEvents(logger log.Logger, txManagr txmgr.TxManager, l1 L1Reader, fdgAddr common.Address, events types.EventSink, m metrics.Metricer, breaker *CircuitBreaker) (*faultResponder, error) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
+	resolveClaimAbi, err := abi.JSON(strings.NewReader(resolveClaimABI))
+	if err != nil {
+		return nil, err
+	}
 	return &faultResponder{
-		log:     logger,
-		txMgr:   txManagr,
-		fdgAddr: fdgAddr,
-		fdgAbi:  fdgAbi,
+		log:             logger,
+		txMgr:           txManagr,
+		l1:              l1,
+		fdgAddr:         fdgAddr,
+		fdgAbi:          fdgAbi,
+		resolveClaimAbi: resolveClaimAbi,
+		events:          events,
+		metrics:         m,
+		breaker:         breaker,
+		spent:           new(big.Int),
 	}, nil
 }
 
@@ -101,13 +179,77 @@ func (r *faultResponder) CallResolve(ctx context.Context) (types.GameStatus, err
 }
 
 // Resolve executes a resolve transaction to resolve a fault dispute game.
+// Returns [ErrResolveReverted] if the transaction reverts.
 func (r *faultResponder) Resolve(ctx context.Context) error {
 	txData, err := r.buildResolveData()
 	if err != nil {
 		return err
 	}
 
-	return r.sendTxAndWait(ctx, txData)
+	receipt, err := r.sendTxAndWait(ctx, actionResolve, txData, false)
+	if err != nil {
+		return err
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		return fmt.Errorf("%w: %v", ErrResolveReverted, receipt.TxHash)
+	}
+	return nil
+}
+
+// CallResolveClaim determines if the resolveClaim function on the fault dispute game contract would succeed
+// for the claim at claimIdx. Returns an error if the call would revert, which can be checked with
+// [IsClaimAlreadyResolved] to find if the claim's subgame is already resolved.
+func (r *faultResponder) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
+	txData, err := r.resolveClaimAbi.Pack(methodResolveClaim, new(big.Int).SetUint64(claimIdx))
+	if err != nil {
+		return err
+	}
+	_, err = r.txMgr.Call(ctx, ethereum.CallMsg{
+		From: r.txMgr.From(),
+		To:   &r.fdgAddr,
+		Data: txData,
+	}, nil)
+	return err
+}
+
+// ResolveClaim executes a resolveClaim transaction to resolve the subgame of the claim at claimIdx.
+// Returns [ErrResolveClaimReverted] if the transaction reverts.
+func (r *faultResponder) ResolveClaim(ctx context.Context, claimIdx uint64) error {
+	txData, err := r.resolveClaimAbi.Pack(methodResolveClaim, new(big.Int).SetUint64(claimIdx))
+	if err != nil {
+		return err
+	}
+	receipt, err := r.sendTxAndWait(ctx, actionResolveClaim, txData, false)
+	if err != nil {
+		return err
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		return fmt.Errorf("%w: %v", ErrResolveClaimReverted, receipt.TxHash)
+	}
+	return nil
+}
+
+// IsClaimAlreadyResolved returns true if err is a revert caused by resolving a claim that is already resolved.
+func IsClaimAlreadyResolved(err error) bool {
+	return isRevertWithSelector(err, claimAlreadyResolvedSelector)
+}
+
+// IsGameAlreadyResolved returns true if err is a revert caused by resolving a game that is already resolved.
+func IsGameAlreadyResolved(err error) bool {
+	return isRevertWithSelector(err, gameNotInProgressSelector)
+}
+
+func isRevertWithSelector(err error, selector []byte) bool {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	return decodeErr == nil && bytes.Equal(data, selector)
 }
 
 // Respond takes a [Claim] and executes the response action.
@@ -116,26 +258,103 @@ func (r *faultResponder) Respond(ctx context.Context, response types.Claim) erro
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, txData)
+	receipt, err := r.sendTxAndWait(ctx, actionMove, txData, false)
+	if err != nil {
+		return err
+	}
+	r.emitTxEvent(types.EventClaimCountered, response.ParentContractIndex, receipt)
+	return nil
+}
+
+// EstimateRespondGas estimates the gas required to execute the response action for a [Claim].
+func (r *faultResponder) EstimateRespondGas(ctx context.Context, response types.Claim) (uint64, error) {
+	txData, err := r.BuildTx(ctx, response)
+	if err != nil {
+		return 0, err
+	}
+	return r.estimateGas(ctx, txData)
+}
+
+// estimateGas estimates the gas required to send a transaction with txData from the [txmgr] account.
+func (r *faultResponder) estimateGas(ctx context.Context, txData []byte) (uint64, error) {
+	return r.l1.EstimateGas(ctx, ethereum.CallMsg{
+		From: r.txMgr.From(),
+		To:   &r.fdgAddr,
+		Data: txData,
+	})
 }
 
-// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
+// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt, recording the gas used by the action.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
-func (r *faultResponder) sendTxAndWait(ctx context.Context, txData []byte) error {
+// Returns [ErrCircuitOpen] without sending the transaction if the circuit breaker is open, unless it is critical.
+// Critical transactions are sent with urgent fees.
+func (r *faultResponder) sendTxAndWait(ctx context.Context, action string, txData []byte, critical bool) (*ethtypes.Receipt, error) {
+	probe, err := r.breaker.acquire(critical)
+	if err != nil {
+		return nil, err
+	}
+	if critical {
+		ctx = WithUrgentFees(ctx)
+	}
 	receipt, err := r.txMgr.Send(ctx, txmgr.TxCandidate{
 		To:       &r.fdgAddr,
 		TxData:   txData,
 		GasLimit: 0,
 	})
+	r.breaker.release(probe, receipt, err)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		r.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
 	} else {
 		r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
 	}
-	return nil
+	var fee *big.Int
+	if receipt.EffectiveGasPrice != nil {
+		fee = new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	}
+	r.metrics.RecordActionGas(r.fdgAddr, action, receipt.GasUsed, fee)
+	if fee != nil {
+		r.recordSpend(fee)
+	}
+	return receipt, nil
+}
+
+// recordSpend adds amount to the value spent on the game's transactions.
+// Moves don't post a bond with this version of the game contract, so the spend is the fees paid.
+func (r *faultResponder) recordSpend(amount *big.Int) {
+	r.spentLock.Lock()
+	defer r.spentLock.Unlock()
+	r.spent.Add(r.spent, amount)
+	r.metrics.RecordGameSpend(r.fdgAddr, amount)
+}
+
+// Spent returns the total value in wei spent on the game's mined transactions, including those that reverted.
+func (r *faultResponder) Spent() *big.Int {
+	r.spentLock.Lock()
+	defer r.spentLock.Unlock()
+	return new(big.Int).Set(r.spent)
+}
+
+// emitTxEvent emits an event for a mined transaction acting on the claim at claimIndex.
+func (r *faultResponder) emitTxEvent(eventType types.EventType, claimIndex int, receipt *ethtypes.Receipt) {
+	txHash := receipt.TxHash
+	var blockNumber uint64
+	if receipt.BlockNumber != nil {
+		blockNumber = receipt.BlockNumber.Uint64()
+	}
+	r.events.Emit(types.Event{
+		Type:       eventType,
+		Game:       r.fdgAddr,
+		ClaimIndex: &claimIndex,
+		TxHash:     &txHash,
+		Reverted:   receipt.Status == ethtypes.ReceiptStatusFailed,
+
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+		BlockNumber:       blockNumber,
+	})
 }
 
 // buildStepTxData creates the transaction data for the step function.
@@ -155,5 +374,31 @@ func (r *faultResponder) Step(ctx context.Context, stepData types.StepCallData)
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, txData)
+	receipt, err := r.sendTxAndWait(ctx, actionStep, txData, stepData.ClockCritical)
+	if err != nil {
+		return err
+	}
+	r.emitTxEvent(types.EventStepExecuted, int(stepData.ClaimIndex), receipt)
+	return nil
+}
+
+// EstimateStepGas estimates the gas required to execute the step on the fault dispute game contract.
+func (r *faultResponder) EstimateStepGas(ctx context.Context, stepData types.StepCallData) (uint64, error) {
+	txData, err := r.buildStepTxData(stepData)
+	if err != nil {
+		return 0, err
+	}
+	return r.estimateGas(ctx, txData)
+}
+
+// BaseFee returns the base fee of the latest L1 block.
+func (r *faultResponder) BaseFee(ctx context.Context) (*big.Int, error) {
+	head, err := r.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("L1 head has no base fee")
+	}
+	return head.BaseFee, nil
 }
@@ -3,16 +3,19 @@ package responder
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -23,6 +26,7 @@ var (
 	mockFdgAddress = common.HexToAddress("0x1234")
 	mockSendError  = errors.New("mock send error")
 	mockCallError  = errors.New("mock call error")
+	mockGasError   = errors.New("mock gas error")
 )
 
 // TestCallResolve tests the [Responder.CallResolve].
@@ -64,6 +68,13 @@ func TestResolve(t *testing.T) {
 		require.Equal(t, 0, mockTxMgr.sends)
 	})
 
+	t.Run("Reverted", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.reverts = true
+		err := responder.Resolve(context.Background())
+		require.ErrorIs(t, err, ErrResolveReverted)
+	})
+
 	t.Run("Success", func(t *testing.T) {
 		responder, mockTxMgr := newTestFaultResponder(t)
 		err := responder.Resolve(context.Background())
@@ -72,6 +83,62 @@ func TestResolve(t *testing.T) {
 	})
 }
 
+// TestResolveClaim tests the [Responder.CallResolveClaim] and [Responder.ResolveClaim] methods.
+func TestResolveClaim(t *testing.T) {
+	t.Run("CallFails", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.callFails = true
+		err := responder.CallResolveClaim(context.Background(), 2)
+		require.ErrorIs(t, err, mockCallError)
+	})
+
+	t.Run("CallSuccess", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		require.NoError(t, responder.CallResolveClaim(context.Background(), 2))
+		require.Equal(t, 1, mockTxMgr.calls)
+	})
+
+	t.Run("SendFails", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.sendFails = true
+		err := responder.ResolveClaim(context.Background(), 2)
+		require.ErrorIs(t, err, mockSendError)
+	})
+
+	t.Run("Reverted", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.reverts = true
+		err := responder.ResolveClaim(context.Background(), 2)
+		require.ErrorIs(t, err, ErrResolveClaimReverted)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		require.NoError(t, responder.ResolveClaim(context.Background(), 2))
+		require.Equal(t, 1, mockTxMgr.sends)
+		expected, err := responder.resolveClaimAbi.Pack(methodResolveClaim, big.NewInt(2))
+		require.NoError(t, err)
+		require.Equal(t, expected, mockTxMgr.sendData)
+	})
+}
+
+func TestIsClaimAlreadyResolved(t *testing.T) {
+	require.True(t, IsClaimAlreadyResolved(&revertError{data: hexutil.Encode(claimAlreadyResolvedSelector)}))
+	require.True(t, IsClaimAlreadyResolved(fmt.Errorf("wrapped: %w", &revertError{data: hexutil.Encode(claimAlreadyResolvedSelector)})))
+	require.False(t, IsClaimAlreadyResolved(&revertError{data: "0x12345678"}))
+	require.False(t, IsClaimAlreadyResolved(&revertError{data: "not hex"}))
+	require.False(t, IsClaimAlreadyResolved(errors.New("execution reverted")))
+	require.False(t, IsClaimAlreadyResolved(nil))
+}
+
+func TestIsGameAlreadyResolved(t *testing.T) {
+	require.True(t, IsGameAlreadyResolved(&revertError{data: hexutil.Encode(gameNotInProgressSelector)}))
+	require.True(t, IsGameAlreadyResolved(fmt.Errorf("wrapped: %w", &revertError{data: hexutil.Encode(gameNotInProgressSelector)})))
+	require.False(t, IsGameAlreadyResolved(&revertError{data: hexutil.Encode(claimAlreadyResolvedSelector)}))
+	require.False(t, IsGameAlreadyResolved(errors.New("execution reverted")))
+	require.False(t, IsGameAlreadyResolved(nil))
+}
+
 // TestRespond tests the [Responder.Respond] method.
 func TestRespond(t *testing.T) {
 	t.Run("send fails", func(t *testing.T) {
@@ -90,6 +157,183 @@ func TestRespond(t *testing.T) {
 	})
 }
 
+// TestEvents tests that mined moves and steps are emitted as events.
+func TestEvents(t *testing.T) {
+	setup := func(t *testing.T) (*faultResponder, *mockTxManager, *stubEventSink) {
+		mockTxMgr := &mockTxManager{}
+		events := &stubEventSink{}
+		responder, err := NewFaultResponderWithEvents(testlog.Logger(t, log.LvlError), mockTxMgr, mockTxMgr, mockFdgAddress, events, metrics.NoopMetrics, nil)
+		require.NoError(t, err)
+		return responder, mockTxMgr, events
+	}
+
+	t.Run("Respond", func(t *testing.T) {
+		responder, _, events := setup(t)
+		response := generateMockResponseClaim()
+		response.ParentContractIndex = 3
+		require.NoError(t, responder.Respond(context.Background(), response))
+		claimIndex := 3
+		require.Equal(t, []types.Event{{
+			Type:       types.EventClaimCountered,
+			Game:       mockFdgAddress,
+			ClaimIndex: &claimIndex,
+			TxHash:     &common.Hash{},
+		}}, events.events)
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		responder, mockTxMgr, events := setup(t)
+		mockTxMgr.reverts = true
+		require.NoError(t, responder.Step(context.Background(), types.StepCallData{ClaimIndex: 5}))
+		claimIndex := 5
+		require.Equal(t, []types.Event{{
+			Type:       types.EventStepExecuted,
+			Game:       mockFdgAddress,
+			ClaimIndex: &claimIndex,
+			TxHash:     &common.Hash{},
+			Reverted:   true,
+		}}, events.events)
+	})
+
+	t.Run("IncludesReceipt", func(t *testing.T) {
+		responder, mockTxMgr, events := setup(t)
+		mockTxMgr.receipt = &ethtypes.Receipt{
+			Status:            ethtypes.ReceiptStatusSuccessful,
+			TxHash:            common.Hash{0xaa},
+			GasUsed:           52_000,
+			EffectiveGasPrice: big.NewInt(3_000_000_000),
+			BlockNumber:       big.NewInt(1234),
+		}
+		require.NoError(t, responder.Respond(context.Background(), generateMockResponseClaim()))
+		require.Len(t, events.events, 1)
+		event := events.events[0]
+		require.Equal(t, common.Hash{0xaa}, *event.TxHash)
+		require.Equal(t, uint64(52_000), event.GasUsed)
+		require.Equal(t, big.NewInt(3_000_000_000), event.EffectiveGasPrice)
+		require.Equal(t, uint64(1234), event.BlockNumber)
+	})
+
+	t.Run("NotEmittedWhenSendFails", func(t *testing.T) {
+		responder, mockTxMgr, events := setup(t)
+		mockTxMgr.sendFails = true
+		require.ErrorIs(t, responder.Respond(context.Background(), generateMockResponseClaim()), mockSendError)
+		require.ErrorIs(t, responder.Step(context.Background(), types.StepCallData{}), mockSendError)
+		require.Empty(t, events.events)
+	})
+}
+
+// TestRecordActionGas tests that the gas used and fee paid by each action's transaction are recorded.
+func TestRecordActionGas(t *testing.T) {
+	mockTxMgr := &mockTxManager{receipt: &ethtypes.Receipt{
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		GasUsed:           50_000,
+		EffectiveGasPrice: big.NewInt(2),
+	}}
+	m := &stubGasMetrics{Metricer: metrics.NoopMetrics}
+	responder, err := NewFaultResponderWithEvents(testlog.Logger(t, log.LvlError), mockTxMgr, mockTxMgr, mockFdgAddress, types.NoopEventSink{}, m, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, responder.Respond(context.Background(), generateMockResponseClaim()))
+	require.NoError(t, responder.Step(context.Background(), types.StepCallData{}))
+	require.NoError(t, responder.Resolve(context.Background()))
+	require.NoError(t, responder.ResolveClaim(context.Background(), 0))
+	require.Equal(t, []string{actionMove, actionStep, actionResolve, actionResolveClaim}, m.actions)
+	require.Equal(t, uint64(4*50_000), m.gasUsed)
+	require.Equal(t, big.NewInt(4*100_000), &m.fees)
+
+	t.Run("UnknownGasPrice", func(t *testing.T) {
+		mockTxMgr.receipt.EffectiveGasPrice = nil
+		m := &stubGasMetrics{Metricer: metrics.NoopMetrics}
+		responder, err := NewFaultResponderWithEvents(testlog.Logger(t, log.LvlError), mockTxMgr, mockTxMgr, mockFdgAddress, types.NoopEventSink{}, m, nil)
+		require.NoError(t, err)
+		require.NoError(t, responder.Respond(context.Background(), generateMockResponseClaim()))
+		require.Equal(t, uint64(50_000), m.gasUsed)
+		require.Zero(t, m.fees.Sign(), "should not record a fee without the gas price")
+	})
+}
+
+// TestSpent tests that the fees of every mined transaction, including those that revert, are counted as spent.
+func TestSpent(t *testing.T) {
+	mockTxMgr := &mockTxManager{receipt: &ethtypes.Receipt{
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		GasUsed:           50_000,
+		EffectiveGasPrice: big.NewInt(2),
+	}}
+	m := &stubGasMetrics{Metricer: metrics.NoopMetrics}
+	responder, err := NewFaultResponderWithEvents(testlog.Logger(t, log.LvlError), mockTxMgr, mockTxMgr, mockFdgAddress, types.NoopEventSink{}, m, nil)
+	require.NoError(t, err)
+	require.Zero(t, responder.Spent().Sign())
+
+	require.NoError(t, responder.Respond(context.Background(), generateMockResponseClaim()))
+	mockTxMgr.receipt.Status = ethtypes.ReceiptStatusFailed
+	require.NoError(t, responder.Step(context.Background(), types.StepCallData{}))
+	require.Equal(t, big.NewInt(200_000), responder.Spent())
+	require.Equal(t, big.NewInt(200_000), &m.spend)
+
+	mockTxMgr.sendFails = true
+	require.ErrorIs(t, responder.Resolve(context.Background()), mockSendError)
+	require.Equal(t, big.NewInt(200_000), responder.Spent(), "should not count transactions that weren't mined")
+
+	spent := responder.Spent()
+	spent.SetInt64(0)
+	require.Equal(t, big.NewInt(200_000), responder.Spent(), "should not be modified through the returned value")
+}
+
+type stubGasMetrics struct {
+	metrics.Metricer
+	actions []string
+	gasUsed uint64
+	fees    big.Int
+	spend   big.Int
+}
+
+func (s *stubGasMetrics) RecordGameSpend(game common.Address, amount *big.Int) {
+	s.spend.Add(&s.spend, amount)
+}
+
+func (s *stubGasMetrics) RecordActionGas(game common.Address, action string, gasUsed uint64, fee *big.Int) {
+	s.actions = append(s.actions, action)
+	s.gasUsed += gasUsed
+	if fee != nil {
+		s.fees.Add(&s.fees, fee)
+	}
+}
+
+// TestEstimateGas tests estimating gas for responses and steps.
+func TestEstimateGas(t *testing.T) {
+	t.Run("respond", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.from = common.Address{0xaa}
+		mockTxMgr.gas = 1234
+		response := generateMockResponseClaim()
+		gas, err := responder.EstimateRespondGas(context.Background(), response)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1234), gas)
+
+		expectedData, err := responder.BuildTx(context.Background(), response)
+		require.NoError(t, err)
+		require.Equal(t, expectedData, mockTxMgr.gasMsg.Data)
+		require.Equal(t, &mockFdgAddress, mockTxMgr.gasMsg.To)
+		require.Equal(t, common.Address{0xaa}, mockTxMgr.gasMsg.From)
+		require.Zero(t, mockTxMgr.sends, "should not send transaction")
+	})
+
+	t.Run("step", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.gas = 5678
+		gas, err := responder.EstimateStepGas(context.Background(), types.StepCallData{ClaimIndex: 2, StateData: []byte{0x01}})
+		require.NoError(t, err)
+		require.Equal(t, uint64(5678), gas)
+	})
+
+	t.Run("fails", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		mockTxMgr.gasFails = true
+		_, err := responder.EstimateRespondGas(context.Background(), generateMockResponseClaim())
+		require.ErrorIs(t, err, mockGasError)
+	})
+}
+
 // TestBuildTx tests the [Responder.BuildTx] method.
 func TestBuildTx(t *testing.T) {
 	t.Run("attack", func(t *testing.T) {
@@ -139,10 +383,21 @@ func TestBuildTx(t *testing.T) {
 	})
 }
 
+func TestBaseFee(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t)
+	_, err := responder.BaseFee(context.Background())
+	require.ErrorContains(t, err, "no base fee")
+
+	mockTxMgr.baseFee = big.NewInt(30_000_000_000)
+	baseFee, err := responder.BaseFee(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, mockTxMgr.baseFee, baseFee)
+}
+
 func newTestFaultResponder(t *testing.T) (*faultResponder, *mockTxManager) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
-	responder, err := NewFaultResponder(log, mockTxMgr, mockFdgAddress)
+	responder, err := NewFaultResponder(log, mockTxMgr, mockTxMgr, mockFdgAddress)
 	require.NoError(t, err)
 	return responder, mockTxMgr
 }
@@ -154,6 +409,16 @@ type mockTxManager struct {
 	sendFails bool
 	callFails bool
 	callBytes []byte
+	gas       uint64
+	gasFails  bool
+	gasMsg    ethereum.CallMsg
+	sendData  []byte
+	reverts   bool
+	// urgent is whether the last transaction was sent with urgent fees.
+	urgent  bool
+	baseFee *big.Int
+	// receipt is the receipt returned by Send, if set.
+	receipt *ethtypes.Receipt
 }
 
 func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
@@ -161,9 +426,14 @@ func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (
 		return nil, mockSendError
 	}
 	m.sends++
+	m.sendData = candidate.TxData
+	m.urgent = UrgentFees(ctx)
+	if m.receipt != nil {
+		return m.receipt, nil
+	}
 	return ethtypes.NewReceipt(
 		[]byte{},
-		false,
+		m.reverts,
 		0,
 	), nil
 }
@@ -181,6 +451,18 @@ func (m *mockTxManager) Call(_ context.Context, _ ethereum.CallMsg, _ *big.Int)
 	), nil
 }
 
+func (m *mockTxManager) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if m.gasFails {
+		return 0, mockGasError
+	}
+	m.gasMsg = msg
+	return m.gas, nil
+}
+
+func (m *mockTxManager) HeaderByNumber(_ context.Context, _ *big.Int) (*ethtypes.Header, error) {
+	return &ethtypes.Header{Number: big.NewInt(1), BaseFee: m.baseFee}, nil
+}
+
 func (m *mockTxManager) BlockNumber(ctx context.Context) (uint64, error) {
 	panic("not implemented")
 }
@@ -203,3 +485,24 @@ func generateMockResponseClaim() types.Claim {
 		ParentContractIndex: 0,
 	}
 }
+
+type stubEventSink struct {
+	events []types.Event
+}
+
+func (s *stubEventSink) Emit(event types.Event) {
+	s.events = append(s.events, event)
+}
+
+// revertError is a JSON-RPC error carrying revert data, as returned by eth_call when a call reverts.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
@@ -0,0 +1,149 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/file"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrUnsupportedGameType = errors.New("unsupported game type")
+
+// TraceProviderCreator creates the trace provider and oracle updater for a game.
+type TraceProviderCreator func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error)
+
+// TraceProviderSelector selects how to create the trace provider for a game based on its game type.
+type TraceProviderSelector interface {
+	// SelectTraceProvider returns the creator for the game type or false if the game type is not supported.
+	SelectTraceProvider(gameType uint8) (TraceProviderCreator, bool)
+}
+
+// TraceProviders is a [TraceProviderSelector] that maps game types to the creator of their trace provider.
+type TraceProviders map[uint8]TraceProviderCreator
+
+func (p TraceProviders) SelectTraceProvider(gameType uint8) (TraceProviderCreator, bool) {
+	creator, ok := p[gameType]
+	return creator, ok
+}
+
+// NewTraceProviders creates a [TraceProviders] that supports the game types of each configured trace type.
+// If registry is not nil, cannon traces are shared with other games that have the same trace instead of being
+// generated in the game's directory.
+func NewTraceProviders(
+	logger log.Logger,
+	cfg *config.Config,
+	dir string,
+	addr common.Address,
+	txMgr txmgr.TxManager,
+	client bind.ContractCaller,
+	registry *cannon.ProviderRegistry,
+) TraceProviders {
+	providers := make(TraceProviders)
+	for _, traceType := range cfg.TraceTypes {
+		switch traceType {
+		case config.TraceTypeCannon:
+			providers[config.CannonFaultGameID] = func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error) {
+				provider, err := newCannonTraceProvider(ctx, logger, cfg, dir, addr, client, registry)
+				if err != nil {
+					return nil, nil, fmt.Errorf("create cannon trace provider: %w", err)
+				}
+				updater, err := cannon.NewOracleUpdater(ctx, logger, txMgr, addr, client)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to create the cannon updater: %w", err)
+				}
+				return provider, updater, nil
+			}
+		case config.TraceTypeAlphabet:
+			providers[config.AlphabetFaultGameID] = func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error) {
+				provider, err := alphabet.NewTraceProviderFromSpec(cfg.AlphabetTrace, gameDepth)
+				if err != nil {
+					return nil, nil, fmt.Errorf("create alphabet trace provider: %w", err)
+				}
+				return provider, alphabet.NewOracleUpdater(logger), nil
+			}
+		case config.TraceTypeFile:
+			// A trace file may be exported from either game type so support both. The absolute prestate
+			// validation rejects games the trace doesn't belong to.
+			providers[config.CannonFaultGameID] = func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error) {
+				provider, err := file.NewTraceProvider(cfg.TraceFile, gameDepth)
+				if err != nil {
+					return nil, nil, fmt.Errorf("create file trace provider: %w", err)
+				}
+				updater, err := cannon.NewOracleUpdater(ctx, logger, txMgr, addr, client)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to create the cannon updater: %w", err)
+				}
+				return provider, updater, nil
+			}
+			providers[config.AlphabetFaultGameID] = func(ctx context.Context, gameDepth uint64) (types.TraceProvider, types.OracleUpdater, error) {
+				provider, err := file.NewTraceProvider(cfg.TraceFile, gameDepth)
+				if err != nil {
+					return nil, nil, fmt.Errorf("create file trace provider: %w", err)
+				}
+				return provider, alphabet.NewOracleUpdater(logger), nil
+			}
+		}
+	}
+	return providers
+}
+
+func newCannonTraceProvider(
+	ctx context.Context,
+	logger log.Logger,
+	cfg *config.Config,
+	dir string,
+	addr common.Address,
+	client bind.ContractCaller,
+	registry *cannon.ProviderRegistry,
+) (types.TraceProvider, error) {
+	if registry != nil {
+		return cannon.NewSharedTraceProvider(ctx, cfg, client, registry, addr, dir)
+	}
+	return cannon.NewTraceProvider(ctx, logger, cfg, client, dir, addr)
+}
+
+// AbsolutePrestates returns the absolute prestate hash for each game type supported by the configured trace types.
+func AbsolutePrestates(ctx context.Context, cfg *config.Config) (map[uint8]common.Hash, error) {
+	prestates := make(map[uint8]common.Hash)
+	for _, traceType := range cfg.TraceTypes {
+		switch traceType {
+		case config.TraceTypeCannon:
+			hash, err := cannon.PreStateCommitment(cfg.CannonAbsolutePreState)
+			if err != nil {
+				return nil, fmt.Errorf("cannon absolute prestate: %w", err)
+			}
+			prestates[config.CannonFaultGameID] = hash
+		case config.TraceTypeAlphabet:
+			provider, err := alphabet.NewTraceProviderFromSpec(cfg.AlphabetTrace, 0)
+			if err != nil {
+				return nil, fmt.Errorf("alphabet trace: %w", err)
+			}
+			hash, err := provider.AbsolutePreStateCommitment(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("alphabet absolute prestate: %w", err)
+			}
+			prestates[config.AlphabetFaultGameID] = hash
+		case config.TraceTypeFile:
+			provider, err := file.NewTraceProvider(cfg.TraceFile, 0)
+			if err != nil {
+				return nil, fmt.Errorf("file absolute prestate: %w", err)
+			}
+			hash, err := provider.AbsolutePreStateCommitment(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("file absolute prestate: %w", err)
+			}
+			prestates[config.CannonFaultGameID] = hash
+			prestates[config.AlphabetFaultGameID] = hash
+		}
+	}
+	return prestates, nil
+}
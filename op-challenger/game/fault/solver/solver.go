@@ -29,12 +29,17 @@ func NewSolver(gameDepth int, traceProvider types.TraceProvider) *Solver {
 }
 
 // NextMove returns the next move to make given the current state of the game.
+// The move is determined by whether our trace agrees with the claim: a disputed claim means the divergence is
+// at or before its trace index so it is attacked, otherwise the divergence is after it and it is defended.
+// Either way the move bisects the remaining range so no other choice reaches the divergence in fewer moves.
 func (s *Solver) NextMove(ctx context.Context, claim types.Claim, agreeWithClaimLevel bool) (*types.Claim, error) {
 	if agreeWithClaimLevel {
 		return nil, nil
 	}
-	if claim.Depth() == s.gameDepth {
-		return nil, types.ErrGameDepthReached
+	// A response is one level deeper than the claim so it must not go beyond the leaves, even if the claim is
+	// deeper than the game allows, or a trace that disagrees at every level would bisect forever.
+	if claim.Depth() >= s.gameDepth {
+		return nil, fmt.Errorf("%w: claim depth %v, max depth %v", types.ErrGameDepthReached, claim.Depth(), s.gameDepth)
 	}
 	agree, err := s.agreeWithClaim(ctx, claim.ClaimData)
 	if err != nil {
@@ -48,11 +53,15 @@ func (s *Solver) NextMove(ctx context.Context, claim types.Claim, agreeWithClaim
 }
 
 type StepData struct {
-	LeafClaim  types.Claim
-	IsAttack   bool
+	LeafClaim types.Claim
+	IsAttack  bool
+	// TraceIndex is the index the step data was requested from the trace provider at.
+	TraceIndex uint64
 	PreState   []byte
 	ProofData  []byte
 	OracleData *types.PreimageOracleData
+	// Disagreement is how the leaf claim differs from the trace when it is attacked. Nil if it is defended.
+	Disagreement *types.ClaimDisagreement
 }
 
 // AttemptStep determines what step should occur for a given leaf claim.
@@ -64,10 +73,11 @@ func (s *Solver) AttemptStep(ctx context.Context, claim types.Claim, agreeWithCl
 	if agreeWithClaimLevel {
 		return StepData{}, ErrStepAgreedClaim
 	}
-	claimCorrect, err := s.agreeWithClaim(ctx, claim.ClaimData)
+	disagreement, err := s.Disagreement(ctx, claim)
 	if err != nil {
 		return StepData{}, err
 	}
+	claimCorrect := disagreement == nil
 	index := claim.TraceIndex(s.gameDepth)
 	var preState []byte
 	var proofData []byte
@@ -83,18 +93,21 @@ func (s *Solver) AttemptStep(ctx context.Context, claim types.Claim, agreeWithCl
 		// We agree with the claim so Defend and use this claim as the starting point to execute the step after
 		// Thus we need the pre-state of the next step
 		// Note: This makes our maximum depth 63 because we need to add 1 without overflowing.
-		preState, proofData, oracleData, err = s.trace.GetStepData(ctx, index+1)
+		index++
+		preState, proofData, oracleData, err = s.trace.GetStepData(ctx, index)
 		if err != nil {
 			return StepData{}, err
 		}
 	}
 
 	return StepData{
-		LeafClaim:  claim,
-		IsAttack:   !claimCorrect,
-		PreState:   preState,
-		ProofData:  proofData,
-		OracleData: oracleData,
+		LeafClaim:    claim,
+		IsAttack:     !claimCorrect,
+		TraceIndex:   index,
+		PreState:     preState,
+		ProofData:    proofData,
+		OracleData:   oracleData,
+		Disagreement: disagreement,
 	}, nil
 }
 
@@ -129,6 +142,29 @@ func (s *Solver) defend(ctx context.Context, claim types.Claim) (*types.Claim, e
 	}, nil
 }
 
+// AgreeWithClaim returns true if the claim is correct according to the internal [TraceProvider].
+func (s *Solver) AgreeWithClaim(ctx context.Context, claim types.ClaimData) (bool, error) {
+	return s.agreeWithClaim(ctx, claim)
+}
+
+// Disagreement returns how claim differs from the internal [TraceProvider], or nil if the claim is correct.
+func (s *Solver) Disagreement(ctx context.Context, claim types.Claim) (*types.ClaimDisagreement, error) {
+	index := claim.TraceIndex(s.gameDepth)
+	ourValue, err := s.trace.Get(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if ourValue == claim.Value {
+		return nil, nil
+	}
+	return &types.ClaimDisagreement{
+		ClaimIndex: claim.ContractIndex,
+		TraceIndex: index,
+		Claimed:    claim.Value,
+		Expected:   ourValue,
+	}, nil
+}
+
 // agreeWithClaim returns true if the claim is correct according to the internal [TraceProvider].
 func (s *Solver) agreeWithClaim(ctx context.Context, claim types.ClaimData) (bool, error) {
 	ourValue, err := s.traceAtPosition(ctx, claim.Position)
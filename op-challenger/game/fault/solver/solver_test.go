@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -80,6 +81,11 @@ func TestNextMove(t *testing.T) {
 			claim:       builder.CreateLeafClaim(6, false),
 			expectedErr: types.ErrGameDepthReached,
 		},
+		{
+			name:        "ErrorWhenClaimBeyondMaxDepth",
+			claim:       types.Claim{ClaimData: types.ClaimData{Position: types.NewPosition(maxDepth+1, 0)}},
+			expectedErr: types.ErrGameDepthReached,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -101,6 +107,56 @@ func TestNextMove(t *testing.T) {
 	}
 }
 
+// TestNextMove_BisectsToDivergence plays the solver against an opponent whose trace diverges from the correct
+// trace at each possible index and checks the game always narrows to the step at the divergence in one move
+// per level. Whether to attack or defend is decided by whether the correct trace agrees with the claim so
+// divergences on either side of each midpoint exercise both moves.
+func TestNextMove_BisectsToDivergence(t *testing.T) {
+	maxDepth := 4
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	honest := solver.NewSolver(maxDepth, builder.CorrectTraceProvider())
+	ctx := context.Background()
+
+	var attacks, defends int
+	for divergence := uint64(0); divergence < 1<<maxDepth; divergence++ {
+		opponent := solver.NewSolver(maxDepth, &divergentTraceProvider{builder.CorrectTraceProvider(), divergence})
+		claim := builder.CreateRootClaim(false)
+		for claim.Depth() < maxDepth {
+			move, err := honest.NextMove(ctx, claim, false)
+			require.NoError(t, err)
+			require.NotNil(t, move)
+			if move.Position == claim.Attack() {
+				attacks++
+			} else {
+				defends++
+			}
+			require.Equal(t, claim.Depth()+1, move.Depth(), "should move one level down")
+			counter, err := opponent.NextMove(ctx, *move, false)
+			require.NoError(t, err)
+			require.NotNil(t, counter)
+			claim = *counter
+		}
+		step, err := honest.AttemptStep(ctx, claim, false)
+		require.NoError(t, err)
+		require.Equalf(t, divergence, step.TraceIndex, "should step at divergence %v", divergence)
+	}
+	require.NotZero(t, attacks, "should attack")
+	require.NotZero(t, defends, "should defend")
+}
+
+// divergentTraceProvider returns the correct trace up to the divergence index and an incorrect trace from then on.
+type divergentTraceProvider struct {
+	types.TraceProvider
+	divergence uint64
+}
+
+func (d *divergentTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	if i < d.divergence {
+		return d.TraceProvider.Get(ctx, i)
+	}
+	return common.Hash{0xbb, byte(i)}, nil
+}
+
 func TestAttemptStep(t *testing.T) {
 	maxDepth := 3
 	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
@@ -119,6 +175,7 @@ func TestAttemptStep(t *testing.T) {
 		agreeWithLevel     bool
 		expectedErr        error
 		expectAttack       bool
+		expectTraceIndex   uint64
 		expectPreState     []byte
 		expectProofData    []byte
 		expectedOracleData *types.PreimageOracleData
@@ -127,6 +184,7 @@ func TestAttemptStep(t *testing.T) {
 			name:               "AttackFirstTraceIndex",
 			claim:              builder.CreateLeafClaim(0, false),
 			expectAttack:       true,
+			expectTraceIndex:   0,
 			expectPreState:     builder.CorrectPreState(0),
 			expectProofData:    builder.CorrectProofData(0),
 			expectedOracleData: builder.CorrectOracleData(0),
@@ -135,6 +193,7 @@ func TestAttemptStep(t *testing.T) {
 			name:               "DefendFirstTraceIndex",
 			claim:              builder.CreateLeafClaim(0, true),
 			expectAttack:       false,
+			expectTraceIndex:   1,
 			expectPreState:     builder.CorrectPreState(1),
 			expectProofData:    builder.CorrectProofData(1),
 			expectedOracleData: builder.CorrectOracleData(1),
@@ -143,6 +202,7 @@ func TestAttemptStep(t *testing.T) {
 			name:               "AttackMiddleTraceIndex",
 			claim:              builder.CreateLeafClaim(4, false),
 			expectAttack:       true,
+			expectTraceIndex:   4,
 			expectPreState:     builder.CorrectPreState(4),
 			expectProofData:    builder.CorrectProofData(4),
 			expectedOracleData: builder.CorrectOracleData(4),
@@ -151,6 +211,7 @@ func TestAttemptStep(t *testing.T) {
 			name:               "DefendMiddleTraceIndex",
 			claim:              builder.CreateLeafClaim(4, true),
 			expectAttack:       false,
+			expectTraceIndex:   5,
 			expectPreState:     builder.CorrectPreState(5),
 			expectProofData:    builder.CorrectProofData(5),
 			expectedOracleData: builder.CorrectOracleData(5),
@@ -159,6 +220,7 @@ func TestAttemptStep(t *testing.T) {
 			name:               "AttackLastTraceIndex",
 			claim:              builder.CreateLeafClaim(lastLeafTraceIndex, false),
 			expectAttack:       true,
+			expectTraceIndex:   lastLeafTraceIndex,
 			expectPreState:     builder.CorrectPreState(lastLeafTraceIndex),
 			expectProofData:    builder.CorrectProofData(lastLeafTraceIndex),
 			expectedOracleData: builder.CorrectOracleData(lastLeafTraceIndex),
@@ -167,6 +229,7 @@ func TestAttemptStep(t *testing.T) {
 			name:               "DefendLastTraceIndex",
 			claim:              builder.CreateLeafClaim(lastLeafTraceIndex, true),
 			expectAttack:       false,
+			expectTraceIndex:   lastLeafTraceIndex + 1,
 			expectPreState:     builder.CorrectPreState(lastLeafTraceIndex + 1),
 			expectProofData:    builder.CorrectProofData(lastLeafTraceIndex + 1),
 			expectedOracleData: builder.CorrectOracleData(lastLeafTraceIndex + 1),
@@ -204,12 +267,20 @@ func TestAttemptStep(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tableTest.claim, step.LeafClaim)
 				require.Equal(t, tableTest.expectAttack, step.IsAttack)
+				require.Equal(t, tableTest.expectTraceIndex, step.TraceIndex)
 				require.Equal(t, tableTest.expectPreState, step.PreState)
 				require.Equal(t, tableTest.expectProofData, step.ProofData)
 				require.Equal(t, tableTest.expectedOracleData.IsLocal, step.OracleData.IsLocal)
 				require.Equal(t, tableTest.expectedOracleData.OracleKey, step.OracleData.OracleKey)
 				require.Equal(t, tableTest.expectedOracleData.OracleData, step.OracleData.OracleData)
 				require.Equal(t, tableTest.expectedOracleData.OracleOffset, step.OracleData.OracleOffset)
+				if tableTest.expectAttack {
+					require.NotNil(t, step.Disagreement)
+					require.Equal(t, tableTest.expectTraceIndex, step.Disagreement.TraceIndex)
+					require.Equal(t, tableTest.claim.Value, step.Disagreement.Claimed)
+				} else {
+					require.Nil(t, step.Disagreement)
+				}
 			} else {
 				require.ErrorIs(t, err, tableTest.expectedErr)
 				require.Equal(t, solver.StepData{}, step)
@@ -217,3 +288,29 @@ func TestAttemptStep(t *testing.T) {
 		})
 	}
 }
+
+func TestDisagreement(t *testing.T) {
+	maxDepth := 3
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	alphabetSolver := solver.NewSolver(maxDepth, builder.CorrectTraceProvider())
+	ctx := context.Background()
+
+	t.Run("AgreedClaim", func(t *testing.T) {
+		disagreement, err := alphabetSolver.Disagreement(ctx, builder.CreateLeafClaim(4, true))
+		require.NoError(t, err)
+		require.Nil(t, disagreement)
+	})
+
+	t.Run("DisputedClaim", func(t *testing.T) {
+		claim := builder.CreateLeafClaim(4, false)
+		claim.ContractIndex = 7
+		disagreement, err := alphabetSolver.Disagreement(ctx, claim)
+		require.NoError(t, err)
+		require.Equal(t, &types.ClaimDisagreement{
+			ClaimIndex: 7,
+			TraceIndex: 4,
+			Claimed:    claim.Value,
+			Expected:   builder.CorrectClaim(4),
+		}, disagreement)
+	})
+}
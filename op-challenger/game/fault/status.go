@@ -0,0 +1,135 @@
+package fault
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+// StatusRegistry records a summary of the latest state of each game being played.
+// It is safe for concurrent use by the players of different games.
+type StatusRegistry struct {
+	clock clock.Clock
+
+	mu    sync.Mutex
+	games map[common.Address]*types.GameSummary
+}
+
+func NewStatusRegistry(cl clock.Clock) *StatusRegistry {
+	return &StatusRegistry{
+		clock: cl,
+		games: make(map[common.Address]*types.GameSummary),
+	}
+}
+
+// RecordState records the status, claim count and soonest chess clock deadline of game.
+// A zero deadline means no chess clock is running.
+func (r *StatusRegistry) RecordState(game common.Address, status types.GameStatus, claims uint64, deadline time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := r.summary(game)
+	summary.Status = status.String()
+	summary.Claims = claims
+	summary.ClockDeadline = nil
+	if !deadline.IsZero() {
+		summary.ClockDeadline = &deadline
+	}
+	summary.Updated = r.clock.Now()
+}
+
+// RecordError records the most recent error progressing game.
+func (r *StatusRegistry) RecordError(game common.Address, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := r.summary(game)
+	now := r.clock.Now()
+	summary.LastError = err.Error()
+	summary.LastErrorTime = &now
+	summary.Updated = now
+}
+
+// RecordOutlook records the latest analysis of whether game is expected to be won.
+func (r *StatusRegistry) RecordOutlook(game common.Address, outlook Outlook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := r.summary(game)
+	summary.Outlook = string(outlook)
+	summary.Updated = r.clock.Now()
+}
+
+// RecordDisputedResolution records that game resolved with a status other than expected.
+func (r *StatusRegistry) RecordDisputedResolution(game common.Address, expected types.GameStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := r.summary(game)
+	summary.ExpectedStatus = expected.String()
+	summary.Updated = r.clock.Now()
+}
+
+// RecordResolution records when game resolved and the address credited with the root claim's bond.
+// Zero values are unknown and not recorded.
+func (r *StatusRegistry) RecordResolution(game common.Address, resolvedAt time.Time, winner common.Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := r.summary(game)
+	if !resolvedAt.IsZero() {
+		summary.ResolvedAt = &resolvedAt
+	}
+	if winner != (common.Address{}) {
+		summary.Winner = &winner
+	}
+	summary.Updated = r.clock.Now()
+}
+
+// DisputedResolutions returns the summary of each game that resolved with a status other than expected, ordered by
+// address.
+func (r *StatusRegistry) DisputedResolutions() []types.GameSummary {
+	var disputed []types.GameSummary
+	for _, summary := range r.Summaries() {
+		if summary.ExpectedStatus != "" {
+			disputed = append(disputed, summary)
+		}
+	}
+	return disputed
+}
+
+// RemoveAllExcept stops recording the games not in keep, typically because they are no longer being played.
+// Games with a disputed resolution are kept.
+func (r *StatusRegistry) RemoveAllExcept(keep []common.Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for game, summary := range r.games {
+		if summary.ExpectedStatus == "" && !slices.Contains(keep, game) {
+			delete(r.games, game)
+		}
+	}
+}
+
+// Summaries returns the summary of each recorded game, ordered by address.
+func (r *StatusRegistry) Summaries() []types.GameSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summaries := make([]types.GameSummary, 0, len(r.games))
+	for _, summary := range r.games {
+		summaries = append(summaries, *summary)
+	}
+	slices.SortFunc(summaries, func(a, b types.GameSummary) bool {
+		return bytes.Compare(a.Game[:], b.Game[:]) < 0
+	})
+	return summaries
+}
+
+// summary returns the summary of game, creating it if required. The lock must be held.
+func (r *StatusRegistry) summary(game common.Address) *types.GameSummary {
+	summary, ok := r.games[game]
+	if !ok {
+		summary = &types.GameSummary{Game: game}
+		r.games[game] = summary
+	}
+	return summary
+}
@@ -0,0 +1,90 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusRegistry(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	registry := NewStatusRegistry(cl)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	require.Empty(t, registry.Summaries())
+
+	deadline := time.Unix(5000, 0)
+	registry.RecordState(game2, types.GameStatusInProgress, 3, deadline)
+	registry.RecordError(game1, errors.New("boom"))
+	errTime := cl.Now()
+	cl.AdvanceTime(time.Minute)
+	registry.RecordState(game1, types.GameStatusDefenderWon, 5, time.Time{})
+	registry.RecordOutlook(game1, OutlookWinning)
+
+	require.Equal(t, []types.GameSummary{
+		{
+			Game:          game1,
+			Status:        types.GameStatusDefenderWon.String(),
+			Claims:        5,
+			LastError:     "boom",
+			LastErrorTime: &errTime,
+			Outlook:       "winning",
+			Updated:       cl.Now(),
+		},
+		{
+			Game:          game2,
+			Status:        types.GameStatusInProgress.String(),
+			Claims:        3,
+			ClockDeadline: &deadline,
+			Updated:       time.Unix(1000, 0),
+		},
+	}, registry.Summaries())
+
+	registry.RemoveAllExcept([]common.Address{game2})
+	summaries := registry.Summaries()
+	require.Len(t, summaries, 1)
+	require.Equal(t, game2, summaries[0].Game)
+}
+
+func TestStatusRegistry_DisputedResolutions(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	registry := NewStatusRegistry(cl)
+	disputed := common.Address{0xaa}
+	done := common.Address{0xbb}
+	registry.RecordState(disputed, types.GameStatusDefenderWon, 3, time.Time{})
+	registry.RecordDisputedResolution(disputed, types.GameStatusChallengerWon)
+	registry.RecordState(done, types.GameStatusDefenderWon, 3, time.Time{})
+
+	expected := []types.GameSummary{{
+		Game:           disputed,
+		Status:         types.GameStatusDefenderWon.String(),
+		Claims:         3,
+		ExpectedStatus: types.GameStatusChallengerWon.String(),
+		Updated:        cl.Now(),
+	}}
+	require.Equal(t, expected, registry.DisputedResolutions())
+
+	registry.RemoveAllExcept(nil)
+	require.Equal(t, expected, registry.Summaries(), "should keep games with disputed resolutions")
+}
+
+func TestStatusRegistry_RecordResolution(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	registry := NewStatusRegistry(cl)
+	game := common.Address{0xaa}
+	unknown := common.Address{0xbb}
+	resolvedAt := time.Unix(900, 0)
+	winner := common.Address{0xcc}
+	registry.RecordResolution(game, resolvedAt, winner)
+	registry.RecordResolution(unknown, time.Time{}, common.Address{})
+
+	require.Equal(t, []types.GameSummary{
+		{Game: game, ResolvedAt: &resolvedAt, Winner: &winner, Updated: cl.Now()},
+		{Game: unknown, Updated: cl.Now()},
+	}, registry.Summaries())
+}
@@ -0,0 +1,55 @@
+package fault
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// ActionType is the kind of action the agent can take against a claim.
+type ActionType string
+
+const (
+	// ActionTypeStep executes a step against a leaf claim at the max game depth.
+	ActionTypeStep ActionType = "step"
+	// ActionTypeMove posts a counter claim responding to the claim.
+	ActionTypeMove ActionType = "move"
+)
+
+// Action is an action the agent takes against a claim in the game.
+type Action struct {
+	Type  ActionType
+	Claim types.Claim
+}
+
+// ResolutionStrategy decides which actions the agent takes given the current claim tree.
+// The agent still determines the details of each action with the solver, skipping any that aren't required
+// such as stepping against a claim it agrees with or posting a duplicate move.
+type ResolutionStrategy interface {
+	// NextActions returns the actions to take against game, in the order they should be submitted.
+	NextActions(game types.Game) []Action
+}
+
+// AggressiveStrategy steps against every leaf claim at the max game depth as soon as it is posted and
+// counters every other claim.
+// Actions are returned in a deterministic order so that the same game state always produces the same
+// sequence of transactions: steps before moves, each ordered by the claim they respond to.
+type AggressiveStrategy struct {
+	maxDepth int
+}
+
+func NewAggressiveStrategy(maxDepth int) *AggressiveStrategy {
+	return &AggressiveStrategy{maxDepth: maxDepth}
+}
+
+func (s *AggressiveStrategy) NextActions(game types.Game) []Action {
+	claims := sortClaims(game.Claims())
+	actions := make([]Action, 0, len(claims))
+	for _, claim := range claims {
+		if claim.Depth() == s.maxDepth {
+			actions = append(actions, Action{Type: ActionTypeStep, Claim: claim})
+		}
+	}
+	for _, claim := range claims {
+		actions = append(actions, Action{Type: ActionTypeMove, Claim: claim})
+	}
+	return actions
+}
@@ -0,0 +1,30 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggressiveStrategy(t *testing.T) {
+	maxDepth := 2
+	builder := test.NewAlphabetClaimBuilder(t, maxDepth)
+	root := builder.CreateRootClaim(false)
+	attack := builder.AttackClaim(root, false)
+	attack.ContractIndex = 1
+	leaf := builder.AttackClaim(attack, false)
+	leaf.ContractIndex = 2
+	leaf.ParentContractIndex = 1
+	game := types.NewGameState(false, root, uint64(maxDepth))
+	require.NoError(t, game.PutAll([]types.Claim{attack, leaf}))
+
+	actions := NewAggressiveStrategy(maxDepth).NextActions(game)
+	require.Equal(t, []Action{
+		{Type: ActionTypeStep, Claim: leaf},
+		{Type: ActionTypeMove, Claim: root},
+		{Type: ActionTypeMove, Claim: attack},
+		{Type: ActionTypeMove, Claim: leaf},
+	}, actions)
+}
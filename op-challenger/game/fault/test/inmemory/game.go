@@ -0,0 +1,468 @@
+// Package inmemory plays complete fault dispute games between agents entirely in memory, without an L1 chain or
+// contracts, so the agents and their solver can be tested and profiled in isolation.
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrGameNotResolvable = errors.New("game not resolvable")
+	ErrGameNotInProgress = errors.New("game not in progress")
+	ErrClaimExists       = errors.New("claim already exists")
+	ErrInvalidMove       = errors.New("invalid move")
+	ErrInvalidStep       = errors.New("invalid step")
+	ErrUnfinishedGame    = errors.New("game did not finish")
+	ErrInvariantViolated = errors.New("game invariant violated")
+)
+
+// ActionType is the kind of action an agent sent to the game.
+type ActionType string
+
+const (
+	ActionMove         ActionType = "move"
+	ActionStep         ActionType = "step"
+	ActionResolve      ActionType = "resolve"
+	ActionUpdateOracle ActionType = "update_oracle"
+)
+
+// Action is an action an agent sent to the game, recorded whether or not it was accepted.
+type Action struct {
+	Type ActionType
+	// Player is the name of the player that sent the action. Empty for agents created without a name.
+	Player string
+	// Round is the round of [Play] or [Simulate] the action was sent in.
+	Round int
+	// Claim is the claim posted by a move, or the leaf claim stepped against.
+	Claim types.Claim
+	Err   error
+}
+
+// Game is an in-memory fault dispute game that is both the [fault.Responder] of the agents playing it and the source
+// of the claims they act on. It follows the rules of the contract that decide the outcome: moves must counter an
+// existing claim at an attack or defend position without duplicating a claim, and steps only succeed if the VM
+// execution they prove contradicts the leaf claim. Clocks aren't simulated, so the game can be resolved once a round of
+// every agent acting makes no changes.
+// It is safe for concurrent use.
+type Game struct {
+	maxDepth int
+	correct  types.TraceProvider
+
+	mu         sync.Mutex
+	claims     []types.Claim
+	stepped    map[int]bool
+	status     types.GameStatus
+	resolvable bool
+	actions    []Action
+	round      int
+}
+
+// NewGame creates a game of maxDepth with the given root claim. Steps are checked against the correct trace.
+func NewGame(correct types.TraceProvider, maxDepth int, rootClaim common.Hash) *Game {
+	root := types.Claim{
+		ClaimData: types.ClaimData{Value: rootClaim, Position: types.NewPositionFromGIndex(1)},
+	}
+	return &Game{
+		maxDepth: maxDepth,
+		correct:  correct,
+		claims:   []types.Claim{root},
+		stepped:  make(map[int]bool),
+	}
+}
+
+// NewAgent creates an agent that plays the game using trace, taking the opposite side to the root claim if
+// agreeWithProposedOutput is true.
+func (g *Game) NewAgent(trace types.TraceProvider, agreeWithProposedOutput bool, logger log.Logger) *fault.Agent {
+	return g.newAgent("", trace, agreeWithProposedOutput, logger)
+}
+
+// NewPlayer creates a player named name that progresses the game as the challenger does, with an agent using trace
+// that takes the opposite side to the root claim if agreeWithProposedOutput is true. The player's actions are
+// recorded with its name.
+func (g *Game) NewPlayer(name string, trace types.TraceProvider, agreeWithProposedOutput bool, logger log.Logger) *fault.GamePlayer {
+	logger = logger.New("player", name)
+	agent := g.newAgent(name, trace, agreeWithProposedOutput, logger)
+	return fault.NewLocalGamePlayer(logger, metrics.NoopMetrics, clock.SystemClock, common.Address{}, g, agent, !agreeWithProposedOutput)
+}
+
+func (g *Game) newAgent(name string, trace types.TraceProvider, agreeWithProposedOutput bool, logger log.Logger) *fault.Agent {
+	player := &playerResponder{Game: g, name: name}
+	return fault.NewAgent(metrics.NoopMetrics, clock.SystemClock, common.Address{}, g.maxDepth, fault.MoveLimits{}, 0, trace, player, player, nil, agreeWithProposedOutput, logger)
+}
+
+// Snapshot returns a copy of the game's current claims, as loaded at the start of an agent's cycle.
+func (g *Game) Snapshot() *fault.GameSnapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	claims := make([]types.Claim, len(g.claims))
+	copy(claims, g.claims)
+	return &fault.GameSnapshot{Claims: claims}
+}
+
+// Claims returns the game's claims in contract index order.
+func (g *Game) Claims() []types.Claim {
+	return g.Snapshot().Claims
+}
+
+// Actions returns the actions sent by the agents in the order they were received.
+func (g *Game) Actions() []Action {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	actions := make([]Action, len(g.actions))
+	copy(actions, g.actions)
+	return actions
+}
+
+// Status returns the status of the game, which is [types.GameStatusInProgress] until it is resolved.
+func (g *Game) Status() types.GameStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.status
+}
+
+// GetGameStatus returns the status of the game, so it can be progressed by a [fault.GamePlayer].
+func (g *Game) GetGameStatus(_ context.Context) (types.GameStatus, error) {
+	return g.Status(), nil
+}
+
+// BlockHashAt returns an empty hash, as the game isn't on a chain that can be reorged.
+func (g *Game) BlockHashAt(_ context.Context, _ uint64) (common.Hash, error) {
+	return common.Hash{}, nil
+}
+
+// FetchClaims returns the game's claims, loaded at an empty block as the game isn't on a chain.
+func (g *Game) FetchClaims(_ context.Context) ([]types.Claim, eth.L1BlockRef, error) {
+	return g.Claims(), eth.L1BlockRef{}, nil
+}
+
+// CheckInvariants returns [ErrInvariantViolated] if any claim is at a position its parent can't be countered at or
+// beyond the maximum depth, or duplicates another claim countering the same parent.
+func (g *Game) CheckInvariants() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	type counter struct {
+		parent int
+		data   types.ClaimData
+	}
+	seen := make(map[counter]int)
+	for i, claim := range g.claims[1:] {
+		index := i + 1
+		if claim.ContractIndex != index {
+			return fmt.Errorf("%w: claim %v has contract index %v", ErrInvariantViolated, index, claim.ContractIndex)
+		}
+		if claim.ParentContractIndex < 0 || claim.ParentContractIndex >= index {
+			return fmt.Errorf("%w: claim %v counters claim %v which isn't before it", ErrInvariantViolated, index, claim.ParentContractIndex)
+		}
+		parent := g.claims[claim.ParentContractIndex]
+		pos := claim.Position
+		if pos.Depth() > g.maxDepth {
+			return fmt.Errorf("%w: claim %v at depth %v beyond max depth %v", ErrInvariantViolated, index, pos.Depth(), g.maxDepth)
+		}
+		if pos != parent.Attack() && (parent.IsRoot() || pos != parent.Defend()) {
+			return fmt.Errorf("%w: claim %v at position %v can't counter claim %v at position %v",
+				ErrInvariantViolated, index, pos.ToGIndex(), parent.ContractIndex, parent.Position.ToGIndex())
+		}
+		key := counter{parent: claim.ParentContractIndex, data: claim.ClaimData}
+		if dup, ok := seen[key]; ok {
+			return fmt.Errorf("%w: claim %v duplicates claim %v", ErrInvariantViolated, index, dup)
+		}
+		seen[key] = index
+	}
+	return nil
+}
+
+// Play runs each agent in turn until a round changes nothing, after which the game becomes resolvable and the
+// agents are run once more to resolve it. Returns [ErrUnfinishedGame] if the game isn't resolved within maxRounds.
+func Play(ctx context.Context, g *Game, maxRounds int, agents ...*fault.Agent) (types.GameStatus, error) {
+	turns := make([]func(ctx context.Context) error, len(agents))
+	for i, agent := range agents {
+		agent := agent
+		turns[i] = func(ctx context.Context) error {
+			return agent.Act(ctx, g.Snapshot())
+		}
+	}
+	status, _, err := g.play(ctx, maxRounds, turns)
+	return status, err
+}
+
+// play takes each turn in order each round, returning the status and the number of rounds played once the game is
+// resolved. As clocks aren't simulated, the game becomes resolvable once a round changes nothing.
+func (g *Game) play(ctx context.Context, maxRounds int, turns []func(ctx context.Context) error) (types.GameStatus, int, error) {
+	for round := 0; round < maxRounds; round++ {
+		g.mu.Lock()
+		g.round = round
+		g.mu.Unlock()
+		before := g.changes()
+		for _, turn := range turns {
+			if err := turn(ctx); err != nil {
+				return types.GameStatusInProgress, round + 1, fmt.Errorf("round %v: %w", round, err)
+			}
+		}
+		if status := g.Status(); status != types.GameStatusInProgress {
+			return status, round + 1, nil
+		}
+		if g.changes() == before {
+			g.mu.Lock()
+			g.resolvable = true
+			g.mu.Unlock()
+		}
+	}
+	return types.GameStatusInProgress, maxRounds, fmt.Errorf("%w in %v rounds", ErrUnfinishedGame, maxRounds)
+}
+
+// changes returns a count that increases whenever a claim is added or stepped against.
+func (g *Game) changes() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.claims) + len(g.stepped)
+}
+
+func (g *Game) record(player string, actionType ActionType, claim types.Claim, err error) error {
+	g.actions = append(g.actions, Action{Type: actionType, Player: player, Round: g.round, Claim: claim, Err: err})
+	return err
+}
+
+// outcome returns the status the game resolves to. Each claim wins its subgame unless it was stepped against or
+// any of its counter claims won theirs.
+func (g *Game) outcome() types.GameStatus {
+	won := make([]bool, len(g.claims))
+	for i := range won {
+		won[i] = !g.stepped[i]
+	}
+	// Counter claims always come after the claim they counter.
+	for i := len(g.claims) - 1; i > 0; i-- {
+		if won[i] {
+			won[g.claims[i].ParentContractIndex] = false
+		}
+	}
+	if won[0] {
+		return types.GameStatusDefenderWon
+	}
+	return types.GameStatusChallengerWon
+}
+
+func (g *Game) CallResolve(_ context.Context) (types.GameStatus, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.status != types.GameStatusInProgress {
+		return types.GameStatusInProgress, ErrGameNotInProgress
+	}
+	if !g.resolvable {
+		return types.GameStatusInProgress, ErrGameNotResolvable
+	}
+	return g.outcome(), nil
+}
+
+func (g *Game) Resolve(_ context.Context) error {
+	return g.resolve("")
+}
+
+func (g *Game) resolve(player string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.status != types.GameStatusInProgress {
+		return g.record(player, ActionResolve, g.claims[0], ErrGameNotInProgress)
+	}
+	if !g.resolvable {
+		return g.record(player, ActionResolve, g.claims[0], ErrGameNotResolvable)
+	}
+	g.status = g.outcome()
+	return g.record(player, ActionResolve, g.claims[0], nil)
+}
+
+// CallResolveClaim always fails as the game doesn't record claimants, so its agents resolve it as a whole.
+func (g *Game) CallResolveClaim(_ context.Context, _ uint64) error {
+	return ErrGameNotResolvable
+}
+
+func (g *Game) ResolveClaim(_ context.Context, _ uint64) error {
+	return ErrGameNotResolvable
+}
+
+func (g *Game) Respond(_ context.Context, response types.Claim) error {
+	return g.respond("", response)
+}
+
+// respond records the claim added by a successful move, or the response if it is rejected.
+func (g *Game) respond(player string, response types.Claim) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.move(response); err != nil {
+		return g.record(player, ActionMove, response, err)
+	}
+	return g.record(player, ActionMove, g.claims[len(g.claims)-1], nil)
+}
+
+func (g *Game) move(response types.Claim) error {
+	if g.status != types.GameStatusInProgress {
+		return ErrGameNotInProgress
+	}
+	parentIdx := response.ParentContractIndex
+	if parentIdx < 0 || parentIdx >= len(g.claims) {
+		return fmt.Errorf("%w: no parent %v", ErrInvalidMove, parentIdx)
+	}
+	parent := &g.claims[parentIdx]
+	pos := response.Position
+	if pos.Depth() > g.maxDepth || (pos != parent.Attack() && (parent.IsRoot() || pos != parent.Defend())) {
+		return fmt.Errorf("%w: position %v against %v", ErrInvalidMove, pos.ToGIndex(), parent.Position.ToGIndex())
+	}
+	for _, claim := range g.claims {
+		if claim.ParentContractIndex == parentIdx && claim.ClaimData == response.ClaimData {
+			return ErrClaimExists
+		}
+	}
+	parent.Countered = true
+	g.claims = append(g.claims, types.Claim{
+		ClaimData:           response.ClaimData,
+		Parent:              parent.ClaimData,
+		ContractIndex:       len(g.claims),
+		ParentContractIndex: parentIdx,
+	})
+	return nil
+}
+
+func (g *Game) Step(ctx context.Context, stepData types.StepCallData) error {
+	return g.stepAs(ctx, "", stepData)
+}
+
+func (g *Game) stepAs(ctx context.Context, player string, stepData types.StepCallData) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if stepData.ClaimIndex >= uint64(len(g.claims)) {
+		return g.record(player, ActionStep, types.Claim{}, fmt.Errorf("%w: no claim %v", ErrInvalidStep, stepData.ClaimIndex))
+	}
+	claim := g.claims[stepData.ClaimIndex]
+	return g.record(player, ActionStep, claim, g.step(ctx, claim, stepData.IsAttack))
+}
+
+// step counters the leaf claim if the step's VM execution contradicts the claims as the contract checks it.
+// An attack executes from the claim at the previous trace index to the leaf claim, while a defence executes from
+// the leaf claim to the claim its ancestors make at the next trace index. Executions are modelled on the correct
+// trace: one is respected if its pre-state and post-state claims are equally correct, as a trace is only
+// inconsistent with the VM at the index it diverges from the correct trace.
+func (g *Game) step(ctx context.Context, claim types.Claim, isAttack bool) error {
+	if g.status != types.GameStatusInProgress {
+		return ErrGameNotInProgress
+	}
+	if claim.Depth() != g.maxDepth || g.stepped[claim.ContractIndex] {
+		return fmt.Errorf("%w: against claim %v", ErrInvalidStep, claim.ContractIndex)
+	}
+	traceIndex := claim.TraceIndex(g.maxDepth)
+	pre, post := true, claim
+	var err error
+	if isAttack {
+		if traceIndex > 0 {
+			// The absolute pre-state is always correct.
+			preClaim, ok := g.traceAncestor(claim, traceIndex-1)
+			if !ok {
+				return fmt.Errorf("%w: no claim at trace index %v to attack claim %v", ErrInvalidStep, traceIndex-1, claim.ContractIndex)
+			}
+			if pre, err = g.isCorrect(ctx, preClaim); err != nil {
+				return err
+			}
+		}
+	} else {
+		var ok bool
+		if post, ok = g.traceAncestor(claim, traceIndex+1); !ok {
+			return fmt.Errorf("%w: no claim at trace index %v to defend claim %v", ErrInvalidStep, traceIndex+1, claim.ContractIndex)
+		}
+		if pre, err = g.isCorrect(ctx, claim); err != nil {
+			return err
+		}
+	}
+	postCorrect, err := g.isCorrect(ctx, post)
+	if err != nil {
+		return err
+	}
+	respected := pre == postCorrect
+	// The leaf claim commits to the post-state if it is the post-state or was made by the same side.
+	leafAgreesWithPost := (claim.Depth()-post.Depth())%2 == 0
+	if leafAgreesWithPost == respected {
+		return fmt.Errorf("%w: step does not counter claim %v", ErrInvalidStep, claim.ContractIndex)
+	}
+	g.stepped[claim.ContractIndex] = true
+	g.claims[claim.ContractIndex].Countered = true
+	return nil
+}
+
+// traceAncestor returns the closest ancestor of claim that commits to the trace index.
+func (g *Game) traceAncestor(claim types.Claim, traceIndex uint64) (types.Claim, bool) {
+	for !claim.IsRoot() {
+		claim = g.claims[claim.ParentContractIndex]
+		if claim.TraceIndex(g.maxDepth) == traceIndex {
+			return claim, true
+		}
+	}
+	return types.Claim{}, false
+}
+
+func (g *Game) isCorrect(ctx context.Context, claim types.Claim) (bool, error) {
+	correct, err := g.correct.Get(ctx, claim.TraceIndex(g.maxDepth))
+	if err != nil {
+		return false, fmt.Errorf("load correct claim: %w", err)
+	}
+	return claim.Value == correct, nil
+}
+
+func (g *Game) EstimateRespondGas(_ context.Context, _ types.Claim) (uint64, error) {
+	return 0, nil
+}
+
+func (g *Game) EstimateStepGas(_ context.Context, _ types.StepCallData) (uint64, error) {
+	return 0, nil
+}
+
+func (g *Game) BaseFee(_ context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+// Spent returns 0 as an in-memory game's transactions cost nothing.
+func (g *Game) Spent() *big.Int {
+	return big.NewInt(0)
+}
+
+// UpdateOracle records the oracle data required by a step, which needs no loading for an in-memory game.
+func (g *Game) UpdateOracle(_ context.Context, _ *types.PreimageOracleData) error {
+	return g.updateOracle("")
+}
+
+func (g *Game) updateOracle(player string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.record(player, ActionUpdateOracle, types.Claim{}, nil)
+}
+
+// playerResponder is the responder and oracle updater of an agent, recording the actions it sends to the game with
+// the name of its player.
+type playerResponder struct {
+	*Game
+	name string
+}
+
+func (p *playerResponder) Resolve(_ context.Context) error {
+	return p.resolve(p.name)
+}
+
+func (p *playerResponder) Respond(_ context.Context, response types.Claim) error {
+	return p.respond(p.name, response)
+}
+
+func (p *playerResponder) Step(ctx context.Context, stepData types.StepCallData) error {
+	return p.stepAs(ctx, p.name, stepData)
+}
+
+func (p *playerResponder) UpdateOracle(_ context.Context, _ *types.PreimageOracleData) error {
+	return p.updateOracle(p.name)
+}
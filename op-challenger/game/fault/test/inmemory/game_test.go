@@ -0,0 +1,187 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const correctStates = "abcdefghijklmnopqrstuvwxyz"
+
+func TestPlayAlphabetGame(t *testing.T) {
+	tests := []struct {
+		name        string
+		depth       int
+		challengers int
+	}{
+		{name: "Depth4", depth: 4, challengers: 1},
+		{name: "Depth8", depth: 8, challengers: 1},
+		{name: "OddDepth", depth: 5, challengers: 1},
+		{name: "MultipleChallengers", depth: 6, challengers: 4},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("HonestRoot", func(t *testing.T) {
+				game, agents := setupAlphabetGame(t, test.depth, test.challengers, true)
+				status, err := Play(context.Background(), game, maxRounds(test.depth), agents...)
+				require.NoError(t, err)
+				require.Equal(t, types.GameStatusDefenderWon, status)
+				requireHonestSteps(t, game, test.depth, true)
+			})
+
+			t.Run("DishonestRoot", func(t *testing.T) {
+				game, agents := setupAlphabetGame(t, test.depth, test.challengers, false)
+				status, err := Play(context.Background(), game, maxRounds(test.depth), agents...)
+				require.NoError(t, err)
+				require.Equal(t, types.GameStatusChallengerWon, status)
+				requireHonestSteps(t, game, test.depth, false)
+			})
+		})
+	}
+}
+
+func TestPlay_Unfinished(t *testing.T) {
+	game, agents := setupAlphabetGame(t, 8, 1, true)
+	_, err := Play(context.Background(), game, 2, agents...)
+	require.ErrorIs(t, err, ErrUnfinishedGame)
+	require.Equal(t, types.GameStatusInProgress, game.Status())
+}
+
+func TestGame_RejectsInvalidActions(t *testing.T) {
+	ctx := context.Background()
+	correct := alphabet.NewTraceProvider(correctStates, 2)
+	root, err := correct.Get(ctx, 3)
+	require.NoError(t, err)
+	game := NewGame(correct, 2, root)
+	rootClaim := game.Claims()[0]
+
+	attack := types.Claim{ClaimData: types.ClaimData{Value: root, Position: rootClaim.Attack()}}
+	require.NoError(t, game.Respond(ctx, attack))
+	require.ErrorIs(t, game.Respond(ctx, attack), ErrClaimExists)
+	require.ErrorIs(t, game.Respond(ctx, types.Claim{ClaimData: types.ClaimData{Position: rootClaim.Defend()}}), ErrInvalidMove)
+	require.ErrorIs(t, game.Respond(ctx, types.Claim{ParentContractIndex: 5}), ErrInvalidMove)
+	require.ErrorIs(t, game.Step(ctx, types.StepCallData{ClaimIndex: 1}), ErrInvalidStep, "should not step on non-leaf claims")
+
+	leaf := types.Claim{ClaimData: types.ClaimData{Value: root, Position: game.Claims()[1].Attack()}, ParentContractIndex: 1}
+	require.NoError(t, game.Respond(ctx, leaf))
+	claims := game.Claims()
+	require.True(t, claims[1].Countered)
+	require.Equal(t, claims[1].ClaimData, claims[2].Parent)
+	require.NoError(t, game.Step(ctx, types.StepCallData{ClaimIndex: 2}), "should step on incorrect leaf claim")
+	require.ErrorIs(t, game.Step(ctx, types.StepCallData{ClaimIndex: 2}), ErrInvalidStep, "should only step once")
+
+	_, err = game.CallResolve(ctx)
+	require.ErrorIs(t, err, ErrGameNotResolvable)
+	require.Len(t, game.Actions(), 8)
+}
+
+// BenchmarkPlayAlphabetGame plays complete games of increasing depth between an honest defender and a challenger,
+// bisecting down to a step.
+func BenchmarkPlayAlphabetGame(b *testing.B) {
+	for _, depth := range []int{8, 16, 24} {
+		depth := depth
+		b.Run(fmt.Sprintf("depth=%v", depth), func(b *testing.B) {
+			benchmarkPlayAlphabetGame(b, depth, 1)
+		})
+	}
+}
+
+// BenchmarkPlayAlphabetGame_Challengers plays complete games against an increasing number of challengers whose
+// traces diverge from the correct one at different indices. Each challenger counters every honest claim it
+// disagrees with, so the number of claims grows rapidly with the number of challengers.
+func BenchmarkPlayAlphabetGame_Challengers(b *testing.B) {
+	for _, challengers := range []int{1, 2, 4} {
+		challengers := challengers
+		b.Run(fmt.Sprintf("challengers=%v", challengers), func(b *testing.B) {
+			benchmarkPlayAlphabetGame(b, 8, challengers)
+		})
+	}
+}
+
+func benchmarkPlayAlphabetGame(b *testing.B, depth int, challengers int) {
+	b.ReportAllocs()
+	claims := 0
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		game, agents := setupAlphabetGame(b, depth, challengers, true)
+		b.StartTimer()
+		status, err := Play(context.Background(), game, maxRounds(depth), agents...)
+		if err != nil || status != types.GameStatusDefenderWon {
+			b.Fatalf("unexpected result %v: %v", status, err)
+		}
+		claims = len(game.Claims())
+	}
+	b.ReportMetric(float64(claims), "claims/op")
+}
+
+// setupAlphabetGame creates a game with an honest agent using the correct trace and challengers that each diverge
+// from it at a different index. The honest agent defends the root claim, which is correct if honestRoot is true.
+func setupAlphabetGame(t testing.TB, depth int, challengers int, honestRoot bool) (*Game, []*fault.Agent) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	if _, ok := t.(*testing.B); ok {
+		// Discard logs without formatting them so they don't dominate the allocations being profiled.
+		logger = log.New()
+		logger.SetHandler(log.DiscardHandler())
+	}
+	correct := alphabet.NewTraceProvider(correctStates, uint64(depth))
+	states, err := alphabet.ParseTrace(correctStates)
+	require.NoError(t, err)
+	root, err := correct.RootClaim(context.Background())
+	require.NoError(t, err)
+	var traces []types.TraceProvider
+	for i := 0; i < challengers; i++ {
+		traces = append(traces, alphabet.NewTraceProviderWithStates(divergentStates(states, 3*i+1), uint64(depth)))
+	}
+	if !honestRoot {
+		root, err = traces[0].(*alphabet.AlphabetTraceProvider).RootClaim(context.Background())
+		require.NoError(t, err)
+	}
+	game := NewGame(correct, depth, root)
+	agents := []*fault.Agent{game.NewAgent(correct, !honestRoot, logger)}
+	for _, trace := range traces {
+		agents = append(agents, game.NewAgent(trace, honestRoot, logger))
+	}
+	return game, agents
+}
+
+// divergentStates returns a copy of states that differs from the original at every index from onwards.
+func divergentStates(states []*big.Int, from int) []*big.Int {
+	out := make([]*big.Int, len(states))
+	for i, state := range states {
+		out[i] = state
+		if i >= from {
+			out[i] = new(big.Int).Add(state, big.NewInt(1000+int64(from)))
+		}
+	}
+	return out
+}
+
+// maxRounds allows for a bisection one level per round, the steps and resolution.
+func maxRounds(depth int) int {
+	return depth + 5
+}
+
+// requireHonestSteps checks the honest agent stepped against the leaf claims if they were made by the other side,
+// and that any steps against its own leaf claims failed. The root claim's side makes the leaf claims of even depths.
+func requireHonestSteps(t *testing.T, game *Game, depth int, honestRoot bool) {
+	steps := 0
+	for _, action := range game.Actions() {
+		if action.Type == ActionStep && action.Err == nil {
+			steps++
+		}
+	}
+	if honestLeaves := (depth%2 == 0) == honestRoot; honestLeaves {
+		require.Zero(t, steps, "should not step against honest leaf claims")
+	} else {
+		require.NotZero(t, steps, "should step against dishonest leaf claims")
+	}
+}
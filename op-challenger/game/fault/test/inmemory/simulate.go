@@ -0,0 +1,130 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The names of the players in a simulated game.
+const (
+	HonestPlayer    = "honest"
+	DishonestPlayer = "dishonest"
+)
+
+// Simulation is the result of a game simulated between an honest and a dishonest player.
+type Simulation struct {
+	Status types.GameStatus
+	// HonestWon is true if the game resolved in favour of the honest player.
+	HonestWon  bool
+	HonestRoot bool
+	MaxDepth   int
+	Rounds     int
+	Claims     []types.Claim
+	// Actions are every action sent by the players, including those the game rejected, in the order they were sent.
+	Actions []Action
+}
+
+// Winner returns the name of the player that won the game.
+func (s *Simulation) Winner() string {
+	if s.HonestWon {
+		return HonestPlayer
+	}
+	return DishonestPlayer
+}
+
+// Simulate plays a game of maxDepth between an honest player using the honest trace and a dishonest player using the
+// dishonest trace, each progressing the game in turn as the challenger does until it resolves. The root claim is made
+// from the honest trace if honestRoot is true, otherwise from the dishonest trace, and each player defends the root
+// claim if it agrees with it. Steps are checked against the honest trace.
+// The game's invariants are checked after every turn. Returns [ErrInvariantViolated] if they are broken, or
+// [ErrUnfinishedGame] if the game isn't resolved within maxRounds, along with the game played so far.
+func Simulate(ctx context.Context, logger log.Logger, honest types.TraceProvider, dishonest types.TraceProvider, maxDepth int, honestRoot bool, maxRounds int) (*Simulation, error) {
+	rootTrace := dishonest
+	if honestRoot {
+		rootTrace = honest
+	}
+	rootPos := types.NewPositionFromGIndex(1)
+	root, err := rootTrace.Get(ctx, rootPos.TraceIndex(maxDepth))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the root claim: %w", err)
+	}
+	game := NewGame(honest, maxDepth, root)
+	players := []struct {
+		name  string
+		trace types.TraceProvider
+		// attacksRoot is true if the player disagrees with the root claim.
+		attacksRoot bool
+	}{
+		{name: HonestPlayer, trace: honest, attacksRoot: !honestRoot},
+		{name: DishonestPlayer, trace: dishonest, attacksRoot: honestRoot},
+	}
+	turns := make([]func(ctx context.Context) error, len(players))
+	for i, p := range players {
+		player := game.NewPlayer(p.name, p.trace, p.attacksRoot, logger)
+		turns[i] = func(ctx context.Context) error {
+			player.ProgressGame(ctx)
+			return game.CheckInvariants()
+		}
+	}
+	status, rounds, err := game.play(ctx, maxRounds, turns)
+	expected := types.GameStatusChallengerWon
+	if honestRoot {
+		expected = types.GameStatusDefenderWon
+	}
+	return &Simulation{
+		Status:     status,
+		HonestWon:  status == expected,
+		HonestRoot: honestRoot,
+		MaxDepth:   maxDepth,
+		Rounds:     rounds,
+		Claims:     game.Claims(),
+		Actions:    game.Actions(),
+	}, err
+}
+
+// WriteTranscript writes each action sent by the players, followed by the outcome of the game.
+func (s *Simulation) WriteTranscript(w io.Writer) error {
+	for _, action := range s.Actions {
+		if _, err := fmt.Fprintf(w, "round %-3v %-9v %v\n", action.Round, action.Player, s.describe(action)); err != nil {
+			return err
+		}
+	}
+	root := DishonestPlayer
+	if s.HonestRoot {
+		root = HonestPlayer
+	}
+	_, err := fmt.Fprintf(w, "%v after %v rounds with %v claims, root claim by %v player: %v player won\n",
+		s.Status, s.Rounds, len(s.Claims), root, s.Winner())
+	return err
+}
+
+func (s *Simulation) describe(action Action) string {
+	claim := action.Claim
+	var desc string
+	switch action.Type {
+	case ActionMove:
+		kind := "attack"
+		if claim.DefendsParent() {
+			kind = "defend"
+		}
+		desc = fmt.Sprintf("move     %v claim %v: depth %v, index at depth %v, trace index %v, value %v",
+			kind, claim.ParentContractIndex, claim.Depth(), claim.IndexAtDepth(), claim.TraceIndex(s.MaxDepth), claim.Value)
+		if action.Err == nil {
+			desc = fmt.Sprintf("%v, added claim %v", desc, claim.ContractIndex)
+		}
+	case ActionStep:
+		desc = fmt.Sprintf("step     against claim %v: trace index %v, value %v", claim.ContractIndex, claim.TraceIndex(s.MaxDepth), claim.Value)
+	case ActionResolve:
+		desc = "resolve"
+	default:
+		desc = string(action.Type)
+	}
+	if action.Err != nil {
+		desc = fmt.Sprintf("%v (rejected: %v)", desc, action.Err)
+	}
+	return desc
+}
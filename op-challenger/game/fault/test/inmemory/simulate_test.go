@@ -0,0 +1,123 @@
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	states, err := alphabet.ParseTrace(correctStates)
+	require.NoError(t, err)
+	for _, depth := range []int{4, 5} {
+		depth := depth
+		honest := alphabet.NewTraceProvider(correctStates, uint64(depth))
+		dishonest := alphabet.NewTraceProviderWithStates(divergentStates(states, 3), uint64(depth))
+		for _, honestRoot := range []bool{true, false} {
+			honestRoot := honestRoot
+			t.Run(fmt.Sprintf("Depth%v/HonestRoot=%v", depth, honestRoot), func(t *testing.T) {
+				sim, err := Simulate(context.Background(), testlog.Logger(t, log.LvlCrit), honest, dishonest, depth, honestRoot, maxRounds(depth))
+				require.NoError(t, err)
+				require.True(t, sim.HonestWon)
+				require.Equal(t, HonestPlayer, sim.Winner())
+				if honestRoot {
+					require.Equal(t, types.GameStatusDefenderWon, sim.Status)
+				} else {
+					require.Equal(t, types.GameStatusChallengerWon, sim.Status)
+				}
+				movers := make(map[string]bool)
+				for _, action := range sim.Actions {
+					if action.Type == ActionMove && action.Err == nil {
+						movers[action.Player] = true
+					}
+				}
+				require.Equal(t, map[string]bool{HonestPlayer: true, DishonestPlayer: true}, movers, "both players should move")
+				last := sim.Actions[len(sim.Actions)-1]
+				if last.Type != ActionResolve {
+					// The loser may still act in the round the game is resolved in.
+					last = sim.Actions[len(sim.Actions)-2]
+				}
+				require.Equal(t, ActionResolve, last.Type)
+				require.NoError(t, last.Err)
+			})
+		}
+	}
+}
+
+func TestSimulate_Unfinished(t *testing.T) {
+	honest := alphabet.NewTraceProvider(correctStates, 8)
+	dishonest := alphabet.NewTraceProvider(strings.ToUpper(correctStates), 8)
+	sim, err := Simulate(context.Background(), testlog.Logger(t, log.LvlCrit), honest, dishonest, 8, true, 2)
+	require.ErrorIs(t, err, ErrUnfinishedGame)
+	require.Equal(t, types.GameStatusInProgress, sim.Status)
+	require.Equal(t, 2, sim.Rounds)
+	require.NotEmpty(t, sim.Actions, "should report the game played so far")
+}
+
+func TestSimulation_WriteTranscript(t *testing.T) {
+	honest := alphabet.NewTraceProvider(correctStates, 4)
+	dishonest := alphabet.NewTraceProvider(strings.ToUpper(correctStates), 4)
+	sim, err := Simulate(context.Background(), testlog.Logger(t, log.LvlCrit), honest, dishonest, 4, false, maxRounds(4))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, sim.WriteTranscript(&out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, len(sim.Actions)+1)
+	require.Contains(t, lines[0], "honest    move     attack claim 0: depth 1")
+	require.Contains(t, lines[0], "added claim 1")
+	require.Equal(t, "Challenger Won after 5 rounds with 5 claims, root claim by dishonest player: honest player won", lines[len(lines)-1])
+}
+
+func TestGame_CheckInvariants(t *testing.T) {
+	ctx := context.Background()
+	correct := alphabet.NewTraceProvider(correctStates, 2)
+	root, err := correct.Get(ctx, 3)
+	require.NoError(t, err)
+	setup := func(t *testing.T) *Game {
+		game := NewGame(correct, 2, root)
+		rootClaim := game.Claims()[0]
+		require.NoError(t, game.Respond(ctx, types.Claim{ClaimData: types.ClaimData{Value: root, Position: rootClaim.Attack()}}))
+		require.NoError(t, game.CheckInvariants())
+		return game
+	}
+	addClaim := func(game *Game, claim types.Claim) {
+		claim.ContractIndex = len(game.claims)
+		game.claims = append(game.claims, claim)
+	}
+
+	t.Run("DuplicateClaim", func(t *testing.T) {
+		game := setup(t)
+		addClaim(game, game.claims[1])
+		require.ErrorIs(t, game.CheckInvariants(), ErrInvariantViolated)
+	})
+
+	t.Run("BeyondMaxDepth", func(t *testing.T) {
+		game := setup(t)
+		leaf := game.claims[1].Attack()
+		addClaim(game, types.Claim{ClaimData: types.ClaimData{Position: leaf}, ParentContractIndex: 1})
+		require.NoError(t, game.CheckInvariants())
+		addClaim(game, types.Claim{ClaimData: types.ClaimData{Position: leaf.Attack()}, ParentContractIndex: 2})
+		require.ErrorIs(t, game.CheckInvariants(), ErrInvariantViolated)
+	})
+
+	t.Run("InvalidPosition", func(t *testing.T) {
+		game := setup(t)
+		addClaim(game, types.Claim{ClaimData: types.ClaimData{Position: game.claims[0].Defend()}, ParentContractIndex: 0})
+		require.ErrorIs(t, game.CheckInvariants(), ErrInvariantViolated, "root claim can't be defended")
+	})
+
+	t.Run("ParentAfterClaim", func(t *testing.T) {
+		game := setup(t)
+		addClaim(game, types.Claim{ClaimData: types.ClaimData{Position: game.claims[1].Attack()}, ParentContractIndex: 5})
+		require.ErrorIs(t, game.CheckInvariants(), ErrInvariantViolated)
+	})
+}
@@ -1,35 +1,128 @@
 package alphabet
 
 import (
+	"bufio"
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"os"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
 	ErrIndexTooLarge = errors.New("index is larger than the maximum index")
+	ErrEmptyTrace    = errors.New("trace has no states")
+	ErrInvalidState  = errors.New("invalid trace state")
 )
 
 // AlphabetTraceProvider is a [TraceProvider] that provides claims for specific
 // indices in the given trace.
 type AlphabetTraceProvider struct {
-	state  []string
+	state  []*big.Int
 	maxLen uint64
 }
 
-// NewTraceProvider returns a new [AlphabetProvider].
+// NewTraceProvider returns a new [AlphabetProvider] with a state for each letter of the given word.
 func NewTraceProvider(state string, depth uint64) *AlphabetTraceProvider {
+	values := make([]*big.Int, 0, len(state))
+	for _, letter := range strings.Split(state, "") {
+		values = append(values, new(big.Int).SetBytes(LetterToBytes(letter)))
+	}
+	return NewTraceProviderWithStates(values, depth)
+}
+
+// NewTraceProviderWithStates returns a new [AlphabetProvider] with the given state values.
+// The states must not be empty and the first state must be greater than zero, see [ParseTrace].
+func NewTraceProviderWithStates(states []*big.Int, depth uint64) *AlphabetTraceProvider {
 	return &AlphabetTraceProvider{
-		state:  strings.Split(state, ""),
+		state:  states,
 		maxLen: uint64(1 << depth),
 	}
 }
 
+// NewTraceProviderFromSpec returns a new [AlphabetProvider] with the states described by spec, see [ParseTrace].
+func NewTraceProviderFromSpec(spec string, depth uint64) (*AlphabetTraceProvider, error) {
+	states, err := ParseTrace(spec)
+	if err != nil {
+		return nil, err
+	}
+	return NewTraceProviderWithStates(states, depth), nil
+}
+
+// ParseTrace parses the state values of an alphabet trace. The spec is one of:
+//   - 0x-prefixed hex data, with each byte a state, so 0x616263 is the same trace as abc
+//   - @ followed by the path to a file of state values, one hex or decimal value per line
+//   - a word, with each letter a state
+//
+// The on-chain alphabet VM adds one to the state at each step from the absolute pre-state, which is one less than
+// the first state. The first state must therefore be greater than zero, and states must fit in 32 bytes.
+func ParseTrace(spec string) ([]*big.Int, error) {
+	var states []*big.Int
+	switch {
+	case strings.HasPrefix(spec, "0x"):
+		data, err := hexutil.Decode(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidState, err)
+		}
+		for _, b := range data {
+			states = append(states, new(big.Int).SetUint64(uint64(b)))
+		}
+	case strings.HasPrefix(spec, "@"):
+		var err error
+		states, err = readTraceFile(spec[1:])
+		if err != nil {
+			return nil, err
+		}
+	default:
+		for _, b := range []byte(spec) {
+			states = append(states, new(big.Int).SetUint64(uint64(b)))
+		}
+	}
+	if len(states) == 0 {
+		return nil, ErrEmptyTrace
+	}
+	if states[0].Sign() <= 0 {
+		return nil, fmt.Errorf("%w: first state must be greater than zero", ErrInvalidState)
+	}
+	for i, state := range states {
+		if state.Sign() < 0 || state.BitLen() > 256 {
+			return nil, fmt.Errorf("%w: state %v does not fit in 32 bytes", ErrInvalidState, i)
+		}
+	}
+	return states, nil
+}
+
+func readTraceFile(path string) ([]*big.Int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	defer f.Close()
+	var states []*big.Int
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		state, ok := new(big.Int).SetString(text, 0)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q on line %v", ErrInvalidState, text, line)
+		}
+		states = append(states, state)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace file: %w", err)
+	}
+	return states, nil
+}
+
 func (ap *AlphabetTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
 	if i == 0 {
 		prestate, err := ap.AbsolutePreState(ctx)
@@ -48,7 +141,7 @@ func (ap *AlphabetTraceProvider) GetStepData(ctx context.Context, i uint64) ([]b
 	if i >= uint64(len(ap.state)) {
 		return ap.GetStepData(ctx, uint64(len(ap.state)))
 	}
-	return BuildAlphabetPreimage(i, ap.state[i]), []byte{}, nil, nil
+	return buildPreimage(i, ap.state[i]), []byte{}, nil, nil
 }
 
 // Get returns the claim value at the given index in the trace.
@@ -61,9 +154,27 @@ func (ap *AlphabetTraceProvider) Get(ctx context.Context, i uint64) (common.Hash
 	return crypto.Keccak256Hash(claimBytes), nil
 }
 
-// AbsolutePreState returns the absolute pre-state for the alphabet trace.
+// RootClaim returns the claim at the last index of the trace, which is the root claim of a game the trace agrees with.
+func (ap *AlphabetTraceProvider) RootClaim(ctx context.Context) (common.Hash, error) {
+	return ap.Get(ctx, ap.maxLen-1)
+}
+
+// AbsolutePreState returns the absolute pre-state for the alphabet trace, the state before the first state.
 func (ap *AlphabetTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
-	return common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000060"), nil
+	if len(ap.state) == 0 {
+		return nil, ErrEmptyTrace
+	}
+	prestate := new(big.Int).Sub(ap.state[0], common.Big1)
+	return prestate.FillBytes(make([]byte, 32)), nil
+}
+
+// AbsolutePreStateCommitment returns the hash of the absolute pre-state for the alphabet trace.
+func (ap *AlphabetTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	prestate, err := ap.AbsolutePreState(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(prestate), nil
 }
 
 // BuildAlphabetPreimage constructs the claim bytes for the index and state item.
@@ -71,6 +182,10 @@ func BuildAlphabetPreimage(i uint64, letter string) []byte {
 	return append(IndexToBytes(i), LetterToBytes(letter)...)
 }
 
+func buildPreimage(i uint64, state *big.Int) []byte {
+	return append(IndexToBytes(i), state.FillBytes(make([]byte, 32))...)
+}
+
 // IndexToBytes converts an index to a byte slice big endian
 func IndexToBytes(i uint64) []byte {
 	big := new(big.Int)
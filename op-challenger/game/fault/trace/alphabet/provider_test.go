@@ -3,6 +3,9 @@ package alphabet
 import (
 	"context"
 	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -102,3 +105,81 @@ func TestGet_Extends(t *testing.T) {
 	expected := alphabetClaim(2, "c")
 	require.Equal(t, expected, claim)
 }
+
+// TestParseTrace tests parsing the states of an alphabet trace from a word, hex data or a file.
+func TestParseTrace(t *testing.T) {
+	letters, err := ParseTrace("abc")
+	require.NoError(t, err)
+	require.Equal(t, []*big.Int{big.NewInt('a'), big.NewInt('b'), big.NewInt('c')}, letters)
+
+	t.Run("Hex", func(t *testing.T) {
+		states, err := ParseTrace("0x616263")
+		require.NoError(t, err)
+		require.Equal(t, letters, states)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "trace.txt")
+		require.NoError(t, os.WriteFile(path, []byte("0x10\n\n 17 \n0x"+strings.Repeat("ff", 32)+"\n"), 0644))
+		states, err := ParseTrace("@" + path)
+		require.NoError(t, err)
+		max := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+		require.Equal(t, []*big.Int{big.NewInt(16), big.NewInt(17), max}, states)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "trace.txt")
+		require.NoError(t, os.WriteFile(path, []byte("0x10\nzz\n"), 0644))
+		tooLarge := filepath.Join(t.TempDir(), "large.txt")
+		require.NoError(t, os.WriteFile(tooLarge, []byte("0x01"+strings.Repeat("00", 32)), 0644))
+		for spec, expected := range map[string]error{
+			"":             ErrEmptyTrace,
+			"0x":           ErrEmptyTrace,
+			"0x6":          ErrInvalidState,
+			"0x0061":       ErrInvalidState,
+			"@" + path:     ErrInvalidState,
+			"@" + tooLarge: ErrInvalidState,
+		} {
+			_, err := ParseTrace(spec)
+			require.ErrorIs(t, err, expected, spec)
+		}
+		_, err := ParseTrace("@" + filepath.Join(t.TempDir(), "missing.txt"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+// TestCustomTrace tests step data, claims and the absolute pre-state of a trace with custom states.
+func TestCustomTrace(t *testing.T) {
+	ap, err := NewTraceProviderFromSpec("0x1020", 2)
+	require.NoError(t, err)
+
+	prestate, err := ap.AbsolutePreState(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(0x0f)).Bytes(), prestate, "should be the state before the first")
+	stepData, _, _, err := ap.GetStepData(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, prestate, stepData)
+
+	stepData, _, _, err = ap.GetStepData(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, append(IndexToBytes(1), common.BigToHash(big.NewInt(0x20)).Bytes()...), stepData)
+
+	lastClaim, err := ap.Get(context.Background(), 1)
+	require.NoError(t, err)
+	extended, err := ap.Get(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, lastClaim, extended, "should extend the last state to the maximum depth")
+	rootClaim, err := ap.RootClaim(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, extended, rootClaim)
+
+	_, err = ap.Get(context.Background(), 4)
+	require.ErrorIs(t, err, ErrIndexTooLarge)
+}
+
+// TestAbsolutePreState_Letters tests that a trace of letters keeps the pre-state of the original alphabet trace.
+func TestAbsolutePreState_Letters(t *testing.T) {
+	prestate, err := NewTraceProvider("abcdefgh", 3).AbsolutePreState(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000060"), prestate)
+}
@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	diskCachePrefix = "game-"
+	diskCacheSuffix = ".json"
+)
+
+// diskCache is the serialized form of a game's disk cache.
+type diskCache struct {
+	RootClaim common.Hash `json:"rootClaim"`
+	// Entries are ordered from least to most recently used.
+	Entries []diskEntry `json:"entries"`
+}
+
+type diskEntry struct {
+	Index      uint64                    `json:"index"`
+	Step       bool                      `json:"step,omitempty"`
+	Value      common.Hash               `json:"value"`
+	Prestate   hexutil.Bytes             `json:"prestate,omitempty"`
+	ProofData  hexutil.Bytes             `json:"proofData,omitempty"`
+	OracleData *types.PreimageOracleData `json:"oracleData,omitempty"`
+}
+
+// DiskCachePath returns the path of the disk cache file for game within dir.
+func DiskCachePath(dir string, game common.Address) string {
+	return filepath.Join(dir, diskCachePrefix+game.Hex()+diskCacheSuffix)
+}
+
+// PruneDiskCache removes the disk cache files in dir of all games except those in keep.
+func PruneDiskCache(dir string, keep []common.Address) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to list trace cache directory: %w", err)
+	}
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, diskCachePrefix) || !strings.HasSuffix(name, diskCacheSuffix) {
+			continue
+		}
+		addr := common.HexToAddress(strings.TrimSuffix(strings.TrimPrefix(name, diskCachePrefix), diskCacheSuffix))
+		if addr == (common.Address{}) || slices.Contains(keep, addr) {
+			continue
+		}
+		errs = append(errs, os.Remove(filepath.Join(dir, name)))
+	}
+	return errors.Join(errs...)
+}
+
+// DiskTraceProvider is a [types.TraceProvider] decorator that persists the results of Get and GetStepData to a
+// file so they don't need to be recomputed when the challenger restarts.
+// The file records the root claim of the game and is discarded if it doesn't match the current root claim.
+// The least recently used entries are evicted once the cached data exceeds the size limit.
+type DiskTraceProvider struct {
+	types.TraceProvider
+	logger    log.Logger
+	path      string
+	rootClaim common.Hash
+
+	mu    sync.Mutex
+	cache *byteLRU[cacheKey, cacheEntry]
+}
+
+// NewDiskTraceProvider wraps provider with a cache of up to maxBytes persisted to path, loading any entries
+// previously cached there for rootClaim.
+// An unreadable cache file is discarded rather than failing as the entries can always be recomputed.
+func NewDiskTraceProvider(logger log.Logger, provider types.TraceProvider, path string, rootClaim common.Hash, maxBytes uint64) *DiskTraceProvider {
+	c := &DiskTraceProvider{
+		TraceProvider: provider,
+		logger:        logger,
+		path:          path,
+		rootClaim:     rootClaim,
+		cache:         newByteLRU[cacheKey, cacheEntry](maxBytes),
+	}
+	c.load()
+	return c
+}
+
+func (c *DiskTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	key := cacheKey{index: i}
+	if entry, ok := c.get(key); ok {
+		return entry.value, nil
+	}
+	value, err := c.TraceProvider.Get(ctx, i)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.add(key, cacheEntry{value: value})
+	return value, nil
+}
+
+func (c *DiskTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	key := cacheKey{index: i, step: true}
+	if entry, ok := c.get(key); ok {
+		return entry.prestate, entry.proofData, entry.oracleData, nil
+	}
+	prestate, proofData, oracleData, err := c.TraceProvider.GetStepData(ctx, i)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c.add(key, cacheEntry{prestate: prestate, proofData: proofData, oracleData: oracleData})
+	return prestate, proofData, oracleData, nil
+}
+
+// ValidateStepData delegates to the wrapped provider if it implements [types.StepDataValidator].
+func (c *DiskTraceProvider) ValidateStepData(stateData []byte, proofData []byte) error {
+	if validator, ok := c.TraceProvider.(types.StepDataValidator); ok {
+		return validator.ValidateStepData(stateData, proofData)
+	}
+	return nil
+}
+
+// Size returns the total size in bytes of the cached data.
+func (c *DiskTraceProvider) Size() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Size()
+}
+
+func (c *DiskTraceProvider) get(key cacheKey) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Get(key)
+}
+
+func (c *DiskTraceProvider) add(key cacheKey, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(key, entry, entry.size())
+	if err := c.save(); err != nil {
+		// The value is still valid, it will just need to be recomputed after a restart.
+		c.logger.Warn("Failed to write trace cache", "path", c.path, "err", err)
+	}
+}
+
+func (c *DiskTraceProvider) load() {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		c.logger.Warn("Failed to read trace cache, discarding it", "path", c.path, "err", err)
+		return
+	}
+	var stored diskCache
+	if err := json.Unmarshal(data, &stored); err != nil {
+		c.logger.Warn("Failed to decode trace cache, discarding it", "path", c.path, "err", err)
+		return
+	}
+	if stored.RootClaim != c.rootClaim {
+		c.logger.Info("Discarding trace cache for a different root claim", "path", c.path, "cached", stored.RootClaim, "root", c.rootClaim)
+		if err := os.Remove(c.path); err != nil {
+			c.logger.Warn("Failed to remove trace cache", "path", c.path, "err", err)
+		}
+		return
+	}
+	for _, e := range stored.Entries {
+		entry := cacheEntry{value: e.Value, prestate: e.Prestate, proofData: e.ProofData, oracleData: e.OracleData}
+		c.cache.Add(cacheKey{index: e.Index, step: e.Step}, entry, entry.size())
+	}
+	c.logger.Info("Loaded trace cache", "path", c.path, "entries", c.cache.Len(), "bytes", c.cache.Size())
+}
+
+// save writes the cached entries to disk, replacing the previous file atomically so a crash mid-write can't
+// leave a truncated cache behind.
+func (c *DiskTraceProvider) save() error {
+	stored := diskCache{RootClaim: c.rootClaim}
+	c.cache.Range(func(key cacheKey, entry cacheEntry) {
+		stored.Entries = append(stored.Entries, diskEntry{
+			Index:      key.index,
+			Step:       key.step,
+			Value:      entry.value,
+			Prestate:   entry.prestate,
+			ProofData:  entry.proofData,
+			OracleData: entry.oracleData,
+		})
+	})
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode trace cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create trace cache dir: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write trace cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to replace trace cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskTraceProvider(t *testing.T) {
+	root := common.Hash{0xaa}
+	setup := func(t *testing.T) (string, *stubTraceProvider) {
+		path := DiskCachePath(t.TempDir(), common.Address{0x12})
+		return path, &stubTraceProvider{prestate: []byte{1, 2}, proof: []byte{3, 4}}
+	}
+
+	t.Run("LoadPersistedEntries", func(t *testing.T) {
+		path, stub := setup(t)
+		logger := testlog.Logger(t, log.LvlInfo)
+		provider := NewDiskTraceProvider(logger, stub, path, root, 10_000)
+		_, err := provider.Get(context.Background(), 3)
+		require.NoError(t, err)
+		_, _, _, err = provider.GetStepData(context.Background(), 4)
+		require.NoError(t, err)
+
+		restarted := &stubTraceProvider{}
+		provider = NewDiskTraceProvider(logger, restarted, path, root, 10_000)
+		value, err := provider.Get(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{3}, value)
+		prestate, proof, _, err := provider.GetStepData(context.Background(), 4)
+		require.NoError(t, err)
+		require.Equal(t, stub.prestate, prestate)
+		require.Equal(t, stub.proof, proof)
+		require.Zero(t, restarted.getCalls, "should load value from disk")
+		require.Zero(t, restarted.stepCalls, "should load step data from disk")
+	})
+
+	t.Run("PersistOracleData", func(t *testing.T) {
+		path, stub := setup(t)
+		logger := testlog.Logger(t, log.LvlInfo)
+		oracleData := types.NewPreimageOracleData([]byte{1, 5}, []byte{6, 7}, 8)
+		provider := NewDiskTraceProvider(logger, &oracleTraceProvider{stubTraceProvider: *stub, oracleData: oracleData}, path, root, 10_000)
+		_, _, _, err := provider.GetStepData(context.Background(), 1)
+		require.NoError(t, err)
+
+		provider = NewDiskTraceProvider(logger, &stubTraceProvider{}, path, root, 10_000)
+		_, _, actual, err := provider.GetStepData(context.Background(), 1)
+		require.NoError(t, err)
+		require.Equal(t, oracleData, actual)
+	})
+
+	t.Run("InvalidateOnRootClaimMismatch", func(t *testing.T) {
+		path, stub := setup(t)
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		provider := NewDiskTraceProvider(logger, stub, path, root, 10_000)
+		_, err := provider.Get(context.Background(), 3)
+		require.NoError(t, err)
+
+		restarted := &stubTraceProvider{}
+		provider = NewDiskTraceProvider(logger, restarted, path, common.Hash{0xbb}, 10_000)
+		require.NotNil(t, handler.FindLog(log.LvlInfo, "Discarding trace cache for a different root claim"))
+		require.NoFileExists(t, path)
+		_, err = provider.Get(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, 1, restarted.getCalls, "should recompute discarded entries")
+	})
+
+	t.Run("DiscardCorruptFile", func(t *testing.T) {
+		path, stub := setup(t)
+		require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+		provider := NewDiskTraceProvider(testlog.Logger(t, log.LvlError), stub, path, root, 10_000)
+		_, err := provider.Get(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, 1, stub.getCalls)
+
+		provider = NewDiskTraceProvider(testlog.Logger(t, log.LvlError), &stubTraceProvider{}, path, root, 10_000)
+		require.Equal(t, uint64(entryOverhead), provider.Size(), "should replace corrupt file")
+	})
+
+	t.Run("EvictToMaxSize", func(t *testing.T) {
+		path, stub := setup(t)
+		logger := testlog.Logger(t, log.LvlInfo)
+		provider := NewDiskTraceProvider(logger, stub, path, root, 2*entryOverhead)
+		for i := uint64(0); i < 3; i++ {
+			_, err := provider.Get(context.Background(), i)
+			require.NoError(t, err)
+		}
+		require.Equal(t, uint64(2*entryOverhead), provider.Size())
+
+		restarted := &stubTraceProvider{}
+		provider = NewDiskTraceProvider(logger, restarted, path, root, 2*entryOverhead)
+		for _, i := range []uint64{1, 2} {
+			_, err := provider.Get(context.Background(), i)
+			require.NoError(t, err)
+		}
+		require.Zero(t, restarted.getCalls, "should persist most recently used entries")
+		_, err := provider.Get(context.Background(), 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, restarted.getCalls, "should not persist evicted entry")
+	})
+}
+
+func TestPruneDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	keep := common.Address{0x53}
+	remove := common.Address{0xaa}
+	for _, game := range []common.Address{keep, remove} {
+		require.NoError(t, os.WriteFile(DiskCachePath(dir, game), []byte("{}"), 0644))
+	}
+	unexpectedFile := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(unexpectedFile, []byte("test"), 0644))
+
+	require.NoError(t, PruneDiskCache(dir, []common.Address{keep}))
+	require.FileExists(t, DiskCachePath(dir, keep))
+	require.NoFileExists(t, DiskCachePath(dir, remove))
+	require.FileExists(t, unexpectedFile, "should not delete unexpected file")
+
+	require.NoError(t, PruneDiskCache(filepath.Join(dir, "missing"), nil), "should ignore missing dir")
+}
+
+type oracleTraceProvider struct {
+	stubTraceProvider
+	oracleData *types.PreimageOracleData
+}
+
+func (o *oracleTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	prestate, proof, _, err := o.stubTraceProvider.GetStepData(ctx, i)
+	return prestate, proof, o.oracleData, err
+}
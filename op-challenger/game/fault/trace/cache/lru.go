@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"container/list"
+)
+
+// byteLRU is a least recently used cache that limits the total size of its entries in bytes
+// rather than the number of entries.
+// It is not safe for concurrent use.
+type byteLRU[K comparable, V any] struct {
+	maxBytes uint64
+	size     uint64
+	order    *list.List
+	entries  map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+	size  uint64
+}
+
+func newByteLRU[K comparable, V any](maxBytes uint64) *byteLRU[K, V] {
+	return &byteLRU[K, V]{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value for key and marks it as the most recently used.
+func (c *byteLRU[K, V]) Get(key K) (V, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		var empty V
+		return empty, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// Add stores value with the given size, evicting the least recently used entries until the cache is within
+// its byte budget. Values larger than the whole budget are not stored.
+func (c *byteLRU[K, V]) Add(key K, value V, size uint64) {
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	if size > c.maxBytes {
+		return
+	}
+	for c.size+size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, size: size})
+	c.size += size
+}
+
+// Size returns the total size in bytes of the cached entries.
+func (c *byteLRU[K, V]) Size() uint64 {
+	return c.size
+}
+
+// Len returns the number of cached entries.
+func (c *byteLRU[K, V]) Len() int {
+	return len(c.entries)
+}
+
+// Range calls fn with each cached entry, from the least to the most recently used.
+func (c *byteLRU[K, V]) Range(fn func(key K, value V)) {
+	for elem := c.order.Back(); elem != nil; elem = elem.Prev() {
+		entry := elem.Value.(*lruEntry[K, V])
+		fn(entry.key, entry.value)
+	}
+}
+
+func (c *byteLRU[K, V]) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry[K, V])
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteLRU_EvictsToByteBudget(t *testing.T) {
+	c := newByteLRU[int, string](100)
+	c.Add(1, "small", 10)
+	c.Add(2, "medium", 40)
+	c.Add(3, "large", 30)
+	require.Equal(t, uint64(80), c.Size())
+	require.Equal(t, 3, c.Len())
+
+	// Adding a large entry evicts the oldest entries until it fits
+	c.Add(4, "larger", 60)
+	require.Equal(t, uint64(90), c.Size())
+	_, ok := c.Get(1)
+	require.False(t, ok, "should evict oldest entry")
+	_, ok = c.Get(2)
+	require.False(t, ok, "should evict until within budget")
+	_, ok = c.Get(3)
+	require.True(t, ok)
+	_, ok = c.Get(4)
+	require.True(t, ok)
+}
+
+func TestByteLRU_GetMarksRecentlyUsed(t *testing.T) {
+	c := newByteLRU[int, string](100)
+	c.Add(1, "a", 40)
+	c.Add(2, "b", 40)
+	_, ok := c.Get(1)
+	require.True(t, ok)
+
+	c.Add(3, "c", 40)
+	_, ok = c.Get(1)
+	require.True(t, ok, "should keep recently used entry")
+	_, ok = c.Get(2)
+	require.False(t, ok, "should evict least recently used entry")
+}
+
+func TestByteLRU_ReplaceUpdatesSize(t *testing.T) {
+	c := newByteLRU[int, string](100)
+	c.Add(1, "a", 40)
+	c.Add(1, "b", 10)
+	require.Equal(t, uint64(10), c.Size())
+	require.Equal(t, 1, c.Len())
+	value, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "b", value)
+}
+
+func TestByteLRU_DoNotStoreEntriesLargerThanBudget(t *testing.T) {
+	c := newByteLRU[int, string](100)
+	c.Add(1, "a", 40)
+	c.Add(2, "huge", 101)
+	require.Equal(t, uint64(40), c.Size())
+	_, ok := c.Get(2)
+	require.False(t, ok)
+	_, ok = c.Get(1)
+	require.True(t, ok, "should not evict entries for a value that can't be stored")
+}
+
+func TestByteLRU_MixedSizes(t *testing.T) {
+	const budget = 10_000
+	c := newByteLRU[int, []byte](budget)
+	sizes := []int{100, 5000, 32, 2048, 7000, 1, 900, 4096, 64, 3000}
+	for i, size := range sizes {
+		c.Add(i, make([]byte, size), uint64(size))
+		require.LessOrEqualf(t, c.Size(), uint64(budget), "should be within budget after adding entry %v", i)
+	}
+	var total uint64
+	for i, size := range sizes {
+		if _, ok := c.Get(i); ok {
+			total += uint64(size)
+		}
+	}
+	require.Equal(t, c.Size(), total, "size should match the cached entries")
+}
+
+func TestByteLRU_RangeLeastRecentlyUsedFirst(t *testing.T) {
+	c := newByteLRU[int, string](100)
+	c.Add(1, "a", 10)
+	c.Add(2, "b", 10)
+	c.Add(3, "c", 10)
+	_, ok := c.Get(1)
+	require.True(t, ok)
+
+	var keys []int
+	c.Range(func(key int, _ string) { keys = append(keys, key) })
+	require.Equal(t, []int{2, 3, 1}, keys)
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// entryOverhead approximates the per-entry bookkeeping cost so that small entries are still weighted.
+const entryOverhead = 64
+
+type cacheKey struct {
+	index uint64
+	step  bool
+}
+
+type cacheEntry struct {
+	value      common.Hash
+	prestate   []byte
+	proofData  []byte
+	oracleData *types.PreimageOracleData
+}
+
+// size returns the weight of the entry in bytes, based on the size of its serialized data.
+func (e cacheEntry) size() uint64 {
+	size := uint64(entryOverhead + len(e.prestate) + len(e.proofData))
+	if e.oracleData != nil {
+		size += uint64(len(e.oracleData.OracleKey) + len(e.oracleData.OracleData))
+	}
+	return size
+}
+
+// CachingTraceProvider is a [types.TraceProvider] decorator that caches the results of Get and GetStepData
+// in a least recently used cache limited by the total size of the cached data.
+type CachingTraceProvider struct {
+	types.TraceProvider
+	recordUsage func(bytes uint64)
+
+	mu    sync.Mutex
+	cache *byteLRU[cacheKey, cacheEntry]
+}
+
+// NewTraceProvider wraps provider with a cache of up to maxBytes.
+// recordUsage is called with the size of the cache in bytes each time it changes.
+func NewTraceProvider(provider types.TraceProvider, maxBytes uint64, recordUsage func(bytes uint64)) *CachingTraceProvider {
+	return &CachingTraceProvider{
+		TraceProvider: provider,
+		recordUsage:   recordUsage,
+		cache:         newByteLRU[cacheKey, cacheEntry](maxBytes),
+	}
+}
+
+func (c *CachingTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	key := cacheKey{index: i}
+	if entry, ok := c.get(key); ok {
+		return entry.value, nil
+	}
+	value, err := c.TraceProvider.Get(ctx, i)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.add(key, cacheEntry{value: value})
+	return value, nil
+}
+
+func (c *CachingTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	key := cacheKey{index: i, step: true}
+	if entry, ok := c.get(key); ok {
+		return entry.prestate, entry.proofData, entry.oracleData, nil
+	}
+	prestate, proofData, oracleData, err := c.TraceProvider.GetStepData(ctx, i)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c.add(key, cacheEntry{prestate: prestate, proofData: proofData, oracleData: oracleData})
+	return prestate, proofData, oracleData, nil
+}
+
+// ValidateStepData delegates to the wrapped provider if it implements [types.StepDataValidator].
+func (c *CachingTraceProvider) ValidateStepData(stateData []byte, proofData []byte) error {
+	if validator, ok := c.TraceProvider.(types.StepDataValidator); ok {
+		return validator.ValidateStepData(stateData, proofData)
+	}
+	return nil
+}
+
+// Size returns the total size in bytes of the cached data.
+func (c *CachingTraceProvider) Size() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Size()
+}
+
+func (c *CachingTraceProvider) get(key cacheKey) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Get(key)
+}
+
+func (c *CachingTraceProvider) add(key cacheKey, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(key, entry, entry.size())
+	if c.recordUsage != nil {
+		c.recordUsage(c.cache.Size())
+	}
+}
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	stub := &stubTraceProvider{}
+	var usage []uint64
+	provider := NewTraceProvider(stub, 1000, func(bytes uint64) { usage = append(usage, bytes) })
+
+	value, err := provider.Get(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{3}, value)
+	value, err = provider.Get(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{3}, value)
+	require.Equal(t, 1, stub.getCalls, "should load from cache")
+	require.Equal(t, []uint64{entryOverhead}, usage)
+
+	stub.err = errors.New("boom")
+	_, err = provider.Get(context.Background(), 4)
+	require.ErrorIs(t, err, stub.err)
+	_, err = provider.Get(context.Background(), 4)
+	require.ErrorIs(t, err, stub.err, "should not cache errors")
+	require.Equal(t, 3, stub.getCalls)
+}
+
+func TestGetStepData(t *testing.T) {
+	stub := &stubTraceProvider{prestate: make([]byte, 200), proof: make([]byte, 300)}
+	var usage []uint64
+	provider := NewTraceProvider(stub, 2*(entryOverhead+500), func(bytes uint64) { usage = append(usage, bytes) })
+
+	for i := uint64(0); i < 3; i++ {
+		prestate, proof, _, err := provider.GetStepData(context.Background(), i)
+		require.NoError(t, err)
+		require.Equal(t, stub.prestate, prestate)
+		require.Equal(t, stub.proof, proof)
+	}
+	require.Equal(t, 3, stub.stepCalls)
+	require.Equal(t, []uint64{564, 1128, 1128}, usage, "should evict to stay within budget")
+
+	// Index 0 was evicted, index 2 is still cached
+	_, _, _, err := provider.GetStepData(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, stub.stepCalls)
+	_, _, _, err = provider.GetStepData(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, stub.stepCalls)
+}
+
+func TestStepDataWeightIncludesOracleData(t *testing.T) {
+	entry := cacheEntry{
+		prestate:   make([]byte, 10),
+		proofData:  make([]byte, 20),
+		oracleData: types.NewPreimageOracleData(make([]byte, 32), make([]byte, 100), 0),
+	}
+	require.Equal(t, uint64(entryOverhead+10+20+32+100), entry.size())
+}
+
+func TestValidateStepData(t *testing.T) {
+	t.Run("DelegateToValidator", func(t *testing.T) {
+		validator := &validatingTraceProvider{err: errors.New("invalid")}
+		provider := NewTraceProvider(validator, 1000, nil)
+		require.ErrorIs(t, provider.ValidateStepData([]byte{1}, []byte{2}), validator.err)
+	})
+
+	t.Run("NoValidator", func(t *testing.T) {
+		provider := NewTraceProvider(&stubTraceProvider{}, 1000, nil)
+		require.NoError(t, provider.ValidateStepData([]byte{1}, []byte{2}))
+	})
+}
+
+type stubTraceProvider struct {
+	types.TraceProvider
+	err       error
+	prestate  []byte
+	proof     []byte
+	getCalls  int
+	stepCalls int
+}
+
+func (s *stubTraceProvider) Get(_ context.Context, i uint64) (common.Hash, error) {
+	s.getCalls++
+	if s.err != nil {
+		return common.Hash{}, s.err
+	}
+	return common.Hash{byte(i)}, nil
+}
+
+func (s *stubTraceProvider) GetStepData(_ context.Context, _ uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	s.stepCalls++
+	if s.err != nil {
+		return nil, nil, nil, s.err
+	}
+	return s.prestate, s.proof, nil, nil
+}
+
+type validatingTraceProvider struct {
+	stubTraceProvider
+	err error
+}
+
+func (v *validatingTraceProvider) ValidateStepData(_ []byte, _ []byte) error {
+	return v.err
+}
@@ -1,13 +1,20 @@
 package cannon
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// memoryField is the JSON field of a [mipsevm.State] containing the memory pages.
+const memoryField = "memory"
+
 func parseState(path string) (*mipsevm.State, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -21,3 +28,176 @@ func parseState(path string) (*mipsevm.State, error) {
 	}
 	return &state, nil
 }
+
+// hashState computes the keccak256 hash of the witness of the state stored at path.
+// Unlike parseState, memory pages are hashed as they are read so the full state is never held in memory.
+func hashState(path string) (common.Hash, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("cannot open state file (%v): %w", path, err)
+	}
+	defer file.Close()
+	witness, err := streamWitness(bufio.NewReader(file))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid mipsevm state (%v): %w", path, err)
+	}
+	return crypto.Keccak256Hash(witness), nil
+}
+
+// streamWitness reads a JSON encoded [mipsevm.State] and returns its witness.
+func streamWitness(r io.Reader) ([]byte, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	// All fields other than memory are small so are collected and decoded as a normal state.
+	fields := make(map[string]json.RawMessage)
+	var memRoot *[32]byte
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected token %v", token)
+		}
+		if key != memoryField {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, fmt.Errorf("invalid field %v: %w", key, err)
+			}
+			fields[key] = value
+			continue
+		}
+		root, err := streamMemoryRoot(dec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory: %w", err)
+		}
+		memRoot = &root
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	state := mipsevm.State{Memory: mipsevm.NewMemory()}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	// The memory was excluded from the state so replace the empty memory root which starts the witness.
+	witness := state.EncodeWitness()
+	if memRoot != nil {
+		copy(witness[:32], memRoot[:])
+	}
+	return witness, nil
+}
+
+// streamMemoryRoot decodes the pages of a [mipsevm.Memory] one at a time and computes the memory merkle root.
+// Only the root of each page is retained.
+func streamMemoryRoot(dec *json.Decoder) ([32]byte, error) {
+	if err := expectDelim(dec, '['); err != nil {
+		return [32]byte{}, err
+	}
+	h := newPairHasher()
+	pageRoots := make(map[uint32][32]byte)
+	entry := struct {
+		Index uint32        `json:"index"`
+		Data  *mipsevm.Page `json:"data"`
+	}{Data: new(mipsevm.Page)}
+	for dec.More() {
+		if err := dec.Decode(&entry); err != nil {
+			return [32]byte{}, err
+		}
+		if _, ok := pageRoots[entry.Index]; ok {
+			return [32]byte{}, fmt.Errorf("duplicate page index %d", entry.Index)
+		}
+		pageRoots[entry.Index] = h.pageRoot(entry.Data)
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return [32]byte{}, err
+	}
+	return h.memoryRoot(pageRoots), nil
+}
+
+// pairHasher computes merkle nodes using a single reused hasher to avoid allocating for every node.
+type pairHasher struct {
+	keccak crypto.KeccakState
+	pair   [64]byte
+	out    [32]byte
+	nodes  [mipsevm.PageSize / 32][32]byte
+}
+
+func newPairHasher() *pairHasher {
+	return &pairHasher{keccak: crypto.NewKeccakState()}
+}
+
+func (h *pairHasher) hashPair(left, right [32]byte) [32]byte {
+	copy(h.pair[:32], left[:])
+	copy(h.pair[32:], right[:])
+	h.keccak.Reset()
+	_, _ = h.keccak.Write(h.pair[:])
+	_, _ = h.keccak.Read(h.out[:])
+	return h.out
+}
+
+// pageRoot computes the merkle root of a page, matching [mipsevm.CachedPage.MerkleRoot].
+func (h *pairHasher) pageRoot(page *mipsevm.Page) [32]byte {
+	for i := range h.nodes {
+		copy(h.nodes[i][:], page[i*32:(i+1)*32])
+	}
+	for width := len(h.nodes); width > 1; width /= 2 {
+		for i := 0; i < width/2; i++ {
+			h.nodes[i] = h.hashPair(h.nodes[2*i], h.nodes[2*i+1])
+		}
+	}
+	return h.nodes[0]
+}
+
+// memoryRoot combines page roots into the memory merkle root, treating missing pages as zero.
+func (h *pairHasher) memoryRoot(pageRoots map[uint32][32]byte) [32]byte {
+	// Start from the root of a zero page, which has 32 byte leaves.
+	var zero [32]byte
+	for i := 0; i < mipsevm.PageAddrSize-5; i++ {
+		zero = h.hashPair(zero, zero)
+	}
+	level := pageRoots
+	for i := 0; i < mipsevm.PageKeySize; i++ {
+		next := make(map[uint32][32]byte, (len(level)+1)/2)
+		for k := range level {
+			parent := k >> 1
+			if _, ok := next[parent]; ok {
+				continue
+			}
+			left, ok := level[parent<<1]
+			if !ok {
+				left = zero
+			}
+			right, ok := level[parent<<1|1]
+			if !ok {
+				right = zero
+			}
+			next[parent] = h.hashPair(left, right)
+		}
+		level = next
+		zero = h.hashPair(zero, zero)
+	}
+	if root, ok := level[0]; ok {
+		return root
+	}
+	return zero
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v but got %v", delim, token)
+	}
+	return nil
+}
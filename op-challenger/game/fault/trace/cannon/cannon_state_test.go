@@ -0,0 +1,111 @@
+package cannon
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashState(t *testing.T) {
+	t.Run("EmptyMemory", func(t *testing.T) {
+		path := writeState(t, t.TempDir(), &mipsevm.State{Memory: mipsevm.NewMemory(), PC: 4, NextPC: 8, Step: 3})
+		requireHashMatchesState(t, path)
+	})
+
+	t.Run("SparsePages", func(t *testing.T) {
+		state := randomState(rand.New(rand.NewSource(1)), 64)
+		path := writeState(t, t.TempDir(), state)
+		requireHashMatchesState(t, path)
+	})
+
+	t.Run("MissingMemory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"pc": 4, "nextPC": 8}`), 0644))
+		hash, err := hashState(path)
+		require.NoError(t, err)
+		expected := mipsevm.State{Memory: mipsevm.NewMemory(), PC: 4, NextPC: 8}
+		require.Equal(t, crypto.Keccak256Hash(expected.EncodeWitness()), hash)
+	})
+
+	t.Run("DuplicatePage", func(t *testing.T) {
+		page := make([]byte, mipsevm.PageSize*2)
+		for i := range page {
+			page[i] = '0'
+		}
+		data := `{"memory": [{"index": 1, "data": "` + string(page) + `"}, {"index": 1, "data": "` + string(page) + `"}]}`
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+		_, err := hashState(path)
+		require.ErrorContains(t, err, "duplicate page index")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := hashState("test_data/invalid.json")
+		require.ErrorContains(t, err, "invalid mipsevm state")
+	})
+}
+
+func BenchmarkHashState(b *testing.B) {
+	// 2048 pages is an 8MiB memory.
+	path := writeState(b, b.TempDir(), randomState(rand.New(rand.NewSource(1)), 2048))
+
+	b.Run("Stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := hashState(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			state, err := parseState(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+			crypto.Keccak256Hash(state.EncodeWitness())
+		}
+	})
+}
+
+func requireHashMatchesState(t *testing.T, path string) {
+	state, err := parseState(path)
+	require.NoError(t, err)
+	hash, err := hashState(path)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(state.EncodeWitness()), hash)
+}
+
+func randomState(rnd *rand.Rand, pageCount int) *mipsevm.State {
+	state := &mipsevm.State{
+		Memory: mipsevm.NewMemory(),
+		PC:     rnd.Uint32(),
+		NextPC: rnd.Uint32(),
+		Heap:   rnd.Uint32(),
+		Step:   rnd.Uint64(),
+	}
+	for i := range state.Registers {
+		state.Registers[i] = rnd.Uint32()
+	}
+	for i := 0; i < pageCount; i++ {
+		page := state.Memory.AllocPage(rnd.Uint32() & mipsevm.PageKeyMask)
+		rnd.Read(page.Data[:])
+	}
+	return state
+}
+
+func writeState(t testing.TB, dir string, state *mipsevm.State) string {
+	path := filepath.Join(dir, "state.json")
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
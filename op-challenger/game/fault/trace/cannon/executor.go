@@ -63,7 +63,13 @@ func NewExecutor(logger log.Logger, cfg *config.Config, inputs LocalGameInputs)
 }
 
 func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) error {
-	snapshotDir := filepath.Join(dir, snapsDir)
+	// Snapshots are kept per absolute prestate so a restart with a different prestate, such as after an upgrade,
+	// never resumes from a snapshot of the old prestate's execution.
+	prestate, err := PreStateCommitment(e.absolutePreState)
+	if err != nil {
+		return fmt.Errorf("load absolute prestate commitment: %w", err)
+	}
+	snapshotDir := filepath.Join(dir, snapsDir, prestate.Hex())
 	start, err := e.selectSnapshot(e.logger, snapshotDir, e.absolutePreState, i)
 	if err != nil {
 		return fmt.Errorf("find starting snapshot: %w", err)
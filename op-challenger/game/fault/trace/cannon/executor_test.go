@@ -23,8 +23,11 @@ func TestGenerateProof(t *testing.T) {
 	input := "starting.json"
 	tempDir := t.TempDir()
 	dir := filepath.Join(tempDir, "gameDir")
-	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", config.TraceTypeCannon, true, tempDir)
-	cfg.CannonAbsolutePreState = "pre.json"
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", []config.TraceType{config.TraceTypeCannon}, true, tempDir)
+	cfg.CannonAbsolutePreState = "test_data/state.json"
+	prestate, err := PreStateCommitment(cfg.CannonAbsolutePreState)
+	require.NoError(t, err)
+	snapshotDir := filepath.Join(dir, snapsDir, prestate.Hex())
 	cfg.CannonBin = "./bin/cannon"
 	cfg.CannonServer = "./bin/op-program"
 	cfg.CannonL2 = "http://localhost:9999"
@@ -40,6 +43,7 @@ func TestGenerateProof(t *testing.T) {
 	captureExec := func(t *testing.T, cfg config.Config, proofAt uint64) (string, string, map[string]string) {
 		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), &cfg, inputs)
 		executor.selectSnapshot = func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error) {
+			require.Equal(t, snapshotDir, dir, "should resume from snapshots of the absolute prestate")
 			return input, nil
 		}
 		var binary string
@@ -71,7 +75,7 @@ func TestGenerateProof(t *testing.T) {
 		binary, subcommand, args := captureExec(t, cfg, 150_000_000)
 		require.DirExists(t, filepath.Join(dir, preimagesDir))
 		require.DirExists(t, filepath.Join(dir, proofsDir))
-		require.DirExists(t, filepath.Join(dir, snapsDir))
+		require.DirExists(t, snapshotDir)
 		require.Equal(t, cfg.CannonBin, binary)
 		require.Equal(t, "run", subcommand)
 		require.Equal(t, input, args["--input"])
@@ -89,7 +93,7 @@ func TestGenerateProof(t *testing.T) {
 		require.Equal(t, cfg.CannonL2, args["--l2"])
 		require.Equal(t, filepath.Join(dir, preimagesDir), args["--datadir"])
 		require.Equal(t, filepath.Join(dir, proofsDir, "%d.json"), args["--proof-fmt"])
-		require.Equal(t, filepath.Join(dir, snapsDir, "%d.json"), args["--snapshot-fmt"])
+		require.Equal(t, filepath.Join(snapshotDir, "%d.json"), args["--snapshot-fmt"])
 		require.Equal(t, cfg.CannonNetwork, args["--network"])
 		require.NotContains(t, args, "--rollup.config")
 		require.NotContains(t, args, "--l2.genesis")
@@ -112,6 +116,37 @@ func TestGenerateProof(t *testing.T) {
 		require.Equal(t, cfg.CannonL2GenesisPath, args["--l2.genesis"])
 	})
 
+	t.Run("IgnoreSnapshotsOfOtherPrestates", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "gameDir")
+		for _, stale := range []string{filepath.Join(dir, snapsDir), filepath.Join(dir, snapsDir, common.Hash{0xaa}.Hex())} {
+			require.NoError(t, os.MkdirAll(stale, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(stale, "100.json"), []byte{}, 0644))
+		}
+		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), &cfg, inputs)
+		var start string
+		executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+			for i := range a {
+				if a[i] == "--input" {
+					start = a[i+1]
+				}
+			}
+			return nil
+		}
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 150))
+		require.Equal(t, cfg.CannonAbsolutePreState, start)
+	})
+
+	t.Run("InvalidPrestate", func(t *testing.T) {
+		cfg := cfg
+		cfg.CannonAbsolutePreState = filepath.Join(tempDir, "missing.json")
+		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), &cfg, inputs)
+		executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+			t.Fatal("should not execute cannon")
+			return nil
+		}
+		require.ErrorContains(t, executor.GenerateProof(context.Background(), dir, 150_000_000), "load absolute prestate commitment")
+	})
+
 	t.Run("NoStopAtWhenProofIsMaxUInt", func(t *testing.T) {
 		cfg.CannonNetwork = "mainnet"
 		cfg.CannonRollupConfigPath = "rollup.json"
@@ -0,0 +1,74 @@
+package cannon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// L2FinalityChecker reports whether the L2 block a game disputes is finalized on the L2 node, so the game's cannon
+// trace is derived only from finalized data.
+type L2FinalityChecker struct {
+	l2Client      L2DataSource
+	close         func()
+	l2BlockNumber *big.Int
+	// finalized is set once the block is found to be finalized, as it then can't become unfinalized.
+	finalized atomic.Bool
+}
+
+// NewL2FinalityChecker creates a checker for the L2 block disputed by the game at gameAddr.
+// The checker holds a connection to the L2 node until closed.
+func NewL2FinalityChecker(ctx context.Context, cfg *config.Config, l1Client bind.ContractCaller, gameAddr common.Address) (*L2FinalityChecker, error) {
+	gameCaller, err := bindings.NewFaultDisputeGameCaller(gameAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("create caller for game %v: %w", gameAddr, err)
+	}
+	proposals, err := gameCaller.Proposals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("fetch proposals: %w", err)
+	}
+	l2Client, err := ethclient.DialContext(ctx, cfg.CannonL2)
+	if err != nil {
+		return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
+	}
+	return newL2FinalityChecker(l2Client, l2Client.Close, proposals.Disputed.L2BlockNumber), nil
+}
+
+func newL2FinalityChecker(l2Client L2DataSource, close func(), l2BlockNumber *big.Int) *L2FinalityChecker {
+	return &L2FinalityChecker{
+		l2Client:      l2Client,
+		close:         close,
+		l2BlockNumber: l2BlockNumber,
+	}
+}
+
+// L2BlockFinalized returns true if the L2 node's finalized head is at or beyond the disputed L2 block.
+func (c *L2FinalityChecker) L2BlockFinalized(ctx context.Context) (bool, error) {
+	if c.finalized.Load() {
+		return true, nil
+	}
+	header, err := c.l2Client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return false, fmt.Errorf("fetch finalized L2 block header: %w", err)
+	}
+	if header.Number.Cmp(c.l2BlockNumber) < 0 {
+		return false, nil
+	}
+	c.finalized.Store(true)
+	return true, nil
+}
+
+func (c *L2FinalityChecker) Close() error {
+	if c.close != nil {
+		c.close()
+	}
+	return nil
+}
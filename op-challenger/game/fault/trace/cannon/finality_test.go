@@ -0,0 +1,39 @@
+package cannon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2BlockFinalized(t *testing.T) {
+	ctx := context.Background()
+	l2Client := &mockL2DataSource{}
+	closed := false
+	checker := newL2FinalityChecker(l2Client, func() { closed = true }, big.NewInt(100))
+
+	_, err := checker.L2BlockFinalized(ctx)
+	require.Error(t, err, "should fail without a finalized block")
+
+	l2Client.finalized = &ethtypes.Header{Number: big.NewInt(99)}
+	finalized, err := checker.L2BlockFinalized(ctx)
+	require.NoError(t, err)
+	require.False(t, finalized)
+
+	l2Client.finalized = &ethtypes.Header{Number: big.NewInt(100)}
+	finalized, err = checker.L2BlockFinalized(ctx)
+	require.NoError(t, err)
+	require.True(t, finalized)
+
+	l2Client.err = errors.New("boom")
+	finalized, err = checker.L2BlockFinalized(ctx)
+	require.NoError(t, err, "should not check again once finalized")
+	require.True(t, finalized)
+
+	require.NoError(t, checker.Close())
+	require.True(t, closed)
+}
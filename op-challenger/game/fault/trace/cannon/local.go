@@ -2,10 +2,13 @@ package cannon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -32,6 +35,8 @@ type GameInputsSource interface {
 	}, error)
 }
 
+// fetchLocalInputs loads the inputs cannon needs to run for the game.
+// Returns [types.ErrOutputNotAvailable] if the L2 node doesn't have the blocks of the game's proposals yet.
 func fetchLocalInputs(ctx context.Context, gameAddr common.Address, caller GameInputsSource, l2Client L2DataSource) (LocalGameInputs, error) {
 	opts := &bind.CallOpts{Context: ctx}
 	l1Head, err := caller.L1Head(opts)
@@ -45,11 +50,15 @@ func fetchLocalInputs(ctx context.Context, gameAddr common.Address, caller GameI
 	}
 	claimedOutput := proposals.Disputed
 	agreedOutput := proposals.Starting
-	agreedHeader, err := l2Client.HeaderByNumber(ctx, agreedOutput.L2BlockNumber)
+	agreedHeader, err := fetchL2Header(ctx, l2Client, agreedOutput.L2BlockNumber)
 	if err != nil {
-		return LocalGameInputs{}, fmt.Errorf("fetch L2 block header %v: %w", agreedOutput.L2BlockNumber, err)
+		return LocalGameInputs{}, err
 	}
 	l2Head := agreedHeader.Hash()
+	// The claimed block isn't an input but the claims can't be verified until the L2 node has it.
+	if _, err := fetchL2Header(ctx, l2Client, claimedOutput.L2BlockNumber); err != nil {
+		return LocalGameInputs{}, err
+	}
 
 	return LocalGameInputs{
 		L1Head:        l1Head,
@@ -59,3 +68,13 @@ func fetchLocalInputs(ctx context.Context, gameAddr common.Address, caller GameI
 		L2BlockNumber: claimedOutput.L2BlockNumber,
 	}, nil
 }
+
+func fetchL2Header(ctx context.Context, l2Client L2DataSource, number *big.Int) (*ethtypes.Header, error) {
+	header, err := l2Client.HeaderByNumber(ctx, number)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("%w: L2 block header %v: %w", types.ErrOutputNotAvailable, number, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("fetch L2 block header %v: %w", number, err)
+	}
+	return header, nil
+}
@@ -2,14 +2,17 @@ package cannon
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,21 +32,33 @@ func TestFetchLocalInputs(t *testing.T) {
 			OutputRoot:    common.Hash{0xee},
 		},
 	}
+	agreedHeader := ethtypes.Header{Number: l1Client.starting.L2BlockNumber}
 	l2Client := &mockL2DataSource{
 		chainID: big.NewInt(88422),
-		header: ethtypes.Header{
-			Number: l1Client.starting.L2BlockNumber,
-		},
+		headers: []ethtypes.Header{agreedHeader, {Number: l1Client.disputed.L2BlockNumber}},
 	}
 
 	inputs, err := fetchLocalInputs(ctx, gameAddr, l1Client, l2Client)
 	require.NoError(t, err)
 
 	require.Equal(t, l1Client.l1Head, inputs.L1Head)
-	require.Equal(t, l2Client.header.Hash(), inputs.L2Head)
+	require.Equal(t, agreedHeader.Hash(), inputs.L2Head)
 	require.EqualValues(t, l1Client.starting.OutputRoot, inputs.L2OutputRoot)
 	require.EqualValues(t, l1Client.disputed.OutputRoot, inputs.L2Claim)
 	require.Equal(t, l1Client.disputed.L2BlockNumber, inputs.L2BlockNumber)
+
+	t.Run("OutputNotAvailable", func(t *testing.T) {
+		l2Client := &mockL2DataSource{headers: []ethtypes.Header{agreedHeader}}
+		_, err := fetchLocalInputs(ctx, gameAddr, l1Client, l2Client)
+		require.ErrorIs(t, err, types.ErrOutputNotAvailable)
+	})
+
+	t.Run("L2Error", func(t *testing.T) {
+		l2Client := &mockL2DataSource{err: errors.New("boom")}
+		_, err := fetchLocalInputs(ctx, gameAddr, l1Client, l2Client)
+		require.ErrorIs(t, err, l2Client.err)
+		require.NotErrorIs(t, err, types.ErrOutputNotAvailable)
+	})
 }
 
 type mockGameInputsSource struct {
@@ -71,7 +86,10 @@ func (s *mockGameInputsSource) Proposals(opts *bind.CallOpts) (struct {
 
 type mockL2DataSource struct {
 	chainID *big.Int
-	header  ethtypes.Header
+	headers []ethtypes.Header
+	// finalized is the header returned for the finalized block, nil if there is no finalized block.
+	finalized *ethtypes.Header
+	err       error
 }
 
 func (s *mockL2DataSource) ChainID(ctx context.Context) (*big.Int, error) {
@@ -79,8 +97,19 @@ func (s *mockL2DataSource) ChainID(ctx context.Context) (*big.Int, error) {
 }
 
 func (s *mockL2DataSource) HeaderByNumber(ctx context.Context, num *big.Int) (*ethtypes.Header, error) {
-	if s.header.Number.Cmp(num) == 0 {
-		return &s.header, nil
+	if s.err != nil {
+		return nil, s.err
+	}
+	if num.Int64() == int64(rpc.FinalizedBlockNumber) {
+		if s.finalized == nil {
+			return nil, ethereum.NotFound
+		}
+		return s.finalized, nil
+	}
+	for i, header := range s.headers {
+		if header.Number.Cmp(num) == 0 {
+			return &s.headers[i], nil
+		}
 	}
 	return nil, ethereum.NotFound
 }
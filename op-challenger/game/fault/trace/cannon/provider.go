@@ -21,6 +21,15 @@ import (
 
 const (
 	proofsDir = "proofs"
+
+	// stateWitnessSize is the length of an encoded [mipsevm.State] witness.
+	stateWitnessSize = 226
+	// stateExitedOffset is the offset of the exited flag in the state witness.
+	stateExitedOffset = 89
+	// memProofSize is the length of the merkle proof for a single memory access.
+	memProofSize = 28 * 32
+	// stepProofSize is the length of the proof for a step, which proves the instruction and one other memory access.
+	stepProofSize = 2 * memProofSize
 )
 
 type proofData struct {
@@ -51,20 +60,44 @@ type CannonTraceProvider struct {
 }
 
 func NewTraceProvider(ctx context.Context, logger log.Logger, cfg *config.Config, l1Client bind.ContractCaller, dir string, gameAddr common.Address) (*CannonTraceProvider, error) {
+	localInputs, err := loadLocalInputs(ctx, cfg, l1Client, gameAddr)
+	if err != nil {
+		return nil, err
+	}
+	return NewTraceProviderFromInputs(logger, cfg, localInputs, dir), nil
+}
+
+// NewSharedTraceProvider creates a trace provider for the game that shares its trace with all other games
+// in registry that have the same absolute prestate and local inputs.
+func NewSharedTraceProvider(ctx context.Context, cfg *config.Config, l1Client bind.ContractCaller, registry *ProviderRegistry, gameAddr common.Address, gameDir string) (*SharedTraceProvider, error) {
+	localInputs, err := loadLocalInputs(ctx, cfg, l1Client, gameAddr)
+	if err != nil {
+		return nil, err
+	}
+	prestate, err := PreStateCommitment(cfg.CannonAbsolutePreState)
+	if err != nil {
+		return nil, fmt.Errorf("cannot hash absolute pre-state: %w", err)
+	}
+	return registry.Acquire(prestate, localInputs, gameDir, func(logger log.Logger, dir string) *CannonTraceProvider {
+		return NewTraceProviderFromInputs(logger, cfg, localInputs, dir)
+	}), nil
+}
+
+func loadLocalInputs(ctx context.Context, cfg *config.Config, l1Client bind.ContractCaller, gameAddr common.Address) (LocalGameInputs, error) {
 	l2Client, err := ethclient.DialContext(ctx, cfg.CannonL2)
 	if err != nil {
-		return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
+		return LocalGameInputs{}, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
 	}
 	defer l2Client.Close() // Not needed after fetching the inputs
 	gameCaller, err := bindings.NewFaultDisputeGameCaller(gameAddr, l1Client)
 	if err != nil {
-		return nil, fmt.Errorf("create caller for game %v: %w", gameAddr, err)
+		return LocalGameInputs{}, fmt.Errorf("create caller for game %v: %w", gameAddr, err)
 	}
 	localInputs, err := fetchLocalInputs(ctx, gameAddr, gameCaller, l2Client)
 	if err != nil {
-		return nil, fmt.Errorf("fetch local game inputs: %w", err)
+		return LocalGameInputs{}, fmt.Errorf("fetch local game inputs: %w", err)
 	}
-	return NewTraceProviderFromInputs(logger, cfg, localInputs, dir), nil
+	return localInputs, nil
 }
 
 func NewTraceProviderFromInputs(logger log.Logger, cfg *config.Config, localInputs LocalGameInputs, dir string) *CannonTraceProvider {
@@ -117,6 +150,36 @@ func (p *CannonTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, err
 	return state.EncodeWitness(), nil
 }
 
+// AbsolutePreStateCommitment returns the hash of the absolute pre-state witness.
+// The pre-state is streamed from disk so the full state is not loaded into memory.
+func (p *CannonTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	hash, err := PreStateCommitment(p.prestate)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("cannot hash absolute pre-state: %w", err)
+	}
+	return hash, nil
+}
+
+// PreStateCommitment returns the hash of the witness of the pre-state stored at path.
+func PreStateCommitment(path string) (common.Hash, error) {
+	return hashState(path)
+}
+
+// ValidateStepData checks the state is a MIPS state witness and the proof contains the two memory proofs a step requires.
+// No proof is required once the program has exited as a step of an exited state does not access memory.
+func (p *CannonTraceProvider) ValidateStepData(stateData []byte, proofData []byte) error {
+	if len(stateData) != stateWitnessSize {
+		return fmt.Errorf("state data has length %v but expected %v", len(stateData), stateWitnessSize)
+	}
+	if stateData[stateExitedOffset] != 0 && len(proofData) == 0 {
+		return nil
+	}
+	if len(proofData) != stepProofSize {
+		return fmt.Errorf("proof data has length %v but expected %v", len(proofData), stepProofSize)
+	}
+	return nil
+}
+
 // loadProof will attempt to load or generate the proof data at the specified index
 // If the requested index is beyond the end of the actual trace it is extended with no-op instructions.
 func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofData, error) {
@@ -185,6 +185,49 @@ func TestAbsolutePreState(t *testing.T) {
 			Registers:      [32]uint32{},
 		}
 		require.Equal(t, state.EncodeWitness(), preState)
+
+		commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256Hash(preState), commitment)
+	})
+
+	t.Run("CommitmentStateUnavailable", func(t *testing.T) {
+		provider, _ := setupWithTestData(t, "/dir/does/not/exist", prestate)
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("CommitmentInvalidStateFile", func(t *testing.T) {
+		setupPreState(t, dataDir, "invalid.json")
+		provider, _ := setupWithTestData(t, dataDir, prestate)
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorContains(t, err, "invalid mipsevm state")
+	})
+}
+
+func TestValidateStepData(t *testing.T) {
+	provider, _ := setupWithTestData(t, t.TempDir(), "state.json")
+	running := (&mipsevm.State{Memory: mipsevm.NewMemory()}).EncodeWitness()
+	exited := (&mipsevm.State{Memory: mipsevm.NewMemory(), Exited: true}).EncodeWitness()
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, provider.ValidateStepData(running, make([]byte, stepProofSize)))
+	})
+
+	t.Run("ExitedWithoutProof", func(t *testing.T) {
+		require.NoError(t, provider.ValidateStepData(exited, []byte{}))
+	})
+
+	t.Run("InvalidStateLength", func(t *testing.T) {
+		err := provider.ValidateStepData(running[1:], make([]byte, stepProofSize))
+		require.ErrorContains(t, err, "state data has length 225")
+	})
+
+	t.Run("InvalidProofLength", func(t *testing.T) {
+		for _, length := range []int{0, 32, memProofSize, stepProofSize + 1} {
+			err := provider.ValidateStepData(running, make([]byte, length))
+			require.ErrorContainsf(t, err, "proof data has length", "length %v", length)
+		}
 	})
 }
 
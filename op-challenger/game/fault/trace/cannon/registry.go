@@ -0,0 +1,195 @@
+package cannon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const sharedDirPrefix = "cannon-"
+
+type RegistryMetricer interface {
+	RecordTraceProviderCacheHit()
+}
+
+// providerKey identifies a cannon trace.
+// Every local input is included, not just the L2 block number, as they are all inputs to op-program and
+// change the trace. Games over the same output root created at different L1 heads don't share a trace.
+type providerKey struct {
+	prestate      common.Hash
+	l1Head        common.Hash
+	l2Head        common.Hash
+	l2OutputRoot  common.Hash
+	l2Claim       common.Hash
+	l2BlockNumber string
+}
+
+func newProviderKey(prestate common.Hash, inputs LocalGameInputs) providerKey {
+	blockNumber := new(big.Int)
+	if inputs.L2BlockNumber != nil {
+		blockNumber = inputs.L2BlockNumber
+	}
+	return providerKey{
+		prestate:      prestate,
+		l1Head:        inputs.L1Head,
+		l2Head:        inputs.L2Head,
+		l2OutputRoot:  inputs.L2OutputRoot,
+		l2Claim:       inputs.L2Claim,
+		l2BlockNumber: blockNumber.String(),
+	}
+}
+
+// dirName returns the name of the directory to store the trace in, derived from the key.
+func (k providerKey) dirName() string {
+	hash := crypto.Keccak256Hash(k.prestate[:], k.l1Head[:], k.l2Head[:], k.l2OutputRoot[:], k.l2Claim[:], []byte(k.l2BlockNumber))
+	return sharedDirPrefix + hash.Hex()
+}
+
+type sharedProvider struct {
+	// mu serialises access to the provider as it is not safe for concurrent use.
+	mu       sync.Mutex
+	provider *CannonTraceProvider
+	dir      string
+	refs     int
+}
+
+// ProviderRegistry shares cannon trace providers between games that have identical traces so that cannon is
+// only executed once for each trace.
+// The trace data of a provider is stored in its own directory under the registry's directory and
+// is deleted when the last game using it is closed, unless traces are retained.
+type ProviderRegistry struct {
+	logger  log.Logger
+	dir     string
+	metrics RegistryMetricer
+	// retain is true if the trace data is moved into the directory of the last game closed instead of being
+	// deleted, so it is kept for as long as that game's data is.
+	retain bool
+
+	mu        sync.Mutex
+	providers map[providerKey]*sharedProvider
+}
+
+// NewProviderRegistry creates a [ProviderRegistry] storing trace data in dir. If retain is set, the trace data is
+// moved into the directory of the last game using it when that game is closed instead of being deleted.
+func NewProviderRegistry(logger log.Logger, dir string, m RegistryMetricer, retain bool) *ProviderRegistry {
+	return &ProviderRegistry{
+		logger:    logger,
+		dir:       dir,
+		metrics:   m,
+		retain:    retain,
+		providers: make(map[providerKey]*sharedProvider),
+	}
+}
+
+// Acquire returns a reference to the provider for the trace with the prestate and local inputs, for the game with
+// data stored in gameDir. If no game currently holds a reference to the trace, create is called to create the
+// provider. The reference must be closed once the game no longer needs it.
+func (r *ProviderRegistry) Acquire(prestate common.Hash, inputs LocalGameInputs, gameDir string, create func(logger log.Logger, dir string) *CannonTraceProvider) *SharedTraceProvider {
+	key := newProviderKey(prestate, inputs)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shared, ok := r.providers[key]
+	if ok {
+		r.metrics.RecordTraceProviderCacheHit()
+	} else {
+		dir := filepath.Join(r.dir, key.dirName())
+		logger := r.logger.New("trace", key.dirName())
+		shared = &sharedProvider{provider: create(logger, dir), dir: dir}
+		r.providers[key] = shared
+	}
+	shared.refs++
+	return &SharedTraceProvider{registry: r, key: key, shared: shared, gameDir: gameDir}
+}
+
+// release drops a reference to the provider for key, deleting its trace data if it was the last reference.
+// If traces are retained, the trace data is moved into gameDir instead.
+func (r *ProviderRegistry) release(key providerKey, gameDir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shared, ok := r.providers[key]
+	if !ok {
+		return nil
+	}
+	shared.refs--
+	if shared.refs > 0 {
+		return nil
+	}
+	delete(r.providers, key)
+	if r.retain && gameDir != "" {
+		err := r.moveTrace(shared.dir, gameDir)
+		if err == nil {
+			return nil
+		}
+		r.logger.Warn("Failed to retain trace data, deleting it", "dir", shared.dir, "game_dir", gameDir, "err", err)
+	}
+	if err := os.RemoveAll(shared.dir); err != nil {
+		return fmt.Errorf("failed to remove trace data (%v): %w", shared.dir, err)
+	}
+	return nil
+}
+
+// moveTrace moves the trace data in dir into gameDir. Nothing is moved if there is no trace data.
+func (r *ProviderRegistry) moveTrace(dir string, gameDir string) error {
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err := os.MkdirAll(gameDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create game directory: %w", err)
+	}
+	return os.Rename(dir, filepath.Join(gameDir, filepath.Base(dir)))
+}
+
+// SharedTraceProvider is a game's reference to a cannon trace provider in a [ProviderRegistry].
+// It is safe for concurrent use.
+type SharedTraceProvider struct {
+	registry *ProviderRegistry
+	key      providerKey
+	shared   *sharedProvider
+	// gameDir is the directory of the game's data, which the trace data is moved into if retained.
+	gameDir string
+	once    sync.Once
+}
+
+var _ types.TraceProvider = (*SharedTraceProvider)(nil)
+
+func (s *SharedTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	s.shared.mu.Lock()
+	defer s.shared.mu.Unlock()
+	return s.shared.provider.Get(ctx, i)
+}
+
+func (s *SharedTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	s.shared.mu.Lock()
+	defer s.shared.mu.Unlock()
+	return s.shared.provider.GetStepData(ctx, i)
+}
+
+func (s *SharedTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
+	return s.shared.provider.AbsolutePreState(ctx)
+}
+
+func (s *SharedTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return s.shared.provider.AbsolutePreStateCommitment(ctx)
+}
+
+func (s *SharedTraceProvider) ValidateStepData(stateData []byte, proofData []byte) error {
+	return s.shared.provider.ValidateStepData(stateData, proofData)
+}
+
+// Close releases the reference to the shared provider. Only the first call has any effect.
+func (s *SharedTraceProvider) Close() error {
+	var err error
+	s.once.Do(func() {
+		err = s.registry.release(s.key, s.gameDir)
+	})
+	return err
+}
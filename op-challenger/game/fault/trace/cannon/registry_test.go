@@ -0,0 +1,156 @@
+package cannon
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderRegistry(t *testing.T) {
+	prestate := common.Hash{0xaa}
+	inputs := LocalGameInputs{
+		L1Head:        common.Hash{0x01},
+		L2Head:        common.Hash{0x02},
+		L2OutputRoot:  common.Hash{0x03},
+		L2Claim:       common.Hash{0x04},
+		L2BlockNumber: big.NewInt(5),
+	}
+	setupRetaining := func(t *testing.T, retain bool) (*ProviderRegistry, *stubRegistryMetrics, func(logger log.Logger, dir string) *CannonTraceProvider, *int) {
+		m := &stubRegistryMetrics{}
+		registry := NewProviderRegistry(testlog.Logger(t, log.LvlInfo), t.TempDir(), m, retain)
+		created := 0
+		create := func(logger log.Logger, dir string) *CannonTraceProvider {
+			created++
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, proofsDir), 0o755))
+			return &CannonTraceProvider{
+				logger: logger,
+				dir:    dir,
+				generator: &stubGenerator{proof: &proofData{
+					ClaimValue: common.Hash{0xbb}.Bytes(),
+					StateData:  []byte{0xcc},
+					ProofData:  []byte{0xdd},
+				}},
+			}
+		}
+		return registry, m, create, &created
+	}
+	setup := func(t *testing.T) (*ProviderRegistry, *stubRegistryMetrics, func(logger log.Logger, dir string) *CannonTraceProvider, *int) {
+		return setupRetaining(t, false)
+	}
+
+	t.Run("ShareProviderForSameTrace", func(t *testing.T) {
+		registry, m, create, created := setup(t)
+		first := registry.Acquire(prestate, inputs, "", create)
+		second := registry.Acquire(prestate, LocalGameInputs{
+			L1Head:        inputs.L1Head,
+			L2Head:        inputs.L2Head,
+			L2OutputRoot:  inputs.L2OutputRoot,
+			L2Claim:       inputs.L2Claim,
+			L2BlockNumber: big.NewInt(5),
+		}, "", create)
+		require.Equal(t, 1, *created)
+		require.Equal(t, 1, m.hits)
+		require.Same(t, first.shared, second.shared)
+	})
+
+	t.Run("SeparateProvidersForDifferentTraces", func(t *testing.T) {
+		registry, m, create, created := setup(t)
+		otherInputs := inputs
+		otherInputs.L1Head = common.Hash{0xff}
+		first := registry.Acquire(prestate, inputs, "", create)
+		second := registry.Acquire(prestate, otherInputs, "", create)
+		third := registry.Acquire(common.Hash{0xee}, inputs, "", create)
+		require.Equal(t, 3, *created)
+		require.Zero(t, m.hits)
+		require.NotSame(t, first.shared, second.shared)
+		require.NotSame(t, first.shared, third.shared)
+	})
+
+	t.Run("RemoveDataWhenLastReferenceClosed", func(t *testing.T) {
+		registry, _, create, created := setup(t)
+		first := registry.Acquire(prestate, inputs, "", create)
+		second := registry.Acquire(prestate, inputs, "", create)
+		_, err := first.Get(context.Background(), 0)
+		require.NoError(t, err)
+		dir := first.shared.dir
+		require.DirExists(t, dir)
+
+		require.NoError(t, first.Close())
+		require.NoError(t, first.Close(), "closing again should not release another reference")
+		require.DirExists(t, dir)
+
+		require.NoError(t, second.Close())
+		require.NoDirExists(t, dir)
+
+		// A new reference recreates the provider.
+		registry.Acquire(prestate, inputs, "", create)
+		require.Equal(t, 2, *created)
+	})
+
+	t.Run("RetainDataInLastGameDir", func(t *testing.T) {
+		registry, _, create, _ := setupRetaining(t, true)
+		gameDir1 := filepath.Join(t.TempDir(), "game1")
+		gameDir2 := filepath.Join(t.TempDir(), "game2")
+		first := registry.Acquire(prestate, inputs, gameDir1, create)
+		second := registry.Acquire(prestate, inputs, gameDir2, create)
+		_, err := first.Get(context.Background(), 0)
+		require.NoError(t, err)
+		dir := first.shared.dir
+
+		require.NoError(t, first.Close())
+		require.DirExists(t, dir)
+		require.NoDirExists(t, gameDir1, "should not move data still used by another game")
+
+		require.NoError(t, second.Close())
+		require.NoDirExists(t, dir)
+		require.DirExists(t, filepath.Join(gameDir2, filepath.Base(dir), proofsDir), "should move data into the last game's dir")
+	})
+
+	t.Run("RetainWithoutData", func(t *testing.T) {
+		registry, _, _, _ := setupRetaining(t, true)
+		gameDir := filepath.Join(t.TempDir(), "game")
+		provider := registry.Acquire(prestate, inputs, gameDir, func(logger log.Logger, dir string) *CannonTraceProvider {
+			return &CannonTraceProvider{logger: logger, dir: dir}
+		})
+		require.NoError(t, provider.Close())
+		require.NoDirExists(t, gameDir, "should not create game dir when there is no data")
+	})
+
+	t.Run("ConcurrentAccess", func(t *testing.T) {
+		registry, _, create, _ := setup(t)
+		providers := []*SharedTraceProvider{
+			registry.Acquire(prestate, inputs, "", create),
+			registry.Acquire(prestate, inputs, "", create),
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			provider := providers[i%len(providers)]
+			idx := uint64(i)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				state, proof, _, err := provider.GetStepData(context.Background(), idx)
+				require.NoError(t, err)
+				require.Equal(t, []byte{0xcc}, state)
+				require.Equal(t, []byte{0xdd}, proof)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+type stubRegistryMetrics struct {
+	hits int
+}
+
+func (s *stubRegistryMetrics) RecordTraceProviderCacheHit() {
+	s.hits++
+}
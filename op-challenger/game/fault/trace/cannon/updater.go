@@ -2,20 +2,28 @@ package cannon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ErrOracleUpdateReverted is returned when an oracle update transaction is mined but reverts, so the step that
+// depends on the data must not be sent.
+var ErrOracleUpdateReverted = errors.New("oracle update reverted")
+
 // cannonUpdater is a [types.OracleUpdater] that exposes a method
 // to update onchain cannon oracles with required data.
 type cannonUpdater struct {
@@ -94,21 +102,81 @@ func (u *cannonUpdater) UpdateOracle(ctx context.Context, data *types.PreimageOr
 }
 
 // sendLocalOracleData sends the local oracle data to the [txmgr].
+// As with global data, loading is skipped if the part is already in the oracle under the game's localized key.
 func (u *cannonUpdater) sendLocalOracleData(ctx context.Context, data *types.PreimageOracleData) error {
+	key := u.localizedKey(data)
+	if loaded, err := u.partLoaded(ctx, key, data.OracleOffset); err != nil {
+		return err
+	} else if loaded {
+		u.log.Debug("Local data part already loaded", "oracleKey", key, "offset", data.OracleOffset)
+		return nil
+	}
 	txData, err := u.BuildLocalOracleData(data)
 	if err != nil {
 		return fmt.Errorf("local oracle tx data build: %w", err)
 	}
-	return u.sendTxAndWait(ctx, u.fdgAddr, txData)
+	if err := u.sendTxAndWait(ctx, u.fdgAddr, txData); err != nil {
+		if loaded, checkErr := u.partLoaded(ctx, key, data.OracleOffset); checkErr == nil && loaded {
+			u.log.Debug("Local data part loaded by another transaction", "oracleKey", key, "offset", data.OracleOffset)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// localizedKey returns the key the pre-image oracle stores the local data under when it is added by the game,
+// matching PreimageKeyLib.localizeIdent.
+func (u *cannonUpdater) localizedKey(data *types.PreimageOracleData) common.Hash {
+	var key common.Hash
+	data.GetIdent().FillBytes(key[:])
+	key[0] = byte(preimage.LocalKeyType)
+	localized := crypto.Keccak256Hash(key.Bytes(), common.LeftPadBytes(u.fdgAddr.Bytes(), common.HashLength))
+	localized[0] = byte(preimage.LocalKeyType)
+	return localized
 }
 
 // sendGlobalOracleData sends the global oracle data to the [txmgr].
+// Loading the data is skipped if the pre-image part is already in the oracle, and a failed load is ignored if the
+// part was loaded in the meantime, such as by another challenger, so the update is idempotent.
 func (u *cannonUpdater) sendGlobalOracleData(ctx context.Context, data *types.PreimageOracleData) error {
+	key := common.BytesToHash(data.OracleKey)
+	if loaded, err := u.partLoaded(ctx, key, data.OracleOffset); err != nil {
+		return err
+	} else if loaded {
+		u.log.Debug("Pre-image part already loaded", "oracleKey", key, "offset", data.OracleOffset)
+		return nil
+	}
 	txData, err := u.BuildGlobalOracleData(data)
 	if err != nil {
 		return fmt.Errorf("global oracle tx data build: %w", err)
 	}
-	return u.sendTxAndWait(ctx, u.fdgAddr, txData)
+	if err := u.sendTxAndWait(ctx, u.preimageOracleAddr, txData); err != nil {
+		if loaded, checkErr := u.partLoaded(ctx, key, data.OracleOffset); checkErr == nil && loaded {
+			u.log.Debug("Pre-image part loaded by another transaction", "oracleKey", key, "offset", data.OracleOffset)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// partLoaded returns true if the pre-image oracle already has the part at offset of the pre-image with the given key.
+// This is a single call so the common case of the part already being loaded adds little latency.
+func (u *cannonUpdater) partLoaded(ctx context.Context, key common.Hash, offset uint32) (bool, error) {
+	callData, err := u.preimageOracleAbi.Pack("preimagePartOk", key, big.NewInt(int64(offset)))
+	if err != nil {
+		return false, fmt.Errorf("pre-image part check build: %w", err)
+	}
+	result, err := u.txMgr.Call(ctx, ethereum.CallMsg{To: &u.preimageOracleAddr, Data: callData}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check pre-image part: %w", err)
+	}
+	values, err := u.preimageOracleAbi.Unpack("preimagePartOk", result)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode pre-image part check: %w", err)
+	}
+	return values[0].(bool), nil
 }
 
 // BuildLocalOracleData takes the local preimage key and data
@@ -135,6 +203,7 @@ func (u *cannonUpdater) BuildGlobalOracleData(data *types.PreimageOracleData) ([
 
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
+// Returns [ErrOracleUpdateReverted] if the transaction reverts.
 func (u *cannonUpdater) sendTxAndWait(ctx context.Context, addr common.Address, txData []byte) error {
 	receipt, err := u.txMgr.Send(ctx, txmgr.TxCandidate{
 		To:       &addr,
@@ -145,9 +214,9 @@ func (u *cannonUpdater) sendTxAndWait(ctx context.Context, addr common.Address,
 		return err
 	}
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
-		u.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
-	} else {
-		u.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
+		u.log.Error("Oracle update tx successfully published but reverted", "tx_hash", receipt.TxHash)
+		return fmt.Errorf("%w: %v", ErrOracleUpdateReverted, receipt.TxHash)
 	}
+	u.log.Debug("Oracle update tx successfully published", "tx_hash", receipt.TxHash)
 	return nil
 }
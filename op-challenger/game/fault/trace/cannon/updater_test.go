@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 
@@ -31,23 +32,47 @@ type mockTxManager struct {
 	sends       int
 	failedSends int
 	sendFails   bool
+	reverts     bool
+	sentTo      []common.Address
+
+	// partLoaded is the result of checking whether a pre-image part is loaded, which becomes true once a part is
+	// sent if loadOnSend is set.
+	partLoaded bool
+	loadOnSend bool
+	calls      int
+	checked    []common.Hash
 }
 
 func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	m.partLoaded = m.partLoaded || m.loadOnSend
 	if m.sendFails {
 		m.failedSends++
 		return nil, mockSendError
 	}
 	m.sends++
+	m.sentTo = append(m.sentTo, *candidate.To)
 	return ethtypes.NewReceipt(
 		[]byte{},
-		false,
+		m.reverts,
 		0,
 	), nil
 }
 
-func (m *mockTxManager) Call(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
-	panic("not implemented")
+func (m *mockTxManager) Call(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if *msg.To != mockPreimageOracleAddress {
+		panic("not implemented")
+	}
+	m.calls++
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	args, err := oracleAbi.Methods["preimagePartOk"].Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	m.checked = append(m.checked, args[0].([32]byte))
+	return oracleAbi.Methods["preimagePartOk"].Outputs.Pack(m.partLoaded)
 }
 
 func (m *mockTxManager) BlockNumber(ctx context.Context) (uint64, error) {
@@ -79,6 +104,48 @@ func TestCannonUpdater_UpdateOracle(t *testing.T) {
 			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
 		}))
 		require.Equal(t, 1, mockTxMgr.sends)
+		require.Equal(t, []common.Address{mockPreimageOracleAddress}, mockTxMgr.sentTo)
+		require.Equal(t, []common.Hash{{0xaa}}, mockTxMgr.checked)
+	})
+
+	t.Run("local data sent to game", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		require.NoError(t, updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			IsLocal:    true,
+			OracleKey:  common.Hash{0xaa}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		}))
+		require.Equal(t, []common.Address{mockFdgAddress}, mockTxMgr.sentTo)
+	})
+
+	t.Run("local data already loaded", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		mockTxMgr.partLoaded = true
+		data := &types.PreimageOracleData{
+			IsLocal:    true,
+			OracleKey:  common.Hash{0x01, 0xaa}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		}
+		require.NoError(t, updater.UpdateOracle(context.Background(), data))
+		require.Zero(t, mockTxMgr.sends, "should not load local data twice")
+
+		// The oracle stores local data under the ident localized to the game that added it.
+		expected := crypto.Keccak256Hash(common.Hash{0x01, 0xaa}.Bytes(), common.LeftPadBytes(mockFdgAddress.Bytes(), 32))
+		expected[0] = 0x01
+		require.Equal(t, []common.Hash{expected}, mockTxMgr.checked)
+	})
+
+	t.Run("local data loaded concurrently", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		mockTxMgr.reverts = true
+		mockTxMgr.loadOnSend = true
+		require.NoError(t, updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			IsLocal:    true,
+			OracleKey:  common.Hash{0x01, 0xaa}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		}))
+		require.Equal(t, []common.Address{mockFdgAddress}, mockTxMgr.sentTo)
+		require.Equal(t, 2, mockTxMgr.calls)
 	})
 
 	t.Run("send fails", func(t *testing.T) {
@@ -89,6 +156,38 @@ func TestCannonUpdater_UpdateOracle(t *testing.T) {
 		}))
 		require.Equal(t, 1, mockTxMgr.failedSends)
 	})
+
+	t.Run("reverts", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		mockTxMgr.reverts = true
+		err := updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			OracleKey:  common.Hash{0xaa}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		})
+		require.ErrorIs(t, err, ErrOracleUpdateReverted)
+	})
+
+	t.Run("already loaded", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		mockTxMgr.partLoaded = true
+		require.NoError(t, updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			OracleKey:  common.Hash{0xaa}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		}))
+		require.Zero(t, mockTxMgr.sends, "should not load a pre-image part twice")
+	})
+
+	t.Run("loaded concurrently", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		mockTxMgr.reverts = true
+		mockTxMgr.loadOnSend = true
+		require.NoError(t, updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			OracleKey:  common.Hash{0xaa}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		}), "should succeed when the load reverts because the part is already loaded")
+		require.Equal(t, 1, mockTxMgr.sends)
+		require.Equal(t, 2, mockTxMgr.calls)
+	})
 }
 
 // TestCannonUpdater_BuildLocalOracleData tests the [cannonUpdater]
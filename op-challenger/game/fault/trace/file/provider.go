@@ -0,0 +1,117 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrIndexTooLarge = errors.New("index is larger than the maximum index")
+	ErrTraceTooShort = errors.New("trace does not cover the game depth")
+)
+
+// traceFile is the JSON format of a trace file.
+// Each proof uses the same fields as the proofs generated by cannon so exported cannon traces can be replayed.
+type traceFile struct {
+	AbsolutePreState hexutil.Bytes `json:"absolute-pre-state"`
+	Proofs           []proofData   `json:"proofs"`
+}
+
+type proofData struct {
+	ClaimValue   common.Hash   `json:"post"`
+	StateData    hexutil.Bytes `json:"state-data"`
+	ProofData    hexutil.Bytes `json:"proof-data"`
+	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
+	OracleValue  hexutil.Bytes `json:"oracle-value,omitempty"`
+	OracleOffset uint32        `json:"oracle-offset,omitempty"`
+}
+
+// FileTraceProvider is a [types.TraceProvider] that replays a trace loaded from a file.
+// The proof at index i provides the claim at trace index i and the step data to execute it.
+type FileTraceProvider struct {
+	prestate []byte
+	proofs   []proofData
+}
+
+// NewTraceProvider loads the trace at path and verifies it has a proof for every trace index of a game with depth.
+func NewTraceProvider(path string, depth uint64) (*FileTraceProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open trace file (%v): %w", path, err)
+	}
+	defer file.Close()
+	var trace traceFile
+	if err := json.NewDecoder(file).Decode(&trace); err != nil {
+		return nil, fmt.Errorf("invalid trace file (%v): %w", path, err)
+	}
+	return newTraceProvider(trace, depth)
+}
+
+func newTraceProvider(trace traceFile, depth uint64) (*FileTraceProvider, error) {
+	if len(trace.AbsolutePreState) == 0 {
+		return nil, errors.New("trace missing absolute pre-state")
+	}
+	if depth >= 64 || uint64(len(trace.Proofs)) < 1<<depth {
+		return nil, fmt.Errorf("%w: %v proofs for depth %v", ErrTraceTooShort, len(trace.Proofs), depth)
+	}
+	for i, proof := range trace.Proofs {
+		if proof.ClaimValue == (common.Hash{}) {
+			return nil, fmt.Errorf("proof %v missing post hash", i)
+		}
+		if len(proof.StateData) == 0 {
+			return nil, fmt.Errorf("proof %v missing state data", i)
+		}
+	}
+	return &FileTraceProvider{
+		prestate: trace.AbsolutePreState,
+		proofs:   trace.Proofs,
+	}, nil
+}
+
+func (p *FileTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	proof, err := p.proof(i)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return proof.ClaimValue, nil
+}
+
+func (p *FileTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	proof, err := p.proof(i)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data := ([]byte)(proof.ProofData)
+	if data == nil {
+		data = []byte{}
+	}
+	var oracleData *types.PreimageOracleData
+	if len(proof.OracleKey) > 0 {
+		oracleData = types.NewPreimageOracleData(proof.OracleKey, proof.OracleValue, proof.OracleOffset)
+	}
+	return proof.StateData, data, oracleData, nil
+}
+
+func (p *FileTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
+	return p.prestate, nil
+}
+
+// AbsolutePreStateCommitment returns the hash of the absolute pre-state from the trace file.
+func (p *FileTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return crypto.Keccak256Hash(p.prestate), nil
+}
+
+func (p *FileTraceProvider) proof(i uint64) (*proofData, error) {
+	if i >= uint64(len(p.proofs)) {
+		return nil, fmt.Errorf("%w: %v with %v proofs", ErrIndexTooLarge, i, len(p.proofs))
+	}
+	return &p.proofs[i], nil
+}
@@ -0,0 +1,143 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTraceProvider(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		path := writeTrace(t, validTrace(4))
+		provider, err := NewTraceProvider(path, 2)
+		require.NoError(t, err)
+		require.Len(t, provider.proofs, 4)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := NewTraceProvider(filepath.Join(t.TempDir(), "missing.json"), 2)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "trace.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+		_, err := NewTraceProvider(path, 2)
+		require.ErrorContains(t, err, "invalid trace file")
+	})
+
+	t.Run("DoesNotCoverDepth", func(t *testing.T) {
+		path := writeTrace(t, validTrace(3))
+		_, err := NewTraceProvider(path, 2)
+		require.ErrorIs(t, err, ErrTraceTooShort)
+	})
+
+	t.Run("MissingPrestate", func(t *testing.T) {
+		trace := validTrace(4)
+		trace.AbsolutePreState = nil
+		_, err := NewTraceProvider(writeTrace(t, trace), 2)
+		require.ErrorContains(t, err, "missing absolute pre-state")
+	})
+
+	t.Run("MissingPostHash", func(t *testing.T) {
+		trace := validTrace(4)
+		trace.Proofs[2].ClaimValue = common.Hash{}
+		_, err := NewTraceProvider(writeTrace(t, trace), 2)
+		require.ErrorContains(t, err, "proof 2 missing post hash")
+	})
+
+	t.Run("MissingStateData", func(t *testing.T) {
+		trace := validTrace(4)
+		trace.Proofs[1].StateData = nil
+		_, err := NewTraceProvider(writeTrace(t, trace), 2)
+		require.ErrorContains(t, err, "proof 1 missing state data")
+	})
+}
+
+func TestGet(t *testing.T) {
+	trace := validTrace(4)
+	provider, err := newTraceProvider(trace, 2)
+	require.NoError(t, err)
+
+	for i, proof := range trace.Proofs {
+		value, err := provider.Get(context.Background(), uint64(i))
+		require.NoError(t, err)
+		require.Equal(t, proof.ClaimValue, value)
+	}
+
+	_, err = provider.Get(context.Background(), 4)
+	require.ErrorIs(t, err, ErrIndexTooLarge)
+}
+
+func TestGetStepData(t *testing.T) {
+	trace := validTrace(4)
+	trace.Proofs[3].OracleKey = []byte{1, 2, 3}
+	trace.Proofs[3].OracleValue = []byte{4, 5, 6}
+	trace.Proofs[3].OracleOffset = 7
+	provider, err := newTraceProvider(trace, 2)
+	require.NoError(t, err)
+
+	t.Run("WithoutOracleData", func(t *testing.T) {
+		state, proof, oracleData, err := provider.GetStepData(context.Background(), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, trace.Proofs[1].StateData, state)
+		require.EqualValues(t, trace.Proofs[1].ProofData, proof)
+		require.Nil(t, oracleData)
+	})
+
+	t.Run("WithOracleData", func(t *testing.T) {
+		_, _, oracleData, err := provider.GetStepData(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, types.NewPreimageOracleData([]byte{1, 2, 3}, []byte{4, 5, 6}, 7), oracleData)
+	})
+
+	t.Run("IndexTooLarge", func(t *testing.T) {
+		_, _, _, err := provider.GetStepData(context.Background(), 4)
+		require.ErrorIs(t, err, ErrIndexTooLarge)
+	})
+}
+
+func TestAbsolutePreState(t *testing.T) {
+	trace := validTrace(4)
+	provider, err := newTraceProvider(trace, 2)
+	require.NoError(t, err)
+
+	prestate, err := provider.AbsolutePreState(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, trace.AbsolutePreState, prestate)
+
+	commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(trace.AbsolutePreState), commitment)
+}
+
+// validTrace creates a trace where the state data of each proof is the previous post state.
+func validTrace(count int) traceFile {
+	trace := traceFile{AbsolutePreState: []byte{0xaa}}
+	prev := []byte(trace.AbsolutePreState)
+	for i := 0; i < count; i++ {
+		post := []byte{byte(i)}
+		trace.Proofs = append(trace.Proofs, proofData{
+			ClaimValue: crypto.Keccak256Hash(post),
+			StateData:  prev,
+			ProofData:  []byte{0xbb, byte(i)},
+		})
+		prev = post
+	}
+	return trace
+}
+
+func writeTrace(t *testing.T, trace traceFile) string {
+	data, err := json.Marshal(trace)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "trace.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned instead of calling the trace provider when the rate limit has been reached.
+// The call should be retried later rather than waiting for the limiter.
+var ErrRateLimited = errors.New("trace provider rate limited")
+
+// RateLimitedTraceProvider is a [types.TraceProvider] decorator that limits the rate of the expensive calls to the
+// wrapped provider: GetStepData and loading the absolute prestate. The limiter may be shared by the providers of
+// many games so that together they can't overwhelm the resources behind them.
+// Calls beyond the limit fail immediately with [ErrRateLimited] rather than blocking until they are allowed.
+type RateLimitedTraceProvider struct {
+	types.TraceProvider
+	limiter *rate.Limiter
+}
+
+// NewTraceProvider wraps provider so that its calls are limited by limiter.
+func NewTraceProvider(provider types.TraceProvider, limiter *rate.Limiter) *RateLimitedTraceProvider {
+	return &RateLimitedTraceProvider{
+		TraceProvider: provider,
+		limiter:       limiter,
+	}
+}
+
+func (p *RateLimitedTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	if !p.limiter.Allow() {
+		return nil, nil, nil, ErrRateLimited
+	}
+	return p.TraceProvider.GetStepData(ctx, i)
+}
+
+func (p *RateLimitedTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
+	if !p.limiter.Allow() {
+		return nil, ErrRateLimited
+	}
+	return p.TraceProvider.AbsolutePreState(ctx)
+}
+
+// AbsolutePreStateCommitment is limited along with AbsolutePreState as providers load the prestate to compute it.
+func (p *RateLimitedTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	if !p.limiter.Allow() {
+		return common.Hash{}, ErrRateLimited
+	}
+	return p.TraceProvider.AbsolutePreStateCommitment(ctx)
+}
+
+// ValidateStepData delegates to the wrapped provider if it implements [types.StepDataValidator].
+func (p *RateLimitedTraceProvider) ValidateStepData(stateData []byte, proofData []byte) error {
+	if validator, ok := p.TraceProvider.(types.StepDataValidator); ok {
+		return validator.ValidateStepData(stateData, proofData)
+	}
+	return nil
+}
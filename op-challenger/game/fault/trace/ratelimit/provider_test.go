@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestDeferCallsBeyondBurst(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 2)
+	stub := &stubTraceProvider{}
+	provider := NewTraceProvider(stub, limiter)
+
+	for i := 0; i < 2; i++ {
+		_, _, _, err := provider.GetStepData(context.Background(), 1)
+		require.NoError(t, err)
+	}
+	_, _, _, err := provider.GetStepData(context.Background(), 1)
+	require.ErrorIs(t, err, ErrRateLimited)
+	_, err = provider.AbsolutePreState(context.Background())
+	require.ErrorIs(t, err, ErrRateLimited)
+	_, err = provider.AbsolutePreStateCommitment(context.Background())
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.Equal(t, 2, stub.calls, "should not call the provider when limited")
+
+	value, err := provider.Get(context.Background(), 3)
+	require.NoError(t, err, "should not limit loading claims")
+	require.Equal(t, common.Hash{3}, value)
+}
+
+func TestShareLimiterAcrossProviders(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	first := &stubTraceProvider{}
+	second := &stubTraceProvider{}
+
+	_, err := NewTraceProvider(first, limiter).AbsolutePreState(context.Background())
+	require.NoError(t, err)
+	_, err = NewTraceProvider(second, limiter).AbsolutePreState(context.Background())
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.Equal(t, 1, first.calls)
+	require.Zero(t, second.calls)
+}
+
+type stubTraceProvider struct {
+	types.TraceProvider
+	calls int
+}
+
+func (s *stubTraceProvider) Get(_ context.Context, i uint64) (common.Hash, error) {
+	return common.Hash{byte(i)}, nil
+}
+
+func (s *stubTraceProvider) GetStepData(_ context.Context, _ uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	s.calls++
+	return []byte{1}, []byte{2}, nil, nil
+}
+
+func (s *stubTraceProvider) AbsolutePreState(_ context.Context) ([]byte, error) {
+	s.calls++
+	return []byte{1}, nil
+}
+
+func (s *stubTraceProvider) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	s.calls++
+	return common.Hash{1}, nil
+}
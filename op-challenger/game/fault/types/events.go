@@ -0,0 +1,61 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type EventType string
+
+const (
+	EventGameDiscovered    EventType = "game_discovered"
+	EventClaimCountered    EventType = "claim_countered"
+	EventStepExecuted      EventType = "step_executed"
+	EventGameResolved      EventType = "game_resolved"
+	EventPrestateValidated EventType = "prestate_validated"
+	EventGameAbandoned     EventType = "game_abandoned"
+	// EventGameCreated records the address that created a game, which is the claimant of its root claim.
+	EventGameCreated EventType = "game_created"
+	// EventResolutionDisputed is emitted when a game resolves contrary to the expected outcome.
+	EventResolutionDisputed EventType = "resolution_disputed"
+)
+
+// Event is a machine readable record of a decision made by the challenger.
+type Event struct {
+	Type EventType `json:"type"`
+	// Time is when the event occurred. Sinks set it to the current time if it is zero.
+	Time time.Time      `json:"time"`
+	Game common.Address `json:"game"`
+	// ClaimIndex is the contract index of the claim countered or stepped against, if any.
+	ClaimIndex *int         `json:"claimIndex,omitempty"`
+	TxHash     *common.Hash `json:"txHash,omitempty"`
+	// Reverted is true if the transaction was included but reverted.
+	Reverted bool `json:"reverted,omitempty"`
+	// GasUsed, EffectiveGasPrice and BlockNumber are from the receipt of the transaction, if any.
+	GasUsed           uint64   `json:"gasUsed,omitempty"`
+	EffectiveGasPrice *big.Int `json:"effectiveGasPrice,omitempty"`
+	BlockNumber       uint64   `json:"blockNumber,omitempty"`
+	Status            string   `json:"status,omitempty"`
+	Error             string   `json:"error,omitempty"`
+	// Reason is why the game was abandoned or its resolution disputed, if it was.
+	Reason string `json:"reason,omitempty"`
+	// ResolvedAt is when the game resolved, if known.
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	// Winner is the address the resolution credits with the root claim's bond, if known.
+	Winner *common.Address `json:"winner,omitempty"`
+	// Creator is the address that created the game, if known.
+	Creator *common.Address `json:"creator,omitempty"`
+}
+
+// EventSink receives the events emitted while playing games.
+// Implementations must be safe for concurrent use by the players of different games.
+type EventSink interface {
+	Emit(event Event)
+}
+
+// NoopEventSink discards all events.
+type NoopEventSink struct{}
+
+func (NoopEventSink) Emit(Event) {}
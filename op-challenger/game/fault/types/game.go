@@ -32,30 +32,54 @@ type Game interface {
 
 type extendedClaim struct {
 	self     Claim
-	children []ClaimData
+	children []claimKey
+}
+
+// claimKey identifies a claim in the game state by its contract index, as claims with the same value and position
+// may counter different parents.
+type claimKey struct {
+	ClaimData
+	index int
+}
+
+// claimID identifies a claim the way the contract does, by its value, position and parent, so a move can be checked
+// for duplicates before it has a contract index.
+type claimID struct {
+	ClaimData
+	parentIndex int
+}
+
+func keyOf(claim Claim) claimKey {
+	return claimKey{ClaimData: claim.ClaimData, index: claim.ContractIndex}
+}
+
+func idOf(claim Claim) claimID {
+	return claimID{ClaimData: claim.ClaimData, parentIndex: claim.ParentContractIndex}
 }
 
 // gameState is a struct that represents the state of a dispute game.
 // The game state implements the [Game] interface.
 type gameState struct {
 	agreeWithProposedOutput bool
-	root                    ClaimData
-	claims                  map[ClaimData]*extendedClaim
+	root                    claimKey
+	claims                  map[claimKey]*extendedClaim
+	ids                     map[claimID]bool
 	depth                   uint64
 }
 
 // NewGameState returns a new game state.
 // The provided [Claim] is used as the root node.
 func NewGameState(agreeWithProposedOutput bool, root Claim, depth uint64) *gameState {
-	claims := make(map[ClaimData]*extendedClaim)
-	claims[root.ClaimData] = &extendedClaim{
+	claims := make(map[claimKey]*extendedClaim)
+	claims[keyOf(root)] = &extendedClaim{
 		self:     root,
-		children: make([]ClaimData, 0),
+		children: make([]claimKey, 0),
 	}
 	return &gameState{
 		agreeWithProposedOutput: agreeWithProposedOutput,
-		root:                    root.ClaimData,
+		root:                    keyOf(root),
 		claims:                  claims,
+		ids:                     map[claimID]bool{idOf(root): true},
 		depth:                   depth,
 	}
 }
@@ -88,37 +112,40 @@ func (g *gameState) Put(claim Claim) error {
 	if claim.IsRoot() || g.IsDuplicate(claim) {
 		return ErrClaimExists
 	}
-	parent, ok := g.claims[claim.Parent]
+	parent, ok := g.claims[claimKey{ClaimData: claim.Parent, index: claim.ParentContractIndex}]
 	if !ok {
 		return errors.New("no parent claim")
 	} else {
-		parent.children = append(parent.children, claim.ClaimData)
+		parent.children = append(parent.children, keyOf(claim))
 	}
-	g.claims[claim.ClaimData] = &extendedClaim{
+	g.claims[keyOf(claim)] = &extendedClaim{
 		self:     claim,
-		children: make([]ClaimData, 0),
+		children: make([]claimKey, 0),
 	}
+	g.ids[idOf(claim)] = true
 	return nil
 }
 
+// IsDuplicate returns true if the game already has a claim with the same value and position countering the same
+// parent. Matching claims that counter different parents are distinct, as they are in the contract.
 func (g *gameState) IsDuplicate(claim Claim) bool {
-	_, ok := g.claims[claim.ClaimData]
-	return ok
+	return g.ids[idOf(claim)]
 }
 
+// Claims returns the claims in breadth first order from the root claim.
+// The returned slice is used as the queue of the traversal, so it is the only allocation.
 func (g *gameState) Claims() []Claim {
-	queue := []ClaimData{g.root}
-	var out []Claim
-	for len(queue) > 0 {
-		item := queue[0]
-		queue = queue[1:]
-		queue = append(queue, g.getChildren(item)...)
-		out = append(out, g.claims[item].self)
+	out := make([]Claim, 1, len(g.claims))
+	out[0] = g.claims[g.root].self
+	for i := 0; i < len(out); i++ {
+		for _, child := range g.getChildren(keyOf(out[i])) {
+			out = append(out, g.claims[child].self)
+		}
 	}
 	return out
 }
 
-func (g *gameState) getChildren(c ClaimData) []ClaimData {
+func (g *gameState) getChildren(c claimKey) []claimKey {
 	return g.claims[c].children
 }
 
@@ -126,7 +153,7 @@ func (g *gameState) getParent(claim Claim) (Claim, error) {
 	if claim.IsRoot() {
 		return Claim{}, ErrClaimNotFound
 	}
-	if parent, ok := g.claims[claim.Parent]; !ok {
+	if parent, ok := g.claims[claimKey{ClaimData: claim.Parent, index: claim.ParentContractIndex}]; !ok {
 		return Claim{}, ErrClaimNotFound
 	} else {
 		return parent.self, nil
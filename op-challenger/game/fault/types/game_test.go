@@ -72,6 +72,33 @@ func TestGame_Put_RootAlreadyExists(t *testing.T) {
 	require.ErrorIs(t, err, ErrClaimExists)
 }
 
+// TestIsDuplicate_DifferentParents tests that claims with the same value and position are only duplicates if they
+// counter the same parent, matching the contract.
+func TestIsDuplicate_DifferentParents(t *testing.T) {
+	root, top, middle, _ := createTestClaims()
+	otherTop := top
+	otherTop.Value = common.Hash{0xaa}
+	otherTop.ContractIndex = 2
+	top.ContractIndex = 1
+	middle.ParentContractIndex = top.ContractIndex
+	otherMiddle := middle
+	otherMiddle.Parent = otherTop.ClaimData
+	otherMiddle.ParentContractIndex = otherTop.ContractIndex
+	otherMiddle.ContractIndex = 4
+	middle.ContractIndex = 3
+
+	g := NewGameState(false, root, testMaxDepth)
+	require.NoError(t, g.PutAll([]Claim{top, otherTop, middle}))
+	require.True(t, g.IsDuplicate(middle))
+	require.False(t, g.IsDuplicate(otherMiddle), "should not be a duplicate of a claim countering a different parent")
+
+	require.NoError(t, g.Put(otherMiddle))
+	require.ElementsMatch(t, []Claim{root, top, otherTop, middle, otherMiddle}, g.Claims())
+	parent, err := g.getParent(otherMiddle)
+	require.NoError(t, err)
+	require.Equal(t, otherTop, parent)
+}
+
 // TestGame_PutAll_RootAlreadyExists tests the [Game.PutAll] method using a [gameState]
 // instance errors when the root claim already exists in state.
 func TestGame_PutAll_RootAlreadyExists(t *testing.T) {
@@ -195,6 +222,20 @@ func TestGame_ClaimPairs(t *testing.T) {
 	require.ElementsMatch(t, expected, claims)
 }
 
+// TestGame_Claims_BreadthFirst tests the claims are returned in breadth first order with a single allocation.
+func TestGame_Claims_BreadthFirst(t *testing.T) {
+	root, top, middle, bottom := createTestClaims()
+	g := NewGameState(false, root, testMaxDepth)
+	otherTop := Claim{
+		ClaimData: ClaimData{Value: common.Hash{0xaa}, Position: top.Position},
+		Parent:    root.ClaimData,
+	}
+	require.NoError(t, g.PutAll([]Claim{top, middle, bottom, otherTop}))
+
+	require.Equal(t, []Claim{root, top, otherTop, middle, bottom}, g.Claims())
+	require.Equal(t, 1.0, testing.AllocsPerRun(10, func() { g.Claims() }))
+}
+
 func TestAgreeWithClaimLevelDisagreeWithOutput(t *testing.T) {
 	// Setup the game state.
 	root, top, middle, bottom := createTestClaims()
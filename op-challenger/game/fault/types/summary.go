@@ -0,0 +1,56 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GameSummary is the latest known state of a game being played.
+type GameSummary struct {
+	Game   common.Address `json:"game"`
+	Status string         `json:"status"`
+	Claims uint64         `json:"claims"`
+	// ClockDeadline is when the soonest expiring chess clock runs out. Nil if no clock is running.
+	ClockDeadline *time.Time `json:"clockDeadline,omitempty"`
+	// LastError is the most recent error progressing the game. Empty if there hasn't been one.
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+	// Outlook is whether the game is expected to be won if both sides play optimally. Empty if not analyzed.
+	Outlook string `json:"outlook,omitempty"`
+	// ExpectedStatus is the status the game was expected to resolve with, set only if it resolved with another
+	// status. Such games are kept in the registry once they are no longer played so the resolution can be acted on.
+	ExpectedStatus string `json:"expectedStatus,omitempty"`
+	// ResolvedAt is when the game resolved. Nil if it hasn't or the time is unknown.
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	// Winner is the address the resolution credits with the root claim's bond. Nil if unresolved or unknown.
+	Winner  *common.Address `json:"winner,omitempty"`
+	Updated time.Time       `json:"updated"`
+}
+
+// OpponentSummary is the record of the games created by an address, such as the games it lost.
+type OpponentSummary struct {
+	Creator common.Address `json:"creator"`
+	Created int            `json:"created"`
+	// Won and Lost are the number of the creator's games resolved in favour of and against the root claim.
+	Won  int `json:"won"`
+	Lost int `json:"lost"`
+	// RecentLosses is the number of games lost within the window used to decide whether to deprioritize new games.
+	RecentLosses int `json:"recentLosses"`
+	// Deprioritized is true if the creator's games are played at the lowest priority.
+	Deprioritized bool `json:"deprioritized"`
+}
+
+// ActResult is the outcome of progressing a game on request.
+type ActResult struct {
+	Game common.Address `json:"game"`
+	// Succeeded is true if the game was progressed without error.
+	Succeeded bool `json:"succeeded"`
+	// Error is the error that occurred progressing the game. Empty if it succeeded.
+	Error  string `json:"error,omitempty"`
+	Status string `json:"status"`
+	// Claims is the number of claims in the game, 0 if they weren't loaded.
+	Claims uint64 `json:"claims"`
+	// Resolved is true if the game is complete and is no longer progressed.
+	Resolved bool `json:"resolved"`
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NoParent is the parent index of the root node of a [ClaimTree].
+const NoParent = -1
+
+// ErrInvalidClaimTree is returned when claims don't form a valid tree.
+var ErrInvalidClaimTree = errors.New("invalid claim tree")
+
+// ClaimTreeNode is a claim within a [ClaimTree].
+type ClaimTreeNode struct {
+	// Index is the index of the claim in the contract.
+	Index int `json:"index"`
+	// ParentIndex is the index of the claim this claim counters, or [NoParent] for the root claim.
+	ParentIndex  int            `json:"parentIndex"`
+	Position     uint64         `json:"position"`
+	Depth        int            `json:"depth"`
+	IndexAtDepth int            `json:"indexAtDepth"`
+	Claimant     common.Address `json:"claimant"`
+	Value        common.Hash    `json:"value"`
+	Countered    bool           `json:"countered"`
+	// TraceIndex is the index of the claim's position in the trace. Set by [ClaimTree.Annotate].
+	TraceIndex uint64 `json:"traceIndex"`
+	// Agrees is whether the local trace agrees with the claim's value. Nil if not compared with a trace.
+	Agrees *bool `json:"agrees,omitempty"`
+	// Children are the claims that counter this claim, in contract order.
+	Children []*ClaimTreeNode `json:"children,omitempty"`
+}
+
+// ClaimTree is the tree of claims in a game, with each claim a child of the claim it counters.
+type ClaimTree struct {
+	Root *ClaimTreeNode `json:"root"`
+	// Nodes holds every node of the tree by contract index.
+	Nodes []*ClaimTreeNode `json:"-"`
+}
+
+// NewClaimTree builds the tree of the claims of a game, which must be ordered by contract index.
+// Returns [ErrInvalidClaimTree] if the first claim isn't the root or another claim doesn't counter an earlier claim
+// at the depth above it.
+func NewClaimTree(claims []Claim) (*ClaimTree, error) {
+	if len(claims) == 0 {
+		return nil, fmt.Errorf("%w: no claims", ErrInvalidClaimTree)
+	}
+	nodes := make([]*ClaimTreeNode, len(claims))
+	for i, claim := range claims {
+		if claim.ContractIndex != i {
+			return nil, fmt.Errorf("%w: claim %v at index %v", ErrInvalidClaimTree, claim.ContractIndex, i)
+		}
+		node := &ClaimTreeNode{
+			Index:        claim.ContractIndex,
+			ParentIndex:  NoParent,
+			Position:     claim.ToGIndex(),
+			Depth:        claim.Depth(),
+			IndexAtDepth: claim.IndexAtDepth(),
+			Claimant:     claim.Claimant,
+			Value:        claim.Value,
+			Countered:    claim.Countered,
+		}
+		nodes[i] = node
+		if i == 0 {
+			if !claim.IsRoot() {
+				return nil, fmt.Errorf("%w: first claim is not the root", ErrInvalidClaimTree)
+			}
+			continue
+		}
+		if claim.ParentContractIndex < 0 || claim.ParentContractIndex >= i {
+			return nil, fmt.Errorf("%w: claim %v has parent %v", ErrInvalidClaimTree, i, claim.ParentContractIndex)
+		}
+		parent := nodes[claim.ParentContractIndex]
+		if node.Depth != parent.Depth+1 {
+			return nil, fmt.Errorf("%w: claim %v at depth %v counters claim %v at depth %v", ErrInvalidClaimTree, i, node.Depth, parent.Index, parent.Depth)
+		}
+		node.ParentIndex = parent.Index
+		parent.Children = append(parent.Children, node)
+	}
+	return &ClaimTree{Root: nodes[0], Nodes: nodes}, nil
+}
+
+// Annotate sets the trace index of each claim for a game with maxDepth and, if trace is not nil, whether the trace
+// agrees with each claim's value.
+func (t *ClaimTree) Annotate(ctx context.Context, maxDepth int, trace TraceProvider) error {
+	for _, node := range t.Nodes {
+		pos := NewPositionFromGIndex(node.Position)
+		node.TraceIndex = pos.TraceIndex(maxDepth)
+		if trace == nil {
+			continue
+		}
+		value, err := trace.Get(ctx, node.TraceIndex)
+		if err != nil {
+			return fmt.Errorf("failed to get trace index %v for claim %v: %w", node.TraceIndex, node.Index, err)
+		}
+		agrees := value == node.Value
+		node.Agrees = &agrees
+	}
+	return nil
+}
+
+// WriteText writes the tree to w as indented text, one claim per line with its counter claims below it.
+func (t *ClaimTree) WriteText(w io.Writer) error {
+	return writeTextNode(w, t.Root, "", "")
+}
+
+func writeTextNode(w io.Writer, node *ClaimTreeNode, prefix string, childPrefix string) error {
+	line := fmt.Sprintf("%v#%v depth=%v trace=%v value=%v claimant=%v countered=%v",
+		prefix, node.Index, node.Depth, node.TraceIndex, node.Value.TerminalString(), node.Claimant, node.Countered)
+	if node.Agrees != nil {
+		line += fmt.Sprintf(" agrees=%v", *node.Agrees)
+	}
+	if _, err := fmt.Fprintln(w, line); err != nil {
+		return err
+	}
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if err := writeTextNode(w, child, childPrefix+branch, childPrefix+indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClaimTree(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	attackAttack := attack.Attack()
+	attackAttackDefend := attackAttack.Defend()
+	claimantA := common.Address{0xaa}
+	claimantB := common.Address{0xbb}
+	claims := []Claim{
+		treeClaim(0, NoParent, root, claimantA),
+		treeClaim(1, 0, attack, claimantB),
+		treeClaim(2, 1, attackAttack, claimantA),
+		treeClaim(3, 2, attackAttackDefend, claimantB),
+		treeClaim(4, 0, attack, claimantA),
+	}
+	claims[0].Countered = true
+
+	tree, err := NewClaimTree(claims)
+	require.NoError(t, err)
+	require.Len(t, tree.Nodes, len(claims))
+	require.Same(t, tree.Nodes[0], tree.Root)
+
+	require.Equal(t, &ClaimTreeNode{
+		Index:        3,
+		ParentIndex:  2,
+		Position:     attackAttackDefend.ToGIndex(),
+		Depth:        3,
+		IndexAtDepth: 2,
+		Claimant:     claimantB,
+		Value:        common.Hash{0x03},
+	}, tree.Nodes[3])
+
+	require.Equal(t, NoParent, tree.Root.ParentIndex)
+	require.True(t, tree.Root.Countered)
+	require.Equal(t, []*ClaimTreeNode{tree.Nodes[1], tree.Nodes[4]}, tree.Root.Children)
+	require.Equal(t, []*ClaimTreeNode{tree.Nodes[2]}, tree.Nodes[1].Children)
+	require.Equal(t, []*ClaimTreeNode{tree.Nodes[3]}, tree.Nodes[2].Children)
+	require.Empty(t, tree.Nodes[4].Children)
+	for i, node := range tree.Nodes {
+		require.Equal(t, i, node.Index)
+		require.Equal(t, claims[i].Depth(), node.Depth)
+		require.Equal(t, claims[i].Claimant, node.Claimant)
+	}
+}
+
+func TestNewClaimTree_Invalid(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	attackAttack := attack.Attack()
+
+	tests := []struct {
+		name   string
+		claims []Claim
+	}{
+		{name: "NoClaims"},
+		{name: "RootNotFirst", claims: []Claim{treeClaim(0, NoParent, attack, common.Address{})}},
+		{name: "OutOfOrder", claims: []Claim{treeClaim(1, NoParent, root, common.Address{})}},
+		{
+			name: "ParentAfterChild",
+			claims: []Claim{
+				treeClaim(0, NoParent, root, common.Address{}),
+				treeClaim(1, 2, attackAttack, common.Address{}),
+				treeClaim(2, 0, attack, common.Address{}),
+			},
+		},
+		{
+			name: "SkipsDepth",
+			claims: []Claim{
+				treeClaim(0, NoParent, root, common.Address{}),
+				treeClaim(1, 0, attackAttack, common.Address{}),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewClaimTree(test.claims)
+			require.ErrorIs(t, err, ErrInvalidClaimTree)
+		})
+	}
+}
+
+func TestClaimTree_Annotate(t *testing.T) {
+	maxDepth := 2
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	defend := attack.Defend()
+	claims := []Claim{
+		treeClaim(0, NoParent, root, common.Address{}),
+		treeClaim(1, 0, attack, common.Address{}),
+		treeClaim(2, 1, defend, common.Address{}),
+	}
+
+	t.Run("TraceIndexOnly", func(t *testing.T) {
+		tree, err := NewClaimTree(claims)
+		require.NoError(t, err)
+		require.NoError(t, tree.Annotate(context.Background(), maxDepth, nil))
+		require.Equal(t, uint64(3), tree.Nodes[0].TraceIndex)
+		require.Equal(t, uint64(1), tree.Nodes[1].TraceIndex)
+		require.Equal(t, uint64(2), tree.Nodes[2].TraceIndex)
+		for _, node := range tree.Nodes {
+			require.Nil(t, node.Agrees)
+		}
+	})
+
+	t.Run("CompareTrace", func(t *testing.T) {
+		tree, err := NewClaimTree(claims)
+		require.NoError(t, err)
+		trace := &stubTreeTrace{values: map[uint64]common.Hash{1: {0x01}, 2: {0xff}, 3: {0xff}}}
+		require.NoError(t, tree.Annotate(context.Background(), maxDepth, trace))
+		require.False(t, *tree.Nodes[0].Agrees)
+		require.True(t, *tree.Nodes[1].Agrees)
+		require.False(t, *tree.Nodes[2].Agrees)
+	})
+
+	t.Run("TraceError", func(t *testing.T) {
+		tree, err := NewClaimTree(claims)
+		require.NoError(t, err)
+		expected := errors.New("boom")
+		require.ErrorIs(t, tree.Annotate(context.Background(), maxDepth, &stubTreeTrace{err: expected}), expected)
+	})
+}
+
+func TestClaimTree_WriteText(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	attackAttack := attack.Attack()
+	claimant := common.Address{0xaa}
+	claims := []Claim{
+		treeClaim(0, NoParent, root, claimant),
+		treeClaim(1, 0, attack, claimant),
+		treeClaim(2, 1, attackAttack, claimant),
+		treeClaim(3, 0, attack, claimant),
+	}
+	claims[1].Countered = true
+	tree, err := NewClaimTree(claims)
+	require.NoError(t, err)
+	require.NoError(t, tree.Annotate(context.Background(), 2, nil))
+	agrees := true
+	tree.Nodes[3].Agrees = &agrees
+
+	var out strings.Builder
+	require.NoError(t, tree.WriteText(&out))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Equal(t, []string{
+		"#0 depth=0 trace=3 value=000000..000000 claimant=" + claimant.Hex() + " countered=false",
+		"├── #1 depth=1 trace=1 value=010000..000000 claimant=" + claimant.Hex() + " countered=true",
+		"│   └── #2 depth=2 trace=0 value=020000..000000 claimant=" + claimant.Hex() + " countered=false",
+		"└── #3 depth=1 trace=1 value=030000..000000 claimant=" + claimant.Hex() + " countered=false agrees=true",
+	}, lines)
+}
+
+type stubTreeTrace struct {
+	TraceProvider
+	values map[uint64]common.Hash
+	err    error
+}
+
+func (s *stubTreeTrace) Get(_ context.Context, i uint64) (common.Hash, error) {
+	return s.values[i], s.err
+}
+
+func treeClaim(index int, parent int, pos Position, claimant common.Address) Claim {
+	return Claim{
+		ClaimData:           ClaimData{Value: common.Hash{byte(index)}, Position: pos},
+		Claimant:            claimant,
+		ContractIndex:       index,
+		ParentContractIndex: parent,
+	}
+}
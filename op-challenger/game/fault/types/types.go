@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 var (
 	ErrGameDepthReached = errors.New("game depth reached")
+	// ErrOutputNotAvailable is returned by trace providers when the L2 node doesn't have the outputs a game is about
+	// yet, so the game's claims can't be verified until it catches up.
+	ErrOutputNotAvailable = errors.New("L2 output not available")
 )
 
 type GameStatus uint8
@@ -83,6 +87,20 @@ type StepCallData struct {
 	IsAttack   bool
 	StateData  []byte
 	Proof      []byte
+	// ClockCritical is set if the clock of the claim being stepped against is about to expire, so the step is sent
+	// even while transactions are halted after repeated reverts.
+	ClockCritical bool
+}
+
+// ClaimDisagreement is a claim whose value differs from the trace at the claim's trace index. It has both values so
+// the trace can be inspected at the index, such as by running cannon to it.
+type ClaimDisagreement struct {
+	// ClaimIndex is the contract index of the claim.
+	ClaimIndex int
+	TraceIndex uint64
+	// Claimed is the claim's value and Expected is the trace's value at TraceIndex.
+	Claimed  common.Hash
+	Expected common.Hash
 }
 
 // OracleUpdater is a generic interface for updating oracles.
@@ -105,6 +123,16 @@ type TraceProvider interface {
 
 	// AbsolutePreState is the pre-image value of the trace that transitions to the trace value at index 0
 	AbsolutePreState(ctx context.Context) (preimage []byte, err error)
+
+	// AbsolutePreStateCommitment is the commitment of the pre-image value of the trace that transitions to the trace value at index 0
+	// AbsolutePreStateCommitment = Keccak256(AbsolutePreState)
+	AbsolutePreStateCommitment(ctx context.Context) (hash common.Hash, err error)
+}
+
+// StepDataValidator is optionally implemented by a [TraceProvider] to check the step data it returns
+// is correctly encoded for its VM, so that malformed data is rejected before submitting a step that would revert.
+type StepDataValidator interface {
+	ValidateStepData(stateData []byte, proofData []byte) error
 }
 
 // ClaimData is the core of a claim. It must be unique inside a specific game.
@@ -131,8 +159,16 @@ type Claim struct {
 	//       When caching is implemented for the Challenger, this will need
 	//       to be changed/removed to avoid invalid/stale contract state.
 	Countered bool
-	Clock     uint64
-	Parent    ClaimData
+	// Claimant is the address that posted this claim.
+	// Only populated for contract versions that record it, otherwise it is the zero address.
+	Claimant common.Address
+	// Bond is the bond posted with this claim. Nil for contract versions that don't record it.
+	Bond *big.Int
+	// Clock is the timestamp the claim's chess clock was started at, from the lower 64 bits of the packed clock.
+	Clock uint64
+	// ClockDuration is the time already used on the claimant's chess clock when the claim was made.
+	ClockDuration time.Duration
+	Parent        ClaimData
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
 	ContractIndex       int
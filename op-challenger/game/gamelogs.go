@@ -0,0 +1,178 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	gameLogFile = "challenger.log"
+	// DefaultGameLogMaxSize is the size in bytes a game log file may reach before it is rotated.
+	DefaultGameLogMaxSize = 10 * 1024 * 1024
+)
+
+// gameLogHandler is a [log.Handler] that passes all records to a delegate handler and
+// additionally writes records with a game field, up to level, to a JSON log file in that game's directory.
+// Once a game's file is closed its records are only passed to the delegate, so the file isn't recreated after the
+// game's data is removed.
+type gameLogHandler struct {
+	delegate   log.Handler
+	level      log.Lvl
+	dirForGame func(addr common.Address) string
+	maxSize    int64
+	format     log.Format
+
+	mu     sync.Mutex
+	files  map[common.Address]*rotatingFile
+	closed map[common.Address]bool
+}
+
+func newGameLogHandler(delegate log.Handler, level log.Lvl, dirForGame func(addr common.Address) string, maxSize int64) *gameLogHandler {
+	return &gameLogHandler{
+		delegate:   delegate,
+		level:      level,
+		dirForGame: dirForGame,
+		maxSize:    maxSize,
+		format:     log.JSONFormat(),
+		files:      make(map[common.Address]*rotatingFile),
+		closed:     make(map[common.Address]bool),
+	}
+}
+
+func (h *gameLogHandler) Log(r *log.Record) error {
+	err := h.delegate.Log(r)
+	if r.Lvl > h.level {
+		return err
+	}
+	addr, ok := gameFromContext(r.Ctx)
+	if !ok {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed[addr] {
+		return err
+	}
+	file, ok := h.files[addr]
+	if !ok {
+		file = &rotatingFile{path: filepath.Join(h.dirForGame(addr), gameLogFile), maxSize: h.maxSize}
+		h.files[addr] = file
+	}
+	if writeErr := file.Write(h.format.Format(r)); writeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to write game log: %w", writeErr))
+	}
+	return err
+}
+
+// CloseAllExcept closes the log files of all games other than those in keep.
+func (h *gameLogHandler) CloseAllExcept(keep []common.Address) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var errs []error
+	for addr, file := range h.files {
+		if slices.Contains(keep, addr) {
+			continue
+		}
+		errs = append(errs, file.Close())
+		delete(h.files, addr)
+		h.closed[addr] = true
+	}
+	return errors.Join(errs...)
+}
+
+// gameFromContext finds the address in the game field of a log context.
+func gameFromContext(ctx []interface{}) (common.Address, bool) {
+	for i := 0; i+1 < len(ctx); i += 2 {
+		if key, ok := ctx[i].(string); !ok || key != "game" {
+			continue
+		}
+		switch addr := ctx[i+1].(type) {
+		case common.Address:
+			return addr, true
+		case *common.Address:
+			if addr != nil {
+				return *addr, true
+			}
+		}
+	}
+	return common.Address{}, false
+}
+
+// rotatingFile is an append only file that is moved to a single backup file once it exceeds maxSize.
+type rotatingFile struct {
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+func (f *rotatingFile) Write(data []byte) error {
+	if f.file != nil && f.size > 0 && f.size+int64(len(data)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create log dir: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to stat log file: %w", err), file.Close())
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
+
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	f.size = 0
+	return err
+}
+
+// gameLogDiskManager closes the log files of games before their data is removed.
+type gameLogDiskManager struct {
+	scheduler.DiskManager
+	logs *gameLogHandler
+}
+
+func (d *gameLogDiskManager) RemoveAllExcept(keep []common.Address) error {
+	return errors.Join(d.logs.CloseAllExcept(keep), d.DiskManager.RemoveAllExcept(keep))
+}
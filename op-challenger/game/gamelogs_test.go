@@ -0,0 +1,105 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameLogHandler_RoutesByGame(t *testing.T) {
+	disk := newDiskManager(t.TempDir())
+	delegate := &testlog.CapturingHandler{Delegate: log.DiscardHandler()}
+	handler := newGameLogHandler(delegate, log.LvlInfo, disk.DirForGame, DefaultGameLogMaxSize)
+	logger := log.New()
+	logger.SetHandler(handler)
+
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	logger.New("game", game1).Info("First game", "a", 1)
+	logger.New("game", game2).Info("Second game")
+	logger.Info("No game")
+	require.NoError(t, handler.CloseAllExcept(nil))
+
+	require.Len(t, delegate.Logs, 3, "should pass all records to delegate")
+	require.Equal(t, []string{"First game"}, readLogMessages(t, filepath.Join(disk.DirForGame(game1), gameLogFile)))
+	require.Equal(t, []string{"Second game"}, readLogMessages(t, filepath.Join(disk.DirForGame(game2), gameLogFile)))
+}
+
+func TestGameLogHandler_FiltersByLevel(t *testing.T) {
+	disk := newDiskManager(t.TempDir())
+	delegate := &testlog.CapturingHandler{Delegate: log.DiscardHandler()}
+	handler := newGameLogHandler(delegate, log.LvlInfo, disk.DirForGame, DefaultGameLogMaxSize)
+	game := common.Address{0xaa}
+	logger := log.New("game", game)
+	logger.SetHandler(handler)
+
+	logger.Debug("Debug")
+	logger.Info("Info")
+	logger.Warn("Warn")
+	require.NoError(t, handler.CloseAllExcept(nil))
+
+	require.Len(t, delegate.Logs, 3, "should leave filtering the delegate's records to the delegate")
+	require.Equal(t, []string{"Info", "Warn"}, readLogMessages(t, filepath.Join(disk.DirForGame(game), gameLogFile)))
+}
+
+func TestGameLogHandler_Rotate(t *testing.T) {
+	disk := newDiskManager(t.TempDir())
+	handler := newGameLogHandler(log.DiscardHandler(), log.LvlInfo, disk.DirForGame, 200)
+	game := common.Address{0xaa}
+	logger := log.New("game", game)
+	logger.SetHandler(handler)
+
+	logger.Info("First")
+	logger.Info("Second")
+	logger.Info("Third")
+	require.NoError(t, handler.CloseAllExcept(nil))
+
+	path := filepath.Join(disk.DirForGame(game), gameLogFile)
+	require.Equal(t, []string{"Second"}, readLogMessages(t, path+".1"))
+	require.Equal(t, []string{"Third"}, readLogMessages(t, path))
+}
+
+func TestGameLogDiskManager_ClosesRemovedGames(t *testing.T) {
+	dir := t.TempDir()
+	disk := newDiskManager(dir)
+	handler := newGameLogHandler(log.DiscardHandler(), log.LvlInfo, disk.DirForGame, DefaultGameLogMaxSize)
+	logDisk := &gameLogDiskManager{DiskManager: disk, logs: handler}
+	keep := common.Address{0xaa}
+	remove := common.Address{0xbb}
+	logger := log.New()
+	logger.SetHandler(handler)
+	logger.Info("Keep", "game", keep)
+	logger.Info("Remove", "game", remove)
+
+	require.NoError(t, logDisk.RemoveAllExcept([]common.Address{keep}))
+	require.Contains(t, handler.files, keep)
+	require.NotContains(t, handler.files, remove)
+	require.DirExists(t, disk.DirForGame(keep))
+	require.NoDirExists(t, disk.DirForGame(remove))
+
+	logger.Info("Late", "game", remove)
+	require.NoDirExists(t, disk.DirForGame(remove), "should not recreate the removed game's directory")
+	require.NotContains(t, handler.files, remove)
+}
+
+func readLogMessages(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var msgs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		msgs = append(msgs, record["msg"].(string))
+	}
+	require.NoError(t, scanner.Err())
+	return msgs
+}
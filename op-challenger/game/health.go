@@ -0,0 +1,169 @@
+package game
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+// subsystemHealth records the outcomes of a subsystem's operations. Outcomes are recorded with atomics so tracking
+// doesn't add locking to the operations being tracked.
+type subsystemHealth struct {
+	// lastSuccess and failingSince are unix nanoseconds, 0 if the subsystem hasn't succeeded or isn't failing.
+	lastSuccess  atomic.Int64
+	failingSince atomic.Int64
+	lastErr      atomic.Pointer[string]
+}
+
+func (s *subsystemHealth) record(now time.Time, err error) {
+	if err == nil {
+		s.lastSuccess.Store(now.UnixNano())
+		s.failingSince.Store(0)
+		s.lastErr.Store(nil)
+		return
+	}
+	msg := err.Error()
+	s.lastErr.Store(&msg)
+	s.failingSince.CompareAndSwap(0, now.UnixNano())
+}
+
+// status reports the subsystem as unhealthy if it has been failing for longer than window.
+// If expectedSince is set, the subsystem must also have succeeded within window of it, or of now if later, so a
+// subsystem that has stopped running is reported as unhealthy.
+func (s *subsystemHealth) status(now time.Time, window time.Duration, expectedSince time.Time) rpc.SubsystemStatus {
+	status := rpc.SubsystemStatus{Healthy: true}
+	if lastSuccess := s.lastSuccess.Load(); lastSuccess != 0 {
+		t := time.Unix(0, lastSuccess).UTC()
+		status.LastSuccess = &t
+		if !expectedSince.IsZero() && t.After(expectedSince) {
+			expectedSince = t
+		}
+	}
+	if failingSince := s.failingSince.Load(); failingSince != 0 {
+		t := time.Unix(0, failingSince).UTC()
+		status.FailingSince = &t
+		if msg := s.lastErr.Load(); msg != nil {
+			status.Error = *msg
+		}
+		status.Healthy = now.Sub(t) <= window
+	}
+	if !expectedSince.IsZero() && now.Sub(expectedSince) > window {
+		status.Healthy = false
+	}
+	return status
+}
+
+type gameHealth struct {
+	claimLoad subsystemHealth
+	prestate  subsystemHealth
+}
+
+// healthTracker records the last success and failure of each of the challenger's subsystems for the health check.
+// The factory is polled continuously so it is unhealthy if it hasn't succeeded within the staleness window, while
+// the other subsystems only run as needed and are unhealthy once they have been failing for the window.
+type healthTracker struct {
+	clock   clock.Clock
+	started time.Time
+
+	factoryPoll subsystemHealth
+	nonceSync   subsystemHealth
+	// games maps the address of each game being played to its *gameHealth.
+	games sync.Map
+}
+
+func newHealthTracker(cl clock.Clock) *healthTracker {
+	return &healthTracker{
+		clock:   cl,
+		started: cl.Now(),
+	}
+}
+
+func (h *healthTracker) RecordFactoryPoll(err error) {
+	h.factoryPoll.record(h.clock.Now(), err)
+}
+
+func (h *healthTracker) RecordNonceSync(err error) {
+	h.nonceSync.record(h.clock.Now(), err)
+}
+
+func (h *healthTracker) RecordClaimLoad(game common.Address, err error) {
+	h.game(game).claimLoad.record(h.clock.Now(), err)
+}
+
+func (h *healthTracker) RecordPrestateValidation(game common.Address, err error) {
+	h.game(game).prestate.record(h.clock.Now(), err)
+}
+
+func (h *healthTracker) RemoveGame(game common.Address) {
+	h.games.Delete(game)
+}
+
+// RemoveAllExcept stops reporting the health of the games not in keep, typically because they are no longer played.
+func (h *healthTracker) RemoveAllExcept(keep []common.Address) {
+	h.games.Range(func(key, _ any) bool {
+		if !slices.Contains(keep, key.(common.Address)) {
+			h.games.Delete(key)
+		}
+		return true
+	})
+}
+
+func (h *healthTracker) game(game common.Address) *gameHealth {
+	if health, ok := h.games.Load(game); ok {
+		return health.(*gameHealth)
+	}
+	health, _ := h.games.LoadOrStore(game, &gameHealth{})
+	return health.(*gameHealth)
+}
+
+// SubsystemHealth reports the health of each subsystem, with those failing for longer than window unhealthy.
+func (h *healthTracker) SubsystemHealth(window time.Duration) rpc.SubsystemsHealth {
+	now := h.clock.Now()
+	health := rpc.SubsystemsHealth{
+		FactoryPoll: h.factoryPoll.status(now, window, h.started),
+		NonceSync:   h.nonceSync.status(now, window, time.Time{}),
+		Games:       make(map[common.Address]rpc.GameHealth),
+	}
+	h.games.Range(func(key, value any) bool {
+		game := value.(*gameHealth)
+		health.Games[key.(common.Address)] = rpc.GameHealth{
+			ClaimLoad:          game.claimLoad.status(now, window, time.Time{}),
+			PrestateValidation: game.prestate.status(now, window, time.Time{}),
+		}
+		return true
+	})
+	return health
+}
+
+// healthDiskManager stops reporting the health of games before their data is removed, so games dropped by the
+// scheduler don't keep affecting the health check.
+type healthDiskManager struct {
+	scheduler.DiskManager
+	health *healthTracker
+}
+
+func (d *healthDiskManager) RemoveAllExcept(keep []common.Address) error {
+	d.health.RemoveAllExcept(keep)
+	return d.DiskManager.RemoveAllExcept(keep)
+}
+
+// nonceHealthBackend wraps a [txmgr.ETHBackend] to record whether the transaction manager can sync its nonce.
+type nonceHealthBackend struct {
+	txmgr.ETHBackend
+	health *healthTracker
+}
+
+func (b *nonceHealthBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	nonce, err := b.ETHBackend.NonceAt(ctx, account, blockNumber)
+	b.health.RecordNonceSync(err)
+	return nonce, err
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthTracker_FactoryPoll(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	health := newHealthTracker(cl)
+	window := time.Minute
+
+	status := health.SubsystemHealth(window).FactoryPoll
+	require.True(t, status.Healthy, "should be healthy at startup")
+	require.Nil(t, status.LastSuccess)
+
+	cl.AdvanceTime(2 * time.Minute)
+	require.False(t, health.SubsystemHealth(window).FactoryPoll.Healthy, "should be unhealthy without a poll since startup")
+
+	health.RecordFactoryPoll(nil)
+	status = health.SubsystemHealth(window).FactoryPoll
+	require.True(t, status.Healthy)
+	require.Equal(t, cl.Now().UTC(), *status.LastSuccess)
+
+	cl.AdvanceTime(30 * time.Second)
+	health.RecordFactoryPoll(errors.New("boom"))
+	status = health.SubsystemHealth(window).FactoryPoll
+	require.True(t, status.Healthy, "should be healthy while failing within the window")
+	require.Equal(t, cl.Now().UTC(), *status.FailingSince)
+	require.Equal(t, "boom", status.Error)
+
+	cl.AdvanceTime(time.Minute)
+	require.False(t, health.SubsystemHealth(window).FactoryPoll.Healthy, "should be unhealthy once failing beyond the window")
+
+	health.RecordFactoryPoll(nil)
+	status = health.SubsystemHealth(window).FactoryPoll
+	require.True(t, status.Healthy, "should recover on success")
+	require.Nil(t, status.FailingSince)
+	require.Empty(t, status.Error)
+
+	cl.AdvanceTime(2 * time.Minute)
+	require.False(t, health.SubsystemHealth(window).FactoryPoll.Healthy, "should be unhealthy when polls stop")
+}
+
+func TestHealthTracker_OnlyUnhealthyWhenFailing(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	health := newHealthTracker(cl)
+	window := time.Minute
+	game := common.Address{0xaa}
+
+	health.RecordNonceSync(nil)
+	health.RecordClaimLoad(game, nil)
+	health.RecordPrestateValidation(game, nil)
+	cl.AdvanceTime(time.Hour)
+	health.RecordFactoryPoll(nil)
+	status := health.SubsystemHealth(window)
+	require.True(t, status.Healthy(), "should not require subsystems that run as needed to succeed within the window")
+
+	health.RecordClaimLoad(game, errors.New("claims"))
+	health.RecordPrestateValidation(game, errors.New("prestate"))
+	health.RecordNonceSync(errors.New("nonce"))
+	cl.AdvanceTime(30 * time.Second)
+	health.RecordFactoryPoll(nil)
+	require.True(t, health.SubsystemHealth(window).Healthy(), "should be healthy while failing within the window")
+
+	// Repeated failures don't reset when the subsystem started failing.
+	health.RecordClaimLoad(game, errors.New("claims again"))
+	cl.AdvanceTime(time.Minute)
+	health.RecordFactoryPoll(nil)
+	status = health.SubsystemHealth(window)
+	require.False(t, status.Healthy())
+	require.False(t, status.NonceSync.Healthy)
+	require.Equal(t, "nonce", status.NonceSync.Error)
+	require.False(t, status.Games[game].ClaimLoad.Healthy)
+	require.Equal(t, "claims again", status.Games[game].ClaimLoad.Error)
+	require.False(t, status.Games[game].PrestateValidation.Healthy)
+	require.Equal(t, "prestate", status.Games[game].PrestateValidation.Error)
+
+	health.RemoveGame(game)
+	health.RecordNonceSync(nil)
+	status = health.SubsystemHealth(window)
+	require.True(t, status.Healthy())
+	require.Empty(t, status.Games)
+}
+
+func TestMonitorRecordsFactoryPolls(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	health := newHealthTracker(cl)
+	source := &stubGameSource{}
+	fetchBlockNum := func(ctx context.Context) (uint64, error) {
+		return 1, nil
+	}
+	monitor := newGameMonitor(testlog.Logger(t, log.LvlCrit), &stubMonitorMetrics{}, cl, source, &stubScheduler{}, time.Duration(0), fetchBlockNum, nil, nil, nil, health, nil, nil)
+	require.NoError(t, monitor.progressGames(context.Background(), 1))
+	require.Equal(t, cl.Now().UTC(), *health.SubsystemHealth(time.Minute).FactoryPoll.LastSuccess)
+}
+
+func TestNonceHealthBackend(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	health := newHealthTracker(cl)
+	stub := &stubNonceBackend{nonce: 5}
+	backend := &nonceHealthBackend{ETHBackend: stub, health: health}
+
+	nonce, err := backend.NonceAt(context.Background(), common.Address{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), nonce)
+	require.NotNil(t, health.SubsystemHealth(time.Minute).NonceSync.LastSuccess)
+
+	stub.err = errors.New("boom")
+	_, err = backend.NonceAt(context.Background(), common.Address{}, nil)
+	require.ErrorIs(t, err, stub.err)
+	require.Equal(t, "boom", health.SubsystemHealth(time.Minute).NonceSync.Error)
+}
+
+type stubNonceBackend struct {
+	txmgr.ETHBackend
+	nonce uint64
+	err   error
+}
+
+func (s *stubNonceBackend) NonceAt(_ context.Context, _ common.Address, _ *big.Int) (uint64, error) {
+	return s.nonce, s.err
+}
+
+func TestHealthDiskManager(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	health := newHealthTracker(cl)
+	keep := common.Address{0xaa}
+	dropped := common.Address{0xbb}
+	health.RecordClaimLoad(keep, nil)
+	health.RecordClaimLoad(dropped, errors.New("claims"))
+	cl.AdvanceTime(time.Hour)
+	health.RecordFactoryPoll(nil)
+	require.False(t, health.SubsystemHealth(time.Minute).Healthy())
+
+	disk := &healthDiskManager{DiskManager: newDiskManager(t.TempDir()), health: health}
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
+	status := health.SubsystemHealth(time.Minute)
+	require.True(t, status.Healthy(), "should not report the health of dropped games")
+	require.Contains(t, status.Games, keep)
+	require.NotContains(t, status.Games, dropped)
+}
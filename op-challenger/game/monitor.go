@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
 )
 
 type blockNumberFetcher func(ctx context.Context) (uint64, error)
@@ -20,45 +22,116 @@ type gameSource interface {
 	FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockNumber *big.Int) ([]FaultDisputeGame, error)
 }
 
+// syncingGameSource is a [gameSource] that may still be loading historical games, in which case
+// the games are progressed again without waiting for a new block so the sync isn't held up.
+type syncingGameSource interface {
+	gameSource
+	Syncing() bool
+}
+
+type monitorMetrics interface {
+	RecordDeniedGameSkipped()
+}
+
+// fundsWatcher checks the challenger has the funds to play its games.
+type fundsWatcher interface {
+	PauseNewGames(ctx context.Context) bool
+}
+
+// gamePrioritizer decides which games are played at the lowest priority.
+type gamePrioritizer interface {
+	Deprioritized(ctx context.Context, game common.Address) bool
+}
+
 type gameScheduler interface {
-	Schedule([]common.Address) error
+	Schedule([]scheduler.Game) error
 }
 
 type gameMonitor struct {
 	logger           log.Logger
+	metrics          monitorMetrics
 	clock            clock.Clock
 	source           gameSource
 	scheduler        gameScheduler
 	gameWindow       time.Duration
 	fetchBlockNumber blockNumberFetcher
-	allowedGames     []common.Address
+	// health records the outcome of each poll of the factory. Nil if health isn't tracked.
+	health *healthTracker
+	// funds is checked each update to decide whether new games can be played. Nil if the balance isn't checked.
+	funds fundsWatcher
+	// prioritizer decides which games are deprioritized. Nil if no games are deprioritized.
+	prioritizer gamePrioritizer
+	// playing are the games scheduled by the last update, which keep being played while new games are paused.
+	playing map[common.Address]bool
+
+	// listsLock guards the allow and deny lists, which may be replaced while games are being monitored.
+	listsLock    sync.Mutex
+	allowedGames []common.Address
+	deniedGames  []common.Address
+	// deniedLogged are the denied games found by the last update, so each is only logged and counted once.
+	deniedLogged map[common.Address]bool
+
+	// gameTypes are the game types that can be played. Empty if all game types can be played.
+	gameTypes []uint8
+	// unsupportedGames are the games of unsupported types found by the last update, so each is only warned about once.
+	unsupportedGames map[common.Address]bool
 }
 
 func newGameMonitor(
 	logger log.Logger,
+	m monitorMetrics,
 	cl clock.Clock,
 	source gameSource,
 	scheduler gameScheduler,
 	gameWindow time.Duration,
 	fetchBlockNumber blockNumberFetcher,
 	allowedGames []common.Address,
+	deniedGames []common.Address,
+	gameTypes []uint8,
+	health *healthTracker,
+	funds fundsWatcher,
+	prioritizer gamePrioritizer,
 ) *gameMonitor {
 	return &gameMonitor{
 		logger:           logger,
+		metrics:          m,
 		clock:            cl,
 		scheduler:        scheduler,
 		source:           source,
 		gameWindow:       gameWindow,
 		fetchBlockNumber: fetchBlockNumber,
+		health:           health,
+		funds:            funds,
+		prioritizer:      prioritizer,
+		playing:          make(map[common.Address]bool),
 		allowedGames:     allowedGames,
+		deniedGames:      deniedGames,
+		deniedLogged:     make(map[common.Address]bool),
+		gameTypes:        gameTypes,
+		unsupportedGames: make(map[common.Address]bool),
 	}
 }
 
-func (m *gameMonitor) allowedGame(game common.Address) bool {
-	if len(m.allowedGames) == 0 {
+// SetGameLists replaces the allow and deny lists. Games that are no longer allowed stop being scheduled from
+// the next update, with any in-flight progression of them completing first.
+func (m *gameMonitor) SetGameLists(allowedGames []common.Address, deniedGames []common.Address) {
+	m.listsLock.Lock()
+	defer m.listsLock.Unlock()
+	m.allowedGames = allowedGames
+	m.deniedGames = deniedGames
+}
+
+func (m *gameMonitor) gameLists() ([]common.Address, []common.Address) {
+	m.listsLock.Lock()
+	defer m.listsLock.Unlock()
+	return m.allowedGames, m.deniedGames
+}
+
+func allowedGame(allowedGames []common.Address, game common.Address) bool {
+	if len(allowedGames) == 0 {
 		return true
 	}
-	for _, allowed := range m.allowedGames {
+	for _, allowed := range allowedGames {
 		if allowed == game {
 			return true
 		}
@@ -66,6 +139,13 @@ func (m *gameMonitor) allowedGame(game common.Address) bool {
 	return false
 }
 
+func (m *gameMonitor) supportedGameType(gameType uint8) bool {
+	if len(m.gameTypes) == 0 {
+		return true
+	}
+	return slices.Contains(m.gameTypes, gameType)
+}
+
 func (m *gameMonitor) minGameTimestamp() uint64 {
 	if m.gameWindow.Seconds() == 0 {
 		return 0
@@ -80,25 +160,84 @@ func (m *gameMonitor) minGameTimestamp() uint64 {
 
 func (m *gameMonitor) progressGames(ctx context.Context, blockNum uint64) error {
 	games, err := m.source.FetchAllGamesAtBlock(ctx, m.minGameTimestamp(), new(big.Int).SetUint64(blockNum))
+	m.recordFactoryPoll(err)
 	if err != nil {
 		return fmt.Errorf("failed to load games: %w", err)
 	}
-	var gamesToPlay []common.Address
+	allowedGames, deniedGames := m.gameLists()
+	var gamesToPlay []scheduler.Game
+	unsupportedGames := make(map[common.Address]bool)
+	deniedLogged := make(map[common.Address]bool)
 	for _, game := range games {
-		if !m.allowedGame(game.Proxy) {
+		if slices.Contains(deniedGames, game.Proxy) {
+			if !m.deniedLogged[game.Proxy] {
+				m.logger.Info("Skipping game on deny list", "game", game.Proxy)
+				m.metrics.RecordDeniedGameSkipped()
+			}
+			deniedLogged[game.Proxy] = true
+			continue
+		}
+		if !allowedGame(allowedGames, game.Proxy) {
 			m.logger.Debug("Skipping game not on allow list", "game", game.Proxy)
 			continue
 		}
-		gamesToPlay = append(gamesToPlay, game.Proxy)
+		if !m.supportedGameType(game.GameType) {
+			if !m.unsupportedGames[game.Proxy] {
+				m.logger.Warn("Skipping game with unsupported game type", "game", game.Proxy, "game_type", game.GameType)
+			}
+			unsupportedGames[game.Proxy] = true
+			continue
+		}
+		gamesToPlay = append(gamesToPlay, scheduler.Game{Addr: game.Proxy, Factory: game.Factory})
+	}
+	m.unsupportedGames = unsupportedGames
+	m.deniedLogged = deniedLogged
+	if m.funds != nil && m.funds.PauseNewGames(ctx) {
+		gamesToPlay = m.withoutNewGames(gamesToPlay)
+	}
+	if m.prioritizer != nil {
+		for i, game := range gamesToPlay {
+			gamesToPlay[i].Deprioritized = m.prioritizer.Deprioritized(ctx, game.Addr)
+		}
 	}
 	if err := m.scheduler.Schedule(gamesToPlay); errors.Is(err, scheduler.ErrBusy) {
 		m.logger.Info("Scheduler still busy with previous update")
 	} else if err != nil {
 		return fmt.Errorf("failed to schedule games: %w", err)
+	} else {
+		playing := make(map[common.Address]bool, len(gamesToPlay))
+		for _, game := range gamesToPlay {
+			playing[game.Addr] = true
+		}
+		m.playing = playing
 	}
 	return nil
 }
 
+// withoutNewGames returns the games that were scheduled by the last update, so no new games are played.
+func (m *gameMonitor) withoutNewGames(games []scheduler.Game) []scheduler.Game {
+	var existing []scheduler.Game
+	for _, game := range games {
+		if !m.playing[game.Addr] {
+			m.logger.Debug("Not playing new game while underfunded", "game", game.Addr)
+			continue
+		}
+		existing = append(existing, game)
+	}
+	return existing
+}
+
+func (m *gameMonitor) syncing() bool {
+	source, ok := m.source.(syncingGameSource)
+	return ok && source.Syncing()
+}
+
+func (m *gameMonitor) recordFactoryPoll(err error) {
+	if m.health != nil {
+		m.health.RecordFactoryPoll(err)
+	}
+}
+
 func (m *gameMonitor) MonitorGames(ctx context.Context) error {
 	m.logger.Info("Monitoring fault dispute games")
 
@@ -111,9 +250,10 @@ func (m *gameMonitor) MonitorGames(ctx context.Context) error {
 			nextBlockNum, err := m.fetchBlockNumber(ctx)
 			if err != nil {
 				m.logger.Error("Failed to load current block number", "err", err)
+				m.recordFactoryPoll(err)
 				continue
 			}
-			if nextBlockNum > blockNum {
+			if nextBlockNum > blockNum || m.syncing() {
 				blockNum = nextBlockNum
 				if err := m.progressGames(ctx, nextBlockNum); err != nil {
 					m.logger.Error("Failed to progress games", "err", err)
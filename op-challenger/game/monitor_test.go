@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
@@ -69,6 +71,15 @@ func TestMonitorCreateAndProgressGameAgents(t *testing.T) {
 	require.Equal(t, []common.Address{addr1, addr2}, sched.scheduled[0])
 }
 
+func TestMonitorSchedulesGameFactory(t *testing.T) {
+	monitor, source, sched := setupMonitorTest(t, []common.Address{})
+	factory := common.Address{0xff}
+	source.games = []FaultDisputeGame{{Proxy: common.Address{0xaa}, Timestamp: 9999, Factory: factory}}
+
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+	require.Equal(t, [][]scheduler.Game{{{Addr: common.Address{0xaa}, Factory: factory}}}, sched.games)
+}
+
 func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	addr1 := common.Address{0xaa}
 	addr2 := common.Address{0xbb}
@@ -91,6 +102,158 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, []common.Address{addr2}, sched.scheduled[0])
 }
 
+func TestMonitorSkipDeniedGames(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	addr3 := common.Address{0xcc}
+	games := []FaultDisputeGame{
+		{Proxy: addr1, Timestamp: 9999},
+		{Proxy: addr2, Timestamp: 9999},
+		{Proxy: addr3, Timestamp: 9999},
+	}
+
+	t.Run("DenyWinsOverAllow", func(t *testing.T) {
+		monitor, source, sched := setupMonitorTest(t, []common.Address{addr1, addr2})
+		monitor.deniedGames = []common.Address{addr2}
+		source.games = games
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+		require.Equal(t, [][]common.Address{{addr1}}, sched.scheduled)
+	})
+
+	t.Run("LogOnce", func(t *testing.T) {
+		monitor, source, sched := setupMonitorTest(t, []common.Address{})
+		handler := &testlog.CapturingHandler{Delegate: monitor.logger.GetHandler()}
+		monitor.logger.SetHandler(handler)
+		monitor.deniedGames = []common.Address{addr2}
+		source.games = games
+
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+		msg := handler.FindLog(log.LvlInfo, "Skipping game on deny list")
+		require.NotNil(t, msg)
+		require.Equal(t, addr2, msg.GetContextValue("game"))
+
+		handler.Clear()
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(2)))
+		require.Nil(t, handler.FindLog(log.LvlInfo, "Skipping game on deny list"), "should only log once")
+		require.Equal(t, [][]common.Address{{addr1, addr3}, {addr1, addr3}}, sched.scheduled)
+	})
+
+	t.Run("NotLoaded", func(t *testing.T) {
+		monitor, source, sched := setupMonitorTest(t, []common.Address{})
+		m := &stubMonitorMetrics{}
+		monitor.metrics = m
+		monitor.deniedGames = []common.Address{addr1, addr3}
+		source.games = games
+
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(2)))
+		// Players, and so their claim loaders, are only created for scheduled games.
+		require.Equal(t, [][]scheduler.Game{{{Addr: addr2}}, {{Addr: addr2}}}, sched.games)
+		require.Equal(t, 2, m.deniedSkipped, "should count each denied game once")
+	})
+
+	t.Run("DenyRunningGame", func(t *testing.T) {
+		monitor, source, sched := setupMonitorTest(t, []common.Address{})
+		source.games = games
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+
+		monitor.SetGameLists(nil, []common.Address{addr1})
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(2)))
+
+		monitor.SetGameLists([]common.Address{addr1, addr3}, nil)
+		require.NoError(t, monitor.progressGames(context.Background(), uint64(3)))
+		require.Equal(t, [][]common.Address{{addr1, addr2, addr3}, {addr2, addr3}, {addr1, addr3}}, sched.scheduled)
+	})
+}
+
+func TestMonitorPauseNewGames(t *testing.T) {
+	monitor, source, sched := setupMonitorTest(t, []common.Address{})
+	funds := &stubFundsWatcher{}
+	monitor.funds = funds
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	source.games = []FaultDisputeGame{{Proxy: addr1, Timestamp: 9999}}
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+
+	funds.pause = true
+	source.games = append(source.games, FaultDisputeGame{Proxy: addr2, Timestamp: 9999})
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(2)))
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(3)))
+
+	funds.pause = false
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(4)))
+	require.Equal(t, [][]common.Address{{addr1}, {addr1}, {addr1}, {addr1, addr2}}, sched.scheduled)
+}
+
+func TestMonitorDeprioritizeGames(t *testing.T) {
+	monitor, source, sched := setupMonitorTest(t, []common.Address{})
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor.prioritizer = &stubPrioritizer{deprioritized: map[common.Address]bool{addr2: true}}
+	source.games = []FaultDisputeGame{{Proxy: addr1, Timestamp: 9999}, {Proxy: addr2, Timestamp: 9999}}
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+	require.Equal(t, [][]scheduler.Game{{{Addr: addr1}, {Addr: addr2, Deprioritized: true}}}, sched.games)
+}
+
+func TestMonitorOnlyScheduleSupportedGameTypes(t *testing.T) {
+	monitor, source, sched := setupMonitorTest(t, []common.Address{})
+	handler := &testlog.CapturingHandler{Delegate: monitor.logger.GetHandler()}
+	monitor.logger.SetHandler(handler)
+	monitor.gameTypes = []uint8{config.AlphabetFaultGameID}
+
+	cannonGame := common.Address{0xaa}
+	alphabetGame := common.Address{0xbb}
+	source.games = []FaultDisputeGame{
+		{
+			GameType:  config.CannonFaultGameID,
+			Proxy:     cannonGame,
+			Timestamp: 9999,
+		},
+		{
+			GameType:  config.AlphabetFaultGameID,
+			Proxy:     alphabetGame,
+			Timestamp: 9999,
+		},
+	}
+
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(1)))
+	msg := handler.FindLog(log.LvlWarn, "Skipping game with unsupported game type")
+	require.NotNil(t, msg)
+	require.Equal(t, cannonGame, msg.GetContextValue("game"))
+
+	handler.Clear()
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(2)))
+	require.Nil(t, handler.FindLog(log.LvlWarn, "Skipping game with unsupported game type"), "should only warn once")
+	require.Equal(t, [][]common.Address{{alphabetGame}, {alphabetGame}}, sched.scheduled)
+}
+
+func TestMonitorProgressesWhileSyncing(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	source := &stubSyncingGameSource{syncingFetches: 2}
+	sched := &stubScheduler{}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fetchBlockNum := func(ctx context.Context) (uint64, error) {
+		return 1, nil
+	}
+	monitor := newGameMonitor(logger, &stubMonitorMetrics{}, cl, source, sched, time.Duration(0), fetchBlockNum, nil, nil, nil, nil, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = monitor.MonitorGames(ctx)
+	}()
+	for i := 0; i < 3; i++ {
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+		cl.AdvanceTime(time.Second)
+	}
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+	cancel()
+	<-done
+	// Progressed for the first block and again while syncing, then waits for the next block.
+	require.Len(t, sched.scheduled, 2)
+}
+
 func setupMonitorTest(t *testing.T, allowedGames []common.Address) (*gameMonitor, *stubGameSource, *stubScheduler) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	source := &stubGameSource{}
@@ -100,7 +263,7 @@ func setupMonitorTest(t *testing.T, allowedGames []common.Address) (*gameMonitor
 		return i, nil
 	}
 	sched := &stubScheduler{}
-	monitor := newGameMonitor(logger, clock.SystemClock, source, sched, time.Duration(0), fetchBlockNum, allowedGames)
+	monitor := newGameMonitor(logger, &stubMonitorMetrics{}, clock.SystemClock, source, sched, time.Duration(0), fetchBlockNum, allowedGames, nil, nil, nil, nil, nil)
 	return monitor, source, sched
 }
 
@@ -112,11 +275,57 @@ func (s *stubGameSource) FetchAllGamesAtBlock(ctx context.Context, earliest uint
 	return s.games, nil
 }
 
+// stubSyncingGameSource reports that it is syncing until it has been fetched from syncingFetches times.
+type stubSyncingGameSource struct {
+	stubGameSource
+	fetches        int
+	syncingFetches int
+}
+
+func (s *stubSyncingGameSource) FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockNumber *big.Int) ([]FaultDisputeGame, error) {
+	s.fetches++
+	return s.stubGameSource.FetchAllGamesAtBlock(ctx, earliest, blockNumber)
+}
+
+func (s *stubSyncingGameSource) Syncing() bool {
+	return s.fetches < s.syncingFetches
+}
+
+type stubFundsWatcher struct {
+	pause bool
+}
+
+func (s *stubFundsWatcher) PauseNewGames(_ context.Context) bool {
+	return s.pause
+}
+
+type stubPrioritizer struct {
+	deprioritized map[common.Address]bool
+}
+
+func (s *stubPrioritizer) Deprioritized(_ context.Context, game common.Address) bool {
+	return s.deprioritized[game]
+}
+
+type stubMonitorMetrics struct {
+	deniedSkipped int
+}
+
+func (s *stubMonitorMetrics) RecordDeniedGameSkipped() {
+	s.deniedSkipped++
+}
+
 type stubScheduler struct {
 	scheduled [][]common.Address
+	games     [][]scheduler.Game
 }
 
-func (s *stubScheduler) Schedule(games []common.Address) error {
-	s.scheduled = append(s.scheduled, games)
+func (s *stubScheduler) Schedule(games []scheduler.Game) error {
+	var addrs []common.Address
+	for _, game := range games {
+		addrs = append(addrs, game.Addr)
+	}
+	s.scheduled = append(s.scheduled, addrs)
+	s.games = append(s.games, games)
 	return nil
 }
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// factorySource is the [gameSource] for the games of a single dispute game factory.
+type factorySource struct {
+	factory common.Address
+	source  gameSource
+}
+
+// multiFactorySource is a [gameSource] that loads the games of each of a list of dispute game factories, setting
+// the Factory of every game it returns.
+// If some factories fail to load, the games loaded for them by the last successful update are returned along with
+// the games of the other factories, so a single unavailable factory doesn't stop every game being played and its own
+// games aren't dropped. An error is only returned if every factory fails.
+// It is not safe for concurrent use.
+type multiFactorySource struct {
+	logger  log.Logger
+	sources []factorySource
+	// loaded are the games last loaded from each factory.
+	loaded map[common.Address][]FaultDisputeGame
+}
+
+func newMultiFactorySource(logger log.Logger, sources []factorySource) *multiFactorySource {
+	return &multiFactorySource{
+		logger:  logger,
+		sources: sources,
+		loaded:  make(map[common.Address][]FaultDisputeGame),
+	}
+}
+
+// Syncing returns true while any of the factories is still loading historical games.
+func (s *multiFactorySource) Syncing() bool {
+	for _, source := range s.sources {
+		if syncing, ok := source.source.(syncingGameSource); ok && syncing.Syncing() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *multiFactorySource) FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockNumber *big.Int) ([]FaultDisputeGame, error) {
+	var all []FaultDisputeGame
+	var errs []error
+	for _, source := range s.sources {
+		games, err := source.source.FetchAllGamesAtBlock(ctx, earliest, blockNumber)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("factory %v: %w", source.factory, err))
+			all = append(all, s.loaded[source.factory]...)
+			continue
+		}
+		for i := range games {
+			games[i].Factory = source.factory
+		}
+		s.loaded[source.factory] = games
+		all = append(all, games...)
+	}
+	if len(errs) == len(s.sources) {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		s.logger.Error("Failed to load games from factory, using previously loaded games", "err", err)
+	}
+	return all, nil
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiFactorySource(t *testing.T) {
+	factory1 := common.Address{0xf1}
+	factory2 := common.Address{0xf2}
+	setup := func(t *testing.T) (*multiFactorySource, *erroringGameSource, *erroringGameSource, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+		logger.SetHandler(handler)
+		source1 := &erroringGameSource{stubGameSource: stubGameSource{games: []FaultDisputeGame{{Proxy: common.Address{0xaa}}}}}
+		source2 := &erroringGameSource{stubGameSource: stubGameSource{games: []FaultDisputeGame{{Proxy: common.Address{0xbb}}, {Proxy: common.Address{0xcc}}}}}
+		source := newMultiFactorySource(logger, []factorySource{
+			{factory: factory1, source: source1},
+			{factory: factory2, source: source2},
+		})
+		return source, source1, source2, handler
+	}
+	expected := []FaultDisputeGame{
+		{Proxy: common.Address{0xaa}, Factory: factory1},
+		{Proxy: common.Address{0xbb}, Factory: factory2},
+		{Proxy: common.Address{0xcc}, Factory: factory2},
+	}
+
+	t.Run("TagsFactory", func(t *testing.T) {
+		source, _, _, _ := setup(t)
+		games, err := source.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, expected, games)
+	})
+
+	t.Run("UsesPreviousGamesOnFailure", func(t *testing.T) {
+		source, _, source2, handler := setup(t)
+		_, err := source.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(1))
+		require.NoError(t, err)
+
+		source2.err = errors.New("boom")
+		source2.games = nil
+		games, err := source.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(2))
+		require.NoError(t, err, "should not fail while some factories load")
+		require.Equal(t, expected, games, "should keep playing the games of the failed factory")
+		require.NotNil(t, handler.FindLog(log.LvlError, "Failed to load games from factory, using previously loaded games"))
+	})
+
+	t.Run("AllFail", func(t *testing.T) {
+		source, source1, source2, _ := setup(t)
+		source1.err = errors.New("boom1")
+		source2.err = errors.New("boom2")
+		_, err := source.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(1))
+		require.ErrorIs(t, err, source1.err)
+		require.ErrorIs(t, err, source2.err)
+	})
+
+	t.Run("Syncing", func(t *testing.T) {
+		syncing := &stubSyncingGameSource{syncingFetches: 1}
+		source := newMultiFactorySource(testlog.Logger(t, log.LvlInfo), []factorySource{
+			{factory: factory1, source: &stubGameSource{}},
+			{factory: factory2, source: syncing},
+		})
+		require.True(t, source.Syncing(), "should sync while any factory is syncing")
+		_, err := source.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(1))
+		require.NoError(t, err)
+		require.False(t, source.Syncing())
+	})
+}
+
+type erroringGameSource struct {
+	stubGameSource
+	err error
+}
+
+func (s *erroringGameSource) FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockNumber *big.Int) ([]FaultDisputeGame, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stubGameSource.FetchAllGamesAtBlock(ctx, earliest, blockNumber)
+}
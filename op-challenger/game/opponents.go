@@ -0,0 +1,284 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+// creatorFetcher returns the address that created game, which is the claimant of its root claim.
+type creatorFetcher func(ctx context.Context, game common.Address) (common.Address, error)
+
+type opponentMetricer interface {
+	RecordOpponentGames(creator common.Address, created int, won int, lost int)
+	RecordOtherOpponentGames(created int, won int, lost int)
+	DeleteOpponentGames(creator common.Address)
+	RecordGameDeprioritized()
+}
+
+// opponentMetricsLimit is the number of addresses that created the most games whose games are recorded individually,
+// in addition to those that are deprioritized. The creator is chosen by whoever creates the game, so the games of
+// the remaining addresses are combined to limit the number of series.
+const opponentMetricsLimit = 20
+
+// opponentGame is a game recorded in the result store, used to restore the record of each opponent on restart.
+type opponentGame struct {
+	Game    common.Address
+	Creator common.Address
+	// Status is the status the game resolved with. Empty if it hasn't resolved.
+	Status     string
+	ResolvedAt time.Time
+}
+
+type opponentRecord struct {
+	created int
+	won     int
+	lost    int
+	// losses are when the games lost within the window resolved, oldest first.
+	losses []time.Time
+}
+
+// opponentTracker records the games created by each address and how they resolved, so addresses that repeatedly
+// create games that lose, such as spam games that cost gas to respond to, can be found.
+// If maxLosses is not 0, the new games of an address that has lost more than maxLosses games within the window are
+// deprioritized. They are still played, because they must be responded to, but only after the other games.
+type opponentTracker struct {
+	logger       log.Logger
+	metrics      opponentMetricer
+	clock        clock.Clock
+	events       types.EventSink
+	fetchCreator creatorFetcher
+	maxLosses    uint64
+	window       time.Duration
+	metricsLimit int
+
+	mu        sync.Mutex
+	creators  map[common.Address]common.Address
+	resolved  map[common.Address]bool
+	opponents map[common.Address]*opponentRecord
+	// decided is whether each game was deprioritized when it was found, which stays the same while it is played.
+	decided map[common.Address]bool
+	// recorded are the addresses whose games are recorded individually in the metrics.
+	recorded map[common.Address]bool
+}
+
+// newOpponentTracker creates an [opponentTracker] restored from history, that emits the creator of each new game
+// it finds to events so the creators are recorded in the result store. A window of 0 counts all losses.
+func newOpponentTracker(logger log.Logger, m opponentMetricer, cl clock.Clock, events types.EventSink, fetchCreator creatorFetcher, maxLosses uint64, window time.Duration, history []opponentGame) *opponentTracker {
+	t := &opponentTracker{
+		logger:       logger,
+		metrics:      m,
+		clock:        cl,
+		events:       events,
+		fetchCreator: fetchCreator,
+		maxLosses:    maxLosses,
+		window:       window,
+		metricsLimit: opponentMetricsLimit,
+		creators:     make(map[common.Address]common.Address),
+		resolved:     make(map[common.Address]bool),
+		opponents:    make(map[common.Address]*opponentRecord),
+		decided:      make(map[common.Address]bool),
+		recorded:     make(map[common.Address]bool),
+	}
+	for _, game := range history {
+		t.created(game.Game, game.Creator)
+		if game.Status != "" {
+			t.recordResolved(game.Game, game.Status, game.ResolvedAt)
+		}
+	}
+	t.recordMetrics()
+	return t
+}
+
+// Deprioritized returns true if game should be played at the lowest priority, fetching its creator the first
+// time the game is found. Games whose creator can't be fetched aren't deprioritized, and the creator is fetched
+// again the next time.
+func (t *opponentTracker) Deprioritized(ctx context.Context, game common.Address) bool {
+	t.mu.Lock()
+	if deprioritized, ok := t.decided[game]; ok {
+		t.mu.Unlock()
+		return deprioritized
+	}
+	creator, known := t.creators[game]
+	t.mu.Unlock()
+	if !known {
+		var err error
+		creator, err = t.fetchCreator(ctx, game)
+		if err != nil {
+			t.logger.Warn("Failed to fetch the creator of game", "game", game, "err", err)
+			return false
+		}
+		t.recordCreated(game, creator)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	recent := t.recentLosses(creator)
+	deprioritized := t.maxLosses > 0 && uint64(recent) > t.maxLosses
+	t.decided[game] = deprioritized
+	if deprioritized {
+		t.logger.Info("Deprioritizing game created by address that lost too many games",
+			"game", game, "creator", creator, "recent_losses", recent, "window", t.window)
+		t.metrics.RecordGameDeprioritized()
+	}
+	return deprioritized
+}
+
+func (t *opponentTracker) recordCreated(game common.Address, creator common.Address) {
+	t.mu.Lock()
+	isNew := t.created(game, creator)
+	if isNew {
+		t.recordMetrics()
+	}
+	t.mu.Unlock()
+	if isNew {
+		t.events.Emit(types.Event{Type: types.EventGameCreated, Game: game, Creator: &creator})
+	}
+}
+
+// created records creator as the creator of game, returning false if it was already recorded.
+// Must be called with the lock held.
+func (t *opponentTracker) created(game common.Address, creator common.Address) bool {
+	if _, ok := t.creators[game]; ok {
+		return false
+	}
+	t.creators[game] = creator
+	t.opponent(creator).created++
+	return true
+}
+
+// Emit records the outcome of resolved games, ignoring other events.
+func (t *opponentTracker) Emit(event types.Event) {
+	if event.Type != types.EventGameResolved {
+		return
+	}
+	resolvedAt := event.Time
+	if event.ResolvedAt != nil {
+		resolvedAt = *event.ResolvedAt
+	} else if resolvedAt.IsZero() {
+		resolvedAt = t.clock.Now()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.recordResolved(event.Game, event.Status, resolvedAt); ok {
+		t.recordMetrics()
+	}
+}
+
+// recordResolved records the outcome of game for its creator, returning the creator and true if it was recorded.
+// Games already recorded as resolved, that resolved with an unknown status or whose creator isn't known are ignored.
+// Must be called with the lock held.
+func (t *opponentTracker) recordResolved(game common.Address, status string, resolvedAt time.Time) (common.Address, bool) {
+	creator, ok := t.creators[game]
+	if !ok || t.resolved[game] {
+		return common.Address{}, false
+	}
+	record := t.opponent(creator)
+	switch status {
+	case types.GameStatusDefenderWon.String():
+		record.won++
+	case types.GameStatusChallengerWon.String():
+		record.lost++
+		record.losses = append(record.losses, resolvedAt)
+		slices.SortFunc(record.losses, func(a, b time.Time) bool {
+			return a.Before(b)
+		})
+	default:
+		return common.Address{}, false
+	}
+	t.resolved[game] = true
+	return creator, true
+}
+
+// recentLosses returns the number of games creator lost within the window, forgetting older losses.
+// Must be called with the lock held.
+func (t *opponentTracker) recentLosses(creator common.Address) int {
+	record, ok := t.opponents[creator]
+	if !ok {
+		return 0
+	}
+	if t.window == 0 {
+		return len(record.losses)
+	}
+	cutoff := t.clock.Now().Add(-t.window)
+	i := 0
+	for i < len(record.losses) && record.losses[i].Before(cutoff) {
+		i++
+	}
+	record.losses = record.losses[i:]
+	return len(record.losses)
+}
+
+// opponent returns the record of creator, adding it if needed. Must be called with the lock held.
+func (t *opponentTracker) opponent(creator common.Address) *opponentRecord {
+	record, ok := t.opponents[creator]
+	if !ok {
+		record = &opponentRecord{}
+		t.opponents[creator] = record
+	}
+	return record
+}
+
+// recordMetrics records the games of the metricsLimit addresses that created the most games and of the
+// deprioritized addresses, combining the games of the other addresses. Must be called with the lock held.
+func (t *opponentTracker) recordMetrics() {
+	creators := make([]common.Address, 0, len(t.opponents))
+	for creator := range t.opponents {
+		creators = append(creators, creator)
+	}
+	slices.SortFunc(creators, func(a, b common.Address) bool {
+		if createdA, createdB := t.opponents[a].created, t.opponents[b].created; createdA != createdB {
+			return createdA > createdB
+		}
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	recorded := make(map[common.Address]bool)
+	var other opponentRecord
+	for i, creator := range creators {
+		record := t.opponents[creator]
+		if i >= t.metricsLimit && (t.maxLosses == 0 || uint64(t.recentLosses(creator)) <= t.maxLosses) {
+			other.created += record.created
+			other.won += record.won
+			other.lost += record.lost
+			continue
+		}
+		recorded[creator] = true
+		t.metrics.RecordOpponentGames(creator, record.created, record.won, record.lost)
+	}
+	for creator := range t.recorded {
+		if !recorded[creator] {
+			t.metrics.DeleteOpponentGames(creator)
+		}
+	}
+	t.recorded = recorded
+	t.metrics.RecordOtherOpponentGames(other.created, other.won, other.lost)
+}
+
+// Opponents returns the record of each address that created a game, ordered by address.
+func (t *opponentTracker) Opponents() []types.OpponentSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	summaries := make([]types.OpponentSummary, 0, len(t.opponents))
+	for creator, record := range t.opponents {
+		recent := t.recentLosses(creator)
+		summaries = append(summaries, types.OpponentSummary{
+			Creator:       creator,
+			Created:       record.created,
+			Won:           record.won,
+			Lost:          record.lost,
+			RecentLosses:  recent,
+			Deprioritized: t.maxLosses > 0 && uint64(recent) > t.maxLosses,
+		})
+	}
+	slices.SortFunc(summaries, func(a, b types.OpponentSummary) bool {
+		return bytes.Compare(a.Creator[:], b.Creator[:]) < 0
+	})
+	return summaries
+}
@@ -0,0 +1,201 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpponentTracker(t *testing.T) {
+	ctx := context.Background()
+	spammer := common.Address{0x5a}
+	honest := common.Address{0x40}
+	challengerWon := types.GameStatusChallengerWon.String()
+	defenderWon := types.GameStatusDefenderWon.String()
+
+	type fixture struct {
+		tracker  *opponentTracker
+		creators map[common.Address]common.Address
+		fetches  map[common.Address]int
+		events   *recordingEventSink
+		metrics  *stubOpponentMetrics
+		clock    *clock.DeterministicClock
+	}
+	setup := func(t *testing.T, maxLosses uint64, history []opponentGame) *fixture {
+		f := &fixture{
+			creators: make(map[common.Address]common.Address),
+			fetches:  make(map[common.Address]int),
+			events:   &recordingEventSink{},
+			metrics:  &stubOpponentMetrics{games: make(map[common.Address][3]int)},
+			clock:    clock.NewDeterministicClock(time.Unix(100_000, 0)),
+		}
+		fetch := func(_ context.Context, game common.Address) (common.Address, error) {
+			f.fetches[game]++
+			creator, ok := f.creators[game]
+			if !ok {
+				return common.Address{}, errors.New("boom")
+			}
+			return creator, nil
+		}
+		f.tracker = newOpponentTracker(testlog.Logger(t, log.LvlInfo), f.metrics, f.clock, f.events, fetch, maxLosses, time.Hour, history)
+		return f
+	}
+	// play finds a new game created by creator and resolves it with status, returning whether it was deprioritized.
+	play := func(f *fixture, game common.Address, creator common.Address, status string) bool {
+		f.creators[game] = creator
+		deprioritized := f.tracker.Deprioritized(ctx, game)
+		f.tracker.Emit(types.Event{Type: types.EventGameResolved, Time: f.clock.Now(), Game: game, Status: status})
+		return deprioritized
+	}
+
+	t.Run("RecordCreatorOnce", func(t *testing.T) {
+		f := setup(t, 0, nil)
+		game := common.Address{0xaa}
+		f.creators[game] = spammer
+		require.False(t, f.tracker.Deprioritized(ctx, game))
+		require.False(t, f.tracker.Deprioritized(ctx, game))
+		require.Equal(t, 1, f.fetches[game])
+		require.Equal(t, []types.Event{{Type: types.EventGameCreated, Game: game, Creator: &spammer}}, f.events.events)
+		require.Equal(t, [3]int{1, 0, 0}, f.metrics.games[spammer])
+	})
+
+	t.Run("RetryFailedFetch", func(t *testing.T) {
+		f := setup(t, 0, nil)
+		game := common.Address{0xaa}
+		require.False(t, f.tracker.Deprioritized(ctx, game))
+		f.creators[game] = spammer
+		require.False(t, f.tracker.Deprioritized(ctx, game))
+		require.Equal(t, 2, f.fetches[game])
+		require.Len(t, f.events.events, 1)
+	})
+
+	t.Run("RecordOutcomes", func(t *testing.T) {
+		f := setup(t, 0, nil)
+		play(f, common.Address{0x01}, spammer, challengerWon)
+		play(f, common.Address{0x02}, spammer, challengerWon)
+		play(f, common.Address{0x03}, spammer, defenderWon)
+		play(f, common.Address{0x04}, honest, defenderWon)
+		// Resolving again, or with a status that isn't final, isn't counted.
+		f.tracker.Emit(types.Event{Type: types.EventGameResolved, Game: common.Address{0x01}, Status: challengerWon})
+		f.creators[common.Address{0x05}] = honest
+		f.tracker.Deprioritized(ctx, common.Address{0x05})
+		f.tracker.Emit(types.Event{Type: types.EventGameResolved, Game: common.Address{0x05}, Status: types.GameStatusInProgress.String()})
+
+		require.Equal(t, []types.OpponentSummary{
+			{Creator: honest, Created: 2, Won: 1},
+			{Creator: spammer, Created: 3, Won: 1, Lost: 2, RecentLosses: 2},
+		}, f.tracker.Opponents())
+		require.Equal(t, [3]int{3, 1, 2}, f.metrics.games[spammer])
+		require.Equal(t, [3]int{2, 1, 0}, f.metrics.games[honest])
+	})
+
+	t.Run("DeprioritizeNewGamesAfterLosses", func(t *testing.T) {
+		f := setup(t, 2, nil)
+		require.False(t, play(f, common.Address{0x01}, spammer, challengerWon))
+		require.False(t, play(f, common.Address{0x02}, spammer, challengerWon))
+		inProgress := common.Address{0x03}
+		require.False(t, play(f, inProgress, spammer, challengerWon), "should only deprioritize after more than 2 losses")
+
+		game := common.Address{0x04}
+		f.creators[game] = spammer
+		require.True(t, f.tracker.Deprioritized(ctx, game))
+		require.True(t, f.tracker.Deprioritized(ctx, game), "should stay deprioritized")
+		require.False(t, f.tracker.Deprioritized(ctx, inProgress), "should not change the priority of games already found")
+		require.Equal(t, 1, f.metrics.deprioritized)
+		require.True(t, f.tracker.Opponents()[0].Deprioritized)
+
+		other := common.Address{0x05}
+		f.creators[other] = honest
+		require.False(t, f.tracker.Deprioritized(ctx, other))
+	})
+
+	t.Run("ForgetLossesOutsideWindow", func(t *testing.T) {
+		f := setup(t, 1, nil)
+		play(f, common.Address{0x01}, spammer, challengerWon)
+		f.clock.AdvanceTime(30 * time.Minute)
+		play(f, common.Address{0x02}, spammer, challengerWon)
+		f.clock.AdvanceTime(31 * time.Minute)
+
+		game := common.Address{0x03}
+		f.creators[game] = spammer
+		require.False(t, f.tracker.Deprioritized(ctx, game))
+		require.Equal(t, []types.OpponentSummary{{Creator: spammer, Created: 3, Lost: 2, RecentLosses: 1}}, f.tracker.Opponents())
+	})
+
+	t.Run("RestoreFromHistory", func(t *testing.T) {
+		now := time.Unix(100_000, 0)
+		f := setup(t, 1, []opponentGame{
+			{Game: common.Address{0x01}, Creator: spammer, Status: challengerWon, ResolvedAt: now.Add(-time.Minute)},
+			{Game: common.Address{0x02}, Creator: spammer, Status: challengerWon, ResolvedAt: now.Add(-2 * time.Hour)},
+			{Game: common.Address{0x03}, Creator: spammer, Status: challengerWon, ResolvedAt: now.Add(-2 * time.Minute)},
+			{Game: common.Address{0x04}, Creator: honest},
+		})
+		require.Equal(t, []types.OpponentSummary{
+			{Creator: honest, Created: 1},
+			{Creator: spammer, Created: 3, Lost: 3, RecentLosses: 2, Deprioritized: true},
+		}, f.tracker.Opponents())
+		require.Equal(t, [3]int{3, 0, 3}, f.metrics.games[spammer])
+
+		// The creators of known games aren't fetched again and resolving them again isn't counted.
+		require.False(t, f.tracker.Deprioritized(ctx, common.Address{0x04}))
+		require.Zero(t, f.fetches[common.Address{0x04}])
+		require.Empty(t, f.events.events)
+		f.tracker.Emit(types.Event{Type: types.EventGameResolved, Game: common.Address{0x01}, Status: challengerWon})
+		require.Equal(t, 3, f.tracker.Opponents()[1].Lost)
+	})
+
+	t.Run("LimitMetrics", func(t *testing.T) {
+		f := setup(t, 1, nil)
+		f.tracker.metricsLimit = 1
+		sybil1 := common.Address{0x01}
+		sybil2 := common.Address{0x02}
+		play(f, common.Address{0xa1}, honest, defenderWon)
+		play(f, common.Address{0xa2}, honest, defenderWon)
+		play(f, common.Address{0xa3}, sybil1, defenderWon)
+		play(f, common.Address{0xa4}, sybil2, defenderWon)
+		require.Equal(t, map[common.Address][3]int{honest: {2, 2, 0}}, f.metrics.games, "should only record the address with the most games")
+		require.Equal(t, [3]int{2, 2, 0}, f.metrics.other)
+
+		// An address that creates more games than the others replaces them.
+		play(f, common.Address{0xb1}, sybil1, defenderWon)
+		play(f, common.Address{0xb2}, sybil1, defenderWon)
+		require.Equal(t, map[common.Address][3]int{sybil1: {3, 3, 0}}, f.metrics.games)
+		require.Equal(t, [3]int{3, 3, 0}, f.metrics.other)
+
+		// Deprioritized addresses are always recorded individually.
+		play(f, common.Address{0xc1}, spammer, challengerWon)
+		play(f, common.Address{0xc2}, spammer, challengerWon)
+		require.Equal(t, map[common.Address][3]int{sybil1: {3, 3, 0}, spammer: {2, 0, 2}}, f.metrics.games)
+		require.Equal(t, [3]int{3, 3, 0}, f.metrics.other)
+	})
+}
+
+type stubOpponentMetrics struct {
+	games         map[common.Address][3]int
+	other         [3]int
+	deprioritized int
+}
+
+func (s *stubOpponentMetrics) RecordOpponentGames(creator common.Address, created int, won int, lost int) {
+	s.games[creator] = [3]int{created, won, lost}
+}
+
+func (s *stubOpponentMetrics) RecordOtherOpponentGames(created int, won int, lost int) {
+	s.other = [3]int{created, won, lost}
+}
+
+func (s *stubOpponentMetrics) DeleteOpponentGames(creator common.Address) {
+	delete(s.games, creator)
+}
+
+func (s *stubOpponentMetrics) RecordGameDeprioritized() {
+	s.deprioritized++
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/exp/slices"
+)
+
+// pendingTxBackend wraps a [txmgr.ETHBackend] to track the transactions that have been sent but not yet mined.
+// Transactions are grouped by nonce so that once any transaction for a nonce is mined, the transactions it
+// replaced are no longer considered pending.
+type pendingTxBackend struct {
+	txmgr.ETHBackend
+
+	// onSent is called with each transaction once it is sent, if set.
+	onSent func(tx *types.Transaction)
+
+	mu      sync.Mutex
+	pending map[common.Hash]uint64
+}
+
+func newPendingTxBackend(backend txmgr.ETHBackend) *pendingTxBackend {
+	return &pendingTxBackend{
+		ETHBackend: backend,
+		pending:    make(map[common.Hash]uint64),
+	}
+}
+
+func (b *pendingTxBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.ETHBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	if b.onSent != nil {
+		b.onSent(tx)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[tx.Hash()] = tx.Nonce()
+	return nil
+}
+
+func (b *pendingTxBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.ETHBackend.TransactionReceipt(ctx, txHash)
+	if err != nil || receipt == nil {
+		return receipt, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if nonce, ok := b.pending[txHash]; ok {
+		for hash, pendingNonce := range b.pending {
+			if pendingNonce == nonce {
+				delete(b.pending, hash)
+			}
+		}
+	}
+	return receipt, nil
+}
+
+// Pending returns the hashes of the transactions that have been sent but not yet mined, ordered by nonce.
+func (b *pendingTxBackend) Pending() []common.Hash {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hashes := make([]common.Hash, 0, len(b.pending))
+	for hash := range b.pending {
+		hashes = append(hashes, hash)
+	}
+	slices.SortFunc(hashes, func(x, y common.Hash) bool {
+		if b.pending[x] != b.pending[y] {
+			return b.pending[x] < b.pending[y]
+		}
+		return bytes.Compare(x[:], y[:]) < 0
+	})
+	return hashes
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingTxBackend(t *testing.T) {
+	newTx := func(nonce uint64, gasPrice int64) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(gasPrice)})
+	}
+
+	t.Run("TrackSentTransactions", func(t *testing.T) {
+		backend := newPendingTxBackend(&stubTxBackend{})
+		tx1 := newTx(1, 1)
+		tx2 := newTx(2, 1)
+		require.NoError(t, backend.SendTransaction(context.Background(), tx2))
+		require.NoError(t, backend.SendTransaction(context.Background(), tx1))
+		require.Equal(t, []common.Hash{tx1.Hash(), tx2.Hash()}, backend.Pending())
+	})
+
+	t.Run("IgnoreFailedSends", func(t *testing.T) {
+		backend := newPendingTxBackend(&stubTxBackend{sendErr: errors.New("boom")})
+		require.Error(t, backend.SendTransaction(context.Background(), newTx(1, 1)))
+		require.Empty(t, backend.Pending())
+	})
+
+	t.Run("RemoveMinedTransactions", func(t *testing.T) {
+		stub := &stubTxBackend{mined: make(map[common.Hash]bool)}
+		backend := newPendingTxBackend(stub)
+		tx1 := newTx(1, 1)
+		tx2 := newTx(2, 1)
+		require.NoError(t, backend.SendTransaction(context.Background(), tx1))
+		require.NoError(t, backend.SendTransaction(context.Background(), tx2))
+
+		receipt, err := backend.TransactionReceipt(context.Background(), tx1.Hash())
+		require.NoError(t, err)
+		require.Nil(t, receipt)
+		require.Len(t, backend.Pending(), 2)
+
+		stub.mined[tx1.Hash()] = true
+		receipt, err = backend.TransactionReceipt(context.Background(), tx1.Hash())
+		require.NoError(t, err)
+		require.NotNil(t, receipt)
+		require.Equal(t, []common.Hash{tx2.Hash()}, backend.Pending())
+	})
+
+	t.Run("RemoveReplacedTransactions", func(t *testing.T) {
+		stub := &stubTxBackend{mined: make(map[common.Hash]bool)}
+		backend := newPendingTxBackend(stub)
+		tx := newTx(1, 1)
+		replacement := newTx(1, 2)
+		require.NoError(t, backend.SendTransaction(context.Background(), tx))
+		require.NoError(t, backend.SendTransaction(context.Background(), replacement))
+		require.Len(t, backend.Pending(), 2)
+
+		stub.mined[replacement.Hash()] = true
+		_, err := backend.TransactionReceipt(context.Background(), replacement.Hash())
+		require.NoError(t, err)
+		require.Empty(t, backend.Pending())
+	})
+}
+
+type stubTxBackend struct {
+	txmgr.ETHBackend
+	sendErr error
+	mined   map[common.Hash]bool
+}
+
+func (s *stubTxBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return s.sendErr
+}
+
+func (s *stubTxBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if !s.mined[txHash] {
+		return nil, nil
+	}
+	return &types.Receipt{TxHash: txHash}, nil
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errMonitorOnly is returned when a transaction is sent by a challenger that only monitors games.
+var errMonitorOnly = errors.New("not sending transaction in monitor only mode")
+
+type readOnlyBackend interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// readOnlyTxManager is a [txmgr.TxManager] for challengers that only monitor games, which have no key to send
+// transactions with. Calls are made from the zero address and sending a transaction always fails, so that nothing
+// is sent even if a transaction gets past the players' monitor only responders.
+type readOnlyTxManager struct {
+	backend readOnlyBackend
+}
+
+var _ txmgr.TxManager = (*readOnlyTxManager)(nil)
+
+func (m *readOnlyTxManager) Send(_ context.Context, _ txmgr.TxCandidate) (*types.Receipt, error) {
+	return nil, errMonitorOnly
+}
+
+func (m *readOnlyTxManager) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return m.backend.CallContract(ctx, msg, blockNumber)
+}
+
+func (m *readOnlyTxManager) From() common.Address {
+	return common.Address{}
+}
+
+func (m *readOnlyTxManager) BlockNumber(ctx context.Context) (uint64, error) {
+	return m.backend.BlockNumber(ctx)
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyTxManager(t *testing.T) {
+	backend := &stubReadOnlyBackend{result: []byte{0x01}, blockNumber: 7}
+	txMgr := &readOnlyTxManager{backend: backend}
+
+	_, err := txMgr.Send(context.Background(), txmgr.TxCandidate{To: &common.Address{0xaa}})
+	require.ErrorIs(t, err, errMonitorOnly)
+
+	result, err := txMgr.Call(context.Background(), ethereum.CallMsg{To: &common.Address{0xaa}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, result)
+	require.Equal(t, 1, backend.calls)
+
+	blockNumber, err := txMgr.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), blockNumber)
+	require.Equal(t, common.Address{}, txMgr.From())
+}
+
+type stubReadOnlyBackend struct {
+	result      []byte
+	blockNumber uint64
+	calls       int
+}
+
+func (s *stubReadOnlyBackend) BlockNumber(_ context.Context) (uint64, error) {
+	return s.blockNumber, nil
+}
+
+func (s *stubReadOnlyBackend) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	s.calls++
+	return s.result, nil
+}
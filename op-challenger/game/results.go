@@ -0,0 +1,125 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// resultQueueSize is the number of events that can wait to be written to the result store before more are dropped.
+	resultQueueSize = 1000
+	// resultWriteAttempts is the number of times writing an event to the result store is attempted before it is dropped.
+	resultWriteAttempts = 5
+	resultRetryDelay    = time.Second
+)
+
+var errResultQueueFull = errors.New("result store queue is full")
+
+// resultStore records the results of games for long-term analysis.
+type resultStore interface {
+	// Record records event if it is one the store keeps, ignoring it otherwise.
+	Record(ctx context.Context, event types.Event) error
+	Close() error
+}
+
+type resultMetricer interface {
+	RecordResultDropped()
+}
+
+// asyncResultSink is a [types.EventSink] that writes events to a [resultStore] from a background goroutine, so a
+// slow or failing store never blocks or fails playing games. Events are dropped if too many are waiting to be
+// written, or they still can't be written after several attempts.
+type asyncResultSink struct {
+	logger  log.Logger
+	metrics resultMetricer
+	clock   clock.Clock
+	store   resultStore
+
+	queue chan types.Event
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+func newAsyncResultSink(logger log.Logger, m resultMetricer, cl clock.Clock, store resultStore) *asyncResultSink {
+	s := &asyncResultSink{
+		logger:  logger,
+		metrics: m,
+		clock:   cl,
+		store:   store,
+		queue:   make(chan types.Event, resultQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Emit queues event to be written to the store, dropping it if the queue is full.
+func (s *asyncResultSink) Emit(event types.Event) {
+	if event.Time.IsZero() {
+		event.Time = s.clock.Now()
+	}
+	select {
+	case s.queue <- event:
+	default:
+		s.drop(event, errResultQueueFull)
+	}
+}
+
+func (s *asyncResultSink) run() {
+	defer close(s.done)
+	for {
+		select {
+		case event := <-s.queue:
+			s.write(event)
+		case <-s.stop:
+			// Write the events already queued before stopping.
+			for {
+				select {
+				case event := <-s.queue:
+					s.write(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *asyncResultSink) write(event types.Event) {
+	for attempt := 1; ; attempt++ {
+		err := s.store.Record(context.Background(), event)
+		if err == nil {
+			return
+		}
+		if attempt == resultWriteAttempts {
+			s.drop(event, err)
+			return
+		}
+		select {
+		case <-s.clock.After(resultRetryDelay):
+		case <-s.stop:
+			// Don't delay shutting down to retry.
+			s.drop(event, err)
+			return
+		}
+	}
+}
+
+func (s *asyncResultSink) drop(event types.Event, err error) {
+	s.logger.Warn("Dropping game result", "type", event.Type, "game", event.Game, "err", err)
+	s.metrics.RecordResultDropped()
+}
+
+// Close writes the events already queued, then closes the store.
+// Events emitted after Close may not be written.
+func (s *asyncResultSink) Close() error {
+	close(s.stop)
+	<-s.done
+	return s.store.Close()
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncResultSink(t *testing.T) {
+	setup := func(t *testing.T) (*asyncResultSink, *stubResultStore, *stubResultMetrics, *clock.DeterministicClock) {
+		store := &stubResultStore{}
+		m := &stubResultMetrics{}
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		return newAsyncResultSink(testlog.Logger(t, log.LvlError), m, cl, store), store, m, cl
+	}
+
+	t.Run("WritesEvents", func(t *testing.T) {
+		sink, store, m, _ := setup(t)
+		resolvedAt := time.Unix(500, 0)
+		sink.Emit(types.Event{Type: types.EventGameDiscovered, Game: common.Address{0xaa}})
+		sink.Emit(types.Event{Type: types.EventGameResolved, Time: resolvedAt, Game: common.Address{0xaa}})
+		require.NoError(t, sink.Close())
+
+		require.True(t, store.closed)
+		require.Equal(t, []types.Event{
+			{Type: types.EventGameDiscovered, Time: time.Unix(1000, 0), Game: common.Address{0xaa}},
+			{Type: types.EventGameResolved, Time: resolvedAt, Game: common.Address{0xaa}},
+		}, store.recorded)
+		require.Zero(t, m.dropped)
+	})
+
+	t.Run("RetryFailedWrites", func(t *testing.T) {
+		sink, store, m, cl := setup(t)
+		store.failures = resultWriteAttempts - 1
+		sink.Emit(types.Event{Type: types.EventGameDiscovered})
+		for i := 0; i < resultWriteAttempts-1; i++ {
+			require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+			cl.AdvanceTime(resultRetryDelay)
+		}
+		require.NoError(t, sink.Close())
+		require.Len(t, store.recorded, 1)
+		require.Zero(t, m.dropped)
+	})
+
+	t.Run("DropPersistentlyFailingWrites", func(t *testing.T) {
+		sink, store, m, cl := setup(t)
+		store.failures = resultWriteAttempts
+		sink.Emit(types.Event{Type: types.EventGameDiscovered})
+		for i := 0; i < resultWriteAttempts-1; i++ {
+			require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+			cl.AdvanceTime(resultRetryDelay)
+		}
+		require.NoError(t, sink.Close())
+		require.Empty(t, store.recorded)
+		require.Equal(t, 1, m.dropped)
+	})
+
+	t.Run("DontRetryWhenClosing", func(t *testing.T) {
+		sink, store, m, cl := setup(t)
+		store.failures = resultWriteAttempts
+		sink.Emit(types.Event{Type: types.EventGameDiscovered})
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+		require.NoError(t, sink.Close())
+		require.Equal(t, 1, m.dropped)
+	})
+
+	t.Run("DropWhenQueueFull", func(t *testing.T) {
+		sink, store, m, cl := setup(t)
+		// Block the writer retrying the first event so the queue fills up.
+		store.failures = 1
+		sink.Emit(types.Event{Type: types.EventGameDiscovered})
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+		for i := 0; i < resultQueueSize+1; i++ {
+			sink.Emit(types.Event{Type: types.EventClaimCountered})
+		}
+		require.Equal(t, 1, m.dropped, "should not block when the queue is full")
+		cl.AdvanceTime(resultRetryDelay)
+		require.NoError(t, sink.Close())
+		require.Len(t, store.recorded, resultQueueSize+1)
+	})
+}
+
+type stubResultStore struct {
+	mu       sync.Mutex
+	failures int
+	recorded []types.Event
+	closed   bool
+}
+
+func (s *stubResultStore) Record(_ context.Context, event types.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("boom")
+	}
+	s.recorded = append(s.recorded, event)
+	return nil
+}
+
+func (s *stubResultStore) Close() error {
+	s.closed = true
+	return nil
+}
+
+type stubResultMetrics struct {
+	mu      sync.Mutex
+	dropped int
+}
+
+func (s *stubResultMetrics) RecordResultDropped() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped++
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+// gameResolutionFile is the name of the file in a game's directory recording the game's resolution.
+const gameResolutionFile = "resolution.json"
+
+// gameResolution is the recorded resolution of a game, which decides how long its data is retained.
+type gameResolution struct {
+	// ResolvedAt is when the game was first found to be resolved.
+	ResolvedAt time.Time `json:"resolvedAt"`
+	// Won is true if the game resolved with the outcome the challenger was playing for.
+	Won bool `json:"won"`
+}
+
+// gameStatusLoader loads the current status of a game from its contract.
+type gameStatusLoader func(ctx context.Context, game common.Address) (types.GameStatus, error)
+
+type reclaimMetricer interface {
+	RecordGameDataReclaimed(bytes uint64)
+}
+
+// retentionDiskManager keeps the data of resolved games for the retention period instead of removing it once the
+// game resolves, and keeps the data of lost games forever if preserveLost is set.
+// The resolution of each game is recorded in its data directory, so data is retained across restarts. Data of
+// games without a recorded resolution is removed as usual, and data of games still being played is never removed.
+type retentionDiskManager struct {
+	scheduler.DiskManager
+	logger       log.Logger
+	clock        clock.Clock
+	metrics      reclaimMetricer
+	datadir      string
+	loadStatus   gameStatusLoader
+	retention    time.Duration
+	preserveLost bool
+
+	// mu guards resolutions, as resolutions are recorded by the games while data is being removed.
+	mu sync.Mutex
+	// resolutions caches the recorded resolutions that have been loaded.
+	resolutions map[common.Address]gameResolution
+}
+
+// newRetentionDiskManager creates a [retentionDiskManager] for the game data in datadir. A negative retention keeps
+// the data of resolved games forever.
+func newRetentionDiskManager(
+	logger log.Logger,
+	cl clock.Clock,
+	m reclaimMetricer,
+	disk scheduler.DiskManager,
+	datadir string,
+	loadStatus gameStatusLoader,
+	retention time.Duration,
+	preserveLost bool,
+) *retentionDiskManager {
+	return &retentionDiskManager{
+		DiskManager:  disk,
+		logger:       logger,
+		clock:        cl,
+		metrics:      m,
+		datadir:      datadir,
+		loadStatus:   loadStatus,
+		retention:    retention,
+		preserveLost: preserveLost,
+		resolutions:  make(map[common.Address]gameResolution),
+	}
+}
+
+// RecordResolved records the resolution of game so its data is retained for the retention period from now.
+// A game that already has a recorded resolution keeps its original resolution time.
+// It is a [fault.ResolvedCallback].
+func (d *retentionDiskManager) RecordResolved(game common.Address, _ types.GameStatus, won bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	resolution, ok := d.resolution(game)
+	if ok && resolution.Won == won {
+		return
+	}
+	if !ok {
+		resolution.ResolvedAt = d.clock.Now()
+	}
+	resolution.Won = won
+	d.resolutions[game] = resolution
+	if err := d.saveResolution(game, resolution); err != nil {
+		d.logger.Error("Failed to record game resolution, data may not be retained after a restart", "game", game, "err", err)
+	}
+}
+
+// RemoveAllExcept removes the data of every game not in keep, other than resolved games whose data is retained.
+// The number of bytes reclaimed is recorded.
+func (d *retentionDiskManager) RemoveAllExcept(keep []common.Address) error {
+	games, err := gameDirs(d.datadir)
+	if err != nil {
+		return err
+	}
+	now := d.clock.Now()
+	keep = slices.Clone(keep)
+	var removing []gameDir
+	sizes := make(map[common.Address]uint64)
+	d.mu.Lock()
+	for _, game := range games {
+		if slices.Contains(keep, game.addr) {
+			continue
+		}
+		if d.retained(game.addr, now) {
+			keep = append(keep, game.addr)
+			continue
+		}
+		// The size is only used for the metric, so an incomplete size is recorded if it can't be measured.
+		sizes[game.addr], _ = diskUsage(game.dir)
+		removing = append(removing, game)
+		delete(d.resolutions, game.addr)
+	}
+	d.mu.Unlock()
+
+	err = d.DiskManager.RemoveAllExcept(keep)
+	var reclaimed uint64
+	removed := 0
+	for _, game := range removing {
+		if _, statErr := os.Stat(game.dir); errors.Is(statErr, os.ErrNotExist) {
+			reclaimed += sizes[game.addr]
+			removed++
+		}
+	}
+	if removed > 0 {
+		d.logger.Info("Removed game data", "games", removed, "bytes", reclaimed)
+		d.metrics.RecordGameDataReclaimed(reclaimed)
+	}
+	return err
+}
+
+// Sweep removes the data of games that resolved while the challenger wasn't running. The resolution of each game
+// with data but no recorded resolution is recorded if the game's contract reports it resolved, and then the data of
+// resolved games that isn't retained is removed. Data of games that are in progress, or whose status can't be loaded,
+// is kept.
+func (d *retentionDiskManager) Sweep(ctx context.Context) error {
+	games, err := gameDirs(d.datadir)
+	if err != nil {
+		return err
+	}
+	var keep []common.Address
+	for _, game := range games {
+		d.mu.Lock()
+		_, recorded := d.resolution(game.addr)
+		d.mu.Unlock()
+		if recorded {
+			continue
+		}
+		status, err := d.loadStatus(ctx, game.addr)
+		if err != nil {
+			d.logger.Warn("Unable to load game status, keeping game data", "game", game.addr, "err", err)
+			keep = append(keep, game.addr)
+			continue
+		}
+		if status == types.GameStatusInProgress {
+			keep = append(keep, game.addr)
+			continue
+		}
+		// Whether the game was won depends on the side played, which isn't known until the game is played again,
+		// so the data is preserved as if the game was lost.
+		d.RecordResolved(game.addr, status, false)
+	}
+	return d.RemoveAllExcept(keep)
+}
+
+// retained returns true if the data of game should be kept at time now. Must be called with mu held.
+func (d *retentionDiskManager) retained(game common.Address, now time.Time) bool {
+	resolution, ok := d.resolution(game)
+	if !ok {
+		return false
+	}
+	if d.preserveLost && !resolution.Won {
+		return true
+	}
+	return d.retention < 0 || now.Before(resolution.ResolvedAt.Add(d.retention))
+}
+
+// resolution returns the recorded resolution of game, loading it from the game's directory if it isn't cached.
+// Must be called with mu held.
+func (d *retentionDiskManager) resolution(game common.Address) (gameResolution, bool) {
+	if resolution, ok := d.resolutions[game]; ok {
+		return resolution, true
+	}
+	data, err := os.ReadFile(filepath.Join(d.DirForGame(game), gameResolutionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return gameResolution{}, false
+	} else if err != nil {
+		d.logger.Warn("Failed to read game resolution", "game", game, "err", err)
+		return gameResolution{}, false
+	}
+	var resolution gameResolution
+	if err := json.Unmarshal(data, &resolution); err != nil {
+		d.logger.Warn("Failed to decode game resolution", "game", game, "err", err)
+		return gameResolution{}, false
+	}
+	d.resolutions[game] = resolution
+	return resolution, true
+}
+
+// saveResolution writes the resolution to the game's directory. Nothing is written if the game has no data.
+func (d *retentionDiskManager) saveResolution(game common.Address, resolution gameResolution) error {
+	dir := d.DirForGame(game)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	data, err := json.Marshal(resolution)
+	if err != nil {
+		return fmt.Errorf("failed to encode game resolution: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, gameResolutionFile), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write game resolution: %w", err)
+	}
+	return nil
+}
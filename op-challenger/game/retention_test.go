@@ -0,0 +1,184 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionDiskManager(t *testing.T) {
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	setup := func(t *testing.T, dir string, retention time.Duration, preserveLost bool) (*retentionDiskManager, *clock.DeterministicClock, *stubReclaimMetrics) {
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		m := &stubReclaimMetrics{}
+		disk := newRetentionDiskManager(testlog.Logger(t, log.LvlInfo), cl, m, newDiskManager(dir), dir, nil, retention, preserveLost)
+		return disk, cl, m
+	}
+	populate := func(t *testing.T, disk *retentionDiskManager, games ...common.Address) {
+		for _, game := range games {
+			require.NoError(t, os.MkdirAll(disk.DirForGame(game), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(game), "proof.json"), []byte("0123456789"), 0o644))
+		}
+	}
+
+	t.Run("RemoveImmediatelyByDefault", func(t *testing.T) {
+		disk, _, m := setup(t, t.TempDir(), 0, false)
+		populate(t, disk, game1, game2)
+		disk.RecordResolved(game1, types.GameStatusChallengerWon, true)
+
+		require.NoError(t, disk.RemoveAllExcept([]common.Address{game2}))
+		require.NoDirExists(t, disk.DirForGame(game1))
+		require.DirExists(t, disk.DirForGame(game2))
+		require.Greater(t, m.reclaimed, uint64(10), "should record the size of the removed data")
+	})
+
+	t.Run("RetainForPeriod", func(t *testing.T) {
+		disk, cl, m := setup(t, t.TempDir(), time.Hour, false)
+		populate(t, disk, game1)
+		disk.RecordResolved(game1, types.GameStatusChallengerWon, true)
+
+		cl.AdvanceTime(59 * time.Minute)
+		require.NoError(t, disk.RemoveAllExcept(nil))
+		require.DirExists(t, disk.DirForGame(game1))
+		require.Zero(t, m.reclaimed)
+
+		cl.AdvanceTime(time.Minute)
+		require.NoError(t, disk.RemoveAllExcept(nil))
+		require.NoDirExists(t, disk.DirForGame(game1))
+		require.NotZero(t, m.reclaimed)
+	})
+
+	t.Run("RetainForever", func(t *testing.T) {
+		disk, cl, _ := setup(t, t.TempDir(), -1, false)
+		populate(t, disk, game1)
+		disk.RecordResolved(game1, types.GameStatusChallengerWon, true)
+		cl.AdvanceTime(1000 * time.Hour)
+		require.NoError(t, disk.RemoveAllExcept(nil))
+		require.DirExists(t, disk.DirForGame(game1))
+	})
+
+	t.Run("PreserveLostGames", func(t *testing.T) {
+		disk, _, _ := setup(t, t.TempDir(), 0, true)
+		populate(t, disk, game1, game2)
+		disk.RecordResolved(game1, types.GameStatusDefenderWon, false)
+		disk.RecordResolved(game2, types.GameStatusChallengerWon, true)
+
+		require.NoError(t, disk.RemoveAllExcept(nil))
+		require.DirExists(t, disk.DirForGame(game1), "should keep lost game")
+		require.NoDirExists(t, disk.DirForGame(game2), "should remove won game")
+	})
+
+	t.Run("RemoveGamesWithoutResolution", func(t *testing.T) {
+		disk, _, _ := setup(t, t.TempDir(), -1, true)
+		populate(t, disk, game1)
+		require.NoError(t, disk.RemoveAllExcept(nil))
+		require.NoDirExists(t, disk.DirForGame(game1), "should remove games that are no longer played")
+	})
+
+	t.Run("NeverRemoveKeptGames", func(t *testing.T) {
+		disk, cl, _ := setup(t, t.TempDir(), time.Minute, false)
+		populate(t, disk, game1)
+		disk.RecordResolved(game1, types.GameStatusChallengerWon, true)
+		cl.AdvanceTime(time.Hour)
+		require.NoError(t, disk.RemoveAllExcept([]common.Address{game1}))
+		require.DirExists(t, disk.DirForGame(game1))
+	})
+
+	t.Run("RetainAfterRestart", func(t *testing.T) {
+		dir := t.TempDir()
+		disk, _, _ := setup(t, dir, time.Hour, false)
+		populate(t, disk, game1)
+		disk.RecordResolved(game1, types.GameStatusChallengerWon, true)
+
+		restarted, cl, _ := setup(t, dir, time.Hour, false)
+		restarted.RecordResolved(game1, types.GameStatusChallengerWon, true)
+		cl.AdvanceTime(30 * time.Minute)
+		require.NoError(t, restarted.RemoveAllExcept(nil))
+		require.DirExists(t, restarted.DirForGame(game1))
+		cl.AdvanceTime(30 * time.Minute)
+		require.NoError(t, restarted.RemoveAllExcept(nil))
+		require.NoDirExists(t, restarted.DirForGame(game1), "should retain from the original resolution")
+	})
+
+	t.Run("GameWithoutData", func(t *testing.T) {
+		disk, _, m := setup(t, t.TempDir(), time.Hour, false)
+		disk.RecordResolved(game1, types.GameStatusChallengerWon, true)
+		require.NoDirExists(t, disk.DirForGame(game1), "should not create data for the game")
+		require.NoError(t, disk.RemoveAllExcept(nil))
+		require.Zero(t, m.reclaimed)
+	})
+}
+
+func TestRetentionDiskManager_Sweep(t *testing.T) {
+	inProgress := common.Address{0xaa}
+	resolved := common.Address{0xbb}
+	unknown := common.Address{0xcc}
+	retained := common.Address{0xdd}
+	statuses := map[common.Address]types.GameStatus{
+		inProgress: types.GameStatusInProgress,
+		resolved:   types.GameStatusChallengerWon,
+		retained:   types.GameStatusChallengerWon,
+	}
+	loadStatus := func(ctx context.Context, game common.Address) (types.GameStatus, error) {
+		status, ok := statuses[game]
+		if !ok {
+			return 0, errors.New("boom")
+		}
+		return status, nil
+	}
+	setup := func(t *testing.T, preserveLost bool) *retentionDiskManager {
+		dir := t.TempDir()
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		disk := newRetentionDiskManager(testlog.Logger(t, log.LvlInfo), cl, &stubReclaimMetrics{}, newDiskManager(dir), dir, loadStatus, time.Hour, preserveLost)
+		for _, game := range []common.Address{inProgress, resolved, unknown, retained} {
+			require.NoError(t, os.MkdirAll(disk.DirForGame(game), 0o755))
+		}
+		disk.RecordResolved(retained, types.GameStatusChallengerWon, true)
+		cl.AdvanceTime(2 * time.Hour)
+		disk.retention = 3 * time.Hour
+		return disk
+	}
+
+	t.Run("RemoveResolvedGames", func(t *testing.T) {
+		disk := setup(t, false)
+		require.NoError(t, disk.Sweep(context.Background()))
+		require.DirExists(t, disk.DirForGame(inProgress), "should keep in progress game")
+		require.DirExists(t, disk.DirForGame(unknown), "should keep game with unknown status")
+		require.DirExists(t, disk.DirForGame(retained), "should keep retained game")
+		require.DirExists(t, disk.DirForGame(resolved), "should retain newly found resolved game")
+
+		disk.retention = 0
+		require.NoError(t, disk.Sweep(context.Background()))
+		require.NoDirExists(t, disk.DirForGame(resolved))
+		require.NoDirExists(t, disk.DirForGame(retained))
+		require.DirExists(t, disk.DirForGame(inProgress))
+		require.DirExists(t, disk.DirForGame(unknown))
+	})
+
+	t.Run("PreserveGamesWithUnknownOutcome", func(t *testing.T) {
+		disk := setup(t, true)
+		disk.retention = 0
+		require.NoError(t, disk.Sweep(context.Background()))
+		require.DirExists(t, disk.DirForGame(resolved), "should preserve game that may have been lost")
+		require.NoDirExists(t, disk.DirForGame(retained), "should remove game known to be won")
+	})
+}
+
+type stubReclaimMetrics struct {
+	reclaimed uint64
+}
+
+func (s *stubReclaimMetrics) RecordGameDataReclaimed(bytes uint64) {
+	s.reclaimed += bytes
+}
@@ -1,51 +1,116 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"sync"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"golang.org/x/exp/slices"
 )
 
-var errUnknownGame = errors.New("unknown game")
+var (
+	errUnknownGame = errors.New("unknown game")
 
-type PlayerCreator func(address common.Address, dir string) (GamePlayer, error)
+	ErrGameNotTracked = errors.New("game is not being played")
+	ErrGameResolved   = errors.New("game is already resolved")
+)
+
+type PlayerCreator func(game Game, dir string) (GamePlayer, error)
 
 type gameState struct {
+	factory  common.Address
 	player   GamePlayer
 	inflight bool
 	resolved bool
+	status   types.GameStatus
+	// nextCheck is the earliest time the game should be progressed again.
+	nextCheck time.Time
+	// lastActed is when the last progression of the game completed.
+	lastActed time.Time
+	// clockDeadline is when the game's soonest expiring chess clock runs out, zero if unknown.
+	clockDeadline time.Time
+	// claims is the number of claims in the game as of the last progression.
+	claims uint64
+	// waiters receive the outcome of the in-flight progression, which they requested to happen immediately.
+	waiters []chan<- actResponse
 }
 
+// actRequest is a request to progress a game immediately, ahead of the queued games.
+type actRequest struct {
+	game   common.Address
+	result chan<- actResponse
+}
+
+type actResponse struct {
+	result types.ActResult
+	err    error
+}
+
+// The priority buckets of the remaining clock that the time games spend queued is recorded by.
+const (
+	PriorityUnknown  = "unknown"
+	PriorityUnder1h  = "under_1h"
+	PriorityUnder24h = "under_24h"
+	PriorityOver24h  = "over_24h"
+	// PriorityImmediate is the bucket of games progressed immediately on request.
+	PriorityImmediate = "immediate"
+)
+
 // coordinator manages the set of current games, queues games to be played (on separate worker threads) and
 // cleans up data files once a game is resolved.
-// All function calls must be made on the same thread.
+// All function calls must be made on the same thread, other than trackedGames.
 type coordinator struct {
 	// jobQueue is the outgoing queue for jobs being sent to workers for progression
 	jobQueue chan<- job
+	// urgentQueue is the outgoing queue for jobs that workers progress before any in jobQueue
+	urgentQueue chan<- job
 
 	// resultQueue is the incoming queue of jobs that have been completed by workers
 	resultQueue <-chan job
 
 	logger       log.Logger
+	metrics      Metricer
+	clock        clock.Clock
 	createPlayer PlayerCreator
 	states       map[common.Address]*gameState
 	disk         DiskManager
+
+	// jitter is the fraction each game's next check delay is randomly varied by, either way.
+	jitter float64
+	rand   *rand.Rand
+
+	// trackedMu guards tracked, the summary of each game published after the states are updated.
+	trackedMu sync.Mutex
+	tracked   []TrackedGame
 }
 
 // schedule takes the current list of games to attempt to progress, filters out games that have previous
 // progressions already in-flight and schedules jobs to progress on the outbound jobQueue.
+// Jobs are queued in order of the games' remaining chess clocks, soonest to expire first, then by the most claims.
+// Games with the same priority or unknown clocks are queued alternating between the games of each factory so a
+// factory with many games doesn't hold up the games of the others, with unknown clocks after known ones.
+// Deprioritized games are queued after all other games.
 // To avoid deadlock, it may process results from the inbound resultQueue while adding jobs to the outbound jobQueue.
 // Returns an error if a game couldn't be scheduled because of an error. It will continue attempting to progress
 // all games even if an error occurs with one game.
-func (c *coordinator) schedule(ctx context.Context, games []common.Address) error {
+func (c *coordinator) schedule(ctx context.Context, games []Game) error {
 	// First remove any game states we no longer require
+	required := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		required[game.Addr] = true
+	}
 	for addr, state := range c.states {
-		if !state.inflight && !slices.Contains(games, addr) {
+		if !state.inflight && !required[addr] {
 			delete(c.states, addr)
+			c.metrics.DeleteGameMetrics(addr)
 		}
 	}
 
@@ -54,49 +119,126 @@ func (c *coordinator) schedule(ctx context.Context, games []common.Address) erro
 	// Otherwise, results may start being processed before all games are recorded, resulting in existing
 	// data directories potentially being deleted for games that are required.
 	var jobs []job
-	for _, addr := range games {
-		if j, err := c.createJob(addr); err != nil {
+	for _, game := range c.prioritizeGames(games) {
+		if j, err := c.createJob(game); err != nil {
 			errs = append(errs, err)
 		} else if j != nil {
 			jobs = append(jobs, *j)
 		}
 	}
 
+	c.publishTracked()
+
 	// Finally, enqueue the jobs
 	for _, j := range jobs {
-		errs = append(errs, c.enqueueJob(ctx, j))
+		errs = append(errs, c.enqueueJob(ctx, c.jobQueue, j))
 	}
 	return errors.Join(errs...)
 }
 
 // createJob updates the state for the specified game and returns the job to enqueue for it, if any
 // Returns (nil, nil) when there is no error and no job to enqueue
-func (c *coordinator) createJob(game common.Address) (*job, error) {
+func (c *coordinator) createJob(g Game) (*job, error) {
+	game := g.Addr
 	state, ok := c.states[game]
 	if !ok {
-		state = &gameState{}
+		state = &gameState{factory: g.Factory}
 		c.states[game] = state
 	}
 	if state.inflight {
 		c.logger.Debug("Not rescheduling already in-flight game", "game", game)
 		return nil, nil
 	}
-	// Create the player separately to the state so we retry creating it if it fails on the first attempt.
-	if state.player == nil {
-		player, err := c.createPlayer(game, c.disk.DirForGame(game))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create game player: %w", err)
-		}
-		state.player = player
+	if state.resolved {
+		c.logger.Trace("Not rescheduling resolved game", "game", game)
+		return nil, nil
+	}
+	if c.clock.Now().Before(state.nextCheck) {
+		c.logger.Trace("Not rescheduling game until next check", "game", game, "nextCheck", state.nextCheck)
+		return nil, nil
+	}
+	if err := c.ensurePlayer(g, state); err != nil {
+		return nil, err
 	}
 	state.inflight = true
-	return &job{addr: game, player: state.player}, nil
+	now := c.clock.Now()
+	return &job{addr: game, player: state.player, priority: priorityBucket(state.clockDeadline, now), queued: now}, nil
+}
+
+// ensurePlayer creates the player for the game if it hasn't been created yet.
+// The player is created separately to the state so we retry creating it if it fails on the first attempt.
+func (c *coordinator) ensurePlayer(g Game, state *gameState) error {
+	if state.player != nil {
+		return nil
+	}
+	player, err := c.createPlayer(g, c.disk.DirForGame(g.Addr))
+	if err != nil {
+		return fmt.Errorf("failed to create game player: %w", err)
+	}
+	state.player = player
+	return nil
+}
+
+// actNow progresses the requested game ahead of the queued games, sending the outcome to the request's result.
+// A game that is already in flight isn't progressed again, the request receives the outcome of that progression.
+// Only games included in the last schedule that aren't resolved can be progressed.
+func (c *coordinator) actNow(ctx context.Context, req actRequest) {
+	state, ok := c.states[req.game]
+	if !ok {
+		req.result <- actResponse{err: fmt.Errorf("%w: %v", ErrGameNotTracked, req.game)}
+		return
+	}
+	if state.resolved {
+		req.result <- actResponse{err: fmt.Errorf("%w: %v", ErrGameResolved, req.game)}
+		return
+	}
+	state.waiters = append(state.waiters, req.result)
+	if state.inflight {
+		c.logger.Info("Game already being progressed, waiting for the result", "game", req.game)
+		return
+	}
+	if err := c.ensurePlayer(Game{Addr: req.game, Factory: state.factory}, state); err != nil {
+		c.respondWaiters(state, actResponse{err: err})
+		return
+	}
+	c.logger.Info("Progressing game immediately", "game", req.game)
+	state.inflight = true
+	c.publishTracked()
+	j := job{addr: req.game, player: state.player, priority: PriorityImmediate, queued: c.clock.Now()}
+	if err := c.enqueueJob(ctx, c.urgentQueue, j); err != nil {
+		state.inflight = false
+		c.respondWaiters(state, actResponse{err: err})
+	}
+}
+
+// respondWaiters sends resp to each request waiting for the game's progression.
+func (c *coordinator) respondWaiters(state *gameState, resp actResponse) {
+	for _, waiter := range state.waiters {
+		waiter <- resp
+	}
+	state.waiters = nil
 }
 
-func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
+// priorityBucket returns the priority bucket of the time remaining from now until deadline, which is zero if unknown.
+func priorityBucket(deadline time.Time, now time.Time) string {
+	if deadline.IsZero() {
+		return PriorityUnknown
+	}
+	remaining := deadline.Sub(now)
+	switch {
+	case remaining < time.Hour:
+		return PriorityUnder1h
+	case remaining < 24*time.Hour:
+		return PriorityUnder24h
+	default:
+		return PriorityOver24h
+	}
+}
+
+func (c *coordinator) enqueueJob(ctx context.Context, queue chan<- job, j job) error {
 	for {
 		select {
-		case c.jobQueue <- j:
+		case queue <- j:
 			return nil
 		case result := <-c.resultQueue:
 			if err := c.processResult(result); err != nil {
@@ -114,11 +256,140 @@ func (c *coordinator) processResult(j job) error {
 		return fmt.Errorf("game %v received unexpected result: %w", j.addr, errUnknownGame)
 	}
 	state.inflight = false
+	if j.resolved && !state.resolved {
+		c.metrics.DeleteInProgressMetrics(j.addr)
+	}
 	state.resolved = j.resolved
+	state.status = j.status
+	state.lastActed = c.clock.Now()
+	state.nextCheck = state.lastActed.Add(c.jitterDelay(j.nextCheck))
+	state.clockDeadline = j.clockDeadline
+	state.claims = j.claims
+	c.metrics.RecordGameQueueTime(j.priority, j.queueTime)
+	if len(state.waiters) > 0 {
+		result := types.ActResult{
+			Game:      j.addr,
+			Succeeded: j.err == nil,
+			Status:    j.status.String(),
+			Claims:    j.claims,
+			Resolved:  j.resolved,
+		}
+		if j.err != nil {
+			result.Error = j.err.Error()
+		}
+		c.respondWaiters(state, actResponse{result: result})
+	}
+	c.logger.Debug("Progressed game", "game", j.addr, "status", j.status, "resolved", j.resolved,
+		"duration", j.duration, "next_check", state.nextCheck)
 	c.deleteResolvedGameFiles()
+	c.publishTracked()
 	return nil
 }
 
+// jitterDelay randomly varies delay by up to the jitter fraction either way, so games polled at the same interval
+// are spread out rather than all progressed, and loading from the L1 node, at once.
+// Games checked every block have no delay to vary.
+func (c *coordinator) jitterDelay(delay time.Duration) time.Duration {
+	if c.jitter == 0 || delay == 0 {
+		return delay
+	}
+	return delay + time.Duration(float64(delay)*c.jitter*(2*c.rand.Float64()-1))
+}
+
+// publishTracked publishes the summary of each game returned by trackedGames.
+func (c *coordinator) publishTracked() {
+	tracked := make([]TrackedGame, 0, len(c.states))
+	for addr, state := range c.states {
+		tracked = append(tracked, TrackedGame{
+			Game:      addr,
+			Factory:   state.factory,
+			Status:    state.status,
+			LastActed: state.lastActed,
+			InFlight:  state.inflight,
+			Resolved:  state.resolved,
+		})
+	}
+	slices.SortFunc(tracked, func(a, b TrackedGame) bool {
+		return bytes.Compare(a.Game[:], b.Game[:]) < 0
+	})
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+	c.tracked = tracked
+}
+
+// trackedGames returns the summary of each game as of the last update, ordered by address.
+// Unlike the other methods, it is safe to call concurrently.
+func (c *coordinator) trackedGames() []TrackedGame {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+	return slices.Clone(c.tracked)
+}
+
+// prioritizeGames orders games so deprioritized games come after the others. The games in each group are ordered
+// by the soonest expiring clock then the most claims as of their last progression, and otherwise interleaved by factory.
+func (c *coordinator) prioritizeGames(games []Game) []Game {
+	var normal, deprioritized []Game
+	for _, game := range games {
+		if game.Deprioritized {
+			deprioritized = append(deprioritized, game)
+		} else {
+			normal = append(normal, game)
+		}
+	}
+	if len(deprioritized) == 0 {
+		return c.sortByClock(interleaveFactories(games))
+	}
+	return append(c.sortByClock(interleaveFactories(normal)), c.sortByClock(interleaveFactories(deprioritized))...)
+}
+
+// sortByClock stably sorts games by the deadline of their soonest expiring clock, then by the most claims.
+// Games with unknown clocks, such as those not progressed yet, come after the others in their existing order.
+func (c *coordinator) sortByClock(games []Game) []Game {
+	slices.SortStableFunc(games, func(a, b Game) bool {
+		var stateA, stateB gameState
+		if state, ok := c.states[a.Addr]; ok {
+			stateA = *state
+		}
+		if state, ok := c.states[b.Addr]; ok {
+			stateB = *state
+		}
+		switch {
+		case stateA.clockDeadline.IsZero() || stateB.clockDeadline.IsZero():
+			return !stateA.clockDeadline.IsZero() && stateB.clockDeadline.IsZero()
+		case !stateA.clockDeadline.Equal(stateB.clockDeadline):
+			return stateA.clockDeadline.Before(stateB.clockDeadline)
+		default:
+			return stateA.claims > stateB.claims
+		}
+	})
+	return games
+}
+
+// interleaveFactories orders games taking one from each factory in turn, in the order each factory first appears.
+// The games of each factory stay in their original order.
+func interleaveFactories(games []Game) []Game {
+	var factories []common.Address
+	byFactory := make(map[common.Address][]Game)
+	for _, game := range games {
+		if _, ok := byFactory[game.Factory]; !ok {
+			factories = append(factories, game.Factory)
+		}
+		byFactory[game.Factory] = append(byFactory[game.Factory], game)
+	}
+	if len(factories) <= 1 {
+		return games
+	}
+	out := make([]Game, 0, len(games))
+	for i := 0; len(out) < len(games); i++ {
+		for _, factory := range factories {
+			if i < len(byFactory[factory]) {
+				out = append(out, byFactory[factory][i])
+			}
+		}
+	}
+	return out
+}
+
 func (c *coordinator) deleteResolvedGameFiles() {
 	var keepGames []common.Address
 	for addr, state := range c.states {
@@ -131,13 +402,18 @@ func (c *coordinator) deleteResolvedGameFiles() {
 	}
 }
 
-func newCoordinator(logger log.Logger, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager) *coordinator {
+func newCoordinator(logger log.Logger, m Metricer, cl clock.Clock, jobQueue chan<- job, urgentQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, jitter float64) *coordinator {
 	return &coordinator{
 		logger:       logger,
+		metrics:      m,
+		clock:        cl,
 		jobQueue:     jobQueue,
+		urgentQueue:  urgentQueue,
 		resultQueue:  resultQueue,
 		createPlayer: createPlayer,
 		disk:         disk,
 		states:       make(map[common.Address]*gameState),
+		jitter:       jitter,
+		rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
@@ -2,10 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
@@ -18,7 +24,7 @@ func TestScheduleNewGames(t *testing.T) {
 	gameAddr2 := common.Address{0xbb}
 	gameAddr3 := common.Address{0xcc}
 	ctx := context.Background()
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1, gameAddr2, gameAddr3}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1, gameAddr2, gameAddr3)))
 
 	require.Len(t, workQueue, 3, "should schedule job for each game")
 	require.Len(t, games.created, 3, "should have created players")
@@ -39,11 +45,11 @@ func TestSkipSchedulingInflightGames(t *testing.T) {
 	ctx := context.Background()
 
 	// Schedule the game once
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1)))
 	require.Len(t, workQueue, 1, "should schedule game")
 
 	// And then attempt to schedule again
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1)))
 	require.Len(t, workQueue, 1, "should not reschedule in-flight game")
 }
 
@@ -55,7 +61,7 @@ func TestExitWhenContextDoneWhileSchedulingJob(t *testing.T) {
 	cancel() // Context is cancelled
 
 	// Should not block because the context is done.
-	err := c.schedule(ctx, []common.Address{gameAddr1})
+	err := c.schedule(ctx, gamesOf(gameAddr1))
 	require.ErrorIs(t, err, context.Canceled)
 	require.Empty(t, workQueue, "should not have been able to schedule game")
 }
@@ -66,7 +72,7 @@ func TestScheduleGameAgainAfterCompletion(t *testing.T) {
 	ctx := context.Background()
 
 	// Schedule the game once
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1)))
 	require.Len(t, workQueue, 1, "should schedule game")
 
 	// Read the job
@@ -77,10 +83,323 @@ func TestScheduleGameAgainAfterCompletion(t *testing.T) {
 	require.NoError(t, c.processResult(j))
 
 	// And then attempt to schedule again
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1)))
 	require.Len(t, workQueue, 1, "should reschedule completed game")
 }
 
+func TestSkipSchedulingResolvedGames(t *testing.T) {
+	c, workQueue, _, games, _ := setupCoordinatorTest(t, 10)
+	m := &stubQueueMetrics{}
+	c.metrics = m
+	gameAddr1 := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1)))
+	j := <-workQueue
+	j.resolved = true
+	require.NoError(t, c.processResult(j))
+	require.Equal(t, []common.Address{gameAddr1}, m.completed, "should delete in progress metrics of resolved game")
+	require.Empty(t, m.deleted, "should keep outcome metrics while the game is tracked")
+
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1)))
+	require.Empty(t, workQueue, "should not reschedule resolved game")
+	require.Len(t, games.created, 1, "should not recreate player")
+}
+
+func TestDelayGameUntilNextCheck(t *testing.T) {
+	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.clock = cl
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1, gameAddr2)))
+	for i := 0; i < 2; i++ {
+		j := <-workQueue
+		if j.addr == gameAddr1 {
+			j.nextCheck = time.Minute
+		}
+		require.NoError(t, c.processResult(j))
+	}
+
+	// Game 1 isn't due yet but game 2 should be checked every time
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1, gameAddr2)))
+	require.Len(t, workQueue, 1, "should only schedule game without delay")
+	j := <-workQueue
+	require.Equal(t, gameAddr2, j.addr)
+	require.NoError(t, c.processResult(j))
+
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1, gameAddr2)))
+	require.Len(t, workQueue, 2, "should schedule game 1 once due")
+}
+
+func TestJitterNextCheck(t *testing.T) {
+	delay := 10 * time.Minute
+	nextChecks := func(t *testing.T, jitter float64, delay time.Duration) []time.Duration {
+		c, workQueue, _, _, _ := setupCoordinatorTest(t, 50)
+		c.clock = clock.NewDeterministicClock(time.Unix(1000, 0))
+		c.jitter = jitter
+		c.rand = rand.New(rand.NewSource(1))
+		var games []common.Address
+		for i := 0; i < 50; i++ {
+			games = append(games, common.Address{0xaa, byte(i)})
+		}
+		require.NoError(t, c.schedule(context.Background(), gamesOf(games...)))
+		var delays []time.Duration
+		for range games {
+			j := <-workQueue
+			j.nextCheck = delay
+			require.NoError(t, c.processResult(j))
+			state := c.states[j.addr]
+			delays = append(delays, state.nextCheck.Sub(state.lastActed))
+		}
+		return delays
+	}
+
+	t.Run("Distributed", func(t *testing.T) {
+		delays := nextChecks(t, 0.2, delay)
+		distinct := make(map[time.Duration]bool)
+		for _, d := range delays {
+			require.GreaterOrEqual(t, d, 8*time.Minute)
+			require.LessOrEqual(t, d, 12*time.Minute)
+			distinct[d] = true
+		}
+		require.Greater(t, len(distinct), len(delays)/2, "should spread out the next checks")
+		slices.Sort(delays)
+		require.Less(t, delays[0], delay, "should check some games early")
+		require.Greater(t, delays[len(delays)-1], delay, "should check some games late")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		for _, d := range nextChecks(t, 0, delay) {
+			require.Equal(t, delay, d)
+		}
+	})
+
+	t.Run("EveryBlock", func(t *testing.T) {
+		for _, d := range nextChecks(t, 0.2, 0) {
+			require.Zero(t, d, "should not delay games checked every block")
+		}
+	})
+}
+
+func TestTrackedGames(t *testing.T) {
+	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.clock = cl
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+	require.Empty(t, c.trackedGames())
+
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr2, gameAddr1)))
+	require.Equal(t, []TrackedGame{
+		{Game: gameAddr1, InFlight: true},
+		{Game: gameAddr2, InFlight: true},
+	}, c.trackedGames(), "should track scheduled games ordered by address")
+
+	cl.AdvanceTime(time.Minute)
+	for i := 0; i < 2; i++ {
+		j := <-workQueue
+		if j.addr == gameAddr1 {
+			j.resolved = true
+			j.status = types.GameStatusChallengerWon
+		}
+		require.NoError(t, c.processResult(j))
+	}
+	tracked := c.trackedGames()
+	require.Equal(t, []TrackedGame{
+		{Game: gameAddr1, Status: types.GameStatusChallengerWon, LastActed: cl.Now(), Resolved: true},
+		{Game: gameAddr2, Status: types.GameStatusInProgress, LastActed: cl.Now()},
+	}, tracked)
+
+	tracked[0].Status = types.GameStatusDefenderWon
+	require.Equal(t, types.GameStatusChallengerWon, c.trackedGames()[0].Status, "should return a snapshot")
+
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr2)))
+	require.Equal(t, []common.Address{gameAddr2}, []common.Address{c.trackedGames()[0].Game}, "should stop tracking dropped games")
+	require.Len(t, c.trackedGames(), 1)
+}
+
+func TestTrackedGamesIncludeFactory(t *testing.T) {
+	c, _, _, _, _ := setupCoordinatorTest(t, 10)
+	factory := common.Address{0xff}
+	require.NoError(t, c.schedule(context.Background(), []Game{{Addr: common.Address{0xaa}, Factory: factory}}))
+	require.Equal(t, factory, c.trackedGames()[0].Factory)
+}
+
+func TestScheduleInterleavesFactories(t *testing.T) {
+	c, workQueue, _, games, _ := setupCoordinatorTest(t, 10)
+	factory1 := common.Address{0xf1}
+	factory2 := common.Address{0xf2}
+	factory3 := common.Address{0xf3}
+	toSchedule := []Game{
+		{Addr: common.Address{0xa1}, Factory: factory1},
+		{Addr: common.Address{0xa2}, Factory: factory1},
+		{Addr: common.Address{0xa3}, Factory: factory1},
+		{Addr: common.Address{0xa4}, Factory: factory1},
+		{Addr: common.Address{0xb1}, Factory: factory2},
+		{Addr: common.Address{0xc1}, Factory: factory3},
+		{Addr: common.Address{0xb2}, Factory: factory2},
+	}
+	require.NoError(t, c.schedule(context.Background(), toSchedule))
+	require.Len(t, games.created, len(toSchedule))
+
+	var order []common.Address
+	for range toSchedule {
+		order = append(order, (<-workQueue).addr)
+	}
+	require.Equal(t, []common.Address{
+		{0xa1}, {0xb1}, {0xc1},
+		{0xa2}, {0xb2},
+		{0xa3},
+		{0xa4},
+	}, order, "should alternate between factories")
+}
+
+func TestScheduleDeprioritizedGamesLast(t *testing.T) {
+	c, workQueue, _, games, _ := setupCoordinatorTest(t, 10)
+	factory1 := common.Address{0xf1}
+	factory2 := common.Address{0xf2}
+	toSchedule := []Game{
+		{Addr: common.Address{0xa1}, Factory: factory1, Deprioritized: true},
+		{Addr: common.Address{0xa2}, Factory: factory1},
+		{Addr: common.Address{0xa3}, Factory: factory1, Deprioritized: true},
+		{Addr: common.Address{0xb1}, Factory: factory2, Deprioritized: true},
+		{Addr: common.Address{0xb2}, Factory: factory2},
+		{Addr: common.Address{0xa4}, Factory: factory1},
+	}
+	require.NoError(t, c.schedule(context.Background(), toSchedule))
+	require.Len(t, games.created, len(toSchedule))
+
+	var order []common.Address
+	for range toSchedule {
+		order = append(order, (<-workQueue).addr)
+	}
+	require.Equal(t, []common.Address{
+		{0xa2}, {0xb2}, {0xa4},
+		{0xa1}, {0xb1}, {0xa3},
+	}, order, "should schedule deprioritized games last, still alternating between factories")
+}
+
+func TestScheduleByClockDeadline(t *testing.T) {
+	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	m := &stubQueueMetrics{}
+	c.metrics = m
+	now := c.clock.Now()
+	soon := common.Address{0xa1}
+	later := common.Address{0xa2}
+	latest := common.Address{0xa3}
+	moreClaims := common.Address{0xa4}
+	unknown1 := common.Address{0xa5}
+	unknown2 := common.Address{0xa6}
+	deadlines := map[common.Address]time.Time{
+		soon:       now.Add(30 * time.Minute),
+		later:      now.Add(2 * time.Hour),
+		latest:     now.Add(48 * time.Hour),
+		moreClaims: now.Add(2 * time.Hour),
+	}
+	claims := map[common.Address]uint64{later: 3, moreClaims: 5}
+	toSchedule := gamesOf(unknown1, latest, later, unknown2, moreClaims, soon)
+	require.NoError(t, c.schedule(context.Background(), toSchedule))
+	for range toSchedule {
+		j := <-workQueue
+		j.clockDeadline = deadlines[j.addr]
+		j.claims = claims[j.addr]
+		require.NoError(t, c.processResult(j))
+	}
+	require.Equal(t, []string{PriorityUnknown, PriorityUnknown, PriorityUnknown, PriorityUnknown, PriorityUnknown, PriorityUnknown}, m.priorities, "should not know the clocks before the games are progressed")
+
+	m.priorities = nil
+	require.NoError(t, c.schedule(context.Background(), toSchedule))
+	var order []common.Address
+	for range toSchedule {
+		j := <-workQueue
+		order = append(order, j.addr)
+		require.NoError(t, c.processResult(j))
+	}
+	require.Equal(t, []common.Address{soon, moreClaims, later, latest, unknown1, unknown2}, order,
+		"should schedule the soonest clocks first, then the most claims, then unknown clocks in order")
+	require.Equal(t, []string{PriorityUnder1h, PriorityUnder24h, PriorityUnder24h, PriorityOver24h, PriorityUnknown, PriorityUnknown}, m.priorities)
+}
+
+func TestActNow(t *testing.T) {
+	game := common.Address{0xaa}
+	setup := func(t *testing.T) (*coordinator, <-chan job, <-chan job) {
+		c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+		urgentQueue := make(chan job, 10)
+		c.urgentQueue = urgentQueue
+		require.NoError(t, c.schedule(context.Background(), gamesOf(game)))
+		return c, workQueue, urgentQueue
+	}
+	actNow := func(c *coordinator, addr common.Address) <-chan actResponse {
+		result := make(chan actResponse, 1)
+		c.actNow(context.Background(), actRequest{game: addr, result: result})
+		return result
+	}
+
+	t.Run("UnknownGame", func(t *testing.T) {
+		c, _, urgentQueue := setup(t)
+		resp := <-actNow(c, common.Address{0xbb})
+		require.ErrorIs(t, resp.err, ErrGameNotTracked)
+		require.Empty(t, urgentQueue)
+	})
+
+	t.Run("ProgressAheadOfQueue", func(t *testing.T) {
+		c, workQueue, urgentQueue := setup(t)
+		j := <-workQueue
+		j.nextCheck = time.Hour
+		require.NoError(t, c.processResult(j))
+
+		result := actNow(c, game)
+		require.Len(t, urgentQueue, 1, "should progress the game even though it isn't due to be checked")
+		require.Empty(t, workQueue)
+		j = <-urgentQueue
+		require.Equal(t, PriorityImmediate, j.priority)
+		require.True(t, c.states[game].inflight)
+		require.Empty(t, result, "should wait for the progression")
+
+		j.claims = 5
+		j.status = types.GameStatusInProgress
+		require.NoError(t, c.processResult(j))
+		resp := <-result
+		require.NoError(t, resp.err)
+		require.Equal(t, types.ActResult{Game: game, Succeeded: true, Status: "In Progress", Claims: 5}, resp.result)
+	})
+
+	t.Run("CoalesceWithInFlight", func(t *testing.T) {
+		c, workQueue, urgentQueue := setup(t)
+		result1 := actNow(c, game)
+		result2 := actNow(c, game)
+		require.Empty(t, urgentQueue, "should not progress the game again while it is in flight")
+		require.Len(t, workQueue, 1)
+
+		j := <-workQueue
+		j.err = errors.New("boom")
+		j.claims = 3
+		require.NoError(t, c.processResult(j))
+		expected := types.ActResult{Game: game, Error: "boom", Status: "In Progress", Claims: 3}
+		for _, result := range []<-chan actResponse{result1, result2} {
+			resp := <-result
+			require.NoError(t, resp.err)
+			require.Equal(t, expected, resp.result)
+		}
+		require.Empty(t, c.states[game].waiters)
+	})
+
+	t.Run("ResolvedGame", func(t *testing.T) {
+		c, workQueue, urgentQueue := setup(t)
+		j := <-workQueue
+		j.resolved = true
+		require.NoError(t, c.processResult(j))
+		resp := <-actNow(c, game)
+		require.ErrorIs(t, resp.err, ErrGameResolved)
+		require.Empty(t, urgentQueue)
+	})
+}
+
 func TestResultForUnknownGame(t *testing.T) {
 	c, _, _, _, _ := setupCoordinatorTest(t, 10)
 	err := c.processResult(job{addr: common.Address{0xaa}})
@@ -111,7 +430,7 @@ func TestProcessResultsWhileJobQueueFull(t *testing.T) {
 
 	// Even though work queue length is only 1, should be able to schedule all three games
 	// by reading and processing results
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1, gameAddr2, gameAddr3}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1, gameAddr2, gameAddr3)))
 	require.Len(t, games.created, 3, "should have created 3 games")
 
 loop:
@@ -136,17 +455,8 @@ func TestDeleteDataForResolvedGames(t *testing.T) {
 	gameAddr3 := common.Address{0xcc}
 	ctx := context.Background()
 
-	// First get game 3 marked as resolved
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr3}))
-	require.Len(t, workQueue, 1)
-	j := <-workQueue
-	j.resolved = true
-	require.NoError(t, c.processResult(j))
-	// But ensure its data directory is marked as existing
-	disk.DirForGame(gameAddr3)
-
 	gameAddrs := []common.Address{gameAddr1, gameAddr2, gameAddr3}
-	require.NoError(t, c.schedule(ctx, gameAddrs))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddrs...)))
 
 	require.Len(t, workQueue, len(gameAddrs), "should schedule all games")
 
@@ -173,7 +483,7 @@ func TestDoNotDeleteDataForGameThatFailedToCreatePlayer(t *testing.T) {
 	games.creationFails = gameAddr1
 
 	gameAddrs := []common.Address{gameAddr1, gameAddr2}
-	err := c.schedule(ctx, gameAddrs)
+	err := c.schedule(ctx, gamesOf(gameAddrs...))
 	require.Error(t, err)
 
 	// Game 1 won't be scheduled because the player failed to be created
@@ -187,7 +497,7 @@ func TestDoNotDeleteDataForGameThatFailedToCreatePlayer(t *testing.T) {
 
 	// Should create player for game 1 next time its scheduled
 	games.creationFails = common.Address{}
-	require.NoError(t, c.schedule(ctx, gameAddrs))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddrs...)))
 	require.Len(t, workQueue, len(gameAddrs), "should schedule all games")
 
 	j := <-workQueue
@@ -197,6 +507,8 @@ func TestDoNotDeleteDataForGameThatFailedToCreatePlayer(t *testing.T) {
 
 func TestDropOldGameStates(t *testing.T) {
 	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	m := &stubQueueMetrics{}
+	c.metrics = m
 	gameAddr1 := common.Address{0xaa}
 	gameAddr2 := common.Address{0xbb}
 	gameAddr3 := common.Address{0xcc}
@@ -204,7 +516,7 @@ func TestDropOldGameStates(t *testing.T) {
 	ctx := context.Background()
 
 	// Start tracking game 1, 2 and 3
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr1, gameAddr2, gameAddr3}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr1, gameAddr2, gameAddr3)))
 	require.Len(t, workQueue, 3, "should schedule games")
 
 	// Complete processing of games 1 and 2, leaving 3 in flight
@@ -212,12 +524,13 @@ func TestDropOldGameStates(t *testing.T) {
 	require.NoError(t, c.processResult(<-workQueue))
 
 	// Next update only has games 2 and 4
-	require.NoError(t, c.schedule(ctx, []common.Address{gameAddr2, gameAddr4}))
+	require.NoError(t, c.schedule(ctx, gamesOf(gameAddr2, gameAddr4)))
 
 	require.NotContains(t, c.states, gameAddr1, "should drop state for game 1")
 	require.Contains(t, c.states, gameAddr2, "should keep state for game 2 (still active)")
 	require.Contains(t, c.states, gameAddr3, "should keep state for game 3 (inflight)")
 	require.Contains(t, c.states, gameAddr4, "should create state for game 4")
+	require.Equal(t, []common.Address{gameAddr1}, m.deleted, "should delete metrics of dropped game 1 only")
 }
 
 func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan job, chan job, *createdGames, *stubDiskManager) {
@@ -229,7 +542,7 @@ func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan jo
 		created: make(map[common.Address]*stubGame),
 	}
 	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
-	c := newCoordinator(logger, workQueue, resultQueue, games.CreateGame, disk)
+	c := newCoordinator(logger, metrics.NoopMetrics, clock.SystemClock, workQueue, nil, resultQueue, games.CreateGame, disk, 0)
 	return c, workQueue, resultQueue, games, disk
 }
 
@@ -238,6 +551,7 @@ type stubGame struct {
 	progressCount int
 	done          bool
 	dir           string
+	status        types.GameStatus
 }
 
 func (g *stubGame) ProgressGame(_ context.Context) bool {
@@ -245,6 +559,14 @@ func (g *stubGame) ProgressGame(_ context.Context) bool {
 	return g.done
 }
 
+func (g *stubGame) NextCheckDelay() time.Duration {
+	return 0
+}
+
+func (g *stubGame) Status() types.GameStatus {
+	return g.status
+}
+
 type createdGames struct {
 	t               *testing.T
 	createCompleted common.Address
@@ -252,7 +574,8 @@ type createdGames struct {
 	created         map[common.Address]*stubGame
 }
 
-func (c *createdGames) CreateGame(addr common.Address, dir string) (GamePlayer, error) {
+func (c *createdGames) CreateGame(g Game, dir string) (GamePlayer, error) {
+	addr := g.Addr
 	if c.creationFails == addr {
 		return nil, fmt.Errorf("refusing to create player for game: %v", addr)
 	}
@@ -268,6 +591,24 @@ func (c *createdGames) CreateGame(addr common.Address, dir string) (GamePlayer,
 	return game, nil
 }
 
+type stubQueueMetrics struct {
+	priorities []string
+	completed  []common.Address
+	deleted    []common.Address
+}
+
+func (s *stubQueueMetrics) RecordGameQueueTime(priority string, _ time.Duration) {
+	s.priorities = append(s.priorities, priority)
+}
+
+func (s *stubQueueMetrics) DeleteInProgressMetrics(game common.Address) {
+	s.completed = append(s.completed, game)
+}
+
+func (s *stubQueueMetrics) DeleteGameMetrics(game common.Address) {
+	s.deleted = append(s.deleted, game)
+}
+
 type stubDiskManager struct {
 	gameDirExists map[common.Address]bool
 	deletedDirs   []common.Address
@@ -288,3 +629,11 @@ func (s *stubDiskManager) RemoveAllExcept(addrs []common.Address) error {
 	}
 	return nil
 }
+
+func gamesOf(addrs ...common.Address) []Game {
+	games := make([]Game, 0, len(addrs))
+	for _, addr := range addrs {
+		games = append(games, Game{Addr: addr})
+	}
+	return games
+}
@@ -4,64 +4,123 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var ErrBusy = errors.New("busy scheduling previous update")
+var (
+	ErrBusy            = errors.New("busy scheduling previous update")
+	ErrStopped         = errors.New("scheduler stopped")
+	ErrShutdownTimeout = errors.New("timed out waiting for in-flight games")
+)
 
 type Scheduler struct {
 	logger         log.Logger
+	clock          clock.Clock
 	coordinator    *coordinator
 	maxConcurrency uint
-	scheduleQueue  chan []common.Address
+	scheduleQueue  chan []Game
+	actQueue       chan actRequest
 	jobQueue       chan job
+	urgentQueue    chan job
 	resultQueue    chan job
-	wg             sync.WaitGroup
-	cancel         func()
+	// loopDone is closed once the loop stops handling requests.
+	loopDone   chan struct{}
+	wg         sync.WaitGroup
+	workers    sync.WaitGroup
+	cancel     func()
+	cancelWork func()
+	stopped    atomic.Bool
 }
 
-func NewScheduler(logger log.Logger, disk DiskManager, maxConcurrency uint, createPlayer PlayerCreator) *Scheduler {
+// NewScheduler creates a [Scheduler] progressing up to maxConcurrency games at once.
+// The delay before each game is next progressed is randomly varied by up to the jitter fraction either way.
+// The time each game waits for a worker is recorded to m.
+func NewScheduler(logger log.Logger, m Metricer, cl clock.Clock, disk DiskManager, maxConcurrency uint, jitter float64, createPlayer PlayerCreator) *Scheduler {
 	// Size job and results queues to be fairly small so backpressure is applied early
 	// but with enough capacity to keep the workers busy
 	jobQueue := make(chan job, maxConcurrency*2)
 	resultQueue := make(chan job, maxConcurrency*2)
+	// Few games are progressed immediately, so they only need to wait for the next worker to become available.
+	urgentQueue := make(chan job, 1)
 
 	// scheduleQueue has a size of 1 so backpressure quickly propagates to the caller
 	// allowing them to potentially skip update cycles.
-	scheduleQueue := make(chan []common.Address, 1)
+	scheduleQueue := make(chan []Game, 1)
 
 	return &Scheduler{
 		logger:         logger,
-		coordinator:    newCoordinator(logger, jobQueue, resultQueue, createPlayer, disk),
+		clock:          cl,
+		coordinator:    newCoordinator(logger, m, cl, jobQueue, urgentQueue, resultQueue, createPlayer, disk, jitter),
 		maxConcurrency: maxConcurrency,
 		scheduleQueue:  scheduleQueue,
+		actQueue:       make(chan actRequest),
 		jobQueue:       jobQueue,
+		urgentQueue:    urgentQueue,
 		resultQueue:    resultQueue,
+		loopDone:       make(chan struct{}),
 	}
 }
 
 func (s *Scheduler) Start(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
+	// Games are progressed with a separate context so in-flight progressions can complete while stopping.
+	workCtx, cancelWork := context.WithCancel(context.Background())
+	s.cancelWork = cancelWork
 
 	for i := uint(0); i < s.maxConcurrency; i++ {
-		s.wg.Add(1)
-		go progressGames(ctx, s.jobQueue, s.resultQueue, &s.wg)
+		s.workers.Add(1)
+		go progressGames(ctx, workCtx, s.clock, s.urgentQueue, s.jobQueue, s.resultQueue, &s.workers)
 	}
 
 	s.wg.Add(1)
 	go s.loop(ctx)
 }
 
+// Close stops the scheduler, cancelling any in-flight game progressions.
 func (s *Scheduler) Close() error {
+	return s.Stop(0)
+}
+
+// Stop stops the scheduler from starting new game progressions and waits up to gracePeriod for in-flight
+// progressions to complete. If the grace period expires, in-flight progressions are cancelled and
+// ErrShutdownTimeout is returned.
+func (s *Scheduler) Stop(gracePeriod time.Duration) error {
+	s.stopped.Store(true)
 	s.cancel()
 	s.wg.Wait()
-	return nil
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	var err error
+	if gracePeriod > 0 {
+		timer := s.clock.NewTimer(gracePeriod)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.Ch():
+			err = ErrShutdownTimeout
+		}
+	}
+	s.cancelWork()
+	<-done
+	return err
 }
 
-func (s *Scheduler) Schedule(games []common.Address) error {
+// Schedule progresses games that are due to be checked again, and stops tracking any games not included.
+func (s *Scheduler) Schedule(games []Game) error {
+	if s.stopped.Load() {
+		return ErrStopped
+	}
 	select {
 	case s.scheduleQueue <- games:
 		return nil
@@ -70,8 +129,40 @@ func (s *Scheduler) Schedule(games []common.Address) error {
 	}
 }
 
+// ActNow progresses game as soon as a worker is available, ahead of the games queued to be progressed, and
+// returns the outcome. If the game is already being progressed, the outcome of that progression is returned rather
+// than progressing it again. Returns [ErrGameNotTracked] if the game isn't being played.
+func (s *Scheduler) ActNow(ctx context.Context, game common.Address) (types.ActResult, error) {
+	if s.stopped.Load() {
+		return types.ActResult{}, ErrStopped
+	}
+	result := make(chan actResponse, 1)
+	select {
+	case s.actQueue <- actRequest{game: game, result: result}:
+	case <-s.loopDone:
+		return types.ActResult{}, ErrStopped
+	case <-ctx.Done():
+		return types.ActResult{}, ctx.Err()
+	}
+	select {
+	case resp := <-result:
+		return resp.result, resp.err
+	case <-s.loopDone:
+		return types.ActResult{}, ErrStopped
+	case <-ctx.Done():
+		return types.ActResult{}, ctx.Err()
+	}
+}
+
+// TrackedGames returns a summary of each game being played, ordered by address.
+// It is safe to call while games are being progressed.
+func (s *Scheduler) TrackedGames() []TrackedGame {
+	return s.coordinator.trackedGames()
+}
+
 func (s *Scheduler) loop(ctx context.Context) {
 	defer s.wg.Done()
+	defer close(s.loopDone)
 	for {
 		select {
 		case <-ctx.Done():
@@ -80,6 +171,8 @@ func (s *Scheduler) loop(ctx context.Context) {
 			if err := s.coordinator.schedule(ctx, games); err != nil {
 				s.logger.Error("Failed to schedule game updates", "games", games, "err", err)
 			}
+		case req := <-s.actQueue:
+			s.coordinator.actNow(ctx, req)
 		case j := <-s.resultQueue:
 			if err := s.coordinator.processResult(j); err != nil {
 				s.logger.Error("Error while processing game result", "game", j.addr, "err", err)
@@ -2,9 +2,15 @@ package scheduler
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
@@ -13,12 +19,12 @@ import (
 func TestSchedulerProcessesGames(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	ctx := context.Background()
-	createPlayer := func(addr common.Address, dir string) (GamePlayer, error) {
+	createPlayer := func(game Game, dir string) (GamePlayer, error) {
 		return &stubPlayer{}, nil
 	}
 	removeExceptCalls := make(chan []common.Address)
 	disk := &trackingDiskManager{removeExceptCalls: removeExceptCalls}
-	s := NewScheduler(logger, disk, 2, createPlayer)
+	s := NewScheduler(logger, metrics.NoopMetrics, clock.SystemClock, disk, 2, 0, createPlayer)
 	s.Start(ctx)
 
 	gameAddr1 := common.Address{0xaa}
@@ -26,7 +32,7 @@ func TestSchedulerProcessesGames(t *testing.T) {
 	gameAddr3 := common.Address{0xcc}
 	games := []common.Address{gameAddr1, gameAddr2, gameAddr3}
 
-	require.NoError(t, s.Schedule(games))
+	require.NoError(t, s.Schedule(gamesOf(games...)))
 
 	// All jobs should be executed and completed, the last step being to clean up disk resources
 	for i := 0; i < len(games); i++ {
@@ -41,21 +47,215 @@ func TestSchedulerProcessesGames(t *testing.T) {
 
 func TestReturnBusyWhenScheduleQueueFull(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	createPlayer := func(addr common.Address, dir string) (GamePlayer, error) {
+	createPlayer := func(game Game, dir string) (GamePlayer, error) {
 		return &stubPlayer{}, nil
 	}
 	removeExceptCalls := make(chan []common.Address)
 	disk := &trackingDiskManager{removeExceptCalls: removeExceptCalls}
-	s := NewScheduler(logger, disk, 2, createPlayer)
+	s := NewScheduler(logger, metrics.NoopMetrics, clock.SystemClock, disk, 2, 0, createPlayer)
 
 	// Scheduler not started - first call fills the queue
-	require.NoError(t, s.Schedule([]common.Address{{0xaa}}))
+	require.NoError(t, s.Schedule(gamesOf(common.Address{0xaa})))
 
 	// Second call should return busy
-	err := s.Schedule([]common.Address{{0xaa}})
+	err := s.Schedule(gamesOf(common.Address{0xaa}))
 	require.ErrorIs(t, err, ErrBusy)
 }
 
+func TestStopWaitsForInFlightGames(t *testing.T) {
+	setup := func(t *testing.T) (*Scheduler, *clock.DeterministicClock, *blockingPlayer) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		cl := clock.NewDeterministicClock(time.Unix(0, 0))
+		player := newBlockingPlayer()
+		createPlayer := func(game Game, dir string) (GamePlayer, error) {
+			return player, nil
+		}
+		disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+		s := NewScheduler(logger, metrics.NoopMetrics, cl, disk, 2, 0, createPlayer)
+		s.Start(context.Background())
+		require.NoError(t, s.Schedule(gamesOf(common.Address{0xaa})))
+		readWithTimeout(t, player.started)
+		return s, cl, player
+	}
+
+	t.Run("AllComplete", func(t *testing.T) {
+		s, _, player := setup(t)
+		result := make(chan error, 1)
+		go func() {
+			result <- s.Stop(time.Minute)
+		}()
+		close(player.release)
+		require.NoError(t, readWithTimeout(t, result))
+		require.False(t, player.cancelled)
+		require.ErrorIs(t, s.Schedule(gamesOf(common.Address{0xbb})), ErrStopped)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		s, cl, player := setup(t)
+		result := make(chan error, 1)
+		go func() {
+			result <- s.Stop(time.Minute)
+		}()
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+		cl.AdvanceTime(time.Minute)
+		require.ErrorIs(t, readWithTimeout(t, result), ErrShutdownTimeout)
+		require.True(t, player.cancelled)
+	})
+}
+
+// TestSlowGameDoesNotBlockOthers tests that a game that is slow to progress only holds up its own worker, so other
+// games continue to be progressed, and that it is cancelled when the scheduler closes.
+func TestSlowGameDoesNotBlockOthers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+	logger.SetHandler(handler)
+	slowGame := common.Address{0xaa}
+	fastGames := []common.Address{{0xbb}, {0xcc}}
+	slow := newBlockingPlayer()
+	progressed := make(chan common.Address, 10)
+	createPlayer := func(game Game, dir string) (GamePlayer, error) {
+		if game.Addr == slowGame {
+			return slow, nil
+		}
+		return &notifyingPlayer{addr: game.Addr, progressed: progressed}, nil
+	}
+	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
+	s := NewScheduler(logger, metrics.NoopMetrics, clock.SystemClock, disk, 2, 0, createPlayer)
+	s.Start(context.Background())
+
+	games := gamesOf(append([]common.Address{slowGame}, fastGames...)...)
+	require.NoError(t, s.Schedule(games))
+	readWithTimeout(t, slow.started)
+	counts := make(map[common.Address]int)
+	for counts[fastGames[0]] < 3 || counts[fastGames[1]] < 3 {
+		counts[readWithTimeout(t, progressed)]++
+		// Reschedule until accepted, as the previous update may still be being scheduled.
+		require.Eventually(t, func() bool {
+			return s.Schedule(games) == nil
+		}, 10*time.Second, time.Millisecond)
+	}
+
+	require.NoError(t, s.Close())
+	require.True(t, slow.cancelled, "should cancel the in-flight slow game")
+	record := handler.FindLog(log.LvlDebug, "Progressed game")
+	require.NotNil(t, record, "should log the result of each progression")
+	require.Contains(t, fastGames, record.GetContextValue("game"))
+}
+
+// TestSchedulerActNow tests that a game can be progressed on request, between the progressions scheduled for it.
+func TestSchedulerActNow(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	game := common.Address{0xaa}
+	player := &countingPlayer{claims: 4}
+	createPlayer := func(g Game, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
+	s := NewScheduler(logger, metrics.NoopMetrics, clock.SystemClock, disk, 2, 0, createPlayer)
+	s.Start(context.Background())
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+
+	_, err := s.ActNow(context.Background(), game)
+	require.ErrorIs(t, err, ErrGameNotTracked)
+
+	require.NoError(t, s.Schedule(gamesOf(game)))
+	require.Eventually(t, func() bool {
+		tracked := s.TrackedGames()
+		return len(tracked) == 1 && !tracked[0].InFlight && !tracked[0].LastActed.IsZero()
+	}, 10*time.Second, time.Millisecond)
+	require.EqualValues(t, 1, player.count.Load())
+
+	result, err := s.ActNow(context.Background(), game)
+	require.NoError(t, err)
+	require.Equal(t, types.ActResult{Game: game, Succeeded: true, Status: "In Progress", Claims: 4}, result)
+	require.EqualValues(t, 2, player.count.Load(), "should progress the game once more")
+}
+
+// countingPlayer is a GamePlayer that counts the times it is progressed and reports its claim count.
+type countingPlayer struct {
+	count  atomic.Int32
+	claims uint64
+}
+
+func (p *countingPlayer) ProgressGame(_ context.Context) bool {
+	p.count.Add(1)
+	return false
+}
+
+func (p *countingPlayer) NextCheckDelay() time.Duration {
+	return time.Hour
+}
+
+func (p *countingPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+func (p *countingPlayer) ClockDeadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (p *countingPlayer) ClaimCount() uint64 {
+	return p.claims
+}
+
+// notifyingPlayer is a GamePlayer that sends its address to progressed each time it is progressed.
+type notifyingPlayer struct {
+	addr       common.Address
+	progressed chan<- common.Address
+}
+
+func (p *notifyingPlayer) ProgressGame(ctx context.Context) bool {
+	select {
+	case p.progressed <- p.addr:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+func (p *notifyingPlayer) NextCheckDelay() time.Duration {
+	return 0
+}
+
+func (p *notifyingPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+// blockingPlayer is a GamePlayer that blocks in ProgressGame until it is released or its context is done.
+type blockingPlayer struct {
+	started   chan struct{}
+	release   chan struct{}
+	once      sync.Once
+	cancelled bool
+}
+
+func newBlockingPlayer() *blockingPlayer {
+	return &blockingPlayer{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (p *blockingPlayer) ProgressGame(ctx context.Context) bool {
+	p.once.Do(func() { close(p.started) })
+	select {
+	case <-p.release:
+		return true
+	case <-ctx.Done():
+		p.cancelled = true
+		return false
+	}
+}
+
+func (p *blockingPlayer) NextCheckDelay() time.Duration {
+	return 0
+}
+
+func (p *blockingPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
 type trackingDiskManager struct {
 	removeExceptCalls chan []common.Address
 }
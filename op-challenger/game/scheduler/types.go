@@ -2,12 +2,64 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type GamePlayer interface {
 	ProgressGame(ctx context.Context) bool
+	// NextCheckDelay returns how long to wait after the last ProgressGame before progressing the game again.
+	NextCheckDelay() time.Duration
+	// Status returns the game status loaded by the last ProgressGame.
+	Status() types.GameStatus
+}
+
+// PrioritizedPlayer is optionally implemented by a [GamePlayer] to report how urgently the game needs to be
+// progressed, from the claims loaded by the last ProgressGame.
+type PrioritizedPlayer interface {
+	// ClockDeadline returns when the soonest expiring chess clock runs out, or false if it is unknown or no clock
+	// is running.
+	ClockDeadline() (time.Time, bool)
+	// ClaimCount returns the number of claims in the game.
+	ClaimCount() uint64
+}
+
+// ProgressErrorReporter is optionally implemented by a [GamePlayer] to report the error that occurred during the
+// last ProgressGame, which is reported to requests to progress the game immediately.
+type ProgressErrorReporter interface {
+	ProgressError() error
+}
+
+// Metricer records the time games spend queued for a worker and removes the metrics of games once complete.
+type Metricer interface {
+	RecordGameQueueTime(priority string, duration time.Duration)
+	// DeleteInProgressMetrics is called when the game is complete.
+	DeleteInProgressMetrics(game common.Address)
+	// DeleteGameMetrics is called when the game is no longer played.
+	DeleteGameMetrics(game common.Address)
+}
+
+// Game is a game to play and the dispute game factory that created it.
+type Game struct {
+	Addr    common.Address
+	Factory common.Address
+	// Deprioritized is true if the game is only progressed after the other games due to be progressed.
+	Deprioritized bool
+}
+
+// TrackedGame is a summary of a game being played.
+type TrackedGame struct {
+	Game    common.Address
+	Factory common.Address
+	Status  types.GameStatus
+	// LastActed is when the game was last progressed. Zero if it hasn't been progressed yet.
+	LastActed time.Time
+	// InFlight is true if the game is being progressed.
+	InFlight bool
+	// Resolved is true if the game is complete and no longer progressed.
+	Resolved bool
 }
 
 type DiskManager interface {
@@ -16,7 +68,23 @@ type DiskManager interface {
 }
 
 type job struct {
-	addr     common.Address
-	player   GamePlayer
-	resolved bool
+	addr      common.Address
+	player    GamePlayer
+	resolved  bool
+	status    types.GameStatus
+	nextCheck time.Duration
+	// duration is how long ProgressGame took.
+	duration time.Duration
+
+	// priority is the bucket of the remaining clock when the job was queued, and queued is when it was queued.
+	priority string
+	queued   time.Time
+	// queueTime is how long the job waited for a worker.
+	queueTime time.Duration
+	// clockDeadline and claims are reported by a [PrioritizedPlayer] after ProgressGame.
+	// clockDeadline is zero if unknown.
+	clockDeadline time.Time
+	claims        uint64
+	// err is the error reported by a [ProgressErrorReporter] after ProgressGame.
+	err error
 }
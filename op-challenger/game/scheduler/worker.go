@@ -3,20 +3,55 @@ package scheduler
 import (
 	"context"
 	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
-// progressGames accepts jobs from in channel, calls ProgressGame on the job.player and returns the job
-// with updated job.resolved via the out channel.
-// The loop exits when the ctx is done.  wg.Done() is called when the function returns.
-func progressGames(ctx context.Context, in <-chan job, out chan<- job, wg *sync.WaitGroup) {
+// progressGames accepts jobs from the urgent and in channels, calls ProgressGame on the job.player and returns the job
+// with updated job.resolved, job.status, job.nextCheck, job.duration and job.queueTime via the out channel, along with
+// the clock deadline and claim count of a [PrioritizedPlayer] and the error of a [ProgressErrorReporter].
+// Jobs from the urgent channel are progressed before any waiting in the in channel.
+// Each worker progresses one game at a time, so a slow game only holds up its own worker.
+// The loop exits when the ctx is done, but ProgressGame is called with workCtx so that a job already in progress
+// can complete. Jobs received after ctx is done are dropped. wg.Done() is called when the function returns.
+func progressGames(ctx context.Context, workCtx context.Context, cl clock.Clock, urgent <-chan job, in <-chan job, out chan<- job, wg *sync.WaitGroup) {
 	defer wg.Done()
 	for {
+		var j job
+		select {
+		case <-ctx.Done():
+			return
+		case j = <-urgent:
+		default:
+			select {
+			case <-ctx.Done():
+				return
+			case j = <-urgent:
+			case j = <-in:
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		start := cl.Now()
+		j.queueTime = start.Sub(j.queued)
+		j.resolved = j.player.ProgressGame(workCtx)
+		j.duration = cl.Now().Sub(start)
+		j.status = j.player.Status()
+		j.nextCheck = j.player.NextCheckDelay()
+		if p, ok := j.player.(PrioritizedPlayer); ok {
+			if deadline, ok := p.ClockDeadline(); ok {
+				j.clockDeadline = deadline
+			}
+			j.claims = p.ClaimCount()
+		}
+		if r, ok := j.player.(ProgressErrorReporter); ok {
+			j.err = r.ProgressError()
+		}
 		select {
+		case out <- j:
 		case <-ctx.Done():
 			return
-		case j := <-in:
-			j.resolved = j.player.ProgressGame(ctx)
-			out <- j
 		}
 	}
 }
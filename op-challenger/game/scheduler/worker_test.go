@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,13 +20,13 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 	defer cancel()
 	var wg sync.WaitGroup
 	wg.Add(1)
-	go progressGames(ctx, in, out, &wg)
+	go progressGames(ctx, ctx, clock.SystemClock, nil, in, out, &wg)
 
 	in <- job{
 		player: &stubPlayer{done: false},
 	}
 	in <- job{
-		player: &stubPlayer{done: true},
+		player: &stubPlayer{done: true, nextCheck: time.Minute, status: types.GameStatusDefenderWon},
 	}
 
 	result1 := readWithTimeout(t, out)
@@ -31,20 +34,101 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 
 	require.Equal(t, result1.resolved, false)
 	require.Equal(t, result2.resolved, true)
+	require.Equal(t, result1.nextCheck, time.Duration(0))
+	require.Equal(t, result2.nextCheck, time.Minute)
+	require.Equal(t, types.GameStatusInProgress, result1.status)
+	require.Equal(t, types.GameStatusDefenderWon, result2.status)
 
 	// Cancel the context which should exit the worker
 	cancel()
 	wg.Wait()
 }
 
+func TestWorkerShouldReportPriority(t *testing.T) {
+	in := make(chan job, 2)
+	out := make(chan job, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	go progressGames(ctx, ctx, cl, nil, in, out, &wg)
+
+	deadline := time.Unix(5000, 0)
+	in <- job{
+		player: &stubPlayer{},
+		queued: time.Unix(990, 0),
+	}
+	in <- job{
+		player: &stubPrioritizedPlayer{deadline: deadline, claims: 7},
+	}
+
+	result1 := readWithTimeout(t, out)
+	result2 := readWithTimeout(t, out)
+
+	require.Equal(t, 10*time.Second, result1.queueTime)
+	require.Zero(t, result1.clockDeadline)
+	require.Zero(t, result1.claims)
+	require.Equal(t, deadline, result2.clockDeadline)
+	require.Equal(t, uint64(7), result2.claims)
+
+	cancel()
+	wg.Wait()
+}
+
 type stubPlayer struct {
-	done bool
+	done      bool
+	nextCheck time.Duration
+	status    types.GameStatus
 }
 
 func (s *stubPlayer) ProgressGame(ctx context.Context) bool {
 	return s.done
 }
 
+func (s *stubPlayer) NextCheckDelay() time.Duration {
+	return s.nextCheck
+}
+
+func (s *stubPlayer) Status() types.GameStatus {
+	return s.status
+}
+
+func TestWorkerShouldProgressUrgentJobsFirst(t *testing.T) {
+	urgent := make(chan job, 1)
+	in := make(chan job, 1)
+	out := make(chan job, 2)
+	in <- job{addr: common.Address{0xaa}, player: &stubPlayer{}}
+	urgent <- job{addr: common.Address{0xbb}, player: &stubPlayer{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go progressGames(ctx, ctx, clock.SystemClock, urgent, in, out, &wg)
+
+	require.Equal(t, common.Address{0xbb}, readWithTimeout(t, out).addr)
+	require.Equal(t, common.Address{0xaa}, readWithTimeout(t, out).addr)
+
+	cancel()
+	wg.Wait()
+}
+
+type stubPrioritizedPlayer struct {
+	stubPlayer
+	deadline time.Time
+	claims   uint64
+}
+
+func (s *stubPrioritizedPlayer) ClockDeadline() (time.Time, bool) {
+	return s.deadline, !s.deadline.IsZero()
+}
+
+func (s *stubPrioritizedPlayer) ClaimCount() uint64 {
+	return s.claims
+}
+
 func readWithTimeout[T any](t *testing.T, ch <-chan T) T {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -2,43 +2,110 @@ package game
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
 )
 
-type Service struct {
+// stepPregenWorkers is the number of workers generating step data in the background, shared by all games.
+// Generating a cannon proof is expensive so a single worker keeps pre-generation from competing with the moves
+// being made in games.
+const stepPregenWorkers = 1
+
+var errUnknownFactory = errors.New("game from unknown factory")
+
+// factoryPlayers are the settings for playing the games of a single dispute game factory.
+type factoryPlayers struct {
+	cfg     *config.Config
 	logger  log.Logger
 	metrics metrics.Metricer
-	monitor *gameMonitor
-	sched   *scheduler.Scheduler
+}
+
+type Service struct {
+	logger              log.Logger
+	metrics             metrics.Metricer
+	monitor             *gameMonitor
+	sched               *scheduler.Scheduler
+	retention           *retentionDiskManager
+	pendingTxs          *pendingTxBackend
+	shutdownGracePeriod time.Duration
+	shutdownGuard       *shutdownGuard
+	eventLog            *jsonlEventSink
+	results             *asyncResultSink
+	pregen              *fault.StepPregenerator
+	rpcServer           *rpc.Server
+	actTrigger          *actTrigger
 }
 
 // NewService creates a new Service.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
+	return NewServiceWithRegistry(ctx, logger, cfg, opmetrics.NewRegistry())
+}
+
+// NewServiceWithRegistry creates a new Service that registers its metrics with metricsRegistry, so that services
+// embedded in the same process can share a registry. Each service sharing a registry must be configured with a
+// different metrics instance.
+func NewServiceWithRegistry(ctx context.Context, logger log.Logger, cfg *config.Config, metricsRegistry *prometheus.Registry) (*Service, error) {
 	cl := clock.SystemClock
-	m := metrics.NewMetrics()
-	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &m.TxMetrics, cfg.TxMgrConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+	mode := ""
+	if cfg.MonitorOnly {
+		mode = metrics.ModeMonitor
 	}
+	m := metrics.NewMetricsWithRegistry(metricsRegistry, cfg.MetricsInstance, mode, cfg.GameFactoryAddress, cfg.MetricsLabelByFactory)
+	health := newHealthTracker(cl)
+	skew := newClockSkewEstimator(logger, cl, m, cfg.ClockSkewThreshold)
+	// l1Time is used wherever local time is compared with L1 block timestamps.
+	l1Time := &l1Clock{Clock: cl, skew: skew}
 
 	client, err := client.DialEthClientWithTimeout(client.DefaultDialTimeout, logger, cfg.L1EthRpc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial L1: %w", err)
 	}
 
+	var txMgr txmgr.TxManager
+	var pendingTxs *pendingTxBackend
+	if cfg.MonitorOnly {
+		logger.Info("Monitoring games only, no transactions will be sent")
+		pendingTxs = newPendingTxBackend(client)
+		txMgr = &readOnlyTxManager{backend: client}
+	} else {
+		txMgrConfig, err := txmgr.NewConfig(cfg.TxMgrConfig, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+		}
+		txMgrConfig.Backend = &responder.FeePolicyBackend{
+			ETHBackend: txMgrConfig.Backend,
+			Policy: responder.FeePolicy{
+				MinTip:           cfg.MinMoveTipWei(),
+				MaxTip:           cfg.MaxMoveTipWei(),
+				UrgentMultiplier: cfg.UrgentTipMultiplier,
+			},
+		}
+		pendingTxs = newPendingTxBackend(txMgrConfig.Backend)
+		txMgrConfig.Backend = &nonceHealthBackend{ETHBackend: pendingTxs, health: health}
+		txMgr = txmgr.NewSimpleTxManagerFromConfig("challenger", logger, &m.TxMetrics, txMgrConfig)
+	}
+
 	pprofConfig := cfg.PprofConfig
 	if pprofConfig.Enabled {
 		logger.Info("starting pprof", "addr", pprofConfig.ListenAddr, "port", pprofConfig.ListenPort)
@@ -57,40 +124,286 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 				logger.Error("error starting metrics server", "err", err)
 			}
 		}()
-		m.StartBalanceMetrics(ctx, logger, client, txMgr.From())
+		if !cfg.MonitorOnly {
+			m.StartBalanceMetrics(ctx, logger, client, txMgr.From())
+		}
 	}
 
-	factory, err := bindings.NewDisputeGameFactory(cfg.GameFactoryAddress, client)
+	var disk scheduler.DiskManager = newDiskManager(cfg.Datadir)
+	if cfg.GameLogs {
+		logs := newGameLogHandler(logger.GetHandler(), cfg.GameLogLevel, disk.DirForGame, DefaultGameLogMaxSize)
+		logger = logger.New()
+		logger.SetHandler(logs)
+		disk = &gameLogDiskManager{DiskManager: disk, logs: logs}
+	}
+	if cfg.TraceDiskCacheDir != "" {
+		logger.Info("Persisting trace data", "dir", cfg.TraceDiskCacheDir)
+		disk = &traceCacheDiskManager{DiskManager: disk, dir: cfg.TraceDiskCacheDir}
+	}
+	statuses := fault.NewStatusRegistry(cl)
+	disk = &statusDiskManager{DiskManager: disk, statuses: statuses}
+	progress := fault.NewProgressTracker(cl)
+	disk = &progressDiskManager{DiskManager: disk, progress: progress}
+	disk = &healthDiskManager{DiskManager: disk, health: health}
+	loadStatus := func(ctx context.Context, game common.Address) (types.GameStatus, error) {
+		loader, err := fault.NewLoaderFromBindings(logger, game, client, 0)
+		if err != nil {
+			return 0, err
+		}
+		return loader.GetGameStatus(ctx)
+	}
+	retention := newRetentionDiskManager(logger, cl, m, disk, cfg.Datadir, loadStatus, cfg.ResolvedGameRetention, cfg.PreserveLostGameData)
+	disk = retention
+	retainData := cfg.ResolvedGameRetention != 0 || cfg.PreserveLostGameData
+	if retainData {
+		logger.Info("Retaining data of resolved games", "retention", cfg.ResolvedGameRetention, "preserve_lost", cfg.PreserveLostGameData)
+	}
+	registry := cannon.NewProviderRegistry(logger, cfg.Datadir, m, retainData)
+	clocks := fault.NewClockTracker()
+	abandoned := fault.NewAbandonedGames(cl, disk.DirForGame)
+	journal, err := fault.NewActionJournal(logger, client, disk.DirForGame)
 	if err != nil {
-		return nil, fmt.Errorf("failed to bind the fault dispute game factory contract: %w", err)
+		return nil, fmt.Errorf("failed to create the action journal: %w", err)
+	}
+	// Set once the journal can be created, before any transactions are sent.
+	pendingTxs.onSent = journal.RecordSent
+	var sinks eventSinks
+	var eventLog *jsonlEventSink
+	if cfg.EventLog != "" {
+		logger.Info("Writing game events", "path", cfg.EventLog)
+		eventLog = newJSONLEventSink(logger, cl, cfg.EventLog, int64(cfg.EventLogMaxSize))
+		sinks = append(sinks, eventLog)
+	}
+	var results *asyncResultSink
+	var opponentHistory []opponentGame
+	if cfg.ResultStoreSQLite != "" {
+		logger.Info("Recording game results", "path", cfg.ResultStoreSQLite)
+		store, err := openSQLiteResultStore(ctx, cfg.ResultStoreSQLite)
+		if err != nil {
+			return nil, err
+		}
+		opponentHistory, err = store.OpponentGames(ctx)
+		if err != nil {
+			_ = store.Close()
+			return nil, err
+		}
+		results = newAsyncResultSink(logger, m, cl, store)
+		sinks = append(sinks, results)
+	}
+	fetchCreator := func(ctx context.Context, game common.Address) (common.Address, error) {
+		loader, err := fault.NewLoaderFromBindings(logger, game, client, 0)
+		if err != nil {
+			return common.Address{}, err
+		}
+		root, err := loader.FetchRootClaim(ctx)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return root.Claimant, nil
+	}
+	if cfg.DeprioritizeAfterLosses > 0 {
+		logger.Info("Deprioritizing games of addresses that lose too many games", "losses", cfg.DeprioritizeAfterLosses, "window", cfg.DeprioritizeWindow)
+	}
+	// The tracker records the creator of each game with the other sinks, and the outcome of the games from them.
+	opponents := newOpponentTracker(logger, m, cl, sinks, fetchCreator, cfg.DeprioritizeAfterLosses, cfg.DeprioritizeWindow, opponentHistory)
+	events := append(sinks, opponents)
+	var pregen *fault.StepPregenerator
+	if cfg.StepPregenDepth > 0 {
+		logger.Info("Pre-generating step data", "depth", cfg.StepPregenDepth)
+		pregen = fault.NewStepPregenerator(logger, stepPregenWorkers)
+	}
+	var traceLimiter *rate.Limiter
+	if cfg.TraceRateLimit > 0 {
+		logger.Info("Rate limiting trace providers", "rate", cfg.TraceRateLimit, "burst", cfg.TraceRateBurst)
+		traceLimiter = rate.NewLimiter(rate.Limit(cfg.TraceRateLimit), int(cfg.TraceRateBurst))
+	}
+	var breaker *responder.CircuitBreaker
+	if cfg.BreakerFailures > 0 {
+		logger.Info("Halting transactions after consecutive failures", "failures", cfg.BreakerFailures, "window", cfg.BreakerWindow, "probe_interval", cfg.BreakerProbeInterval)
+		breaker = responder.NewCircuitBreaker(logger, cl, m, cfg.BreakerFailures, cfg.BreakerWindow, cfg.BreakerProbeInterval)
+	}
+	factories := cfg.GameFactories()
+	players := make(map[common.Address]factoryPlayers, len(factories))
+	sources := make([]factorySource, 0, len(factories))
+	for i, factoryAddr := range factories {
+		factoryCfg, err := cfg.ForFactory(factoryAddr)
+		if err != nil {
+			return nil, err
+		}
+		factoryLogger := logger
+		if len(factories) > 1 {
+			factoryLogger = logger.New("factory", factoryAddr)
+			factoryLogger.Info("Playing games from factory")
+		}
+		factory, err := bindings.NewDisputeGameFactory(factoryAddr, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind the fault dispute game factory contract %v: %w", factoryAddr, err)
+		}
+		loader := NewGameLoader(factory)
+		sources = append(sources, factorySource{
+			factory: factoryAddr,
+			source:  newGameSync(factoryLogger, loader, gameSyncPath(cfg.Datadir, factoryAddr, i == 0), factoryAddr, gameSyncChunkSize),
+		})
+		players[factoryAddr] = factoryPlayers{cfg: &factoryCfg, logger: factoryLogger, metrics: m.ForFactory(factoryAddr)}
 	}
-	loader := NewGameLoader(factory)
-
-	disk := newDiskManager(cfg.Datadir)
 	sched := scheduler.NewScheduler(
 		logger,
+		m,
+		cl,
 		disk,
 		cfg.MaxConcurrency,
-		func(addr common.Address, dir string) (scheduler.GamePlayer, error) {
-			return fault.NewGamePlayer(ctx, logger, cfg, dir, addr, txMgr, client)
+		cfg.ScheduleJitter,
+		func(game scheduler.Game, dir string) (scheduler.GamePlayer, error) {
+			f, ok := players[game.Factory]
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", errUnknownFactory, game.Factory)
+			}
+			return fault.NewGamePlayer(ctx, f.logger, f.metrics, f.cfg, dir, game.Addr, txMgr, client, l1Time, fault.GamePlayerDeps{
+				Registry:     registry,
+				Clocks:       clocks,
+				Progress:     progress,
+				Events:       events,
+				Abandoned:    abandoned,
+				Statuses:     statuses,
+				Journal:      journal,
+				Pregen:       pregen,
+				TraceLimiter: traceLimiter,
+				Breaker:      breaker,
+				Health:       health,
+				OnResolved:   retention.RecordResolved,
+			})
 		})
 
-	monitor := newGameMonitor(logger, cl, loader, sched, cfg.GameWindow, client.BlockNumber, cfg.GameAllowlist)
+	gameTraceTypes, err := cfg.GameTraceTypes()
+	if err != nil {
+		return nil, err
+	}
+	gameTypes := make([]uint8, 0, len(gameTraceTypes))
+	for gameType, traceType := range gameTraceTypes {
+		logger.Info("Playing games", "game_type", gameType, "trace_type", traceType)
+		gameTypes = append(gameTypes, gameType)
+	}
+	var funds fundsWatcher
+	if !cfg.MonitorOnly {
+		funds = newBalanceWatcher(logger, m, client, txMgr.From(), statuses, cfg.MoveBondWei(), cfg.StopNewGamesUnderfunded)
+	}
+	source := newMultiFactorySource(logger, sources)
+	monitor := newGameMonitor(logger, m, l1Time, source, sched, cfg.GameWindow, skew.BlockNumberFetcher(client.HeaderByNumber), cfg.GameAllowlist, cfg.GameDenylist, gameTypes, health, funds, opponents)
+
+	info := newVersionInfo(ctx, logger, cfg)
+	var rpcServer *rpc.Server
+	rpcCfg := cfg.RPCConfig
+	if rpcCfg.Enabled {
+		logger.Info("starting RPC server", "addr", rpcCfg.ListenAddr, "port", rpcCfg.ListenPort)
+		rpcServer = rpc.NewServer(logger, rpcCfg.ListenAddr, rpcCfg.ListenPort)
+		if err := rpcServer.AddAPI("challenger", rpc.NewChallengerAPI(info, statuses, opponents)); err != nil {
+			return nil, fmt.Errorf("failed to register the challenger API: %w", err)
+		}
+		if rpcCfg.EnableAdmin {
+			logger.Info("Admin API enabled")
+			if err := rpcServer.AddAPI("admin", rpc.NewAdminAPI(newTrackedAbandoner(abandoned, sched), sched)); err != nil {
+				return nil, fmt.Errorf("failed to register the admin API: %w", err)
+			}
+		}
+		rpcServer.AddHandler(rpc.HealthPath, rpc.NewHealthHandler(logger, progress, health, cfg.StaleGameThreshold, cfg.HealthStalenessWindow))
+		if cfg.Dashboard {
+			logger.Info("Serving dashboard", "path", rpc.DashboardPath)
+			rpcServer.AddHandler(rpc.DashboardPath, rpc.NewDashboardHandler())
+			rpcServer.AddHandler(rpc.DashboardDataPath, rpc.NewDashboardDataHandler(logger, newDashboardSource(statuses, cfg.Datadir, client, txMgr.From())))
+		}
+		if _, err := rpcServer.Start(); err != nil {
+			return nil, fmt.Errorf("error starting RPC server: %w", err)
+		}
+	}
 
 	m.RecordInfo(version.SimpleWithMeta)
+	m.RecordBuildInfo(info.Version, info.GitCommit, info.TraceTypes)
 	m.RecordUp()
 
 	return &Service{
-		logger:  logger,
-		metrics: m,
-		monitor: monitor,
-		sched:   sched,
+		logger:              logger,
+		metrics:             m,
+		monitor:             monitor,
+		sched:               sched,
+		retention:           retention,
+		pendingTxs:          pendingTxs,
+		shutdownGracePeriod: cfg.ShutdownGracePeriod,
+		shutdownGuard:       newShutdownGuard(logger, l1Time, clocks, cfg.ShutdownProtection, cfg.ShutdownConfirmTimeout, cfg.ForceShutdown),
+		eventLog:            eventLog,
+		results:             results,
+		pregen:              pregen,
+		rpcServer:           rpcServer,
+		actTrigger:          newActTrigger(logger, cl, cfg.Datadir, sched),
 	}, nil
 }
 
 // MonitorGame monitors the fault dispute game and attempts to progress it.
 func (s *Service) MonitorGame(ctx context.Context) error {
+	// Swept before the scheduler starts so it does not race with the scheduler removing game data.
+	if err := s.retention.Sweep(ctx); err != nil {
+		s.logger.Error("Failed to remove data of resolved games", "err", err)
+	}
 	s.sched.Start(ctx)
-	defer s.sched.Close()
+	if s.results != nil {
+		// Deferred first so the queued results are written after in-flight games stop emitting events.
+		defer func() {
+			if err := s.results.Close(); err != nil {
+				s.logger.Error("Error closing result store", "err", err)
+			}
+		}()
+	}
+	if s.eventLog != nil {
+		// Deferred early so the event log is closed after in-flight games stop emitting events.
+		defer func() {
+			if err := s.eventLog.Close(); err != nil {
+				s.logger.Error("Error closing event log", "err", err)
+			}
+		}()
+	}
+	if s.pregen != nil {
+		// Deferred before stopping the scheduler so in-flight games can't queue more requests after it is closed.
+		defer s.pregen.Close()
+	}
+	defer s.stopScheduler()
+	// Requests are stopped before the scheduler so none are waiting on it while it stops.
+	triggerCtx, stopTrigger := context.WithCancel(ctx)
+	defer stopTrigger()
+	go s.actTrigger.run(triggerCtx)
+	if s.rpcServer != nil {
+		defer func() {
+			if err := s.rpcServer.Stop(); err != nil {
+				s.logger.Error("Error shutting down RPC server", "err", err)
+			}
+		}()
+	}
 	return s.monitor.MonitorGames(ctx)
 }
+
+// AwaitShutdown blocks until a shutdown request is received and confirmed, returning true.
+// Shutdown requests that would leave games unattended close to their clock expiring must be confirmed by a second
+// request unless the shutdown is forced. Returns false if ctx is done first.
+func (s *Service) AwaitShutdown(ctx context.Context, requests <-chan struct{}) bool {
+	return s.shutdownGuard.Await(ctx, requests)
+}
+
+// SetGameLists replaces the allow and deny lists of games to play. Games that are no longer allowed stop being
+// played once their current progression completes.
+func (s *Service) SetGameLists(allowedGames []common.Address, deniedGames []common.Address) {
+	s.monitor.SetGameLists(allowedGames, deniedGames)
+}
+
+// TrackedGames returns a snapshot of the games currently being played.
+func (s *Service) TrackedGames() []scheduler.TrackedGame {
+	return s.sched.TrackedGames()
+}
+
+// stopScheduler stops progressing new games and waits for games already in progress to finish their current
+// actions, logging any transactions that are still pending if the shutdown grace period expires.
+func (s *Service) stopScheduler() {
+	s.logger.Info("Waiting for in-flight games to complete", "grace_period", s.shutdownGracePeriod)
+	if err := s.sched.Stop(s.shutdownGracePeriod); errors.Is(err, scheduler.ErrShutdownTimeout) {
+		s.logger.Warn("Shutdown grace period expired before in-flight games completed", "pending_txs", s.pendingTxs.Pending())
+	} else if err != nil {
+		s.logger.Error("Failed to stop scheduler", "err", err)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServicesShareMetricsRegistry(t *testing.T) {
+	l1 := rpc.NewServer()
+	require.NoError(t, l1.RegisterName("eth", &stubChainIDAPI{}))
+	l1Server := httptest.NewServer(l1)
+	t.Cleanup(l1Server.Close)
+
+	newConfig := func(instance string, factory common.Address) *config.Config {
+		cfg := config.NewConfig(factory, l1Server.URL, []config.TraceType{config.TraceTypeAlphabet}, true, t.TempDir())
+		cfg.AlphabetTrace = "abcdefgh"
+		cfg.TxMgrConfig.PrivateKey = "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+		cfg.MetricsInstance = instance
+		require.NoError(t, cfg.Check())
+		return &cfg
+	}
+	registry := prometheus.NewRegistry()
+	logger := testlog.Logger(t, log.LvlCrit)
+	_, err := NewServiceWithRegistry(context.Background(), logger, newConfig("goerli", common.Address{0x01}), registry)
+	require.NoError(t, err)
+	_, err = NewServiceWithRegistry(context.Background(), logger, newConfig("sepolia", common.Address{0x02}), registry)
+	require.NoError(t, err, "should register the metrics of both services")
+
+	metricsServer := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	t.Cleanup(metricsServer.Close)
+	resp, err := http.Get(metricsServer.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	for _, series := range []string{`op_challenger_up{instance="goerli"} 1`, `op_challenger_up{instance="sepolia"} 1`} {
+		require.Contains(t, strings.Split(string(body), "\n"), series)
+	}
+}
+
+func TestMonitorOnlyService(t *testing.T) {
+	l1 := rpc.NewServer()
+	l1Server := httptest.NewServer(l1)
+	t.Cleanup(l1Server.Close)
+
+	cfg := config.NewConfig(common.Address{0x01}, l1Server.URL, []config.TraceType{config.TraceTypeAlphabet}, true, t.TempDir())
+	cfg.AlphabetTrace = "abcdefgh"
+	cfg.MonitorOnly = true
+	require.NoError(t, cfg.Check())
+	registry := prometheus.NewRegistry()
+	_, err := NewServiceWithRegistry(context.Background(), testlog.Logger(t, log.LvlCrit), &cfg, registry)
+	require.NoError(t, err, "should not need a private key")
+
+	metricsServer := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	t.Cleanup(metricsServer.Close)
+	resp, err := http.Get(metricsServer.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, strings.Split(string(body), "\n"), `op_challenger_up{mode="monitor"} 1`)
+}
+
+type stubChainIDAPI struct{}
+
+func (s *stubChainIDAPI) ChainId() hexutil.Big {
+	return hexutil.Big(*common.Big1)
+}
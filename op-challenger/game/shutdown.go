@@ -0,0 +1,79 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// shutdownGuard delays shutdown requests that would leave games unattended shortly before one of their
+// chess clocks expires, requiring the shutdown to be confirmed by a second request.
+type shutdownGuard struct {
+	logger         log.Logger
+	clock          clock.Clock
+	clocks         *fault.ClockTracker
+	window         time.Duration
+	confirmTimeout time.Duration
+	force          bool
+}
+
+func newShutdownGuard(logger log.Logger, cl clock.Clock, clocks *fault.ClockTracker, window time.Duration, confirmTimeout time.Duration, force bool) *shutdownGuard {
+	return &shutdownGuard{
+		logger:         logger,
+		clock:          cl,
+		clocks:         clocks,
+		window:         window,
+		confirmTimeout: confirmTimeout,
+		force:          force,
+	}
+}
+
+// Await blocks until a shutdown request is received and confirmed, returning true.
+// Returns false if ctx is done first.
+func (g *shutdownGuard) Await(ctx context.Context, requests <-chan struct{}) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-requests:
+			if g.confirm(ctx, requests) {
+				return true
+			}
+		}
+	}
+}
+
+// confirm determines if a shutdown request should proceed.
+// If any game clock expires within the protection window, a second request must be received within the confirm
+// timeout for the shutdown to proceed.
+func (g *shutdownGuard) confirm(ctx context.Context, requests <-chan struct{}) bool {
+	if g.force || g.window == 0 {
+		return true
+	}
+	now := g.clock.Now()
+	atRisk := g.clocks.ExpiringBetween(now, now.Add(g.window))
+	if len(atRisk) == 0 {
+		return true
+	}
+	for _, game := range atRisk {
+		g.logger.Error("Game clock expires soon, shutting down may lose the game",
+			"game", game.Game, "deadline", game.Deadline, "remaining", game.Deadline.Sub(now))
+	}
+	g.logger.Error("SHUTDOWN DELAYED: game clocks expire within the shutdown protection window, interrupt again to confirm",
+		"games", len(atRisk), "window", g.window, "confirm_timeout", g.confirmTimeout)
+	timer := g.clock.NewTimer(g.confirmTimeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-requests:
+		g.logger.Warn("Shutdown confirmed with game clocks expiring soon", "games", len(atRisk))
+		return true
+	case <-timer.Ch():
+		g.logger.Warn("Shutdown not confirmed, continuing to run", "games", len(atRisk))
+		return false
+	}
+}
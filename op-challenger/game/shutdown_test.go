@@ -0,0 +1,133 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testProtectionWindow = 10 * time.Minute
+	testConfirmTimeout   = 10 * time.Second
+)
+
+func TestShutdownGuard_Unprotected(t *testing.T) {
+	t.Run("NoActiveGames", func(t *testing.T) {
+		guard, _, _, _ := setupShutdownGuardTest(t, testProtectionWindow, false)
+		require.True(t, guard.confirm(context.Background(), nil))
+	})
+
+	t.Run("DeadlineOutsideWindow", func(t *testing.T) {
+		guard, cl, clocks, _ := setupShutdownGuardTest(t, testProtectionWindow, false)
+		clocks.Update(common.Address{0xaa}, cl.Now().Add(testProtectionWindow+time.Second))
+		require.True(t, guard.confirm(context.Background(), nil))
+	})
+
+	t.Run("DeadlineAlreadyPassed", func(t *testing.T) {
+		guard, cl, clocks, _ := setupShutdownGuardTest(t, testProtectionWindow, false)
+		clocks.Update(common.Address{0xaa}, cl.Now().Add(-time.Second))
+		require.True(t, guard.confirm(context.Background(), nil))
+	})
+
+	t.Run("ProtectionDisabled", func(t *testing.T) {
+		guard, cl, clocks, _ := setupShutdownGuardTest(t, 0, false)
+		clocks.Update(common.Address{0xaa}, cl.Now().Add(time.Minute))
+		require.True(t, guard.confirm(context.Background(), nil))
+	})
+
+	t.Run("Forced", func(t *testing.T) {
+		guard, cl, clocks, _ := setupShutdownGuardTest(t, testProtectionWindow, true)
+		clocks.Update(common.Address{0xaa}, cl.Now().Add(time.Minute))
+		require.True(t, guard.confirm(context.Background(), nil))
+	})
+}
+
+func TestShutdownGuard_Protected(t *testing.T) {
+	t.Run("Confirmed", func(t *testing.T) {
+		guard, cl, clocks, logs := setupShutdownGuardTest(t, testProtectionWindow, false)
+		game1 := common.Address{0xaa}
+		game2 := common.Address{0xbb}
+		clocks.Update(game1, cl.Now().Add(5*time.Minute))
+		clocks.Update(game2, cl.Now().Add(time.Minute))
+		requests := make(chan struct{})
+		result := make(chan bool, 1)
+		go func() {
+			result <- guard.confirm(context.Background(), requests)
+		}()
+		requests <- struct{}{}
+		require.True(t, <-result)
+
+		var atRisk []any
+		for _, record := range logs.Logs {
+			if record.Lvl == log.LvlError && record.Msg == "Game clock expires soon, shutting down may lose the game" {
+				atRisk = append(atRisk, (&testlog.HelperRecord{Record: record}).GetContextValue("game"))
+			}
+		}
+		require.Equal(t, []any{game2, game1}, atRisk, "should list at risk games, soonest first")
+		require.NotNil(t, logs.FindLog(log.LvlError, "SHUTDOWN DELAYED: game clocks expire within the shutdown protection window, interrupt again to confirm"))
+		require.NotNil(t, logs.FindLog(log.LvlWarn, "Shutdown confirmed with game clocks expiring soon"))
+	})
+
+	t.Run("NotConfirmed", func(t *testing.T) {
+		guard, cl, clocks, logs := setupShutdownGuardTest(t, testProtectionWindow, false)
+		clocks.Update(common.Address{0xaa}, cl.Now().Add(time.Minute))
+		result := make(chan bool, 1)
+		go func() {
+			result <- guard.confirm(context.Background(), make(chan struct{}))
+		}()
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(30*time.Second), "should wait for confirmation")
+		cl.AdvanceTime(testConfirmTimeout)
+		require.False(t, <-result)
+		require.NotNil(t, logs.FindLog(log.LvlWarn, "Shutdown not confirmed, continuing to run"))
+	})
+}
+
+func TestShutdownGuard_Await(t *testing.T) {
+	t.Run("ReturnsTrueOnRequest", func(t *testing.T) {
+		guard, _, _, _ := setupShutdownGuardTest(t, testProtectionWindow, false)
+		requests := make(chan struct{}, 1)
+		requests <- struct{}{}
+		require.True(t, guard.Await(context.Background(), requests))
+	})
+
+	t.Run("ReturnsFalseWhenContextDone", func(t *testing.T) {
+		guard, _, _, _ := setupShutdownGuardTest(t, testProtectionWindow, false)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.False(t, guard.Await(ctx, make(chan struct{})))
+	})
+
+	t.Run("WaitsForNewRequestAfterUnconfirmed", func(t *testing.T) {
+		guard, cl, clocks, _ := setupShutdownGuardTest(t, testProtectionWindow, false)
+		game := common.Address{0xaa}
+		clocks.Update(game, cl.Now().Add(time.Minute))
+		requests := make(chan struct{})
+		result := make(chan bool, 1)
+		go func() {
+			result <- guard.Await(context.Background(), requests)
+		}()
+		requests <- struct{}{}
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(30*time.Second), "should wait for confirmation")
+		cl.AdvanceTime(testConfirmTimeout)
+
+		// Once the game completes, shutdown is no longer protected.
+		clocks.Remove(game)
+		requests <- struct{}{}
+		require.True(t, <-result)
+	})
+}
+
+func setupShutdownGuardTest(t *testing.T, window time.Duration, force bool) (*shutdownGuard, *clock.DeterministicClock, *fault.ClockTracker, *testlog.CapturingHandler) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	logs := testlog.Capture(logger)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	clocks := fault.NewClockTracker()
+	return newShutdownGuard(logger, cl, clocks, window, testConfirmTimeout, force), cl, clocks, logs
+}
@@ -0,0 +1,212 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	_ "modernc.org/sqlite"
+)
+
+// resultMigrations are the changes made to the result store's schema, applied in order. The schema_version table
+// records how many have been applied, so changes must be made by appending a migration rather than editing one.
+var resultMigrations = []string{
+	`CREATE TABLE games (
+		address TEXT PRIMARY KEY,
+		discovered_at INTEGER NOT NULL
+	);
+	CREATE TABLE moves (
+		tx_hash TEXT PRIMARY KEY,
+		game TEXT NOT NULL,
+		claim_index INTEGER NOT NULL,
+		reverted INTEGER NOT NULL,
+		gas_used INTEGER NOT NULL,
+		effective_gas_price TEXT,
+		block_number INTEGER NOT NULL,
+		time INTEGER NOT NULL
+	);
+	CREATE INDEX moves_game ON moves (game);
+	CREATE TABLE steps (
+		tx_hash TEXT PRIMARY KEY,
+		game TEXT NOT NULL,
+		claim_index INTEGER NOT NULL,
+		reverted INTEGER NOT NULL,
+		gas_used INTEGER NOT NULL,
+		effective_gas_price TEXT,
+		block_number INTEGER NOT NULL,
+		time INTEGER NOT NULL
+	);
+	CREATE INDEX steps_game ON steps (game);
+	CREATE TABLE resolutions (
+		game TEXT PRIMARY KEY,
+		status TEXT NOT NULL,
+		deciding_claim INTEGER,
+		winner TEXT,
+		resolved_at INTEGER,
+		time INTEGER NOT NULL
+	);`,
+	`ALTER TABLE games ADD COLUMN creator TEXT;`,
+}
+
+// sqliteResultStore is a [resultStore] keeping the games played and who created them, the moves and steps the
+// challenger made in them and how they resolved in a SQLite database. Times are stored as unix timestamps in seconds.
+type sqliteResultStore struct {
+	db *sql.DB
+}
+
+// openSQLiteResultStore opens the SQLite database at path, creating it if needed, and migrates it to the latest
+// schema. A path of ":memory:" opens a new in-memory database.
+func openSQLiteResultStore(ctx context.Context, path string) (*sqliteResultStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result store %v: %w", path, err)
+	}
+	// SQLite only allows one writer at a time, and each connection to an in-memory database is a separate database.
+	db.SetMaxOpenConns(1)
+	if err := migrateResultStore(ctx, db, resultMigrations); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate result store %v: %w", path, err)
+	}
+	return &sqliteResultStore{db: db}, nil
+}
+
+// migrateResultStore applies the migrations db hasn't had applied yet, each in its own transaction.
+func migrateResultStore(ctx context.Context, db *sql.DB, migrations []string) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return fmt.Errorf("failed to create schema version table: %w", err)
+	}
+	var version int
+	err := db.QueryRowContext(ctx, `SELECT version FROM schema_version`).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := db.ExecContext(ctx, `INSERT INTO schema_version (version) VALUES (0)`); err != nil {
+			return fmt.Errorf("failed to initialise schema version: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("schema version %v is newer than the latest known version %v", version, len(migrations))
+	}
+	for i := version; i < len(migrations); i++ {
+		if err := applyMigration(ctx, db, i+1, migrations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, version int, migration string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start migration %v: %w", version, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err := tx.ExecContext(ctx, migration); err != nil {
+		return fmt.Errorf("failed to apply migration %v: %w", version, err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE schema_version SET version = ?`, version); err != nil {
+		return fmt.Errorf("failed to record migration %v: %w", version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %v: %w", version, err)
+	}
+	return nil
+}
+
+// Record stores game discoveries and creators, the transactions of moves and steps, and resolutions, ignoring other
+// events. Recording the same event again has no effect, other than updating a resolution.
+func (s *sqliteResultStore) Record(ctx context.Context, event types.Event) error {
+	game := event.Game.Hex()
+	switch event.Type {
+	case types.EventGameDiscovered:
+		_, err := s.db.ExecContext(ctx, `INSERT INTO games (address, discovered_at) VALUES (?, ?) ON CONFLICT DO NOTHING`,
+			game, event.Time.Unix())
+		return err
+	case types.EventGameCreated:
+		if event.Creator == nil {
+			return nil
+		}
+		_, err := s.db.ExecContext(ctx, `INSERT INTO games (address, discovered_at, creator) VALUES (?, ?, ?)
+			ON CONFLICT (address) DO UPDATE SET creator = excluded.creator`,
+			game, event.Time.Unix(), event.Creator.Hex())
+		return err
+	case types.EventClaimCountered:
+		return s.recordTx(ctx, "moves", event)
+	case types.EventStepExecuted:
+		return s.recordTx(ctx, "steps", event)
+	case types.EventGameResolved:
+		var winner, resolvedAt any
+		if event.Winner != nil {
+			winner = event.Winner.Hex()
+		}
+		if event.ResolvedAt != nil {
+			resolvedAt = event.ResolvedAt.Unix()
+		}
+		_, err := s.db.ExecContext(ctx, `INSERT INTO resolutions (game, status, deciding_claim, winner, resolved_at, time)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (game) DO UPDATE SET status = excluded.status, deciding_claim = excluded.deciding_claim,
+				winner = excluded.winner, resolved_at = excluded.resolved_at, time = excluded.time`,
+			game, event.Status, event.ClaimIndex, winner, resolvedAt, event.Time.Unix())
+		return err
+	default:
+		return nil
+	}
+}
+
+// recordTx stores the transaction of a move or step in table.
+func (s *sqliteResultStore) recordTx(ctx context.Context, table string, event types.Event) error {
+	if event.TxHash == nil || event.ClaimIndex == nil {
+		return nil
+	}
+	var gasPrice any
+	if event.EffectiveGasPrice != nil {
+		gasPrice = event.EffectiveGasPrice.String()
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT INTO `+table+` (tx_hash, game, claim_index, reverted, gas_used, effective_gas_price, block_number, time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`,
+		event.TxHash.Hex(), event.Game.Hex(), *event.ClaimIndex, event.Reverted, event.GasUsed, gasPrice, event.BlockNumber, event.Time.Unix())
+	return err
+}
+
+// OpponentGames returns each game whose creator is known, with its resolution if it has resolved.
+func (s *sqliteResultStore) OpponentGames(ctx context.Context) ([]opponentGame, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT g.address, g.creator, r.status, coalesce(r.resolved_at, r.time)
+		FROM games g LEFT JOIN resolutions r ON r.game = g.address
+		WHERE g.creator IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query game creators: %w", err)
+	}
+	defer rows.Close()
+	var games []opponentGame
+	for rows.Next() {
+		var game, creator string
+		var status sql.NullString
+		var resolvedAt sql.NullInt64
+		if err := rows.Scan(&game, &creator, &status, &resolvedAt); err != nil {
+			return nil, fmt.Errorf("failed to read game creator: %w", err)
+		}
+		record := opponentGame{
+			Game:    common.HexToAddress(game),
+			Creator: common.HexToAddress(creator),
+			Status:  status.String,
+		}
+		if resolvedAt.Valid {
+			record.ResolvedAt = time.Unix(resolvedAt.Int64, 0)
+		}
+		games = append(games, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read game creators: %w", err)
+	}
+	return games, nil
+}
+
+func (s *sqliteResultStore) Close() error {
+	return s.db.Close()
+}
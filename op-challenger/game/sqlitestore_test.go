@@ -0,0 +1,185 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteResultStore(t *testing.T) {
+	ctx := context.Background()
+	game := common.Address{0xaa}
+	claimIndex := 3
+	txHash := common.Hash{0xcc}
+	move := types.Event{
+		Type:              types.EventClaimCountered,
+		Time:              time.Unix(1000, 0),
+		Game:              game,
+		ClaimIndex:        &claimIndex,
+		TxHash:            &txHash,
+		GasUsed:           50_000,
+		EffectiveGasPrice: big.NewInt(7),
+		BlockNumber:       42,
+	}
+
+	t.Run("RecordGames", func(t *testing.T) {
+		store := openTestResultStore(t)
+		discovered := types.Event{Type: types.EventGameDiscovered, Time: time.Unix(900, 0), Game: game}
+		require.NoError(t, store.Record(ctx, discovered))
+		discovered.Time = time.Unix(950, 0)
+		require.NoError(t, store.Record(ctx, discovered))
+
+		var discoveredAt int64
+		require.NoError(t, store.db.QueryRow(`SELECT discovered_at FROM games WHERE address = ?`, game.Hex()).Scan(&discoveredAt))
+		require.Equal(t, int64(900), discoveredAt, "should keep the first discovery")
+	})
+
+	t.Run("RecordMovesAndSteps", func(t *testing.T) {
+		store := openTestResultStore(t)
+		require.NoError(t, store.Record(ctx, move))
+		require.NoError(t, store.Record(ctx, move), "should ignore duplicates")
+		stepHash := common.Hash{0xdd}
+		step := move
+		step.Type = types.EventStepExecuted
+		step.TxHash = &stepHash
+		step.Reverted = true
+		require.NoError(t, store.Record(ctx, step))
+
+		var count, gasUsed, blockNumber int
+		var gasPrice string
+		var reverted bool
+		require.NoError(t, store.db.QueryRow(`SELECT count(*), sum(gas_used) FROM moves WHERE game = ?`, game.Hex()).Scan(&count, &gasUsed))
+		require.Equal(t, 1, count)
+		require.Equal(t, 50_000, gasUsed)
+		require.NoError(t, store.db.QueryRow(`SELECT effective_gas_price, block_number, reverted FROM steps WHERE tx_hash = ?`, stepHash.Hex()).Scan(&gasPrice, &blockNumber, &reverted))
+		require.Equal(t, "7", gasPrice)
+		require.Equal(t, 42, blockNumber)
+		require.True(t, reverted)
+	})
+
+	t.Run("RecordResolutions", func(t *testing.T) {
+		store := openTestResultStore(t)
+		resolvedAt := time.Unix(2000, 0)
+		winner := common.Address{0xbb}
+		resolved := types.Event{Type: types.EventGameResolved, Time: time.Unix(900, 0), Game: game, Status: "In Progress", ClaimIndex: &claimIndex}
+		require.NoError(t, store.Record(ctx, resolved))
+		resolved.Status = "Challenger Won"
+		resolved.ResolvedAt = &resolvedAt
+		resolved.Winner = &winner
+		require.NoError(t, store.Record(ctx, resolved))
+
+		var status, recordedWinner string
+		var decidingClaim, recordedResolvedAt int64
+		require.NoError(t, store.db.QueryRow(`SELECT status, deciding_claim, winner, resolved_at FROM resolutions WHERE game = ?`, game.Hex()).
+			Scan(&status, &decidingClaim, &recordedWinner, &recordedResolvedAt))
+		require.Equal(t, "Challenger Won", status)
+		require.Equal(t, int64(claimIndex), decidingClaim)
+		require.Equal(t, winner.Hex(), recordedWinner)
+		require.Equal(t, resolvedAt.Unix(), recordedResolvedAt)
+	})
+
+	t.Run("OpponentGames", func(t *testing.T) {
+		store := openTestResultStore(t)
+		creator := common.Address{0x5a}
+		unresolved := common.Address{0xbb}
+		resolvedAt := time.Unix(2000, 0)
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameDiscovered, Time: time.Unix(900, 0), Game: game}))
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameCreated, Time: time.Unix(950, 0), Game: game, Creator: &creator}))
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameResolved, Time: time.Unix(3000, 0), Game: game, Status: "Challenger Won", ResolvedAt: &resolvedAt}))
+		// Created before being discovered.
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameCreated, Time: time.Unix(960, 0), Game: unresolved, Creator: &creator}))
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameDiscovered, Time: time.Unix(970, 0), Game: unresolved}))
+		// Games without a known creator aren't included.
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameDiscovered, Time: time.Unix(980, 0), Game: common.Address{0xcc}}))
+
+		games, err := store.OpponentGames(ctx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []opponentGame{
+			{Game: game, Creator: creator, Status: "Challenger Won", ResolvedAt: resolvedAt},
+			{Game: unresolved, Creator: creator},
+		}, games)
+
+		var discoveredAt int64
+		require.NoError(t, store.db.QueryRow(`SELECT discovered_at FROM games WHERE address = ?`, game.Hex()).Scan(&discoveredAt))
+		require.Equal(t, int64(900), discoveredAt, "should keep the discovery time")
+	})
+
+	t.Run("IgnoreOtherEvents", func(t *testing.T) {
+		store := openTestResultStore(t)
+		require.NoError(t, store.Record(ctx, types.Event{Type: types.EventGameAbandoned, Game: game}))
+	})
+
+	t.Run("ReopenExisting", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "results.db")
+		store, err := openSQLiteResultStore(ctx, path)
+		require.NoError(t, err)
+		require.NoError(t, store.Record(ctx, move))
+		require.NoError(t, store.Close())
+
+		store, err = openSQLiteResultStore(ctx, path)
+		require.NoError(t, err)
+		defer store.Close()
+		var count int
+		require.NoError(t, store.db.QueryRow(`SELECT count(*) FROM moves`).Scan(&count))
+		require.Equal(t, 1, count)
+	})
+}
+
+func TestMigrateResultStore(t *testing.T) {
+	ctx := context.Background()
+	migrations := []string{
+		`CREATE TABLE first (id INTEGER)`,
+		`CREATE TABLE second (id INTEGER)`,
+	}
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	requireVersion := func(t *testing.T, expected int) {
+		var version int
+		require.NoError(t, db.QueryRow(`SELECT version FROM schema_version`).Scan(&version))
+		require.Equal(t, expected, version)
+	}
+
+	require.NoError(t, migrateResultStore(ctx, db, migrations[:1]))
+	requireVersion(t, 1)
+	_, err = db.Exec(`INSERT INTO first (id) VALUES (1)`)
+	require.NoError(t, err)
+
+	// Only the new migration is applied, failing if the first was applied again.
+	require.NoError(t, migrateResultStore(ctx, db, migrations))
+	requireVersion(t, 2)
+	require.NoError(t, migrateResultStore(ctx, db, migrations))
+	requireVersion(t, 2)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM first`).Scan(&count))
+	require.Equal(t, 1, count)
+
+	t.Run("FailedMigrationNotRecorded", func(t *testing.T) {
+		err := migrateResultStore(ctx, db, append(migrations, `NOT SQL`))
+		require.ErrorContains(t, err, "failed to apply migration 3")
+		requireVersion(t, 2)
+	})
+
+	t.Run("RejectNewerSchema", func(t *testing.T) {
+		err := migrateResultStore(ctx, db, migrations[:1])
+		require.ErrorContains(t, err, "schema version 2 is newer than the latest known version 1")
+	})
+}
+
+func openTestResultStore(t *testing.T) *sqliteResultStore {
+	store, err := openSQLiteResultStore(context.Background(), ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// gameSyncFile is the name of the file in the datadir that records the progress of the game sync.
+	gameSyncFile = "sync.json"
+	// gameSyncFilePrefix prefixes the name of the sync file of each factory other than the first, as each factory
+	// is synced separately.
+	gameSyncFilePrefix = "sync-"
+	// gameSyncChunkSize is the number of historical games loaded by each update while syncing.
+	gameSyncChunkSize = 1000
+)
+
+// gameSyncPath returns the path of the file in dir recording the sync progress of factory. The first factory uses
+// gameSyncFile so the progress of challengers that played a single factory is kept.
+func gameSyncPath(dir string, factory common.Address, first bool) string {
+	if first {
+		return filepath.Join(dir, gameSyncFile)
+	}
+	return filepath.Join(dir, gameSyncFilePrefix+factory.Hex()+".json")
+}
+
+// indexedGameSource loads games from the factory by their index.
+type indexedGameSource interface {
+	FetchGameCount(ctx context.Context, blockNumber *big.Int) (uint64, error)
+	FetchGames(ctx context.Context, blockNumber *big.Int, from uint64, to uint64) ([]FaultDisputeGame, error)
+}
+
+// gameSyncState is the persisted progress of the game sync.
+type gameSyncState struct {
+	Factory common.Address `json:"factory"`
+	// Low and High bound the range of game indices that have been scanned, [Low, High).
+	Low  uint64 `json:"low"`
+	High uint64 `json:"high"`
+	// Complete is true once all games since Earliest have been scanned.
+	Complete bool   `json:"complete"`
+	Earliest uint64 `json:"earliest"`
+	// Games are the scanned games within the game window, newest first.
+	Games []syncedGame `json:"games"`
+}
+
+type syncedGame struct {
+	Index     uint64         `json:"index"`
+	GameType  uint8          `json:"gameType"`
+	Timestamp uint64         `json:"timestamp"`
+	Proxy     common.Address `json:"proxy"`
+}
+
+// gameSync is a [gameSource] that loads the games of the factory incrementally rather than all at once.
+// Each update loads the games created since the previous update and one chunk of older games, working back
+// until it reaches a game older than the game window. The scanned range is persisted after every chunk so a
+// restart resumes the sync rather than loading every game again, and the games found so far are returned so
+// they can be played while older games are still being loaded.
+// It is not safe for concurrent use.
+type gameSync struct {
+	logger    log.Logger
+	source    indexedGameSource
+	path      string
+	factory   common.Address
+	chunkSize uint64
+
+	loaded bool
+	state  gameSyncState
+}
+
+func newGameSync(logger log.Logger, source indexedGameSource, path string, factory common.Address, chunkSize uint64) *gameSync {
+	return &gameSync{
+		logger:    logger,
+		source:    source,
+		path:      path,
+		factory:   factory,
+		chunkSize: chunkSize,
+	}
+}
+
+// Syncing returns true while there are still historical games to load.
+func (s *gameSync) Syncing() bool {
+	return !s.loaded || !s.state.Complete
+}
+
+func (s *gameSync) FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockNumber *big.Int) ([]FaultDisputeGame, error) {
+	if !s.loaded {
+		s.load()
+		s.loaded = true
+	}
+	count, err := s.source.FetchGameCount(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if s.state.Factory != s.factory || count < s.state.High {
+		if s.state.Factory == s.factory {
+			s.logger.Warn("Game count decreased, restarting game sync", "count", count, "synced", s.state.High)
+		}
+		s.state = gameSyncState{Factory: s.factory, Low: count, High: count}
+	}
+	if count > s.state.High {
+		games, err := s.source.FetchGames(ctx, blockNumber, s.state.High, count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load new games: %w", err)
+		}
+		s.state.Games = append(toSyncedGames(games, count), s.state.Games...)
+		s.state.High = count
+	}
+	if s.state.Complete && earliest < s.state.Earliest {
+		// The game window has grown so older games may now be in it.
+		s.state.Complete = false
+	}
+	if !s.state.Complete {
+		if err := s.syncChunk(ctx, earliest, blockNumber); err != nil {
+			return nil, err
+		}
+	}
+	s.prune(earliest)
+	if err := s.save(); err != nil {
+		// The sync resumes from the last saved progress after a restart instead.
+		s.logger.Warn("Failed to save game sync progress", "path", s.path, "err", err)
+	}
+	games := make([]FaultDisputeGame, len(s.state.Games))
+	for i, game := range s.state.Games {
+		games[i] = FaultDisputeGame{GameType: game.GameType, Timestamp: game.Timestamp, Proxy: game.Proxy}
+	}
+	return games, nil
+}
+
+// syncChunk loads the next chunk of games older than those already scanned.
+func (s *gameSync) syncChunk(ctx context.Context, earliest uint64, blockNumber *big.Int) error {
+	from := uint64(0)
+	if s.state.Low > s.chunkSize {
+		from = s.state.Low - s.chunkSize
+	}
+	games, err := s.source.FetchGames(ctx, blockNumber, from, s.state.Low)
+	if err != nil {
+		return fmt.Errorf("failed to load historical games: %w", err)
+	}
+	low := s.state.Low
+	complete := from == 0
+	for _, game := range toSyncedGames(games, s.state.Low) {
+		if game.Timestamp < earliest {
+			complete = true
+			break
+		}
+		s.state.Games = append(s.state.Games, game)
+		low = game.Index
+	}
+	if !complete {
+		low = from
+	}
+	s.state.Low = low
+	if complete {
+		s.state.Complete = true
+		s.state.Earliest = earliest
+		s.logger.Info("Finished syncing historical games", "games", len(s.state.Games), "scanned", s.state.High-s.state.Low)
+		return nil
+	}
+	// Progress is measured against all games as it isn't known in advance where the game window starts.
+	progress := float64(s.state.High-s.state.Low) / float64(s.state.High) * 100
+	s.logger.Info("Syncing historical games", "progress", fmt.Sprintf("%.1f%%", progress), "scanned", s.state.High-s.state.Low, "remaining", s.state.Low)
+	return nil
+}
+
+// prune removes the games that are now older than the game window.
+func (s *gameSync) prune(earliest uint64) {
+	kept := s.state.Games[:0]
+	for _, game := range s.state.Games {
+		if game.Timestamp >= earliest {
+			kept = append(kept, game)
+		}
+	}
+	s.state.Games = kept
+}
+
+// toSyncedGames records the index of each of games, which are ordered newest first ending before index end.
+func toSyncedGames(games []FaultDisputeGame, end uint64) []syncedGame {
+	synced := make([]syncedGame, len(games))
+	for i, game := range games {
+		synced[i] = syncedGame{Index: end - uint64(i) - 1, GameType: game.GameType, Timestamp: game.Timestamp, Proxy: game.Proxy}
+	}
+	return synced
+}
+
+func (s *gameSync) load() {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		s.logger.Warn("Failed to read game sync progress, starting again", "path", s.path, "err", err)
+		return
+	}
+	var stored gameSyncState
+	if err := json.Unmarshal(data, &stored); err != nil {
+		s.logger.Warn("Failed to decode game sync progress, starting again", "path", s.path, "err", err)
+		return
+	}
+	if stored.Factory != s.factory {
+		s.logger.Info("Discarding game sync progress for a different factory", "path", s.path, "synced", stored.Factory, "factory", s.factory)
+		return
+	}
+	s.state = stored
+	s.logger.Info("Resuming game sync", "games", len(stored.Games), "low", stored.Low, "high", stored.High, "complete", stored.Complete)
+}
+
+// save writes the sync progress to disk, replacing the previous file atomically so a crash mid-write can't
+// leave a truncated file behind.
+func (s *gameSync) save() error {
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode game sync progress: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create game sync dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write game sync progress: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace game sync progress: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var syncFactory = common.Address{0xfa}
+
+func TestGameSync_LoadsInChunks(t *testing.T) {
+	source := newStubIndexedGameSource(10)
+	sync, _ := setupGameSyncTest(t, source, t.TempDir(), 4)
+
+	games := fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(9, 6), games, "should load the newest chunk first")
+	require.True(t, sync.Syncing())
+
+	games = fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(9, 2), games)
+	require.True(t, sync.Syncing())
+
+	games = fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(9, 0), games)
+	require.False(t, sync.Syncing())
+	source.requireFetchedOnce(t, 10)
+}
+
+func TestGameSync_LogsProgress(t *testing.T) {
+	source := newStubIndexedGameSource(10)
+	sync, handler := setupGameSyncTest(t, source, t.TempDir(), 4)
+
+	fetchSyncedGames(t, sync, 0)
+	msg := handler.FindLog(log.LvlInfo, "Syncing historical games")
+	require.NotNil(t, msg)
+	require.Equal(t, "40.0%", msg.GetContextValue("progress"))
+	require.Equal(t, uint64(6), msg.GetContextValue("remaining"))
+
+	fetchSyncedGames(t, sync, 0)
+	fetchSyncedGames(t, sync, 0)
+	msg = handler.FindLog(log.LvlInfo, "Finished syncing historical games")
+	require.NotNil(t, msg)
+	require.Equal(t, 10, msg.GetContextValue("games"))
+}
+
+func TestGameSync_StopsAtGameWindow(t *testing.T) {
+	source := newStubIndexedGameSource(10)
+	sync, _ := setupGameSyncTest(t, source, t.TempDir(), 4)
+
+	// Games have timestamps of 100 times their index.
+	fetchSyncedGames(t, sync, 450)
+	games := fetchSyncedGames(t, sync, 450)
+	require.Equal(t, source.newest(9, 5), games)
+	require.False(t, sync.Syncing())
+	require.Equal(t, uint64(5), sync.state.Low)
+
+	t.Run("PruneExpiredGames", func(t *testing.T) {
+		games := fetchSyncedGames(t, sync, 750)
+		require.Equal(t, source.newest(9, 8), games)
+		require.False(t, sync.Syncing())
+	})
+
+	t.Run("ResumeWhenWindowGrows", func(t *testing.T) {
+		games := fetchSyncedGames(t, sync, 250)
+		require.Equal(t, []FaultDisputeGame{source.games[9], source.games[8], source.games[4], source.games[3]}, games,
+			"should only load the games older than those scanned")
+		require.False(t, sync.Syncing())
+	})
+}
+
+func TestGameSync_LoadsNewGames(t *testing.T) {
+	source := newStubIndexedGameSource(10)
+	sync, _ := setupGameSyncTest(t, source, t.TempDir(), 4)
+	fetchSyncedGames(t, sync, 0)
+
+	source.addGames(3)
+	games := fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(12, 2), games, "should load new games along with the next chunk")
+
+	source.addGames(1)
+	games = fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(13, 0), games)
+	require.False(t, sync.Syncing())
+
+	source.addGames(2)
+	games = fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(15, 0), games)
+	source.requireFetchedOnce(t, 16)
+}
+
+func TestGameSync_ResumeAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	source := newStubIndexedGameSource(10)
+	sync, _ := setupGameSyncTest(t, source, dir, 3)
+	fetchSyncedGames(t, sync, 0)
+	fetchSyncedGames(t, sync, 0)
+	require.True(t, sync.Syncing())
+
+	// Restart part way through the sync, with a new game created in the meantime.
+	source.addGames(1)
+	sync, _ = setupGameSyncTest(t, source, dir, 3)
+	games := fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(10, 1), games, "should resume from the persisted progress")
+	games = fetchSyncedGames(t, sync, 0)
+	require.Equal(t, source.newest(10, 0), games)
+	require.False(t, sync.Syncing())
+	source.requireFetchedOnce(t, 11)
+
+	t.Run("NoDuplicateGames", func(t *testing.T) {
+		seen := make(map[common.Address]bool)
+		for _, game := range games {
+			require.False(t, seen[game.Proxy], "duplicate game %v", game.Proxy)
+			seen[game.Proxy] = true
+		}
+	})
+
+	t.Run("CompleteAfterRestart", func(t *testing.T) {
+		sync, _ := setupGameSyncTest(t, source, dir, 3)
+		games := fetchSyncedGames(t, sync, 0)
+		require.Equal(t, source.newest(10, 0), games)
+		require.False(t, sync.Syncing())
+		source.requireFetchedOnce(t, 11)
+	})
+}
+
+func TestGameSync_Restart(t *testing.T) {
+	t.Run("DifferentFactory", func(t *testing.T) {
+		dir := t.TempDir()
+		source := newStubIndexedGameSource(10)
+		sync, _ := setupGameSyncTest(t, source, dir, 4)
+		fetchSyncedGames(t, sync, 0)
+
+		other := newStubIndexedGameSource(10)
+		sync = newGameSync(testlog.Logger(t, log.LvlInfo), other, filepath.Join(dir, gameSyncFile), common.Address{0xbb}, 4)
+		fetchSyncedGames(t, sync, 0)
+		other.requireFetched(t, 9, 1)
+		require.Equal(t, uint64(6), sync.state.Low)
+	})
+
+	t.Run("GameCountDecreased", func(t *testing.T) {
+		source := newStubIndexedGameSource(10)
+		sync, handler := setupGameSyncTest(t, source, t.TempDir(), 4)
+		fetchSyncedGames(t, sync, 0)
+
+		source.count = 8
+		games := fetchSyncedGames(t, sync, 0)
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Game count decreased, restarting game sync"))
+		require.Equal(t, source.newest(7, 4), games)
+	})
+
+	t.Run("CorruptFile", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, gameSyncFile), []byte("{"), 0644))
+		source := newStubIndexedGameSource(10)
+		sync, handler := setupGameSyncTest(t, source, dir, 4)
+		games := fetchSyncedGames(t, sync, 0)
+		require.NotNil(t, handler.FindLog(log.LvlWarn, "Failed to decode game sync progress, starting again"))
+		require.Equal(t, source.newest(9, 6), games)
+	})
+}
+
+func TestGameSyncPath(t *testing.T) {
+	dir := t.TempDir()
+	require.Equal(t, filepath.Join(dir, gameSyncFile), gameSyncPath(dir, common.Address{0xaa}, true))
+	other := gameSyncPath(dir, common.Address{0xbb}, false)
+	require.NotEqual(t, filepath.Join(dir, gameSyncFile), other)
+	require.NotEqual(t, other, gameSyncPath(dir, common.Address{0xcc}, false), "should sync each factory separately")
+}
+
+func TestGameSync_Errors(t *testing.T) {
+	t.Run("GameCount", func(t *testing.T) {
+		source := newStubIndexedGameSource(10)
+		source.countErr = errors.New("boom")
+		sync, _ := setupGameSyncTest(t, source, t.TempDir(), 4)
+		_, err := sync.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(1))
+		require.ErrorIs(t, err, source.countErr)
+	})
+
+	t.Run("RetryChunk", func(t *testing.T) {
+		source := newStubIndexedGameSource(10)
+		source.gamesErr = errors.New("boom")
+		sync, _ := setupGameSyncTest(t, source, t.TempDir(), 4)
+		_, err := sync.FetchAllGamesAtBlock(context.Background(), 0, big.NewInt(1))
+		require.ErrorIs(t, err, source.gamesErr)
+
+		source.gamesErr = nil
+		games := fetchSyncedGames(t, sync, 0)
+		require.Equal(t, source.newest(9, 6), games)
+	})
+}
+
+func setupGameSyncTest(t *testing.T, source *stubIndexedGameSource, dir string, chunkSize uint64) (*gameSync, *testlog.CapturingHandler) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	handler := &testlog.CapturingHandler{Delegate: logger.GetHandler()}
+	logger.SetHandler(handler)
+	return newGameSync(logger, source, filepath.Join(dir, gameSyncFile), syncFactory, chunkSize), handler
+}
+
+func fetchSyncedGames(t *testing.T, sync *gameSync, earliest uint64) []FaultDisputeGame {
+	games, err := sync.FetchAllGamesAtBlock(context.Background(), earliest, big.NewInt(1))
+	require.NoError(t, err)
+	return games
+}
+
+type stubIndexedGameSource struct {
+	games    []FaultDisputeGame
+	count    uint64
+	countErr error
+	gamesErr error
+	fetched  map[uint64]int
+}
+
+func newStubIndexedGameSource(count uint64) *stubIndexedGameSource {
+	s := &stubIndexedGameSource{fetched: make(map[uint64]int)}
+	s.addGames(count)
+	return s
+}
+
+func (s *stubIndexedGameSource) addGames(n uint64) {
+	for i := uint64(0); i < n; i++ {
+		index := uint64(len(s.games))
+		s.games = append(s.games, FaultDisputeGame{
+			Proxy:     common.BigToAddress(new(big.Int).SetUint64(index + 1)),
+			Timestamp: index * 100,
+		})
+	}
+	s.count = uint64(len(s.games))
+}
+
+// newest returns the games from index newest down to index oldest.
+func (s *stubIndexedGameSource) newest(newest uint64, oldest uint64) []FaultDisputeGame {
+	var games []FaultDisputeGame
+	for i := newest + 1; i > oldest; i-- {
+		games = append(games, s.games[i-1])
+	}
+	return games
+}
+
+func (s *stubIndexedGameSource) requireFetched(t *testing.T, index uint64, times int) {
+	require.Equalf(t, times, s.fetched[index], "game %v fetched", index)
+}
+
+// requireFetchedOnce checks each of the first count games were fetched exactly once.
+func (s *stubIndexedGameSource) requireFetchedOnce(t *testing.T, count uint64) {
+	for i := uint64(0); i < count; i++ {
+		s.requireFetched(t, i, 1)
+	}
+}
+
+func (s *stubIndexedGameSource) FetchGameCount(_ context.Context, _ *big.Int) (uint64, error) {
+	return s.count, s.countErr
+}
+
+func (s *stubIndexedGameSource) FetchGames(_ context.Context, _ *big.Int, from uint64, to uint64) ([]FaultDisputeGame, error) {
+	if s.gamesErr != nil {
+		return nil, s.gamesErr
+	}
+	games := make([]FaultDisputeGame, 0, to-from)
+	for i := to; i > from; i-- {
+		s.fetched[i-1]++
+		games = append(games, s.games[i-1])
+	}
+	return games, nil
+}
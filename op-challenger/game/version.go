@@ -0,0 +1,42 @@
+package game
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
+	"github.com/ethereum-optimism/optimism/op-challenger/version"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// newVersionInfo describes the build variables and the resolved config.
+// Absolute prestates that can't be loaded are omitted so that they don't prevent the challenger starting.
+func newVersionInfo(ctx context.Context, logger log.Logger, cfg *config.Config) rpc.VersionInfo {
+	prestates := make(map[string]common.Hash)
+	hashes, err := fault.AbsolutePrestates(ctx, cfg)
+	if err != nil {
+		logger.Warn("Failed to load absolute prestates", "err", err)
+	}
+	for gameType, hash := range hashes {
+		name, ok := config.GameIdToString[gameType]
+		if !ok {
+			continue
+		}
+		prestates[name] = hash
+	}
+	traceTypes := make([]string, 0, len(cfg.TraceTypes))
+	for _, traceType := range cfg.TraceTypes {
+		traceTypes = append(traceTypes, traceType.String())
+	}
+	return rpc.VersionInfo{
+		Version:            version.Version,
+		Meta:               version.Meta,
+		GitCommit:          version.GitCommit,
+		GitDate:            version.GitDate,
+		TraceTypes:         traceTypes,
+		GameFactoryAddress: cfg.GameFactoryAddress,
+		AbsolutePrestates:  prestates,
+	}
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/version"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVersionInfo(t *testing.T) {
+	setBuildVars(t, "v1.2.3", "test", "0123456789abcdef", "1690000000")
+	logger := testlog.Logger(t, log.LvlInfo)
+	factory := common.Address{0xaa}
+
+	t.Run("Alphabet", func(t *testing.T) {
+		cfg := config.NewConfig(factory, "http://localhost:8545", []config.TraceType{config.TraceTypeAlphabet}, true, t.TempDir())
+		cfg.AlphabetTrace = "abcdefgh"
+		info := newVersionInfo(context.Background(), logger, &cfg)
+		require.Equal(t, "v1.2.3", info.Version)
+		require.Equal(t, "test", info.Meta)
+		require.Equal(t, "0123456789abcdef", info.GitCommit)
+		require.Equal(t, "1690000000", info.GitDate)
+		require.Equal(t, []string{"alphabet"}, info.TraceTypes)
+		require.Equal(t, factory, info.GameFactoryAddress)
+		expected, err := alphabet.NewTraceProvider(cfg.AlphabetTrace, 0).AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, map[string]common.Hash{"Alphabet": expected}, info.AbsolutePrestates)
+	})
+
+	t.Run("OmitUnavailablePrestates", func(t *testing.T) {
+		cfg := config.NewConfig(factory, "http://localhost:8545", []config.TraceType{config.TraceTypeCannon}, true, t.TempDir())
+		cfg.CannonAbsolutePreState = "/does/not/exist.json"
+		info := newVersionInfo(context.Background(), logger, &cfg)
+		require.Equal(t, []string{"cannon"}, info.TraceTypes)
+		require.Empty(t, info.AbsolutePrestates)
+	})
+}
+
+func setBuildVars(t *testing.T, ver, meta, gitCommit, gitDate string) {
+	prevVersion, prevMeta, prevCommit, prevDate := version.Version, version.Meta, version.GitCommit, version.GitDate
+	t.Cleanup(func() {
+		version.Version, version.Meta, version.GitCommit, version.GitDate = prevVersion, prevMeta, prevCommit, prevDate
+	})
+	version.Version, version.Meta, version.GitCommit, version.GitDate = ver, meta, gitCommit, gitDate
+}
@@ -2,10 +2,15 @@ package metrics
 
 import (
 	"context"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -14,35 +19,160 @@ import (
 
 const Namespace = "op_challenger"
 
+// InstanceLabel is the label added to every metric to distinguish challengers sharing a registry.
+const InstanceLabel = "instance"
+
+// ModeLabel is the label added to every metric of a challenger running in a mode other than the default, so its
+// series are distinct from those of challengers that submit transactions.
+const ModeLabel = "mode"
+
+// ModeMonitor is the mode of challengers that monitor games without submitting transactions.
+const ModeMonitor = "monitor"
+
+// DeferReason is the reason a move was deferred, or would have been if it wasn't urgent.
+type DeferReason string
+
+const (
+	// DeferGasPrice is used while the L1 base fee is above the gas price ceiling.
+	DeferGasPrice DeferReason = "gas_price"
+	// DeferL2Finality is used while the L2 data a move derives from isn't finalized.
+	DeferL2Finality DeferReason = "l2_finality"
+)
+
+// otherOpponents is the creator label of the combined games of the addresses not recorded individually.
+const otherOpponents = "other"
+
+// balanceInterval is how often the balance of the challenger's account is recorded.
+const balanceInterval = 10 * time.Second
+
 type Metricer interface {
 	RecordInfo(version string)
+	RecordBuildInfo(version string, gitCommit string, traceTypes []string)
 	RecordUp()
 
 	// Record Tx metrics
 	txmetrics.TxMetricer
+
+	// Record per-game metrics
+	RecordGameMove(game common.Address)
+	RecordGameStep(game common.Address)
+	RecordMoveDeferred(game common.Address, reason DeferReason)
+	RecordMoveForced(game common.Address, reason DeferReason)
+	RecordGameClaims(game common.Address, count uint64)
+	RecordGameStatus(game common.Address, status uint8)
+	RecordGameWon(game common.Address)
+	RecordGameLost(game common.Address)
+	RecordDisputedResolution(game common.Address)
+	RecordSelfConflict(game common.Address)
+	RecordBondsClaimed(game common.Address, amount *big.Int)
+	RecordActionGas(game common.Address, action string, gasUsed uint64, fee *big.Int)
+	RecordGameSpend(game common.Address, amount *big.Int)
+	RecordTraceCacheUsage(game common.Address, bytes uint64)
+	RecordActDuration(game common.Address, phase string, duration time.Duration)
+	RecordTimeToResolution(gameType uint8, outcome string, duration time.Duration)
+	DeleteInProgressMetrics(game common.Address)
+	DeleteGameMetrics(game common.Address)
+
+	RecordTraceProviderCacheHit()
+	RecordCircuitBreakerOpen(open bool)
+	RecordGameDataReclaimed(bytes uint64)
+	RecordClockSkew(skew time.Duration)
+	RecordDeniedGameSkipped()
+	RecordLowBalance(low bool)
+	RecordResultDropped()
+	RecordOpponentGames(creator common.Address, created int, won int, lost int)
+	RecordOtherOpponentGames(created int, won int, lost int)
+	DeleteOpponentGames(creator common.Address)
+	RecordGameDeprioritized()
+	RecordGameQueueTime(priority string, duration time.Duration)
 }
 
 type Metrics struct {
 	ns       string
 	registry *prometheus.Registry
 	factory  opmetrics.Factory
+	// instance is the value of the instance label on every metric, empty if metrics aren't labelled by instance.
+	instance string
+	// mode is the value of the mode label on every metric, empty if metrics aren't labelled by mode.
+	mode string
 
 	txmetrics.TxMetrics
 
-	info prometheus.GaugeVec
-	up   prometheus.Gauge
+	info      prometheus.GaugeVec
+	buildInfo prometheus.GaugeVec
+	up        prometheus.Gauge
+
+	// factory is used as the game label instead of the game address when labelByFactory is set.
+	factoryAddr    common.Address
+	labelByFactory bool
+
+	moves      prometheus.CounterVec
+	steps      prometheus.CounterVec
+	deferred   prometheus.CounterVec
+	forced     prometheus.CounterVec
+	claims     prometheus.GaugeVec
+	gameStatus prometheus.GaugeVec
+	gamesWon   prometheus.CounterVec
+	gamesLost  prometheus.CounterVec
+	disputed   prometheus.CounterVec
+	conflicts  prometheus.CounterVec
+	bonds      prometheus.CounterVec
+	txFees     prometheus.CounterVec
+	spend      prometheus.CounterVec
+	actionGas  prometheus.HistogramVec
+	traceCache prometheus.GaugeVec
+	actTime    prometheus.HistogramVec
+	resolution prometheus.GaugeVec
+
+	traceProviderHits prometheus.Counter
+	breakerOpen       prometheus.Gauge
+	reclaimed         prometheus.Counter
+	clockSkew         prometheus.Gauge
+	deniedSkipped     prometheus.Counter
+	lowBalance        prometheus.Gauge
+	resultsDropped    prometheus.Counter
+	opponentGames     prometheus.GaugeVec
+	deprioritized     prometheus.Counter
+	queueTime         prometheus.HistogramVec
 }
 
 var _ Metricer = (*Metrics)(nil)
 
-func NewMetrics() *Metrics {
-	registry := opmetrics.NewRegistry()
-	factory := opmetrics.With(registry)
+// NewMetrics creates a new [Metrics] with its own registry.
+// Per-game metrics are labelled by game address unless labelByFactory is set, in which case
+// they are labelled by the factory address to limit cardinality and the claims and game status,
+// which can't be combined across games, aren't recorded.
+func NewMetrics(factoryAddr common.Address, labelByFactory bool) *Metrics {
+	return NewMetricsWithRegistry(opmetrics.NewRegistry(), "", "", factoryAddr, labelByFactory)
+}
+
+// NewMetricsWithRegistry creates a new [Metrics] registered with registry, which may be shared with other
+// challengers in the same process. If instance is not empty, every metric is labelled with it so each challenger
+// records its own series. Challengers sharing a registry must each use a different instance.
+// If mode is not empty, such as [ModeMonitor], every metric is also labelled with it.
+func NewMetricsWithRegistry(registry *prometheus.Registry, instance string, mode string, factoryAddr common.Address, labelByFactory bool) *Metrics {
+	labels := prometheus.Labels{}
+	if instance != "" {
+		labels[InstanceLabel] = instance
+	}
+	if mode != "" {
+		labels[ModeLabel] = mode
+	}
+	var registerer prometheus.Registerer = registry
+	if len(labels) > 0 {
+		registerer = prometheus.WrapRegistererWith(labels, registry)
+	}
+	factory := opmetrics.With(registerer)
 
 	return &Metrics{
 		ns:       Namespace,
 		registry: registry,
 		factory:  factory,
+		instance: instance,
+		mode:     mode,
+
+		factoryAddr:    factoryAddr,
+		labelByFactory: labelByFactory,
 
 		TxMetrics: txmetrics.MakeTxMetrics(Namespace, factory),
 
@@ -53,11 +183,201 @@ func NewMetrics() *Metrics {
 		}, []string{
 			"version",
 		}),
+		buildInfo: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "build_info",
+			Help:      "Pseudo-metric tracking the build and enabled trace types",
+		}, []string{
+			"version",
+			"git_commit",
+			"trace_types",
+		}),
 		up: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "up",
 			Help:      "1 if the op-challenger has finished starting up",
 		}),
+		moves: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "moves",
+			Help:      "Number of game moves made by the challenge agent",
+		}, []string{
+			"game",
+		}),
+		steps: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "steps",
+			Help:      "Number of game steps made by the challenge agent",
+		}, []string{
+			"game",
+		}),
+		deferred: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "moves_deferred",
+			Help:      "Number of moves deferred, by reason: gas_price while the L1 base fee was above the gas price ceiling or l2_finality while the L2 data was unfinalized",
+		}, []string{
+			"game",
+			"reason",
+		}),
+		forced: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "moves_forced",
+			Help:      "Number of urgent moves made despite a reason to defer them, by reason: gas_price or l2_finality",
+		}, []string{
+			"game",
+			"reason",
+		}),
+		claims: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claims",
+			Help:      "Number of claims loaded from the game in the most recent update",
+		}, []string{
+			"game",
+		}),
+		gameStatus: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_status",
+			Help:      "Most recently loaded status of the game",
+		}, []string{
+			"game",
+		}),
+		gamesWon: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_won",
+			Help:      "Number of games that completed with the expected status",
+		}, []string{
+			"game",
+		}),
+		gamesLost: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_lost",
+			Help:      "Number of games that completed with an unexpected status",
+		}, []string{
+			"game",
+		}),
+		disputed: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "disputed_resolutions",
+			Help:      "Number of games that resolved contrary to the outcome expected from output validation or their claims",
+		}, []string{
+			"game",
+		}),
+		conflicts: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "self_conflicts",
+			Help:      "Number of opponent claims countering an agreed claim with the value of our own trace",
+		}, []string{
+			"game",
+		}),
+		bonds: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bonds_claimed_wei",
+			Help:      "Total value in wei of the bonds claimed from won games",
+		}, []string{
+			"game",
+		}),
+		txFees: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "tx_fees_wei",
+			Help:      "Total value in wei of the fees paid for the game's transactions",
+		}, []string{
+			"game",
+		}),
+		spend: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_spend_wei",
+			Help:      "Total value in wei spent on the game's mined transactions, counted towards the per-game spend limit",
+		}, []string{
+			"game",
+		}),
+		actionGas: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "action_gas_used",
+			Help:      "Gas used by the transaction of each action, by action type",
+			Buckets:   prometheus.ExponentialBuckets(25_000, 2, 10),
+		}, []string{
+			"action",
+		}),
+		traceCache: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "trace_cache_bytes",
+			Help:      "Size in bytes of the trace data cached for the game",
+		}, []string{
+			"game",
+		}),
+		actTime: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "act_duration_seconds",
+			Help:      "Time taken to act on the game each time it is progressed, in total and by phase",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		}, []string{
+			"game",
+			"phase",
+		}),
+		resolution: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "time_to_resolution_seconds",
+			Help:      "Seconds from when the challenger first observed the most recently resolved game until it saw the game resolved, by game type and outcome",
+		}, []string{
+			"game_type",
+			"outcome",
+		}),
+		traceProviderHits: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "trace_provider_cache_hits",
+			Help:      "Number of games that reused the trace provider of another game with the same trace",
+		}),
+		breakerOpen: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "circuit_breaker_open",
+			Help:      "1 if non-critical transactions are halted after repeated failed transactions",
+		}),
+		reclaimed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_data_reclaimed_bytes",
+			Help:      "Number of bytes of disk space freed by deleting the data of resolved games",
+		}),
+		clockSkew: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "clock_skew_seconds",
+			Help:      "Estimated seconds the local clock is ahead of L1 block timestamps, negative if it is behind",
+		}),
+		deniedSkipped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "denied_games_skipped",
+			Help:      "Number of games found on the deny list and skipped without being loaded",
+		}),
+		lowBalance: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "low_balance",
+			Help:      "1 if the balance of the challenger's account is below the estimate needed to play the games in progress",
+		}),
+		resultsDropped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "result_store_dropped",
+			Help:      "Number of game results dropped without being written to the result store",
+		}),
+		opponentGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "opponent_games",
+			Help:      "Number of games created by the addresses that created the most games or are deprioritized, by whether they were created, won or lost by the creator. The games of all other addresses are combined under the creator other",
+		}, []string{
+			"creator",
+			"outcome",
+		}),
+		deprioritized: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_deprioritized",
+			Help:      "Number of games played at the lowest priority because their creator lost too many games",
+		}),
+		queueTime: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "game_queue_seconds",
+			Help:      "Time games waited for a worker each time they were progressed, by the bucket of their remaining clock",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		}, []string{
+			"priority",
+		}),
 	}
 }
 
@@ -65,8 +385,35 @@ func (m *Metrics) Serve(ctx context.Context, host string, port int) error {
 	return opmetrics.ListenAndServe(ctx, m.registry, host, port)
 }
 
+// StartBalanceMetrics periodically records the balance in ether of account until ctx is done.
+// Unlike the op-service balance metrics, the help doesn't include the account so that challengers sharing a
+// registry with different accounts can all record their balance.
 func (m *Metrics) StartBalanceMetrics(ctx context.Context, l log.Logger, client *ethclient.Client, account common.Address) {
-	opmetrics.LaunchBalanceMetrics(ctx, l, m.registry, m.ns, client, account)
+	balance := m.factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: m.ns,
+		Name:      "balance",
+		Help:      "Balance in ether of the challenger's account",
+	})
+	go func() {
+		ticker := time.NewTicker(balanceInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fetchCtx, cancel := context.WithTimeout(ctx, time.Minute)
+				wei, err := client.BalanceAt(fetchCtx, account, nil)
+				cancel()
+				if err != nil {
+					l.Warn("Failed to get balance of account", "err", err, "address", account)
+					continue
+				}
+				ether, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+				balance.Set(ether)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
@@ -75,12 +422,203 @@ func (m *Metrics) RecordInfo(version string) {
 	m.info.WithLabelValues(version).Set(1)
 }
 
+// RecordBuildInfo sets a pseudo-metric that contains the build and enabled trace types.
+// Multiple trace types are joined with commas.
+func (m *Metrics) RecordBuildInfo(version string, gitCommit string, traceTypes []string) {
+	m.buildInfo.WithLabelValues(version, gitCommit, strings.Join(traceTypes, ",")).Set(1)
+}
+
 // RecordUp sets the up metric to 1.
 func (m *Metrics) RecordUp() {
 	prometheus.MustRegister()
 	m.up.Set(1)
 }
 
+func (m *Metrics) RecordGameMove(game common.Address) {
+	m.moves.WithLabelValues(m.gameLabel(game)).Inc()
+}
+
+func (m *Metrics) RecordGameStep(game common.Address) {
+	m.steps.WithLabelValues(m.gameLabel(game)).Inc()
+}
+
+func (m *Metrics) RecordMoveDeferred(game common.Address, reason DeferReason) {
+	m.deferred.WithLabelValues(m.gameLabel(game), string(reason)).Inc()
+}
+
+func (m *Metrics) RecordMoveForced(game common.Address, reason DeferReason) {
+	m.forced.WithLabelValues(m.gameLabel(game), string(reason)).Inc()
+}
+
+// RecordGameClaims records the number of claims in the game, unless metrics are labelled by factory.
+func (m *Metrics) RecordGameClaims(game common.Address, count uint64) {
+	if m.labelByFactory {
+		return
+	}
+	m.claims.WithLabelValues(m.gameLabel(game)).Set(float64(count))
+}
+
+// RecordGameStatus records the status of the game, unless metrics are labelled by factory.
+func (m *Metrics) RecordGameStatus(game common.Address, status uint8) {
+	if m.labelByFactory {
+		return
+	}
+	m.gameStatus.WithLabelValues(m.gameLabel(game)).Set(float64(status))
+}
+
+func (m *Metrics) RecordGameWon(game common.Address) {
+	m.gamesWon.WithLabelValues(m.gameLabel(game)).Inc()
+}
+
+func (m *Metrics) RecordGameLost(game common.Address) {
+	m.gamesLost.WithLabelValues(m.gameLabel(game)).Inc()
+}
+
+func (m *Metrics) RecordDisputedResolution(game common.Address) {
+	m.disputed.WithLabelValues(m.gameLabel(game)).Inc()
+}
+
+func (m *Metrics) RecordSelfConflict(game common.Address) {
+	m.conflicts.WithLabelValues(m.gameLabel(game)).Inc()
+}
+
+func (m *Metrics) RecordBondsClaimed(game common.Address, amount *big.Int) {
+	wei, _ := new(big.Float).SetInt(amount).Float64()
+	m.bonds.WithLabelValues(m.gameLabel(game)).Add(wei)
+}
+
+// RecordActionGas records the gas used by an action's transaction and the fee paid for it, if known.
+func (m *Metrics) RecordActionGas(game common.Address, action string, gasUsed uint64, fee *big.Int) {
+	m.actionGas.WithLabelValues(action).Observe(float64(gasUsed))
+	if fee != nil {
+		wei, _ := new(big.Float).SetInt(fee).Float64()
+		m.txFees.WithLabelValues(m.gameLabel(game)).Add(wei)
+	}
+}
+
+// RecordGameSpend records value spent on a mined transaction of the game.
+func (m *Metrics) RecordGameSpend(game common.Address, amount *big.Int) {
+	wei, _ := new(big.Float).SetInt(amount).Float64()
+	m.spend.WithLabelValues(m.gameLabel(game)).Add(wei)
+}
+
+func (m *Metrics) RecordTraceCacheUsage(game common.Address, bytes uint64) {
+	m.traceCache.WithLabelValues(m.gameLabel(game)).Set(float64(bytes))
+}
+
+func (m *Metrics) RecordActDuration(game common.Address, phase string, duration time.Duration) {
+	m.actTime.WithLabelValues(m.gameLabel(game), phase).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordTimeToResolution(gameType uint8, outcome string, duration time.Duration) {
+	m.resolution.WithLabelValues(strconv.Itoa(int(gameType)), outcome).Set(duration.Seconds())
+}
+
+// DeleteInProgressMetrics removes the series of game that only describe it while it is in progress, once it is
+// complete. Its outcome is kept until it is no longer played.
+func (m *Metrics) DeleteInProgressMetrics(game common.Address) {
+	m.deleteGameSeries(game, &m.moves, &m.steps, &m.deferred, &m.forced, &m.claims, &m.conflicts, &m.txFees, &m.spend,
+		&m.traceCache, &m.actTime)
+}
+
+// DeleteGameMetrics removes all the series of game once it is no longer played.
+func (m *Metrics) DeleteGameMetrics(game common.Address) {
+	m.DeleteInProgressMetrics(game)
+	m.deleteGameSeries(game, &m.gameStatus, &m.gamesWon, &m.gamesLost, &m.disputed, &m.bonds)
+}
+
+// deleteGameSeries removes the series labelled with game from each of vecs.
+// Nothing is removed when metrics are labelled by factory, as the series are shared by all the factory's games.
+func (m *Metrics) deleteGameSeries(game common.Address, vecs ...interface{ DeletePartialMatch(prometheus.Labels) int }) {
+	if m.labelByFactory {
+		return
+	}
+	labels := prometheus.Labels{"game": m.gameLabel(game)}
+	for _, vec := range vecs {
+		vec.DeletePartialMatch(labels)
+	}
+}
+
+func (m *Metrics) RecordTraceProviderCacheHit() {
+	m.traceProviderHits.Inc()
+}
+
+// RecordCircuitBreakerOpen sets the circuit_breaker_open metric to 1 if the breaker is open, 0 otherwise.
+func (m *Metrics) RecordCircuitBreakerOpen(open bool) {
+	if open {
+		m.breakerOpen.Set(1)
+	} else {
+		m.breakerOpen.Set(0)
+	}
+}
+
+func (m *Metrics) RecordGameDataReclaimed(bytes uint64) {
+	m.reclaimed.Add(float64(bytes))
+}
+
+func (m *Metrics) RecordClockSkew(skew time.Duration) {
+	m.clockSkew.Set(skew.Seconds())
+}
+
+func (m *Metrics) RecordDeniedGameSkipped() {
+	m.deniedSkipped.Inc()
+}
+
+// RecordLowBalance sets the low_balance metric to 1 if the balance is too low, 0 otherwise.
+func (m *Metrics) RecordLowBalance(low bool) {
+	if low {
+		m.lowBalance.Set(1)
+	} else {
+		m.lowBalance.Set(0)
+	}
+}
+
+func (m *Metrics) RecordResultDropped() {
+	m.resultsDropped.Inc()
+}
+
+func (m *Metrics) RecordOpponentGames(creator common.Address, created int, won int, lost int) {
+	m.opponentGames.WithLabelValues(creator.Hex(), "created").Set(float64(created))
+	m.opponentGames.WithLabelValues(creator.Hex(), "won").Set(float64(won))
+	m.opponentGames.WithLabelValues(creator.Hex(), "lost").Set(float64(lost))
+}
+
+// RecordOtherOpponentGames records the combined games of the addresses not recorded individually.
+func (m *Metrics) RecordOtherOpponentGames(created int, won int, lost int) {
+	m.opponentGames.WithLabelValues(otherOpponents, "created").Set(float64(created))
+	m.opponentGames.WithLabelValues(otherOpponents, "won").Set(float64(won))
+	m.opponentGames.WithLabelValues(otherOpponents, "lost").Set(float64(lost))
+}
+
+// DeleteOpponentGames removes the series of creator once its games are combined with the other addresses.
+func (m *Metrics) DeleteOpponentGames(creator common.Address) {
+	m.opponentGames.DeletePartialMatch(prometheus.Labels{"creator": creator.Hex()})
+}
+
+func (m *Metrics) RecordGameDeprioritized() {
+	m.deprioritized.Inc()
+}
+
+func (m *Metrics) RecordGameQueueTime(priority string, duration time.Duration) {
+	m.queueTime.WithLabelValues(priority).Observe(duration.Seconds())
+}
+
+// ForFactory returns a [Metrics] recording to the same metrics for the games of factory, so per-game metrics are
+// labelled by factory when labelByFactory is set.
+func (m *Metrics) ForFactory(factory common.Address) *Metrics {
+	forFactory := *m
+	forFactory.factoryAddr = factory
+	return &forFactory
+}
+
+// gameLabel returns the label value to use for per-game metrics.
+func (m *Metrics) gameLabel(game common.Address) string {
+	if m.labelByFactory {
+		return m.factoryAddr.Hex()
+	}
+	return game.Hex()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareRegistryBetweenInstances(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	game := common.Address{0xaa}
+	first := NewMetricsWithRegistry(registry, "first", "", common.Address{0x01}, false)
+	second := NewMetricsWithRegistry(registry, "second", "", common.Address{0x02}, false)
+
+	first.RecordGameMove(game)
+	second.RecordGameMove(game)
+	second.RecordGameMove(game)
+	first.RecordUp()
+	second.RecordUp()
+
+	require.Equal(t, 1.0, testutil.ToFloat64(first.moves.WithLabelValues(game.Hex())))
+	require.Equal(t, 2.0, testutil.ToFloat64(second.moves.WithLabelValues(game.Hex())))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	instances := make(map[string]bool)
+	for _, family := range families {
+		if family.GetName() != Namespace+"_up" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == InstanceLabel {
+					instances[label.GetValue()] = true
+				}
+			}
+		}
+	}
+	require.Equal(t, map[string]bool{"first": true, "second": true}, instances)
+}
+
+func TestShareRegistryRequiresDistinctInstances(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	NewMetricsWithRegistry(registry, "same", "", common.Address{}, false)
+	require.Panics(t, func() {
+		NewMetricsWithRegistry(registry, "same", "", common.Address{}, false)
+	})
+}
+
+func TestModeLabel(t *testing.T) {
+	modes := func(t *testing.T, registry *prometheus.Registry) []string {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		var modes []string
+		for _, family := range families {
+			if family.GetName() != Namespace+"_up" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == ModeLabel {
+						modes = append(modes, label.GetValue())
+					}
+				}
+			}
+		}
+		return modes
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		NewMetricsWithRegistry(registry, "", "", common.Address{}, false).RecordUp()
+		require.Empty(t, modes(t, registry))
+	})
+
+	t.Run("Monitor", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		NewMetricsWithRegistry(registry, "first", ModeMonitor, common.Address{}, false).RecordUp()
+		require.Equal(t, []string{ModeMonitor}, modes(t, registry))
+	})
+}
+
+func TestRecordActionGas(t *testing.T) {
+	m := NewMetrics(common.Address{}, false)
+	game := common.Address{0xaa}
+	m.RecordActionGas(game, "move", 50_000, big.NewInt(100_000))
+	m.RecordActionGas(game, "step", 200_000, nil)
+
+	require.Equal(t, 100_000.0, testutil.ToFloat64(m.txFees.WithLabelValues(game.Hex())))
+	require.Equal(t, 2, testutil.CollectAndCount(&m.actionGas))
+}
+
+func TestRecordTimeToResolution(t *testing.T) {
+	m := NewMetrics(common.Address{}, false)
+	m.RecordTimeToResolution(0, "challenger_won", 5*time.Minute)
+	m.RecordTimeToResolution(0, "challenger_won", 2*time.Minute)
+	m.RecordTimeToResolution(1, "defender_won", time.Hour)
+
+	require.Equal(t, 120.0, testutil.ToFloat64(m.resolution.WithLabelValues("0", "challenger_won")))
+	require.Equal(t, 3600.0, testutil.ToFloat64(m.resolution.WithLabelValues("1", "defender_won")))
+}
+
+func TestDeleteGameMetrics(t *testing.T) {
+	game := common.Address{0xaa}
+	other := common.Address{0xbb}
+	record := func(m *Metrics, game common.Address) {
+		m.RecordGameMove(game)
+		m.RecordMoveDeferred(game, DeferGasPrice)
+		m.RecordGameClaims(game, 3)
+		m.RecordActDuration(game, "total", time.Second)
+		m.RecordGameStatus(game, 1)
+		m.RecordGameWon(game)
+	}
+
+	t.Run("ByGame", func(t *testing.T) {
+		m := NewMetrics(common.Address{}, false)
+		record(m, game)
+		record(m, other)
+
+		m.DeleteInProgressMetrics(game)
+		require.Equal(t, 1, testutil.CollectAndCount(&m.moves))
+		require.Equal(t, 1, testutil.CollectAndCount(&m.deferred))
+		require.Equal(t, 1, testutil.CollectAndCount(&m.claims))
+		require.Equal(t, 1, testutil.CollectAndCount(&m.actTime))
+		require.Equal(t, 2, testutil.CollectAndCount(&m.gameStatus), "should keep the outcome of a completed game")
+		require.Equal(t, 2, testutil.CollectAndCount(&m.gamesWon), "should keep the outcome of a completed game")
+
+		m.DeleteGameMetrics(game)
+		require.Equal(t, 1, testutil.CollectAndCount(&m.gameStatus))
+		require.Equal(t, 1, testutil.CollectAndCount(&m.gamesWon))
+		require.Equal(t, 1.0, testutil.ToFloat64(m.moves.WithLabelValues(other.Hex())))
+	})
+
+	t.Run("ByFactory", func(t *testing.T) {
+		factory := common.Address{0x01}
+		m := NewMetrics(factory, true)
+		record(m, game)
+		record(m, other)
+		require.Zero(t, testutil.CollectAndCount(&m.claims), "should not record claims by factory")
+		require.Zero(t, testutil.CollectAndCount(&m.gameStatus), "should not record status by factory")
+
+		m.DeleteGameMetrics(game)
+		require.Equal(t, 2.0, testutil.ToFloat64(m.moves.WithLabelValues(factory.Hex())), "should keep series shared with other games")
+	})
+}
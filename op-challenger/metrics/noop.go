@@ -1,6 +1,11 @@
 package metrics
 
 import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
@@ -10,5 +15,48 @@ type noopMetrics struct {
 
 var NoopMetrics Metricer = new(noopMetrics)
 
-func (*noopMetrics) RecordInfo(version string) {}
-func (*noopMetrics) RecordUp()                 {}
+func (*noopMetrics) RecordInfo(version string)                                             {}
+func (*noopMetrics) RecordBuildInfo(version string, gitCommit string, traceTypes []string) {}
+func (*noopMetrics) RecordUp()                                                             {}
+
+func (*noopMetrics) RecordGameMove(game common.Address)                         {}
+func (*noopMetrics) RecordGameStep(game common.Address)                         {}
+func (*noopMetrics) RecordMoveDeferred(game common.Address, reason DeferReason) {}
+func (*noopMetrics) RecordMoveForced(game common.Address, reason DeferReason)   {}
+func (*noopMetrics) RecordGameClaims(game common.Address, count uint64)         {}
+func (*noopMetrics) RecordGameStatus(game common.Address, status uint8)         {}
+func (*noopMetrics) RecordGameWon(game common.Address)                          {}
+func (*noopMetrics) RecordGameLost(game common.Address)                         {}
+func (*noopMetrics) RecordDisputedResolution(game common.Address)               {}
+func (*noopMetrics) RecordSelfConflict(game common.Address)                     {}
+
+func (*noopMetrics) RecordBondsClaimed(game common.Address, amount *big.Int) {}
+
+func (*noopMetrics) RecordActionGas(game common.Address, action string, gasUsed uint64, fee *big.Int) {
+}
+
+func (*noopMetrics) RecordGameSpend(game common.Address, amount *big.Int) {}
+
+func (*noopMetrics) RecordTraceCacheUsage(game common.Address, bytes uint64) {}
+
+func (*noopMetrics) RecordActDuration(game common.Address, phase string, duration time.Duration) {}
+
+func (*noopMetrics) RecordTimeToResolution(gameType uint8, outcome string, duration time.Duration) {}
+
+func (*noopMetrics) DeleteInProgressMetrics(game common.Address) {}
+func (*noopMetrics) DeleteGameMetrics(game common.Address)       {}
+
+func (*noopMetrics) RecordTraceProviderCacheHit()         {}
+func (*noopMetrics) RecordCircuitBreakerOpen(open bool)   {}
+func (*noopMetrics) RecordGameDataReclaimed(bytes uint64) {}
+func (*noopMetrics) RecordClockSkew(skew time.Duration)   {}
+func (*noopMetrics) RecordDeniedGameSkipped()             {}
+func (*noopMetrics) RecordLowBalance(low bool)            {}
+func (*noopMetrics) RecordResultDropped()                 {}
+func (*noopMetrics) RecordGameDeprioritized()             {}
+
+func (*noopMetrics) RecordGameQueueTime(priority string, duration time.Duration) {}
+
+func (*noopMetrics) RecordOpponentGames(creator common.Address, created int, won int, lost int) {}
+func (*noopMetrics) RecordOtherOpponentGames(created int, won int, lost int)                    {}
+func (*noopMetrics) DeleteOpponentGames(creator common.Address)                                 {}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrReasonRequired = errors.New("a reason is required to abandon a game")
+	ErrInvalidToken   = errors.New("invalid confirmation token")
+)
+
+// Abandoner stops the challenger from participating in a game.
+type Abandoner interface {
+	Abandon(game common.Address, reason string) error
+}
+
+// AbandonResult is the response to an admin_abandonGame request.
+type AbandonResult struct {
+	// Abandoned is true once the game has been abandoned.
+	Abandoned bool `json:"abandoned"`
+	// Token must be passed back with the same game and reason to confirm the request.
+	// Only set when the request has not yet been confirmed.
+	Token string `json:"token,omitempty"`
+}
+
+type pendingAbandon struct {
+	token  string
+	reason string
+}
+
+// abandonRequests issues and checks the confirmation tokens required to abandon a game.
+type abandonRequests struct {
+	abandoner Abandoner
+
+	mu      sync.Mutex
+	pending map[common.Address]pendingAbandon
+}
+
+func newAbandonRequests(abandoner Abandoner) *abandonRequests {
+	return &abandonRequests{
+		abandoner: abandoner,
+		pending:   make(map[common.Address]pendingAbandon),
+	}
+}
+
+// request abandons game if token matches the token previously issued for the same game and reason.
+// If token is empty a new token is issued, replacing any previous token for the game.
+// Tokens can only be used once.
+func (r *abandonRequests) request(game common.Address, reason string, token string) (AbandonResult, error) {
+	if reason == "" {
+		return AbandonResult{}, ErrReasonRequired
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token == "" {
+		token, err := newToken()
+		if err != nil {
+			return AbandonResult{}, err
+		}
+		r.pending[game] = pendingAbandon{token: token, reason: reason}
+		return AbandonResult{Token: token}, nil
+	}
+	pending, ok := r.pending[game]
+	if !ok || pending.token != token || pending.reason != reason {
+		return AbandonResult{}, ErrInvalidToken
+	}
+	delete(r.pending, game)
+	if err := r.abandoner.Abandon(game, reason); err != nil {
+		return AbandonResult{}, fmt.Errorf("failed to abandon game %v: %w", game, err)
+	}
+	return AbandonResult{Abandoned: true}, nil
+}
+
+func newToken() (string, error) {
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	return hex.EncodeToString(token[:]), nil
+}
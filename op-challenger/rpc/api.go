@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// VersionInfo describes the build and configuration of a running challenger.
+type VersionInfo struct {
+	Version            string         `json:"version"`
+	Meta               string         `json:"meta"`
+	GitCommit          string         `json:"gitCommit"`
+	GitDate            string         `json:"gitDate"`
+	TraceTypes         []string       `json:"traceTypes"`
+	GameFactoryAddress common.Address `json:"gameFactoryAddress"`
+	// AbsolutePrestates maps the name of each supported game type to the absolute prestate hash used for it.
+	AbsolutePrestates map[string]common.Hash `json:"absolutePrestates"`
+}
+
+// StatusSource provides the latest known state of each game being played.
+type StatusSource interface {
+	Summaries() []types.GameSummary
+}
+
+// OpponentSource provides the record of the games created by each address.
+type OpponentSource interface {
+	Opponents() []types.OpponentSummary
+}
+
+// GameActor progresses a game on request.
+type GameActor interface {
+	ActNow(ctx context.Context, game common.Address) (types.ActResult, error)
+}
+
+type challengerAPI struct {
+	info      VersionInfo
+	statuses  StatusSource
+	opponents OpponentSource
+}
+
+// NewChallengerAPI creates the read-only API served in the challenger namespace.
+func NewChallengerAPI(info VersionInfo, statuses StatusSource, opponents OpponentSource) *challengerAPI {
+	return &challengerAPI{
+		info:      info,
+		statuses:  statuses,
+		opponents: opponents,
+	}
+}
+
+// Version returns the build and configuration of the challenger.
+func (a *challengerAPI) Version(_ context.Context) (VersionInfo, error) {
+	return a.info, nil
+}
+
+// GameStatuses returns the latest known state of each game being played, ordered by address.
+func (a *challengerAPI) GameStatuses(_ context.Context) ([]types.GameSummary, error) {
+	return a.statuses.Summaries(), nil
+}
+
+// Opponents returns the number of games created by each address and how they resolved, ordered by address.
+func (a *challengerAPI) Opponents(_ context.Context) ([]types.OpponentSummary, error) {
+	return a.opponents.Opponents(), nil
+}
+
+type adminAPI struct {
+	abandon *abandonRequests
+	actor   GameActor
+}
+
+// NewAdminAPI creates the API served in the admin namespace, which changes how games are played so is only served
+// when the admin API is enabled.
+func NewAdminAPI(abandoner Abandoner, actor GameActor) *adminAPI {
+	return &adminAPI{
+		abandon: newAbandonRequests(abandoner),
+		actor:   actor,
+	}
+}
+
+// AbandonGame stops the challenger from making moves in game, while it continues to monitor the game and claim
+// its bonds. The request must be confirmed by calling again with the token returned by the first call.
+func (a *adminAPI) AbandonGame(_ context.Context, game common.Address, reason string, token *string) (AbandonResult, error) {
+	var confirm string
+	if token != nil {
+		confirm = *token
+	}
+	return a.abandon.request(game, reason, confirm)
+}
+
+// ActOnGame progresses game immediately rather than waiting for it to next be due, ahead of any games waiting to be
+// progressed, and returns the outcome. If the game is already being progressed, the outcome of that progression is
+// returned instead. Only games being played can be progressed.
+func (a *adminAPI) ActOnGame(ctx context.Context, game common.Address) (types.ActResult, error) {
+	return a.actor.ActNow(ctx, game)
+}
@@ -0,0 +1,219 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengerVersion(t *testing.T) {
+	info := VersionInfo{
+		Version:            "v1.2.3",
+		Meta:               "dev",
+		GitCommit:          "0123456789abcdef",
+		GitDate:            "1690000000",
+		TraceTypes:         []string{"cannon"},
+		GameFactoryAddress: common.Address{0xaa},
+		AbsolutePrestates:  map[string]common.Hash{"Cannon": {0xbb}},
+	}
+	server := NewServer(testlog.Logger(t, log.LvlInfo), "127.0.0.1", 0)
+	require.NoError(t, server.AddAPI("challenger", NewChallengerAPI(info, &stubStatusSource{}, &stubOpponentSource{})))
+	addr, err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%v", addr))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	var result VersionInfo
+	require.NoError(t, client.CallContext(context.Background(), &result, "challenger_version"))
+	require.Equal(t, info, result)
+}
+
+func TestAbandonGame(t *testing.T) {
+	game := common.Address{0xaa}
+	setup := func(t *testing.T) (*rpc.Client, *stubAbandoner) {
+		abandoner := &stubAbandoner{}
+		server := NewServer(testlog.Logger(t, log.LvlInfo), "127.0.0.1", 0)
+		require.NoError(t, server.AddAPI("admin", NewAdminAPI(abandoner, &stubGameActor{})))
+		addr, err := server.Start()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, server.Stop())
+		})
+		client, err := rpc.Dial(fmt.Sprintf("http://%v", addr))
+		require.NoError(t, err)
+		t.Cleanup(client.Close)
+		return client, abandoner
+	}
+	abandonGame := func(client *rpc.Client, reason string, token *string) (AbandonResult, error) {
+		var result AbandonResult
+		err := client.CallContext(context.Background(), &result, "admin_abandonGame", game, reason, token)
+		return result, err
+	}
+
+	t.Run("RequireConfirmation", func(t *testing.T) {
+		client, abandoner := setup(t)
+		result, err := abandonGame(client, "bad prestate", nil)
+		require.NoError(t, err)
+		require.False(t, result.Abandoned)
+		require.NotEmpty(t, result.Token)
+		require.Empty(t, abandoner.abandoned)
+
+		result, err = abandonGame(client, "bad prestate", &result.Token)
+		require.NoError(t, err)
+		require.True(t, result.Abandoned)
+		require.Equal(t, map[common.Address]string{game: "bad prestate"}, abandoner.abandoned)
+	})
+
+	t.Run("RejectInvalidToken", func(t *testing.T) {
+		client, abandoner := setup(t)
+		result, err := abandonGame(client, "bad prestate", nil)
+		require.NoError(t, err)
+		invalid := "invalid"
+		_, err = abandonGame(client, "bad prestate", &invalid)
+		require.ErrorContains(t, err, ErrInvalidToken.Error())
+		_, err = abandonGame(client, "other reason", &result.Token)
+		require.ErrorContains(t, err, ErrInvalidToken.Error())
+		require.Empty(t, abandoner.abandoned)
+	})
+
+	t.Run("TokenUsedOnce", func(t *testing.T) {
+		client, _ := setup(t)
+		result, err := abandonGame(client, "bad prestate", nil)
+		require.NoError(t, err)
+		_, err = abandonGame(client, "bad prestate", &result.Token)
+		require.NoError(t, err)
+		_, err = abandonGame(client, "bad prestate", &result.Token)
+		require.ErrorContains(t, err, ErrInvalidToken.Error())
+	})
+
+	t.Run("RequireReason", func(t *testing.T) {
+		client, _ := setup(t)
+		_, err := abandonGame(client, "", nil)
+		require.ErrorContains(t, err, ErrReasonRequired.Error())
+	})
+}
+
+func TestGameStatuses(t *testing.T) {
+	statuses := &stubStatusSource{summaries: []types.GameSummary{
+		{Game: common.Address{0xaa}, Status: "In Progress", Claims: 3, Outlook: "winning", Updated: time.Unix(1000, 0).UTC()},
+		{Game: common.Address{0xbb}, Status: "Challenger Won", Claims: 5, Updated: time.Unix(2000, 0).UTC()},
+	}}
+	server := NewServer(testlog.Logger(t, log.LvlInfo), "127.0.0.1", 0)
+	require.NoError(t, server.AddAPI("challenger", NewChallengerAPI(VersionInfo{}, statuses, &stubOpponentSource{})))
+	addr, err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%v", addr))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	var result []types.GameSummary
+	require.NoError(t, client.CallContext(context.Background(), &result, "challenger_gameStatuses"))
+	require.Equal(t, statuses.summaries, result)
+}
+
+func TestOpponents(t *testing.T) {
+	opponents := &stubOpponentSource{summaries: []types.OpponentSummary{
+		{Creator: common.Address{0xaa}, Created: 5, Won: 1, Lost: 4, RecentLosses: 2, Deprioritized: true},
+		{Creator: common.Address{0xbb}, Created: 1},
+	}}
+	server := NewServer(testlog.Logger(t, log.LvlInfo), "127.0.0.1", 0)
+	require.NoError(t, server.AddAPI("challenger", NewChallengerAPI(VersionInfo{}, &stubStatusSource{}, opponents)))
+	addr, err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%v", addr))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	var result []types.OpponentSummary
+	require.NoError(t, client.CallContext(context.Background(), &result, "challenger_opponents"))
+	require.Equal(t, opponents.summaries, result)
+}
+
+func TestActOnGame(t *testing.T) {
+	known := common.Address{0xaa}
+	actor := &stubGameActor{results: map[common.Address]types.ActResult{
+		known: {Game: known, Succeeded: true, Status: "In Progress", Claims: 3},
+	}}
+	server := NewServer(testlog.Logger(t, log.LvlInfo), "127.0.0.1", 0)
+	require.NoError(t, server.AddAPI("admin", NewAdminAPI(&stubAbandoner{}, actor)))
+	addr, err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%v", addr))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	var result types.ActResult
+	require.NoError(t, client.CallContext(context.Background(), &result, "admin_actOnGame", known))
+	require.Equal(t, actor.results[known], result)
+
+	err = client.CallContext(context.Background(), &result, "admin_actOnGame", common.Address{0xbb})
+	require.ErrorContains(t, err, errStubUnknownGame.Error())
+}
+
+var errStubUnknownGame = errors.New("unknown game")
+
+type stubGameActor struct {
+	results map[common.Address]types.ActResult
+}
+
+func (s *stubGameActor) ActNow(_ context.Context, game common.Address) (types.ActResult, error) {
+	result, ok := s.results[game]
+	if !ok {
+		return types.ActResult{}, errStubUnknownGame
+	}
+	return result, nil
+}
+
+type stubStatusSource struct {
+	summaries []types.GameSummary
+}
+
+func (s *stubStatusSource) Summaries() []types.GameSummary {
+	return s.summaries
+}
+
+type stubOpponentSource struct {
+	summaries []types.OpponentSummary
+}
+
+func (s *stubOpponentSource) Opponents() []types.OpponentSummary {
+	return s.summaries
+}
+
+type stubAbandoner struct {
+	abandoned map[common.Address]string
+}
+
+func (s *stubAbandoner) Abandon(game common.Address, reason string) error {
+	if s.abandoned == nil {
+		s.abandoned = make(map[common.Address]string)
+	}
+	s.abandoned[game] = reason
+	return nil
+}
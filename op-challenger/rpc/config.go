@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnabledFlagName     = "rpc.enabled"
+	ListenAddrFlagName  = "rpc.addr"
+	PortFlagName        = "rpc.port"
+	EnableAdminFlagName = "rpc.enable-admin"
+	defaultListenAddr   = "0.0.0.0"
+	defaultListenPort   = 8545
+)
+
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		Enabled:    false,
+		ListenAddr: defaultListenAddr,
+		ListenPort: defaultListenPort,
+	}
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    EnabledFlagName,
+			Usage:   "Enable the RPC server",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLED"),
+		},
+		&cli.StringFlag{
+			Name:    ListenAddrFlagName,
+			Usage:   "RPC listening address",
+			Value:   defaultListenAddr,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ADDR"),
+		},
+		&cli.IntFlag{
+			Name:    PortFlagName,
+			Usage:   "RPC listening port",
+			Value:   defaultListenPort,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_PORT"),
+		},
+		&cli.BoolFlag{
+			Name:    EnableAdminFlagName,
+			Usage:   "Enable the admin API, which can abandon games and progress them on request. It is not authenticated so should only be enabled when the RPC server is not publicly reachable",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled     bool
+	ListenAddr  string
+	ListenPort  int
+	EnableAdmin bool
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
+		return errors.New("invalid RPC port")
+	}
+
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:     ctx.Bool(EnabledFlagName),
+		ListenAddr:  ctx.String(ListenAddrFlagName),
+		ListenPort:  ctx.Int(PortFlagName),
+		EnableAdmin: ctx.Bool(EnableAdminFlagName),
+	}
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// DashboardPath is the path the dashboard page is served at.
+	DashboardPath = "/dashboard"
+	// DashboardDataPath is the path the data rendered by the dashboard is served at.
+	DashboardDataPath = "/dashboard/data"
+)
+
+//go:embed dashboard.html
+var dashboardPage []byte
+
+// DashboardData is the state of the challenger rendered by the dashboard.
+type DashboardData struct {
+	Games []types.GameSummary `json:"games"`
+	// DiskUsage is the total size in bytes of the data directory.
+	DiskUsage uint64 `json:"diskUsage"`
+	// Balances maps each account the challenger sends transactions from to its balance in wei.
+	Balances map[common.Address]*hexutil.Big `json:"balances"`
+}
+
+// DashboardSource loads the data rendered by the dashboard.
+type DashboardSource interface {
+	DashboardData(ctx context.Context) (DashboardData, error)
+}
+
+type dashboardHandler struct{}
+
+// NewDashboardHandler creates an HTTP handler that serves the dashboard page.
+// The page renders the data served at [DashboardDataPath] and periodically refreshes it.
+func NewDashboardHandler() http.Handler {
+	return &dashboardHandler{}
+}
+
+func (h *dashboardHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(dashboardPage)
+}
+
+type dashboardDataHandler struct {
+	log    log.Logger
+	source DashboardSource
+}
+
+// NewDashboardDataHandler creates an HTTP handler that serves the data rendered by the dashboard as JSON.
+func NewDashboardDataHandler(logger log.Logger, source DashboardSource) http.Handler {
+	return &dashboardDataHandler{
+		log:    logger,
+		source: source,
+	}
+}
+
+func (h *dashboardDataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, err := h.source.DashboardData(r.Context())
+	if err != nil {
+		h.log.Warn("Failed to load dashboard data", "err", err)
+		http.Error(w, "failed to load dashboard data", http.StatusInternalServerError)
+		return
+	}
+	if data.Games == nil {
+		data.Games = []types.GameSummary{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.log.Warn("Failed to write dashboard data", "err", err)
+	}
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboard(t *testing.T) {
+	setup := func(t *testing.T, source DashboardSource) string {
+		logger := testlog.Logger(t, log.LvlInfo)
+		server := NewServer(logger, "127.0.0.1", 0)
+		server.AddHandler(DashboardPath, NewDashboardHandler())
+		server.AddHandler(DashboardDataPath, NewDashboardDataHandler(logger, source))
+		addr, err := server.Start()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, server.Stop())
+		})
+		return fmt.Sprintf("http://%v", addr)
+	}
+
+	t.Run("Render", func(t *testing.T) {
+		url := setup(t, &stubDashboardSource{})
+		resp, err := http.Get(url + DashboardPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), "<table")
+		require.Contains(t, string(body), DashboardDataPath)
+	})
+
+	t.Run("Data", func(t *testing.T) {
+		deadline := time.Unix(2000, 0).UTC()
+		expected := DashboardData{
+			Games: []types.GameSummary{
+				{Game: common.Address{0xaa}, Status: "In Progress", Claims: 3, ClockDeadline: &deadline, Updated: time.Unix(1000, 0).UTC()},
+				{Game: common.Address{0xbb}, Status: "Defender Won", Claims: 1, LastError: "boom", LastErrorTime: &deadline, Updated: time.Unix(1000, 0).UTC()},
+			},
+			DiskUsage: 1024,
+			Balances:  map[common.Address]*hexutil.Big{{0xcc}: (*hexutil.Big)(big.NewInt(5e18))},
+		}
+		url := setup(t, &stubDashboardSource{data: expected})
+		resp, err := http.Get(url + DashboardDataPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		var data DashboardData
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&data))
+		require.Equal(t, expected, data)
+	})
+
+	t.Run("EmptyGames", func(t *testing.T) {
+		url := setup(t, &stubDashboardSource{})
+		resp, err := http.Get(url + DashboardDataPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var data map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&data))
+		require.Equal(t, []interface{}{}, data["games"])
+	})
+
+	t.Run("SourceError", func(t *testing.T) {
+		url := setup(t, &stubDashboardSource{err: errors.New("boom")})
+		resp, err := http.Get(url + DashboardDataPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+type stubDashboardSource struct {
+	data DashboardData
+	err  error
+}
+
+func (s *stubDashboardSource) DashboardData(_ context.Context) (DashboardData, error) {
+	return s.data, s.err
+}
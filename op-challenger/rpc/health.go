@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HealthPath is the path the health check is served at.
+const HealthPath = "/healthz"
+
+// StaleGamesSource reports the games that have had a response pending without progressing for longer than a threshold.
+type StaleGamesSource interface {
+	GetStaleGames(threshold time.Duration) []common.Address
+}
+
+// SubsystemSource reports the health of the challenger's subsystems, with those failing for longer than window
+// reported as unhealthy.
+type SubsystemSource interface {
+	SubsystemHealth(window time.Duration) SubsystemsHealth
+}
+
+// SubsystemStatus reports the outcomes of a subsystem's operations.
+type SubsystemStatus struct {
+	Healthy bool `json:"healthy"`
+	// LastSuccess is when the subsystem last succeeded, nil if it hasn't yet.
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	// FailingSince is when the subsystem first failed after its last success, nil if it isn't failing.
+	FailingSince *time.Time `json:"failingSince,omitempty"`
+	// Error is the error from the latest failure, empty if it isn't failing.
+	Error string `json:"error,omitempty"`
+}
+
+// GameHealth reports the health of the subsystems playing a game.
+type GameHealth struct {
+	ClaimLoad          SubsystemStatus `json:"claimLoad"`
+	PrestateValidation SubsystemStatus `json:"prestateValidation"`
+}
+
+// SubsystemsHealth reports the health of each of the challenger's subsystems.
+type SubsystemsHealth struct {
+	FactoryPoll SubsystemStatus `json:"factoryPoll"`
+	NonceSync   SubsystemStatus `json:"nonceSync"`
+	// Games reports the subsystems of each game being played.
+	Games map[common.Address]GameHealth `json:"games"`
+}
+
+// Healthy returns true if every subsystem is healthy.
+func (h SubsystemsHealth) Healthy() bool {
+	if !h.FactoryPoll.Healthy || !h.NonceSync.Healthy {
+		return false
+	}
+	for _, game := range h.Games {
+		if !game.ClaimLoad.Healthy || !game.PrestateValidation.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// HealthStatus reports whether the challenger is progressing the games it is playing.
+type HealthStatus struct {
+	Healthy bool `json:"healthy"`
+	// StaleGames lists the unresolved games that have had a response pending without their claim count changing for
+	// longer than the stale game threshold.
+	StaleGames []common.Address `json:"staleGames"`
+	Subsystems SubsystemsHealth `json:"subsystems"`
+}
+
+type healthHandler struct {
+	log        log.Logger
+	games      StaleGamesSource
+	subsystems SubsystemSource
+	threshold  time.Duration
+	window     time.Duration
+}
+
+// NewHealthHandler creates an HTTP handler that reports the challenger as unhealthy, with status 503, if any
+// unresolved game has had a response pending without progressing for longer than threshold or any subsystem has been failing for longer than window.
+func NewHealthHandler(logger log.Logger, games StaleGamesSource, subsystems SubsystemSource, threshold time.Duration, window time.Duration) http.Handler {
+	return &healthHandler{
+		log:        logger,
+		games:      games,
+		subsystems: subsystems,
+		threshold:  threshold,
+		window:     window,
+	}
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	stale := h.games.GetStaleGames(h.threshold)
+	subsystems := h.subsystems.SubsystemHealth(h.window)
+	status := HealthStatus{
+		Healthy:    len(stale) == 0 && subsystems.Healthy(),
+		StaleGames: stale,
+		Subsystems: subsystems,
+	}
+	if status.Subsystems.Games == nil {
+		status.Subsystems.Games = map[common.Address]GameHealth{}
+	}
+	if status.StaleGames == nil {
+		status.StaleGames = []common.Address{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(status); err != nil {
+		h.log.Warn("Failed to write health status", "err", err)
+	}
+}
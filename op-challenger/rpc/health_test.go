@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	lastSuccess := time.Unix(1000, 0).UTC()
+	healthy := SubsystemStatus{Healthy: true, LastSuccess: &lastSuccess}
+	failing := SubsystemStatus{Healthy: false, LastSuccess: &lastSuccess, FailingSince: &lastSuccess, Error: "boom"}
+	healthySubsystems := SubsystemsHealth{
+		FactoryPoll: healthy,
+		NonceSync:   healthy,
+		Games:       map[common.Address]GameHealth{{0xaa}: {ClaimLoad: healthy, PrestateValidation: healthy}},
+	}
+	tests := []struct {
+		name       string
+		stale      []common.Address
+		subsystems SubsystemsHealth
+		httpStatus int
+		expected   HealthStatus
+	}{
+		{"Healthy", nil, healthySubsystems, http.StatusOK, HealthStatus{Healthy: true, StaleGames: []common.Address{}, Subsystems: healthySubsystems}},
+		{
+			name:       "NoGames",
+			subsystems: SubsystemsHealth{FactoryPoll: healthy, NonceSync: healthy},
+			httpStatus: http.StatusOK,
+			expected:   HealthStatus{Healthy: true, StaleGames: []common.Address{}, Subsystems: SubsystemsHealth{FactoryPoll: healthy, NonceSync: healthy, Games: map[common.Address]GameHealth{}}},
+		},
+		{"StaleGames", []common.Address{{0xaa}, {0xbb}}, healthySubsystems, http.StatusServiceUnavailable, HealthStatus{Healthy: false, StaleGames: []common.Address{{0xaa}, {0xbb}}, Subsystems: healthySubsystems}},
+		{
+			name:       "FactoryPollFailing",
+			subsystems: SubsystemsHealth{FactoryPoll: failing, NonceSync: healthy},
+			httpStatus: http.StatusServiceUnavailable,
+			expected:   HealthStatus{Healthy: false, StaleGames: []common.Address{}, Subsystems: SubsystemsHealth{FactoryPoll: failing, NonceSync: healthy, Games: map[common.Address]GameHealth{}}},
+		},
+		{
+			name:       "NonceSyncFailing",
+			subsystems: SubsystemsHealth{FactoryPoll: healthy, NonceSync: failing},
+			httpStatus: http.StatusServiceUnavailable,
+			expected:   HealthStatus{Healthy: false, StaleGames: []common.Address{}, Subsystems: SubsystemsHealth{FactoryPoll: healthy, NonceSync: failing, Games: map[common.Address]GameHealth{}}},
+		},
+		{
+			name:       "GameFailing",
+			subsystems: SubsystemsHealth{FactoryPoll: healthy, NonceSync: healthy, Games: map[common.Address]GameHealth{{0xaa}: {ClaimLoad: healthy, PrestateValidation: failing}}},
+			httpStatus: http.StatusServiceUnavailable,
+			expected:   HealthStatus{Healthy: false, StaleGames: []common.Address{}, Subsystems: SubsystemsHealth{FactoryPoll: healthy, NonceSync: healthy, Games: map[common.Address]GameHealth{{0xaa}: {ClaimLoad: healthy, PrestateValidation: failing}}}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			logger := testlog.Logger(t, log.LvlInfo)
+			games := &stubStaleGames{stale: test.stale}
+			subsystems := &stubSubsystems{health: test.subsystems}
+			server := NewServer(logger, "127.0.0.1", 0)
+			server.AddHandler(HealthPath, NewHealthHandler(logger, games, subsystems, time.Hour, time.Minute))
+			addr, err := server.Start()
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, server.Stop())
+			})
+
+			resp, err := http.Get(fmt.Sprintf("http://%v%v", addr, HealthPath))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, test.httpStatus, resp.StatusCode)
+			var status HealthStatus
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+			require.Equal(t, test.expected, status)
+			require.Equal(t, time.Hour, games.threshold)
+			require.Equal(t, time.Minute, subsystems.window)
+		})
+	}
+}
+
+type stubStaleGames struct {
+	stale     []common.Address
+	threshold time.Duration
+}
+
+func (s *stubStaleGames) GetStaleGames(threshold time.Duration) []common.Address {
+	s.threshold = threshold
+	return s.stale
+}
+
+type stubSubsystems struct {
+	health SubsystemsHealth
+	window time.Duration
+}
+
+func (s *stubSubsystems) SubsystemHealth(window time.Duration) SubsystemsHealth {
+	s.window = window
+	return s.health
+}
@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Server serves the challenger's JSON-RPC APIs over HTTP, along with any additional HTTP handlers.
+// It uses the go-ethereum RPC server directly rather than the op-service server to avoid depending on the
+// go-ethereum node package.
+type Server struct {
+	log        log.Logger
+	endpoint   string
+	rpc        *rpc.Server
+	mux        *http.ServeMux
+	httpServer *http.Server
+}
+
+func NewServer(logger log.Logger, host string, port int) *Server {
+	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
+	srv := rpc.NewServer()
+	mux := http.NewServeMux()
+	mux.Handle("/", srv)
+	return &Server{
+		log:      logger,
+		endpoint: endpoint,
+		rpc:      srv,
+		mux:      mux,
+		httpServer: &http.Server{
+			Addr:    endpoint,
+			Handler: mux,
+		},
+	}
+}
+
+// AddAPI registers the methods of service in the namespace.
+func (s *Server) AddAPI(namespace string, service interface{}) error {
+	return s.rpc.RegisterName(namespace, service)
+}
+
+// AddHandler serves handler at path, alongside the JSON-RPC APIs. It must be called before Start.
+func (s *Server) AddHandler(path string, handler http.Handler) {
+	s.mux.Handle(path, handler)
+}
+
+// Start starts listening and returns the address the server is listening on.
+func (s *Server) Start() (net.Addr, error) {
+	listener, err := net.Listen("tcp", s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %v: %w", s.endpoint, err)
+	}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.Error("RPC server failed", "err", err)
+		}
+	}()
+	return listener.Addr(), nil
+}
+
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.httpServer.Shutdown(ctx)
+	s.rpc.Stop()
+	return err
+}
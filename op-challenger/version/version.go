@@ -3,6 +3,9 @@ package version
 var (
 	Version = "v0.1.0"
 	Meta    = "dev"
+	// GitCommit and GitDate are set at build time.
+	GitCommit = ""
+	GitDate   = ""
 )
 
 var SimpleWithMeta = func() string {
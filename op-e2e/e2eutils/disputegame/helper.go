@@ -31,7 +31,6 @@ import (
 const alphabetGameType uint8 = 255
 const cannonGameType uint8 = 0
 const alphabetGameDepth = 4
-const lastAlphabetTraceIndex = 1<<alphabetGameDepth - 1
 
 type Status uint8
 
@@ -102,8 +101,9 @@ func (h *FactoryHelper) StartAlphabetGame(ctx context.Context, claimedAlphabet s
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
-	trace := alphabet.NewTraceProvider(claimedAlphabet, alphabetGameDepth)
-	rootClaim, err := trace.Get(ctx, lastAlphabetTraceIndex)
+	trace, err := alphabet.NewTraceProviderFromSpec(claimedAlphabet, alphabetGameDepth)
+	h.require.NoError(err, "create alphabet trace")
+	rootClaim, err := trace.RootClaim(ctx)
 	h.require.NoError(err, "get root claim")
 	extraData := make([]byte, 64)
 	binary.BigEndian.PutUint64(extraData[24:], l2BlockNumber)
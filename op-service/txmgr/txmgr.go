@@ -112,7 +112,12 @@ func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLI
 	if err != nil {
 		return nil, err
 	}
+	return NewSimpleTxManagerFromConfig(name, l, m, conf), nil
+}
 
+// NewSimpleTxManagerFromConfig initializes a new SimpleTxManager with the passed Config.
+// This allows callers to customise the Config, such as wrapping the Backend, before creating the SimpleTxManager.
+func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetricer, conf Config) *SimpleTxManager {
 	return &SimpleTxManager{
 		chainID: conf.ChainID,
 		name:    name,
@@ -120,7 +125,7 @@ func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLI
 		backend: conf.Backend,
 		l:       l.New("service", name),
 		metr:    m,
-	}, nil
+	}
 }
 
 func (m *SimpleTxManager) From() common.Address {